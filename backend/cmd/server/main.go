@@ -1,9 +1,11 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
 	"strings"
+	"time"
 
 	dbgen "money-buddy-backend/db/generated"
 	"money-buddy-backend/infra/repository"
@@ -17,15 +19,21 @@ import (
 )
 
 func main() {
-	// Firebase Admin初期化
-	if err := auth.InitFirebase(); err != nil {
-		log.Fatalf("Failed to initialize Firebase: %v", err)
-	}
-
 	// 環境変数から設定を読み込み
 	allowedOrigins := getEnv("ALLOWED_ORIGINS", "http://localhost:3000")
 	port := getEnv("PORT", "8080")
 	env := getEnv("ENV", "development")
+	// 1リクエストあたりのデータベース処理の期限（"0" で無効）
+	dbTimeout, err := time.ParseDuration(getEnv("DB_REQUEST_TIMEOUT", "10s"))
+	if err != nil {
+		log.Fatalf("Invalid DB_REQUEST_TIMEOUT: %v", err)
+	}
+
+	// 認証方式（AUTH_MODE）に応じたトークン検証の初期化
+	verifier, err := auth.NewTokenVerifierFromEnv(context.Background(), env)
+	if err != nil {
+		log.Fatalf("Failed to initialize token verifier: %v", err)
+	}
 
 	// 本番環境ではリリースモードに設定
 	if env == "production" {
@@ -34,6 +42,9 @@ func main() {
 
 	r := gin.Default()
 
+	// リクエストごとに X-Request-ID を割り当てる（変更履歴とログの突き合わせ用）
+	r.Use(middleware.RequestID())
+
 	// CORS設定（複数オリジン対応）
 	origins := strings.Split(allowedOrigins, ",")
 	r.Use(func(c *gin.Context) {
@@ -50,8 +61,9 @@ func main() {
 
 		if allowed {
 			c.Writer.Header().Set("Access-Control-Allow-Origin", origin)
-			c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-			c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+			c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
+			c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, If-Match, Idempotency-Key, X-Request-ID")
+			c.Writer.Header().Set("Access-Control-Expose-Headers", "ETag, Idempotent-Replayed, X-Request-ID")
 			c.Writer.Header().Set("Access-Control-Max-Age", "86400") // 24時間キャッシュ
 			c.Writer.Header().Set("Vary", "Origin")                  // 共有キャッシュ対策
 		}
@@ -76,14 +88,38 @@ func main() {
 	fixedCostRepo := repository.NewFixedCostRepositorySQLC(queries)
 	txManager := db.NewSQLTxManager(dbConn)
 	dashboardRepo := repository.NewDashboardRepositorySQLC(queries)
+	budgetRepo := repository.NewBudgetRepositorySQLC(queries)
+	recurringRepo := repository.NewRecurringRepositorySQLC(queries)
+	apiTokenRepo := repository.NewAPITokenRepositorySQLC(queries)
+	householdRepo := repository.NewHouseholdRepositorySQLC(queries)
+	idempotencyRepo := repository.NewIdempotencyRepositorySQLC(queries)
+	tagRepo := repository.NewTagRepositorySQLC(queries)
+	trashRepo := repository.NewTrashRepositorySQLC(queries)
+	auditRepo := repository.NewAuditRepositorySQLC(queries)
 
 	// サービス初期化
-	service := services.NewExpenseService(repo, categoryRepo)
-	categoryService := services.NewCategoryService(categoryRepo)
-	initialSetupService := services.NewInitialSetupService(userRepo, fixedCostRepo, txManager)
-	userService := services.NewUserService(userRepo)
-	fixedCostService := services.NewFixedCostService(fixedCostRepo)
+	service := services.NewExpenseService(repo, categoryRepo, auditRepo, txManager)
+	categoryService := services.NewCategoryService(categoryRepo, repo, auditRepo, txManager)
+	initialSetupService := services.NewInitialSetupService(userRepo, fixedCostRepo, auditRepo, txManager)
+	userService := services.NewUserService(userRepo, auditRepo, txManager)
+	fixedCostService := services.NewFixedCostService(fixedCostRepo, auditRepo, txManager)
 	dashboardService := services.NewDashboardService(dashboardRepo)
+	budgetService := services.NewBudgetService(budgetRepo, categoryRepo)
+	recurringExpenseService := services.NewRecurringExpenseService(recurringRepo, repo, service, categoryRepo, txManager)
+	reportService := services.NewReportService(dashboardRepo)
+	apiTokenService := services.NewAPITokenService(apiTokenRepo)
+	householdService := services.NewHouseholdService(householdRepo, userRepo, txManager)
+	idempotencyService := services.NewIdempotencyService(idempotencyRepo)
+	tagService := services.NewTagService(tagRepo)
+	trashService := services.NewTrashService(trashRepo)
+	auditService := services.NewAuditService(auditRepo)
+
+	// 有効期限（24時間）を過ぎた Idempotency-Key を1時間ごとに削除する
+	go purgeExpiredIdempotencyKeys(idempotencyService, time.Hour)
+	// 復元できる期限（30日）を過ぎたゴミ箱の支出・固定費を1時間ごとに削除する
+	go purgeExpiredTrash(trashService, time.Hour)
+	// 繰り返しルールの予定支出を起動時と1時間ごとに生成し、生成期間（60日先まで）を保つ
+	go materializeRecurringExpenses(recurringExpenseService, time.Hour)
 
 	// 認証不要なエンドポイント
 	r.GET("/health", func(c *gin.Context) {
@@ -92,7 +128,15 @@ func main() {
 
 	// 認証が必要なエンドポイント（ミドルウェア適用）
 	api := r.Group("/")
-	api.Use(middleware.AuthMiddleware())
+	// クライアントの切断・期限切れでクエリを中断できるよう、リクエストのコンテキストに期限を設定する
+	// 書き出しながら行を読むエクスポートは件数に応じて時間がかかるため、期限を設定せず切断時のみ中断する
+	api.Use(middleware.DBDeadline(dbTimeout, "/expenses/export", "/dashboard/export"))
+	// パーソナルアクセストークンは ID トークンと並べて受け付ける
+	api.Use(middleware.AuthMiddleware(auth.WithAPITokens(verifier, apiTokenService)))
+	// 選択中の世帯を解決し、家計簿のデータは世帯のオーナーのものを参照する
+	api.Use(middleware.HouseholdMiddleware(householdService))
+	// 再送による二重登録を防ぐため、登録系の POST で Idempotency-Key を受け付ける
+	api.Use(middleware.Idempotency(idempotencyService, "/expenses", "/fixed-costs", "/setup"))
 	{
 		handlers.NewExpenseHandler(api, service)
 		handlers.NewCategoryHandler(api, categoryService)
@@ -100,6 +144,14 @@ func main() {
 		handlers.NewUserHandler(api, userService)
 		handlers.NewFixedCostHandler(api, fixedCostService)
 		handlers.NewDashboardHandler(api, dashboardService)
+		handlers.NewBudgetHandler(api, budgetService)
+		handlers.NewRecurringExpenseHandler(api, recurringExpenseService)
+		handlers.NewReportHandler(api, reportService)
+		handlers.NewAPITokenHandler(api, apiTokenService)
+		handlers.NewHouseholdHandler(api, householdService)
+		handlers.NewTagHandler(api, tagService)
+		handlers.NewTrashHandler(api, trashService)
+		handlers.NewAuditHandler(api, auditService)
 	}
 
 	log.Printf("Server starting on port %s (env: %s)", port, env)
@@ -115,3 +167,37 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// purgeExpiredIdempotencyKeys は interval ごとに有効期限を過ぎた Idempotency-Key を削除する
+func purgeExpiredIdempotencyKeys(service services.IdempotencyService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if _, err := service.PurgeExpired(context.Background()); err != nil {
+			log.Printf("Failed to purge expired idempotency keys: %v", err)
+		}
+	}
+}
+
+// purgeExpiredTrash は interval ごとに復元できる期限を過ぎたゴミ箱の支出・固定費を削除する
+func purgeExpiredTrash(service services.TrashService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if _, err := service.PurgeExpired(context.Background()); err != nil {
+			log.Printf("Failed to purge expired trash: %v", err)
+		}
+	}
+}
+
+// materializeRecurringExpenses は起動時と interval ごとに、すべてのユーザーの繰り返しルールから未生成の予定支出を生成する
+func materializeRecurringExpenses(service services.RecurringExpenseService, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, err := service.MaterializeAll(context.Background()); err != nil {
+			log.Printf("Failed to materialize recurring expenses: %v", err)
+		}
+		<-ticker.C
+	}
+}
@@ -11,34 +11,178 @@ import (
 
 const categoryExists = `-- name: CategoryExists :one
 SELECT EXISTS (
-  SELECT 1 
-  FROM categories 
-  WHERE id = $1
+  SELECT 1
+  FROM categories c
+  WHERE c.id = $1
+    AND (c.user_id IS NULL OR c.user_id = $2::text)
+    AND NOT EXISTS (
+      SELECT 1
+      FROM hidden_categories h
+      WHERE h.user_id = $2::text AND h.category_id = c.id
+    )
 )
 `
 
-func (q *Queries) CategoryExists(ctx context.Context, id int32) (bool, error) {
-	row := q.db.QueryRowContext(ctx, categoryExists, id)
+type CategoryExistsParams struct {
+	ID     int32
+	UserID string
+}
+
+func (q *Queries) CategoryExists(ctx context.Context, arg CategoryExistsParams) (bool, error) {
+	row := q.db.QueryRowContext(ctx, categoryExists, arg.ID, arg.UserID)
 	var exists bool
 	err := row.Scan(&exists)
 	return exists, err
 }
 
-const listCategories = `-- name: ListCategories :many
+const countExpensesByCategory = `-- name: CountExpensesByCategory :one
+SELECT COUNT(*)
+FROM (
+  SELECT e.id
+  FROM expenses e
+  WHERE e.user_id = $1
+    AND (
+      e.category_id = $2
+      OR EXISTS (
+        SELECT 1 FROM expense_items i
+        WHERE i.expense_id = e.id AND i.category_id = $2
+      )
+    )
+  UNION ALL
+  SELECT r.id
+  FROM recurring_rules r
+  WHERE r.user_id = $1 AND r.category_id = $2
+) used
+`
+
+type CountExpensesByCategoryParams struct {
+	UserID     string
+	CategoryID int32
+}
+
+// 明細のカテゴリとして使われている支出も数えます。
+// ゴミ箱の支出も数えます（復元したときにカテゴリが残っているようにするため）。
+// 繰り返しルールもカテゴリを参照するため、あわせて数えます。
+func (q *Queries) CountExpensesByCategory(ctx context.Context, arg CountExpensesByCategoryParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countExpensesByCategory, arg.UserID, arg.CategoryID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createCategory = `-- name: CreateCategory :one
+INSERT INTO categories (
+  user_id,
+  name
+) VALUES (
+  $1::text, $2
+)
+RETURNING id, name
+`
+
+type CreateCategoryParams struct {
+	UserID string
+	Name   string
+}
+
+type CreateCategoryRow struct {
+	ID   int32
+	Name string
+}
+
+func (q *Queries) CreateCategory(ctx context.Context, arg CreateCategoryParams) (CreateCategoryRow, error) {
+	row := q.db.QueryRowContext(ctx, createCategory, arg.UserID, arg.Name)
+	var i CreateCategoryRow
+	err := row.Scan(&i.ID, &i.Name)
+	return i, err
+}
+
+const deleteCategory = `-- name: DeleteCategory :exec
+DELETE FROM categories
+WHERE id = $1 AND user_id = $2::text
+`
+
+type DeleteCategoryParams struct {
+	ID     int32
+	UserID string
+}
+
+func (q *Queries) DeleteCategory(ctx context.Context, arg DeleteCategoryParams) error {
+	_, err := q.db.ExecContext(ctx, deleteCategory, arg.ID, arg.UserID)
+	return err
+}
+
+const getCategoryForUser = `-- name: GetCategoryForUser :one
 SELECT
   id,
-  name
+  name,
+  (user_id IS NOT NULL)::boolean AS is_custom
 FROM categories
-ORDER BY id
+WHERE id = $1
+  AND (user_id IS NULL OR user_id = $2::text)
+`
+
+type GetCategoryForUserParams struct {
+	ID     int32
+	UserID string
+}
+
+type GetCategoryForUserRow struct {
+	ID       int32
+	Name     string
+	IsCustom bool
+}
+
+func (q *Queries) GetCategoryForUser(ctx context.Context, arg GetCategoryForUserParams) (GetCategoryForUserRow, error) {
+	row := q.db.QueryRowContext(ctx, getCategoryForUser, arg.ID, arg.UserID)
+	var i GetCategoryForUserRow
+	err := row.Scan(&i.ID, &i.Name, &i.IsCustom)
+	return i, err
+}
+
+const hideCategory = `-- name: HideCategory :exec
+INSERT INTO hidden_categories (
+  user_id,
+  category_id
+) VALUES (
+  $1, $2
+)
+ON CONFLICT DO NOTHING
+`
+
+type HideCategoryParams struct {
+	UserID     string
+	CategoryID int32
+}
+
+func (q *Queries) HideCategory(ctx context.Context, arg HideCategoryParams) error {
+	_, err := q.db.ExecContext(ctx, hideCategory, arg.UserID, arg.CategoryID)
+	return err
+}
+
+const listCategories = `-- name: ListCategories :many
+SELECT
+  c.id,
+  c.name,
+  (c.user_id IS NOT NULL)::boolean AS is_custom
+FROM categories c
+WHERE (c.user_id IS NULL OR c.user_id = $1::text)
+  AND NOT EXISTS (
+    SELECT 1
+    FROM hidden_categories h
+    WHERE h.user_id = $1::text AND h.category_id = c.id
+  )
+ORDER BY c.user_id IS NOT NULL, c.id
 `
 
 type ListCategoriesRow struct {
-	ID   int32
-	Name string
+	ID       int32
+	Name     string
+	IsCustom bool
 }
 
-func (q *Queries) ListCategories(ctx context.Context) ([]ListCategoriesRow, error) {
-	rows, err := q.db.QueryContext(ctx, listCategories)
+func (q *Queries) ListCategories(ctx context.Context, userID string) ([]ListCategoriesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listCategories, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -46,7 +190,7 @@ func (q *Queries) ListCategories(ctx context.Context) ([]ListCategoriesRow, erro
 	var items []ListCategoriesRow
 	for rows.Next() {
 		var i ListCategoriesRow
-		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+		if err := rows.Scan(&i.ID, &i.Name, &i.IsCustom); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -59,3 +203,145 @@ func (q *Queries) ListCategories(ctx context.Context) ([]ListCategoriesRow, erro
 	}
 	return items, nil
 }
+
+const listExpenseIDsByCategory = `-- name: ListExpenseIDsByCategory :many
+SELECT e.id
+FROM expenses e
+WHERE e.user_id = $1
+  AND e.deleted_at IS NULL
+  AND (
+    e.category_id = $2
+    OR EXISTS (
+      SELECT 1 FROM expense_items i
+      WHERE i.expense_id = e.id AND i.category_id = $2
+    )
+  )
+ORDER BY e.id
+`
+
+type ListExpenseIDsByCategoryParams struct {
+	UserID     string
+	CategoryID int32
+}
+
+// 支出または明細のカテゴリが category_id の支出の ID を返します。ゴミ箱の支出は含めません。
+func (q *Queries) ListExpenseIDsByCategory(ctx context.Context, arg ListExpenseIDsByCategoryParams) ([]int32, error) {
+	rows, err := q.db.QueryContext(ctx, listExpenseIDsByCategory, arg.UserID, arg.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockCategory = `-- name: LockCategory :exec
+SELECT id
+FROM categories
+WHERE id = $1 AND user_id = $2::text
+FOR UPDATE
+`
+
+type LockCategoryParams struct {
+	ID     int32
+	UserID string
+}
+
+// 削除中のカテゴリを参照する支出が並行して登録・更新されないよう、行をロックします。
+// 支出・明細の category_id の外部キーの確認はこのロックを待つため、削除の後に確認した登録・更新は外部キー制約違反になります。
+func (q *Queries) LockCategory(ctx context.Context, arg LockCategoryParams) error {
+	_, err := q.db.ExecContext(ctx, lockCategory, arg.ID, arg.UserID)
+	return err
+}
+
+const moveExpensesToCategory = `-- name: MoveExpensesToCategory :exec
+WITH moved_items AS (
+  UPDATE expense_items i
+  SET category_id = $1
+  FROM expenses x
+  WHERE x.id = i.expense_id
+    AND x.user_id = $2
+    AND i.category_id = $3
+),
+moved_rules AS (
+  UPDATE recurring_rules r
+  SET
+    category_id = $1,
+    updated_at = now()
+  WHERE r.user_id = $2
+    AND r.category_id = $3
+)
+UPDATE expenses e
+SET
+  category_id = CASE WHEN e.category_id = $3 THEN $1 ELSE e.category_id END,
+  updated_at = now(),
+  version = e.version + 1
+WHERE e.user_id = $2
+  AND (
+    e.category_id = $3
+    OR EXISTS (
+      SELECT 1 FROM expense_items i
+      WHERE i.expense_id = e.id AND i.category_id = $3
+    )
+  )
+`
+
+type MoveExpensesToCategoryParams struct {
+	ToCategoryID   int32
+	UserID         string
+	FromCategoryID int32
+}
+
+// 明細のカテゴリも移動します。ゴミ箱の支出も移動します。
+// 繰り返しルールのカテゴリも移動し、以降に生成する予定支出も移動先のカテゴリにします。
+// 明細のみが対象の支出も含め、移動した支出のバージョンを進めます。
+func (q *Queries) MoveExpensesToCategory(ctx context.Context, arg MoveExpensesToCategoryParams) error {
+	_, err := q.db.ExecContext(ctx, moveExpensesToCategory, arg.ToCategoryID, arg.UserID, arg.FromCategoryID)
+	return err
+}
+
+const unhideCategory = `-- name: UnhideCategory :exec
+DELETE FROM hidden_categories
+WHERE user_id = $1 AND category_id = $2
+`
+
+type UnhideCategoryParams struct {
+	UserID     string
+	CategoryID int32
+}
+
+func (q *Queries) UnhideCategory(ctx context.Context, arg UnhideCategoryParams) error {
+	_, err := q.db.ExecContext(ctx, unhideCategory, arg.UserID, arg.CategoryID)
+	return err
+}
+
+const updateCategory = `-- name: UpdateCategory :exec
+UPDATE categories
+SET
+  name = $1
+WHERE id = $2 AND user_id = $3::text
+`
+
+type UpdateCategoryParams struct {
+	Name   string
+	ID     int32
+	UserID string
+}
+
+func (q *Queries) UpdateCategory(ctx context.Context, arg UpdateCategoryParams) error {
+	_, err := q.db.ExecContext(ctx, updateCategory, arg.Name, arg.ID, arg.UserID)
+	return err
+}
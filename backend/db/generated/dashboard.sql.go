@@ -7,49 +7,608 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 )
 
+const getMonthlyCategoryExpensesSummary = `-- name: GetMonthlyCategoryExpensesSummary :many
+SELECT
+  c.id AS category_id,
+  c.name AS category_name,
+  b.monthly_limit,
+  COALESCE(SUM(CASE WHEN e.status IN ('confirmed', 'reimbursable') THEN e.amount ELSE 0 END), 0)::bigint AS confirmed_expenses,
+  COALESCE(SUM(CASE WHEN e.status = 'planned' THEN e.amount ELSE 0 END), 0)::bigint AS pending_expenses
+FROM categories c
+LEFT JOIN category_budgets b
+  ON b.category_id = c.id AND b.user_id = $1
+LEFT JOIN expense_lines e
+  ON e.category_id = c.id
+  AND e.user_id = $1
+  AND e.spent_at >= $2::date
+  AND e.spent_at < ($2::date + INTERVAL '1 month')
+WHERE (c.user_id IS NULL OR c.user_id = $1)
+GROUP BY c.id, c.name, b.monthly_limit
+HAVING b.monthly_limit IS NOT NULL OR COUNT(e.expense_id) > 0
+ORDER BY c.id ASC
+`
+
+type GetMonthlyCategoryExpensesSummaryParams struct {
+	UserID     string
+	MonthStart time.Time
+}
+
+type GetMonthlyCategoryExpensesSummaryRow struct {
+	CategoryID        int32
+	CategoryName      string
+	MonthlyLimit      sql.NullInt32
+	ConfirmedExpenses int64
+	PendingExpenses   int64
+}
+
+// 予算が設定されているカテゴリ、または対象月に支出があるカテゴリごとに支出を集計します。
+// 明細に分けた支出は明細ごとのカテゴリ・金額で集計します（expense_lines）。
+func (q *Queries) GetMonthlyCategoryExpensesSummary(ctx context.Context, arg GetMonthlyCategoryExpensesSummaryParams) ([]GetMonthlyCategoryExpensesSummaryRow, error) {
+	rows, err := q.db.QueryContext(ctx, getMonthlyCategoryExpensesSummary, arg.UserID, arg.MonthStart)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetMonthlyCategoryExpensesSummaryRow
+	for rows.Next() {
+		var i GetMonthlyCategoryExpensesSummaryRow
+		if err := rows.Scan(
+			&i.CategoryID,
+			&i.CategoryName,
+			&i.MonthlyLimit,
+			&i.ConfirmedExpenses,
+			&i.PendingExpenses,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getMonthlyExpensesSummary = `-- name: GetMonthlyExpensesSummary :one
 SELECT
-  COALESCE(SUM(CASE WHEN e.status = 'confirmed' THEN e.amount ELSE 0 END), 0)::bigint AS confirmed_expenses,
+  COALESCE(SUM(CASE WHEN e.status IN ('confirmed', 'reimbursable') THEN e.amount ELSE 0 END), 0)::bigint AS confirmed_expenses,
   COALESCE(SUM(CASE WHEN e.status = 'planned' THEN e.amount ELSE 0 END), 0)::bigint AS pending_expenses
 FROM expenses e
 WHERE e.user_id = $1
-  AND DATE_TRUNC('month', e.spent_at) = DATE_TRUNC('month', CURRENT_DATE)
+  AND e.deleted_at IS NULL
+  AND e.spent_at >= $2::date
+  AND e.spent_at < ($2::date + INTERVAL '1 month')
 `
 
+type GetMonthlyExpensesSummaryParams struct {
+	UserID     string
+	MonthStart time.Time
+}
+
 type GetMonthlyExpensesSummaryRow struct {
 	ConfirmedExpenses int64
 	PendingExpenses   int64
 }
 
-func (q *Queries) GetMonthlyExpensesSummary(ctx context.Context, userID string) (GetMonthlyExpensesSummaryRow, error) {
-	row := q.db.QueryRowContext(ctx, getMonthlyExpensesSummary, userID)
+// 立替中（reimbursable）は精算されるまで確定支出に含め、取りやめ（cancelled）・精算済み（reimbursed）は集計しません。
+// ゴミ箱の支出は集計しません（以下の集計もすべて同じ）。
+func (q *Queries) GetMonthlyExpensesSummary(ctx context.Context, arg GetMonthlyExpensesSummaryParams) (GetMonthlyExpensesSummaryRow, error) {
+	row := q.db.QueryRowContext(ctx, getMonthlyExpensesSummary, arg.UserID, arg.MonthStart)
 	var i GetMonthlyExpensesSummaryRow
 	err := row.Scan(&i.ConfirmedExpenses, &i.PendingExpenses)
 	return i, err
 }
 
+const getMonthlyMemberExpensesSummary = `-- name: GetMonthlyMemberExpensesSummary :many
+WITH members AS (
+  SELECT $1::text AS member_id
+  UNION
+  SELECT hm.user_id
+  FROM households h
+  JOIN household_members hm ON hm.household_id = h.id
+  WHERE h.owner_id = $1
+  UNION
+  SELECT x.created_by
+  FROM expenses x
+  WHERE x.user_id = $1
+    AND x.deleted_at IS NULL
+    AND x.spent_at >= $2::date
+    AND x.spent_at < ($2::date + INTERVAL '1 month')
+)
+SELECT
+  m.member_id,
+  COALESCE(SUM(CASE WHEN e.status IN ('confirmed', 'reimbursable') THEN e.amount ELSE 0 END), 0)::bigint AS confirmed_expenses,
+  COALESCE(SUM(CASE WHEN e.status = 'planned' THEN e.amount ELSE 0 END), 0)::bigint AS pending_expenses
+FROM members m
+LEFT JOIN expenses e
+  ON e.created_by = m.member_id
+  AND e.user_id = $1
+  AND e.deleted_at IS NULL
+  AND e.spent_at >= $2::date
+  AND e.spent_at < ($2::date + INTERVAL '1 month')
+GROUP BY m.member_id
+ORDER BY m.member_id = $1 DESC, m.member_id ASC
+`
+
+type GetMonthlyMemberExpensesSummaryParams struct {
+	UserID     string
+	MonthStart time.Time
+}
+
+type GetMonthlyMemberExpensesSummaryRow struct {
+	MemberID          string
+	ConfirmedExpenses int64
+	PendingExpenses   int64
+}
+
+// 家計簿（user_id）を共有する世帯のメンバーと、対象月に支出を登録したユーザーごとに支出を集計します。
+// 世帯を作成していない場合は user_id 本人のみを返します。家計簿の所有者を先頭に返します。
+func (q *Queries) GetMonthlyMemberExpensesSummary(ctx context.Context, arg GetMonthlyMemberExpensesSummaryParams) ([]GetMonthlyMemberExpensesSummaryRow, error) {
+	rows, err := q.db.QueryContext(ctx, getMonthlyMemberExpensesSummary, arg.UserID, arg.MonthStart)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetMonthlyMemberExpensesSummaryRow
+	for rows.Next() {
+		var i GetMonthlyMemberExpensesSummaryRow
+		if err := rows.Scan(&i.MemberID, &i.ConfirmedExpenses, &i.PendingExpenses); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getMonthlySummary = `-- name: GetMonthlySummary :one
 SELECT
-  u.income,
-  u.saving_goal,
-  COALESCE(SUM(fc.amount), 0)::bigint AS fixed_costs
+  COALESCE(us.income, u.income)::int AS income,
+  COALESCE(us.saving_goal, u.saving_goal)::int AS saving_goal,
+  COALESCE((
+    SELECT SUM(
+      CASE
+        WHEN f.months = 1 THEN v.amount
+        WHEN $1::text = 'amortize' THEN ROUND(v.amount::numeric / f.months)
+        WHEN MOD(EXTRACT(MONTH FROM $2::date)::int - v.billing_month + 12, f.months) = 0 THEN v.amount
+        ELSE 0
+      END
+    )
+    FROM fixed_costs fc
+    JOIN LATERAL (
+      SELECT fv.amount, fv.frequency, fv.billing_month
+      FROM fixed_cost_versions fv
+      WHERE fv.fixed_cost_id = fc.id
+        AND fv.effective_from <= $2::date
+      ORDER BY fv.effective_from DESC
+      LIMIT 1
+    ) v ON true
+    JOIN (
+      VALUES ('monthly', 1), ('bimonthly', 2), ('quarterly', 3), ('yearly', 12)
+    ) AS f(frequency, months) ON f.frequency = v.frequency
+    WHERE fc.user_id = u.id
+      AND (fc.ended_from IS NULL OR fc.ended_from > $2::date)
+  ), 0)::bigint AS fixed_costs
 FROM users u
-LEFT JOIN fixed_costs fc ON fc.user_id = u.id
-WHERE u.id = $1
-GROUP BY u.id
+LEFT JOIN LATERAL (
+  SELECT h.income, h.saving_goal
+  FROM user_settings_history h
+  WHERE h.user_id = u.id
+    AND h.effective_from <= $2::date
+  ORDER BY h.effective_from DESC
+  LIMIT 1
+) us ON true
+WHERE u.id = $3
 `
 
+type GetMonthlySummaryParams struct {
+	FixedCostMode string
+	MonthStart    time.Time
+	UserID        string
+}
+
 type GetMonthlySummaryRow struct {
 	Income     int32
 	SavingGoal int32
 	FixedCosts int64
 }
 
-func (q *Queries) GetMonthlySummary(ctx context.Context, id string) (GetMonthlySummaryRow, error) {
-	row := q.db.QueryRowContext(ctx, getMonthlySummary, id)
+// 収入・貯金目標・固定費は、適用開始月が対象月以前で最も新しい版の値を使います。
+// 収入・貯金目標の履歴がない場合は users の値を使い、対象月に有効な版がない固定費や解約済みの固定費は計上しません。
+// ゴミ箱の固定費は削除時に解約月（ended_from）を記録しているため、その月以降は計上しません。
+// 毎月以外の固定費は、fixed_cost_mode が amortize の場合は周期の月数で割って毎月計上し、
+// billing_month の場合は請求月（billing_month から周期ごとの月）にのみ全額を計上します。
+func (q *Queries) GetMonthlySummary(ctx context.Context, arg GetMonthlySummaryParams) (GetMonthlySummaryRow, error) {
+	row := q.db.QueryRowContext(ctx, getMonthlySummary, arg.FixedCostMode, arg.MonthStart, arg.UserID)
 	var i GetMonthlySummaryRow
 	err := row.Scan(&i.Income, &i.SavingGoal, &i.FixedCosts)
 	return i, err
 }
+
+const listMonthlyCategoryEstimateAccuracy = `-- name: ListMonthlyCategoryEstimateAccuracy :many
+SELECT
+  date_trunc('month', e.spent_at)::date AS month_start,
+  c.id AS category_id,
+  c.name AS category_name,
+  COUNT(*)::bigint AS expense_count,
+  SUM(e.planned_amount)::bigint AS planned_total,
+  SUM(e.amount)::bigint AS actual_total,
+  SUM(ABS(e.amount - e.planned_amount))::bigint AS absolute_variance,
+  COUNT(*) FILTER (WHERE e.amount > e.planned_amount)::bigint AS underestimated_count,
+  COUNT(*) FILTER (WHERE e.amount < e.planned_amount)::bigint AS overestimated_count
+FROM expenses e
+JOIN categories c ON c.id = e.category_id
+WHERE e.user_id = $1
+  AND e.deleted_at IS NULL
+  AND e.planned_amount IS NOT NULL
+  AND e.spent_at >= $2::date
+  AND e.spent_at < ($3::date + INTERVAL '1 month')
+GROUP BY 1, c.id, c.name
+ORDER BY month_start ASC, c.id ASC
+`
+
+type ListMonthlyCategoryEstimateAccuracyParams struct {
+	UserID    string
+	FromMonth time.Time
+	ToMonth   time.Time
+}
+
+type ListMonthlyCategoryEstimateAccuracyRow struct {
+	MonthStart          time.Time
+	CategoryID          int32
+	CategoryName        string
+	ExpenseCount        int64
+	PlannedTotal        int64
+	ActualTotal         int64
+	AbsoluteVariance    int64
+	UnderestimatedCount int64
+	OverestimatedCount  int64
+}
+
+// from_month から to_month までに予定から確定にした支出（planned_amount があるもの）の予定金額と確定金額を月・カテゴリごとに集計します。
+func (q *Queries) ListMonthlyCategoryEstimateAccuracy(ctx context.Context, arg ListMonthlyCategoryEstimateAccuracyParams) ([]ListMonthlyCategoryEstimateAccuracyRow, error) {
+	rows, err := q.db.QueryContext(ctx, listMonthlyCategoryEstimateAccuracy, arg.UserID, arg.FromMonth, arg.ToMonth)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListMonthlyCategoryEstimateAccuracyRow
+	for rows.Next() {
+		var i ListMonthlyCategoryEstimateAccuracyRow
+		if err := rows.Scan(
+			&i.MonthStart,
+			&i.CategoryID,
+			&i.CategoryName,
+			&i.ExpenseCount,
+			&i.PlannedTotal,
+			&i.ActualTotal,
+			&i.AbsoluteVariance,
+			&i.UnderestimatedCount,
+			&i.OverestimatedCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMonthlyCategoryExpenses = `-- name: ListMonthlyCategoryExpenses :many
+SELECT
+  date_trunc('month', e.spent_at)::date AS month_start,
+  c.id AS category_id,
+  c.name AS category_name,
+  SUM(CASE WHEN e.status IN ('confirmed', 'reimbursable') THEN e.amount ELSE 0 END)::bigint AS confirmed_expenses,
+  SUM(CASE WHEN e.status = 'planned' THEN e.amount ELSE 0 END)::bigint AS pending_expenses
+FROM expense_lines e
+JOIN categories c ON c.id = e.category_id
+WHERE e.user_id = $1
+  AND e.spent_at >= $2::date
+  AND e.spent_at < ($3::date + INTERVAL '1 month')
+GROUP BY 1, c.id, c.name
+ORDER BY c.id ASC, month_start ASC
+`
+
+type ListMonthlyCategoryExpensesParams struct {
+	UserID    string
+	FromMonth time.Time
+	ToMonth   time.Time
+}
+
+type ListMonthlyCategoryExpensesRow struct {
+	MonthStart        time.Time
+	CategoryID        int32
+	CategoryName      string
+	ConfirmedExpenses int64
+	PendingExpenses   int64
+}
+
+// from_month から to_month までの支出を月・カテゴリごとに集計します。支出がない月・カテゴリの組み合わせは返しません。
+// 明細に分けた支出は明細ごとのカテゴリ・金額で集計します（expense_lines）。
+func (q *Queries) ListMonthlyCategoryExpenses(ctx context.Context, arg ListMonthlyCategoryExpensesParams) ([]ListMonthlyCategoryExpensesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listMonthlyCategoryExpenses, arg.UserID, arg.FromMonth, arg.ToMonth)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListMonthlyCategoryExpensesRow
+	for rows.Next() {
+		var i ListMonthlyCategoryExpensesRow
+		if err := rows.Scan(
+			&i.MonthStart,
+			&i.CategoryID,
+			&i.CategoryName,
+			&i.ConfirmedExpenses,
+			&i.PendingExpenses,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMonthlySummaries = `-- name: ListMonthlySummaries :many
+WITH months AS (
+  SELECT gs::date AS month_start
+  FROM generate_series($1::date, $2::date, INTERVAL '1 month') AS gs
+), spent AS (
+  SELECT
+    date_trunc('month', e.spent_at)::date AS month_start,
+    SUM(CASE WHEN e.status IN ('confirmed', 'reimbursable') THEN e.amount ELSE 0 END) AS confirmed_expenses,
+    SUM(CASE WHEN e.status = 'planned' THEN e.amount ELSE 0 END) AS pending_expenses
+  FROM expenses e
+  WHERE e.user_id = $3
+    AND e.deleted_at IS NULL
+    AND e.spent_at >= $1::date
+    AND e.spent_at < ($2::date + INTERVAL '1 month')
+  GROUP BY 1
+)
+SELECT
+  m.month_start,
+  COALESCE(us.income, u.income)::int AS income,
+  COALESCE(us.saving_goal, u.saving_goal)::int AS saving_goal,
+  COALESCE((
+    SELECT SUM(
+      CASE
+        WHEN f.months = 1 THEN v.amount
+        WHEN $4::text = 'amortize' THEN ROUND(v.amount::numeric / f.months)
+        WHEN MOD(EXTRACT(MONTH FROM m.month_start)::int - v.billing_month + 12, f.months) = 0 THEN v.amount
+        ELSE 0
+      END
+    )
+    FROM fixed_costs fc
+    JOIN LATERAL (
+      SELECT fv.amount, fv.frequency, fv.billing_month
+      FROM fixed_cost_versions fv
+      WHERE fv.fixed_cost_id = fc.id
+        AND fv.effective_from <= m.month_start
+      ORDER BY fv.effective_from DESC
+      LIMIT 1
+    ) v ON true
+    JOIN (
+      VALUES ('monthly', 1), ('bimonthly', 2), ('quarterly', 3), ('yearly', 12)
+    ) AS f(frequency, months) ON f.frequency = v.frequency
+    WHERE fc.user_id = u.id
+      AND (fc.ended_from IS NULL OR fc.ended_from > m.month_start)
+  ), 0)::bigint AS fixed_costs,
+  COALESCE(s.confirmed_expenses, 0)::bigint AS confirmed_expenses,
+  COALESCE(s.pending_expenses, 0)::bigint AS pending_expenses
+FROM months m
+CROSS JOIN users u
+LEFT JOIN LATERAL (
+  SELECT h.income, h.saving_goal
+  FROM user_settings_history h
+  WHERE h.user_id = u.id
+    AND h.effective_from <= m.month_start
+  ORDER BY h.effective_from DESC
+  LIMIT 1
+) us ON true
+LEFT JOIN spent s ON s.month_start = m.month_start
+WHERE u.id = $3
+ORDER BY m.month_start ASC
+`
+
+type ListMonthlySummariesParams struct {
+	FromMonth     time.Time
+	ToMonth       time.Time
+	UserID        string
+	FixedCostMode string
+}
+
+type ListMonthlySummariesRow struct {
+	MonthStart        time.Time
+	Income            int32
+	SavingGoal        int32
+	FixedCosts        int64
+	ConfirmedExpenses int64
+	PendingExpenses   int64
+}
+
+// from_month から to_month までの各月について、GetMonthlySummary・GetMonthlyExpensesSummary と同じ集計をまとめて行います。
+// ユーザーが存在しない場合は行を返しません。
+func (q *Queries) ListMonthlySummaries(ctx context.Context, arg ListMonthlySummariesParams) ([]ListMonthlySummariesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listMonthlySummaries,
+		arg.FromMonth,
+		arg.ToMonth,
+		arg.UserID,
+		arg.FixedCostMode,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListMonthlySummariesRow
+	for rows.Next() {
+		var i ListMonthlySummariesRow
+		if err := rows.Scan(
+			&i.MonthStart,
+			&i.Income,
+			&i.SavingGoal,
+			&i.FixedCosts,
+			&i.ConfirmedExpenses,
+			&i.PendingExpenses,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTagCategoryExpenses = `-- name: ListTagCategoryExpenses :many
+SELECT
+  et.tag_id,
+  c.id AS category_id,
+  c.name AS category_name,
+  SUM(CASE WHEN e.status IN ('confirmed', 'reimbursable') THEN e.amount ELSE 0 END)::bigint AS confirmed_expenses,
+  SUM(CASE WHEN e.status = 'planned' THEN e.amount ELSE 0 END)::bigint AS pending_expenses
+FROM tags t
+JOIN expense_tags et ON et.tag_id = t.id
+JOIN expense_lines e ON e.expense_id = et.expense_id
+JOIN categories c ON c.id = e.category_id
+WHERE t.user_id = $1
+  AND e.status IN ('planned', 'confirmed', 'reimbursable')
+  AND e.spent_at >= $2::date
+  AND e.spent_at <= $3::date
+GROUP BY et.tag_id, c.id, c.name
+ORDER BY et.tag_id ASC, c.id ASC
+`
+
+type ListTagCategoryExpensesParams struct {
+	UserID   string
+	FromDate time.Time
+	ToDate   time.Time
+}
+
+type ListTagCategoryExpensesRow struct {
+	TagID             int32
+	CategoryID        int32
+	CategoryName      string
+	ConfirmedExpenses int64
+	PendingExpenses   int64
+}
+
+// ListTagTotals と同じ支出をタグ・カテゴリごとに集計します。明細に分けた支出は明細ごとのカテゴリ・金額で集計します（expense_lines）。
+func (q *Queries) ListTagCategoryExpenses(ctx context.Context, arg ListTagCategoryExpensesParams) ([]ListTagCategoryExpensesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listTagCategoryExpenses, arg.UserID, arg.FromDate, arg.ToDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTagCategoryExpensesRow
+	for rows.Next() {
+		var i ListTagCategoryExpensesRow
+		if err := rows.Scan(
+			&i.TagID,
+			&i.CategoryID,
+			&i.CategoryName,
+			&i.ConfirmedExpenses,
+			&i.PendingExpenses,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTagTotals = `-- name: ListTagTotals :many
+SELECT
+  t.id AS tag_id,
+  t.name AS tag_name,
+  COUNT(*)::bigint AS expense_count,
+  SUM(CASE WHEN e.status IN ('confirmed', 'reimbursable') THEN e.amount ELSE 0 END)::bigint AS confirmed_expenses,
+  SUM(CASE WHEN e.status = 'planned' THEN e.amount ELSE 0 END)::bigint AS pending_expenses
+FROM tags t
+JOIN expense_tags et ON et.tag_id = t.id
+JOIN expenses e ON e.id = et.expense_id
+WHERE t.user_id = $1
+  AND e.deleted_at IS NULL
+  AND e.status IN ('planned', 'confirmed', 'reimbursable')
+  AND e.spent_at >= $2::date
+  AND e.spent_at <= $3::date
+GROUP BY t.id, t.name
+ORDER BY confirmed_expenses DESC, t.id ASC
+`
+
+type ListTagTotalsParams struct {
+	UserID   string
+	FromDate time.Time
+	ToDate   time.Time
+}
+
+type ListTagTotalsRow struct {
+	TagID             int32
+	TagName           string
+	ExpenseCount      int64
+	ConfirmedExpenses int64
+	PendingExpenses   int64
+}
+
+// from_date から to_date（両端を含む）までの支出をタグごとに集計します。期間内に支出がないタグは返しません。
+// 取りやめ（cancelled）・精算済み（reimbursed）の支出は集計しません。確定支出の多いタグから順に返します。
+func (q *Queries) ListTagTotals(ctx context.Context, arg ListTagTotalsParams) ([]ListTagTotalsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listTagTotals, arg.UserID, arg.FromDate, arg.ToDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTagTotalsRow
+	for rows.Next() {
+		var i ListTagTotalsRow
+		if err := rows.Scan(
+			&i.TagID,
+			&i.TagName,
+			&i.ExpenseCount,
+			&i.ConfirmedExpenses,
+			&i.PendingExpenses,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -9,31 +9,172 @@ import (
 	"context"
 	"database/sql"
 	"time"
+
+	"github.com/lib/pq"
 )
 
+const bulkCreateExpenses = `-- name: BulkCreateExpenses :many
+WITH created AS (
+  INSERT INTO expenses (
+    user_id,
+    amount,
+    category_id,
+    memo,
+    spent_at,
+    status,
+    created_by
+  )
+  SELECT $1::text, a.amount, c.category_id, NULLIF(m.memo, ''), d.spent_at, st.status, cb.created_by
+  FROM UNNEST($2::int[]) WITH ORDINALITY AS a(amount, ord)
+  JOIN UNNEST($3::int[]) WITH ORDINALITY AS c(category_id, ord) USING (ord)
+  JOIN UNNEST($4::text[]) WITH ORDINALITY AS m(memo, ord) USING (ord)
+  JOIN UNNEST($5::date[]) WITH ORDINALITY AS d(spent_at, ord) USING (ord)
+  JOIN UNNEST($6::text[]) WITH ORDINALITY AS st(status, ord) USING (ord)
+  JOIN UNNEST($7::text[]) WITH ORDINALITY AS cb(created_by, ord) USING (ord)
+  ORDER BY ord
+  RETURNING id, amount, memo, spent_at, status, created_by, version, planned_amount, category_id
+)
+SELECT
+  e.id,
+  e.amount,
+  e.memo,
+  e.spent_at,
+  e.status,
+  e.created_by,
+  e.version,
+  e.planned_amount,
+  c.id AS category_id,
+  c.name AS category_name
+FROM created e
+JOIN categories c ON e.category_id = c.id
+ORDER BY e.id ASC
+`
+
+type BulkCreateExpensesParams struct {
+	UserID      string
+	Amounts     []int32
+	CategoryIds []int32
+	Memos       []string
+	SpentAts    []time.Time
+	Statuses    []string
+	CreatedBys  []string
+}
+
+type BulkCreateExpensesRow struct {
+	ID            int32
+	Amount        int32
+	Memo          sql.NullString
+	SpentAt       time.Time
+	Status        string
+	CreatedBy     string
+	Version       int32
+	PlannedAmount sql.NullInt32
+	CategoryID    int32
+	CategoryName  string
+}
+
+// 登録した支出を GetExpenseWithCategoryByID と同じ列で返します。
+// ID は行の順（ORDER BY ord）に採番されるため、ID の昇順が入力の順になります。
+func (q *Queries) BulkCreateExpenses(ctx context.Context, arg BulkCreateExpensesParams) ([]BulkCreateExpensesRow, error) {
+	rows, err := q.db.QueryContext(ctx, bulkCreateExpenses,
+		arg.UserID,
+		pq.Array(arg.Amounts),
+		pq.Array(arg.CategoryIds),
+		pq.Array(arg.Memos),
+		pq.Array(arg.SpentAts),
+		pq.Array(arg.Statuses),
+		pq.Array(arg.CreatedBys),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []BulkCreateExpensesRow
+	for rows.Next() {
+		var i BulkCreateExpensesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Amount,
+			&i.Memo,
+			&i.SpentAt,
+			&i.Status,
+			&i.CreatedBy,
+			&i.Version,
+			&i.PlannedAmount,
+			&i.CategoryID,
+			&i.CategoryName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const createExpense = `-- name: CreateExpense :one
-INSERT INTO expenses (
-  user_id,
-  amount,
-  category_id,
-  memo,
-  spent_at,
-  status
-) VALUES (
-  $1, $2, $3, $4, $5, $6
+WITH created AS (
+  INSERT INTO expenses (
+    user_id,
+    amount,
+    category_id,
+    memo,
+    spent_at,
+    status,
+    created_by
+  ) VALUES (
+    $1, $2, $3, $4, $5, $6, $7
+  )
+  RETURNING id
+), created_items AS (
+  INSERT INTO expense_items (
+    expense_id,
+    position,
+    category_id,
+    amount,
+    memo
+  )
+  SELECT created.id, a.ord, c.category_id, a.amount, NULLIF(m.memo, '')
+  FROM created
+  CROSS JOIN UNNEST($8::int[]) WITH ORDINALITY AS a(amount, ord)
+  JOIN UNNEST($9::int[]) WITH ORDINALITY AS c(category_id, ord) USING (ord)
+  JOIN UNNEST($10::text[]) WITH ORDINALITY AS m(memo, ord) USING (ord)
+), tagged AS (
+  INSERT INTO tags (user_id, name)
+  SELECT $1, t.name
+  FROM UNNEST($11::text[]) AS t(name)
+  ON CONFLICT (user_id, name) DO UPDATE SET name = EXCLUDED.name
+  RETURNING id
+), created_tags AS (
+  INSERT INTO expense_tags (expense_id, tag_id)
+  SELECT created.id, tagged.id
+  FROM created
+  CROSS JOIN tagged
 )
-RETURNING id
+SELECT id FROM created
 `
 
 type CreateExpenseParams struct {
-	UserID     string
-	Amount     int32
-	CategoryID int32
-	Memo       sql.NullString
-	SpentAt    time.Time
-	Status     string
+	UserID          string
+	Amount          int32
+	CategoryID      int32
+	Memo            sql.NullString
+	SpentAt         time.Time
+	Status          string
+	CreatedBy       string
+	ItemAmounts     []int32
+	ItemCategoryIds []int32
+	ItemMemos       []string
+	TagNames        []string
 }
 
+// 明細（item_*、同じ順序の配列）があれば支出と同時に登録します。明細がない場合は空配列を指定します。
+// tag_names のタグを付けます。ユーザーにまだないタグはここで作成します（tag_names に重複がないこと）。
 func (q *Queries) CreateExpense(ctx context.Context, arg CreateExpenseParams) (int32, error) {
 	row := q.db.QueryRowContext(ctx, createExpense,
 		arg.UserID,
@@ -42,6 +183,11 @@ func (q *Queries) CreateExpense(ctx context.Context, arg CreateExpenseParams) (i
 		arg.Memo,
 		arg.SpentAt,
 		arg.Status,
+		arg.CreatedBy,
+		pq.Array(arg.ItemAmounts),
+		pq.Array(arg.ItemCategoryIds),
+		pq.Array(arg.ItemMemos),
+		pq.Array(arg.TagNames),
 	)
 	var id int32
 	err := row.Scan(&id)
@@ -49,8 +195,10 @@ func (q *Queries) CreateExpense(ctx context.Context, arg CreateExpenseParams) (i
 }
 
 const deleteExpense = `-- name: DeleteExpense :exec
-DELETE FROM expenses
-WHERE id = $1 AND user_id = $2
+UPDATE expenses
+SET
+  deleted_at = now()
+WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
 `
 
 type DeleteExpenseParams struct {
@@ -58,6 +206,7 @@ type DeleteExpenseParams struct {
 	UserID string
 }
 
+// 支出をゴミ箱に移します。明細・タグは残し、復元すると元に戻ります。
 func (q *Queries) DeleteExpense(ctx context.Context, arg DeleteExpenseParams) error {
 	_, err := q.db.ExecContext(ctx, deleteExpense, arg.ID, arg.UserID)
 	return err
@@ -72,7 +221,7 @@ SELECT
   spent_at,
   status
 FROM expenses
-WHERE user_id = $1 AND id = $2
+WHERE user_id = $1 AND id = $2 AND deleted_at IS NULL
 `
 
 type GetExpenseByIDParams struct {
@@ -110,11 +259,14 @@ SELECT
   e.memo,
   e.spent_at,
   e.status,
+  e.created_by,
+  e.version,
+  e.planned_amount,
   c.id AS category_id,
   c.name AS category_name
 FROM expenses e
 JOIN categories c ON e.category_id = c.id
-WHERE e.user_id = $1 AND e.id = $2
+WHERE e.user_id = $1 AND e.id = $2 AND e.deleted_at IS NULL
 `
 
 type GetExpenseWithCategoryByIDParams struct {
@@ -123,15 +275,19 @@ type GetExpenseWithCategoryByIDParams struct {
 }
 
 type GetExpenseWithCategoryByIDRow struct {
-	ID           int32
-	Amount       int32
-	Memo         sql.NullString
-	SpentAt      time.Time
-	Status       string
-	CategoryID   int32
-	CategoryName string
+	ID            int32
+	Amount        int32
+	Memo          sql.NullString
+	SpentAt       time.Time
+	Status        string
+	CreatedBy     string
+	Version       int32
+	PlannedAmount sql.NullInt32
+	CategoryID    int32
+	CategoryName  string
 }
 
+// ゴミ箱の支出は返しません。
 func (q *Queries) GetExpenseWithCategoryByID(ctx context.Context, arg GetExpenseWithCategoryByIDParams) (GetExpenseWithCategoryByIDRow, error) {
 	row := q.db.QueryRowContext(ctx, getExpenseWithCategoryByID, arg.UserID, arg.ID)
 	var i GetExpenseWithCategoryByIDRow
@@ -141,39 +297,195 @@ func (q *Queries) GetExpenseWithCategoryByID(ctx context.Context, arg GetExpense
 		&i.Memo,
 		&i.SpentAt,
 		&i.Status,
+		&i.CreatedBy,
+		&i.Version,
+		&i.PlannedAmount,
 		&i.CategoryID,
 		&i.CategoryName,
 	)
 	return i, err
 }
 
+const listExpenseItems = `-- name: ListExpenseItems :many
+SELECT
+  i.expense_id,
+  i.amount,
+  i.memo,
+  c.id AS category_id,
+  c.name AS category_name
+FROM expense_items i
+JOIN categories c ON c.id = i.category_id
+WHERE i.expense_id = ANY($1::int[])
+ORDER BY i.expense_id ASC, i.position ASC
+`
+
+type ListExpenseItemsRow struct {
+	ExpenseID    int32
+	Amount       int32
+	Memo         sql.NullString
+	CategoryID   int32
+	CategoryName string
+}
+
+// expense_ids の支出の明細を、支出ごとに登録順で返します。
+func (q *Queries) ListExpenseItems(ctx context.Context, expenseIds []int32) ([]ListExpenseItemsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listExpenseItems, pq.Array(expenseIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListExpenseItemsRow
+	for rows.Next() {
+		var i ListExpenseItemsRow
+		if err := rows.Scan(
+			&i.ExpenseID,
+			&i.Amount,
+			&i.Memo,
+			&i.CategoryID,
+			&i.CategoryName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listExpenseTags = `-- name: ListExpenseTags :many
+SELECT
+  et.expense_id,
+  t.id AS tag_id,
+  t.name AS tag_name
+FROM expense_tags et
+JOIN tags t ON t.id = et.tag_id
+WHERE et.expense_id = ANY($1::int[])
+ORDER BY et.expense_id ASC, t.name ASC
+`
+
+type ListExpenseTagsRow struct {
+	ExpenseID int32
+	TagID     int32
+	TagName   string
+}
+
+// expense_ids の支出に付けたタグを、支出ごとにタグ名順で返します。
+func (q *Queries) ListExpenseTags(ctx context.Context, expenseIds []int32) ([]ListExpenseTagsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listExpenseTags, pq.Array(expenseIds))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListExpenseTagsRow
+	for rows.Next() {
+		var i ListExpenseTagsRow
+		if err := rows.Scan(&i.ExpenseID, &i.TagID, &i.TagName); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listExpenses = `-- name: ListExpenses :many
-SELECT 
+SELECT
   e.id,
   e.amount,
   e.memo,
   e.spent_at,
   e.status,
+  e.created_by,
+  e.version,
+  e.planned_amount,
   c.id AS category_id,
   c.name AS category_name
 FROM expenses e
 JOIN categories c ON e.category_id = c.id
 WHERE e.user_id = $1
-ORDER BY spent_at DESC
+  AND e.deleted_at IS NULL
+  AND ($2::date IS NULL OR e.spent_at >= $2::date)
+  AND ($3::date IS NULL OR e.spent_at <= $3::date)
+  AND (
+    cardinality($4::int[]) = 0
+    OR e.category_id = ANY($4::int[])
+    OR EXISTS (
+      SELECT 1 FROM expense_items i
+      WHERE i.expense_id = e.id AND i.category_id = ANY($4::int[])
+    )
+  )
+  AND (
+    cardinality($5::int[]) = 0
+    OR EXISTS (
+      SELECT 1 FROM expense_tags et
+      WHERE et.expense_id = e.id AND et.tag_id = ANY($5::int[])
+    )
+  )
+  AND ($6::text IS NULL OR e.status = $6::text)
+  AND ($7::int IS NULL OR e.amount >= $7::int)
+  AND ($8::int IS NULL OR e.amount <= $8::int)
+  AND ($9::text IS NULL OR e.memo ILIKE '%' || $9::text || '%')
+  AND (
+    $10::date IS NULL
+    OR (e.spent_at, e.id) < ($10::date, $11::int)
+  )
+ORDER BY e.spent_at DESC, e.id DESC
+LIMIT $12
 `
 
+type ListExpensesParams struct {
+	UserID        string
+	FromDate      sql.NullTime
+	ToDate        sql.NullTime
+	CategoryIds   []int32
+	TagIds        []int32
+	Status        sql.NullString
+	AmountMin     sql.NullInt32
+	AmountMax     sql.NullInt32
+	Memo          sql.NullString
+	CursorSpentAt sql.NullTime
+	CursorID      int32
+	PageLimit     int32
+}
+
 type ListExpensesRow struct {
-	ID           int32
-	Amount       int32
-	Memo         sql.NullString
-	SpentAt      time.Time
-	Status       string
-	CategoryID   int32
-	CategoryName string
+	ID            int32
+	Amount        int32
+	Memo          sql.NullString
+	SpentAt       time.Time
+	Status        string
+	CreatedBy     string
+	Version       int32
+	PlannedAmount sql.NullInt32
+	CategoryID    int32
+	CategoryName  string
 }
 
-func (q *Queries) ListExpenses(ctx context.Context, userID string) ([]ListExpensesRow, error) {
-	rows, err := q.db.QueryContext(ctx, listExpenses, userID)
+func (q *Queries) ListExpenses(ctx context.Context, arg ListExpensesParams) ([]ListExpensesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listExpenses,
+		arg.UserID,
+		arg.FromDate,
+		arg.ToDate,
+		pq.Array(arg.CategoryIds),
+		pq.Array(arg.TagIds),
+		arg.Status,
+		arg.AmountMin,
+		arg.AmountMax,
+		arg.Memo,
+		arg.CursorSpentAt,
+		arg.CursorID,
+		arg.PageLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -187,6 +499,9 @@ func (q *Queries) ListExpenses(ctx context.Context, userID string) ([]ListExpens
 			&i.Memo,
 			&i.SpentAt,
 			&i.Status,
+			&i.CreatedBy,
+			&i.Version,
+			&i.PlannedAmount,
 			&i.CategoryID,
 			&i.CategoryName,
 		); err != nil {
@@ -203,56 +518,164 @@ func (q *Queries) ListExpenses(ctx context.Context, userID string) ([]ListExpens
 	return items, nil
 }
 
-const updateExpense = `-- name: UpdateExpense :exec
+const restoreExpense = `-- name: RestoreExpense :execrows
+UPDATE expenses
+SET
+  deleted_at = NULL,
+  updated_at = now(),
+  version = version + 1
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at > $3::timestamp
+`
+
+type RestoreExpenseParams struct {
+	ID           int32
+	UserID       string
+	DeletedAfter time.Time
+}
+
+// deleted_after より後にゴミ箱に移した支出を元に戻します。
+func (q *Queries) RestoreExpense(ctx context.Context, arg RestoreExpenseParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreExpense, arg.ID, arg.UserID, arg.DeletedAfter)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateExpense = `-- name: UpdateExpense :execrows
+WITH target AS (
+  SELECT id
+  FROM expenses
+  WHERE id = $1 AND user_id = $2 AND version = $3 AND deleted_at IS NULL
+  FOR UPDATE
+), deleted_items AS (
+  DELETE FROM expense_items
+  WHERE $4::boolean AND expense_id IN (SELECT id FROM target)
+), created_items AS (
+  INSERT INTO expense_items (
+    expense_id,
+    position,
+    category_id,
+    amount,
+    memo
+  )
+  SELECT target.id, a.ord, c.category_id, a.amount, NULLIF(m.memo, '')
+  FROM target
+  CROSS JOIN UNNEST($5::int[]) WITH ORDINALITY AS a(amount, ord)
+  JOIN UNNEST($6::int[]) WITH ORDINALITY AS c(category_id, ord) USING (ord)
+  JOIN UNNEST($7::text[]) WITH ORDINALITY AS m(memo, ord) USING (ord)
+  WHERE $4::boolean
+), tagged AS (
+  INSERT INTO tags (user_id, name)
+  SELECT $2, t.name
+  FROM UNNEST($8::text[]) AS t(name)
+  WHERE $9::boolean AND EXISTS (SELECT 1 FROM target)
+  ON CONFLICT (user_id, name) DO UPDATE SET name = EXCLUDED.name
+  RETURNING id
+), deleted_tags AS (
+  -- 付け直すタグは削除せずに残し、同じ文の中で同じ行を削除・追加しないようにする
+  DELETE FROM expense_tags
+  WHERE $9::boolean
+    AND expense_id IN (SELECT id FROM target)
+    AND tag_id NOT IN (SELECT id FROM tagged)
+), created_tags AS (
+  INSERT INTO expense_tags (expense_id, tag_id)
+  SELECT target.id, tagged.id
+  FROM target
+  CROSS JOIN tagged
+  ON CONFLICT DO NOTHING
+)
 UPDATE expenses
 SET
-  amount = $2,
-  category_id = $3,
-  memo = $4,
-  spent_at = $5,
-  status = $6,
-  updated_at = now()
-WHERE id = $1 AND user_id = $7
+  amount = $10,
+  category_id = $11,
+  memo = $12,
+  spent_at = $13,
+  status = $14,
+  planned_amount = COALESCE($15, planned_amount),
+  updated_at = now(),
+  version = version + 1
+WHERE id IN (SELECT id FROM target)
 `
 
 type UpdateExpenseParams struct {
-	ID         int32
-	Amount     int32
-	CategoryID int32
-	Memo       sql.NullString
-	SpentAt    time.Time
-	Status     string
-	UserID     string
+	ID              int32
+	UserID          string
+	Version         int32
+	ReplaceItems    bool
+	ItemAmounts     []int32
+	ItemCategoryIds []int32
+	ItemMemos       []string
+	TagNames        []string
+	ReplaceTags     bool
+	Amount          int32
+	CategoryID      int32
+	Memo            sql.NullString
+	SpentAt         time.Time
+	Status          string
+	PlannedAmount   sql.NullInt32
 }
 
-func (q *Queries) UpdateExpense(ctx context.Context, arg UpdateExpenseParams) error {
-	_, err := q.db.ExecContext(ctx, updateExpense,
+// version が一致する場合のみ更新します（楽観的排他制御）。
+// planned_amount は NULL の場合は変更しません。
+// replace_items が true の場合は明細を item_* の内容に置き換えます（空配列の場合は明細を削除します）。
+// replace_tags が true の場合はタグを tag_names に置き換えます（ユーザーにまだないタグは作成します）。
+func (q *Queries) UpdateExpense(ctx context.Context, arg UpdateExpenseParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateExpense,
 		arg.ID,
+		arg.UserID,
+		arg.Version,
+		arg.ReplaceItems,
+		pq.Array(arg.ItemAmounts),
+		pq.Array(arg.ItemCategoryIds),
+		pq.Array(arg.ItemMemos),
+		pq.Array(arg.TagNames),
+		arg.ReplaceTags,
 		arg.Amount,
 		arg.CategoryID,
 		arg.Memo,
 		arg.SpentAt,
 		arg.Status,
-		arg.UserID,
+		arg.PlannedAmount,
 	)
-	return err
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
-const updateExpenseStatus = `-- name: UpdateExpenseStatus :exec
+const updateExpenseStatus = `-- name: UpdateExpenseStatus :execrows
 UPDATE expenses
 SET
   status = $2,
-  updated_at = now()
-WHERE id = $1 AND user_id = $3
+  planned_amount = COALESCE($5, planned_amount),
+  updated_at = now(),
+  version = version + 1
+WHERE id = $1 AND user_id = $3 AND version = $4 AND deleted_at IS NULL
 `
 
 type UpdateExpenseStatusParams struct {
-	ID     int32
-	Status string
-	UserID string
+	ID            int32
+	Status        string
+	UserID        string
+	Version       int32
+	PlannedAmount sql.NullInt32
 }
 
-func (q *Queries) UpdateExpenseStatus(ctx context.Context, arg UpdateExpenseStatusParams) error {
-	_, err := q.db.ExecContext(ctx, updateExpenseStatus, arg.ID, arg.Status, arg.UserID)
-	return err
+// ステータスのみを変更します。version が $4 と一致する場合のみ更新します（楽観的排他制御）。
+// planned_amount は $5 が NULL の場合は変更しません。
+func (q *Queries) UpdateExpenseStatus(ctx context.Context, arg UpdateExpenseStatusParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateExpenseStatus,
+		arg.ID,
+		arg.Status,
+		arg.UserID,
+		arg.Version,
+		arg.PlannedAmount,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
@@ -7,86 +7,188 @@ package db
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	"github.com/lib/pq"
 )
 
 const bulkCreateFixedCosts = `-- name: BulkCreateFixedCosts :exec
-INSERT INTO fixed_costs (
-  user_id,
-  name,
-  amount
+WITH created AS (
+  INSERT INTO fixed_costs (
+    user_id,
+    name,
+    amount,
+    frequency,
+    billing_month,
+    billing_day
+  )
+  SELECT u.user_id, n.name, a.amount, f.frequency, NULLIF(bm.billing_month, 0), NULLIF(bd.billing_day, 0)
+  FROM UNNEST($1::text[]) WITH ORDINALITY AS u(user_id, ord)
+  JOIN UNNEST($2::text[]) WITH ORDINALITY AS n(name, ord) USING (ord)
+  JOIN UNNEST($3::int[]) WITH ORDINALITY AS a(amount, ord) USING (ord)
+  JOIN UNNEST($4::text[]) WITH ORDINALITY AS f(frequency, ord) USING (ord)
+  JOIN UNNEST($5::int[]) WITH ORDINALITY AS bm(billing_month, ord) USING (ord)
+  JOIN UNNEST($6::int[]) WITH ORDINALITY AS bd(billing_day, ord) USING (ord)
+  RETURNING id, amount, frequency, billing_month, billing_day
 )
-SELECT u.user_id, n.name, a.amount
-FROM UNNEST($1::text[]) WITH ORDINALITY AS u(user_id, ord)
-JOIN UNNEST($2::text[]) WITH ORDINALITY AS n(name, ord) USING (ord)
-JOIN UNNEST($3::int[]) WITH ORDINALITY AS a(amount, ord) USING (ord)
+INSERT INTO fixed_cost_versions (
+  fixed_cost_id,
+  effective_from,
+  amount,
+  frequency,
+  billing_month,
+  billing_day
+)
+SELECT id, $7::date, amount, frequency, billing_month, billing_day
+FROM created
 `
 
 type BulkCreateFixedCostsParams struct {
 	Column1 []string
 	Column2 []string
 	Column3 []int32
+	Column4 []string
+	Column5 []int32
+	Column6 []int32
+	Column7 time.Time
 }
 
+// billing_months・billing_days の 0 は未指定（NULL）として扱います。
+// 作成した固定費ごとに、$7 の月から適用する版を登録します。
 func (q *Queries) BulkCreateFixedCosts(ctx context.Context, arg BulkCreateFixedCostsParams) error {
-	_, err := q.db.ExecContext(ctx, bulkCreateFixedCosts, pq.Array(arg.Column1), pq.Array(arg.Column2), pq.Array(arg.Column3))
+	_, err := q.db.ExecContext(ctx, bulkCreateFixedCosts,
+		pq.Array(arg.Column1),
+		pq.Array(arg.Column2),
+		pq.Array(arg.Column3),
+		pq.Array(arg.Column4),
+		pq.Array(arg.Column5),
+		pq.Array(arg.Column6),
+		arg.Column7,
+	)
 	return err
 }
 
 const createFixedCost = `-- name: CreateFixedCost :one
-INSERT INTO fixed_costs (
-  user_id,
-  name,
-  amount
-) VALUES (
-  $1, $2, $3
+WITH created AS (
+  INSERT INTO fixed_costs (
+    user_id,
+    name,
+    amount,
+    frequency,
+    billing_month,
+    billing_day
+  ) VALUES (
+    $1, $2, $3, $4, $5, $6
+  )
+  RETURNING id, user_id, name, amount, frequency, billing_month, billing_day, ended_from, created_at, updated_at, version, deleted_at
+), version AS (
+  INSERT INTO fixed_cost_versions (
+    fixed_cost_id,
+    effective_from,
+    amount,
+    frequency,
+    billing_month,
+    billing_day
+  )
+  SELECT id, $7, amount, frequency, billing_month, billing_day
+  FROM created
 )
-RETURNING id, user_id, name, amount, created_at, updated_at
+SELECT id, user_id, name, amount, frequency, billing_month, billing_day, ended_from, created_at, updated_at, version, deleted_at FROM created
 `
 
 type CreateFixedCostParams struct {
-	UserID string
-	Name   string
-	Amount int32
+	UserID        string
+	Name          string
+	Amount        int32
+	Frequency     string
+	BillingMonth  sql.NullInt32
+	BillingDay    sql.NullInt32
+	EffectiveFrom time.Time
+}
+
+type CreateFixedCostRow struct {
+	ID           int32
+	UserID       string
+	Name         string
+	Amount       int32
+	Frequency    string
+	BillingMonth sql.NullInt32
+	BillingDay   sql.NullInt32
+	EndedFrom    sql.NullTime
+	CreatedAt    sql.NullTime
+	UpdatedAt    sql.NullTime
+	Version      int32
+	DeletedAt    sql.NullTime
 }
 
-func (q *Queries) CreateFixedCost(ctx context.Context, arg CreateFixedCostParams) (FixedCost, error) {
-	row := q.db.QueryRowContext(ctx, createFixedCost, arg.UserID, arg.Name, arg.Amount)
-	var i FixedCost
+// 固定費の作成と同時に、$7 の月から適用する版を登録します。
+func (q *Queries) CreateFixedCost(ctx context.Context, arg CreateFixedCostParams) (CreateFixedCostRow, error) {
+	row := q.db.QueryRowContext(ctx, createFixedCost,
+		arg.UserID,
+		arg.Name,
+		arg.Amount,
+		arg.Frequency,
+		arg.BillingMonth,
+		arg.BillingDay,
+		arg.EffectiveFrom,
+	)
+	var i CreateFixedCostRow
 	err := row.Scan(
 		&i.ID,
 		&i.UserID,
 		&i.Name,
 		&i.Amount,
+		&i.Frequency,
+		&i.BillingMonth,
+		&i.BillingDay,
+		&i.EndedFrom,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
-const deleteFixedCost = `-- name: DeleteFixedCost :exec
-DELETE FROM fixed_costs
-WHERE id = $1 AND user_id = $2
+const endFixedCost = `-- name: EndFixedCost :exec
+UPDATE fixed_costs
+SET
+  ended_from = $1::date,
+  deleted_at = now(),
+  updated_at = now()
+WHERE id = $2 AND user_id = $3 AND ended_from IS NULL
 `
 
-type DeleteFixedCostParams struct {
-	ID     int32
-	UserID string
+type EndFixedCostParams struct {
+	EndedFrom time.Time
+	ID        int32
+	UserID    string
 }
 
-func (q *Queries) DeleteFixedCost(ctx context.Context, arg DeleteFixedCostParams) error {
-	_, err := q.db.ExecContext(ctx, deleteFixedCost, arg.ID, arg.UserID)
+// 過去月の集計に影響しないよう、削除せずに ended_from の月以降を計上対象外にします。
+// ユーザーが削除した固定費として deleted_at を記録し、ゴミ箱から復元できるようにします。
+func (q *Queries) EndFixedCost(ctx context.Context, arg EndFixedCostParams) error {
+	_, err := q.db.ExecContext(ctx, endFixedCost, arg.EndedFrom, arg.ID, arg.UserID)
 	return err
 }
 
-const deleteFixedCostsByUser = `-- name: DeleteFixedCostsByUser :exec
-DELETE FROM fixed_costs
-WHERE user_id = $1
+const endFixedCostsByUser = `-- name: EndFixedCostsByUser :exec
+UPDATE fixed_costs
+SET
+  ended_from = $1::date,
+  updated_at = now()
+WHERE user_id = $2 AND ended_from IS NULL
 `
 
-func (q *Queries) DeleteFixedCostsByUser(ctx context.Context, userID string) error {
-	_, err := q.db.ExecContext(ctx, deleteFixedCostsByUser, userID)
+type EndFixedCostsByUserParams struct {
+	EndedFrom time.Time
+	UserID    string
+}
+
+// 過去月の集計に影響しないよう、削除せずに ended_from の月以降を計上対象外にします。
+func (q *Queries) EndFixedCostsByUser(ctx context.Context, arg EndFixedCostsByUserParams) error {
+	_, err := q.db.ExecContext(ctx, endFixedCostsByUser, arg.EndedFrom, arg.UserID)
 	return err
 }
 
@@ -96,10 +198,16 @@ SELECT
   user_id,
   name,
   amount,
+  frequency,
+  billing_month,
+  billing_day,
+  ended_from,
   created_at,
-  updated_at
+  updated_at,
+  version,
+  deleted_at
 FROM fixed_costs
-WHERE user_id = $1
+WHERE user_id = $1 AND ended_from IS NULL
 ORDER BY id ASC
 `
 
@@ -117,8 +225,14 @@ func (q *Queries) ListFixedCostsByUser(ctx context.Context, userID string) ([]Fi
 			&i.UserID,
 			&i.Name,
 			&i.Amount,
+			&i.Frequency,
+			&i.BillingMonth,
+			&i.BillingDay,
+			&i.EndedFrom,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Version,
+			&i.DeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -133,28 +247,108 @@ func (q *Queries) ListFixedCostsByUser(ctx context.Context, userID string) ([]Fi
 	return items, nil
 }
 
-const updateFixedCost = `-- name: UpdateFixedCost :exec
+const restoreFixedCost = `-- name: RestoreFixedCost :execrows
+UPDATE fixed_costs
+SET
+  ended_from = NULL,
+  deleted_at = NULL,
+  updated_at = now(),
+  version = version + 1
+WHERE id = $1
+  AND user_id = $2
+  AND deleted_at > $3::timestamp
+`
+
+type RestoreFixedCostParams struct {
+	ID           int32
+	UserID       string
+	DeletedAfter time.Time
+}
+
+// deleted_after より後にゴミ箱に移した固定費の解約を取り消します。
+func (q *Queries) RestoreFixedCost(ctx context.Context, arg RestoreFixedCostParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restoreFixedCost, arg.ID, arg.UserID, arg.DeletedAfter)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const updateFixedCost = `-- name: UpdateFixedCost :execrows
+WITH target AS (
+  SELECT id
+  FROM fixed_costs
+  WHERE id = $1 AND user_id = $4 AND ended_from IS NULL AND version = $9
+  FOR UPDATE
+), version AS (
+  INSERT INTO fixed_cost_versions (
+    fixed_cost_id,
+    effective_from,
+    amount,
+    frequency,
+    billing_month,
+    billing_day
+  )
+  SELECT id, $8, $3, $5, $6, $7
+  FROM target
+  ON CONFLICT (fixed_cost_id, effective_from) DO UPDATE
+  SET
+    amount = EXCLUDED.amount,
+    frequency = EXCLUDED.frequency,
+    billing_month = EXCLUDED.billing_month,
+    billing_day = EXCLUDED.billing_day
+), cur AS (
+  SELECT NOT EXISTS (
+    SELECT 1
+    FROM fixed_cost_versions v
+    WHERE v.fixed_cost_id = $1
+      AND v.effective_from > $8
+      AND v.effective_from <= date_trunc('month', now())
+  ) AS is_current
+)
 UPDATE fixed_costs
 SET
   name = $2,
-  amount = $3,
-  updated_at = now()
-WHERE id = $1 AND user_id = $4
+  amount = CASE WHEN cur.is_current THEN $3 ELSE fixed_costs.amount END,
+  frequency = CASE WHEN cur.is_current THEN $5 ELSE fixed_costs.frequency END,
+  billing_month = CASE WHEN cur.is_current THEN $6 ELSE fixed_costs.billing_month END,
+  billing_day = CASE WHEN cur.is_current THEN $7 ELSE fixed_costs.billing_day END,
+  updated_at = now(),
+  version = fixed_costs.version + 1
+FROM cur, target
+WHERE fixed_costs.id = target.id
 `
 
 type UpdateFixedCostParams struct {
-	ID     int32
-	Name   string
-	Amount int32
-	UserID string
+	ID            int32
+	Name          string
+	Amount        int32
+	UserID        string
+	Frequency     string
+	BillingMonth  sql.NullInt32
+	BillingDay    sql.NullInt32
+	EffectiveFrom time.Time
+	Version       int32
 }
 
-func (q *Queries) UpdateFixedCost(ctx context.Context, arg UpdateFixedCostParams) error {
-	_, err := q.db.ExecContext(ctx, updateFixedCost,
+// version が $9 と一致する場合のみ更新します（楽観的排他制御）。一致しない場合は版も登録しません。
+// $8 の月から適用する版を登録します（同じ月の版は上書き）。
+// fixed_costs の金額・支払いスケジュールは現在有効な値を保持するため、
+// $8 より後に当月以前から適用済みの版がある場合は名前のみ更新します。
+func (q *Queries) UpdateFixedCost(ctx context.Context, arg UpdateFixedCostParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateFixedCost,
 		arg.ID,
 		arg.Name,
 		arg.Amount,
 		arg.UserID,
+		arg.Frequency,
+		arg.BillingMonth,
+		arg.BillingDay,
+		arg.EffectiveFrom,
+		arg.Version,
 	)
-	return err
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
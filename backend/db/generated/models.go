@@ -6,40 +6,195 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 )
 
+type ApiToken struct {
+	ID         int32
+	UserID     string
+	Name       string
+	TokenHash  string
+	Scopes     []string
+	ExpiresAt  time.Time
+	LastUsedAt sql.NullTime
+	CreatedAt  time.Time
+}
+
+type AuditEvent struct {
+	ID         int64
+	UserID     string
+	ActorID    string
+	EntityType string
+	EntityID   sql.NullInt32
+	Action     string
+	Before     json.RawMessage
+	After      json.RawMessage
+	RequestID  string
+	CreatedAt  time.Time
+}
+
 type Category struct {
 	ID        int32
+	UserID    sql.NullString
 	Name      string
 	CreatedAt time.Time
 }
 
+type CategoryBudget struct {
+	UserID       string
+	CategoryID   int32
+	MonthlyLimit int32
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
 type Expense struct {
+	ID            int32
+	UserID        string
+	CreatedBy     string
+	Amount        int32
+	CategoryID    int32
+	Memo          sql.NullString
+	SpentAt       time.Time
+	Status        string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	Version       int32
+	PlannedAmount sql.NullInt32
+	DeletedAt     sql.NullTime
+}
+
+type ExpenseItem struct {
 	ID         int32
-	UserID     string
-	Amount     int32
+	ExpenseID  int32
+	Position   int32
 	CategoryID int32
+	Amount     int32
 	Memo       sql.NullString
+}
+
+type ExpenseLine struct {
+	ExpenseID  int32
+	UserID     string
 	SpentAt    time.Time
 	Status     string
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
+	CategoryID int32
+	Amount     int32
+}
+
+type ExpenseTag struct {
+	ExpenseID int32
+	TagID     int32
 }
 
 type FixedCost struct {
+	ID           int32
+	UserID       string
+	Name         string
+	Amount       int32
+	Frequency    string
+	BillingMonth sql.NullInt32
+	BillingDay   sql.NullInt32
+	EndedFrom    sql.NullTime
+	CreatedAt    sql.NullTime
+	UpdatedAt    sql.NullTime
+	Version      int32
+	DeletedAt    sql.NullTime
+}
+
+type FixedCostVersion struct {
+	FixedCostID   int32
+	EffectiveFrom time.Time
+	Amount        int32
+	Frequency     string
+	BillingMonth  sql.NullInt32
+	BillingDay    sql.NullInt32
+	CreatedAt     sql.NullTime
+}
+
+type HiddenCategory struct {
+	UserID     string
+	CategoryID int32
+	CreatedAt  time.Time
+}
+
+type Household struct {
+	ID        int32
+	OwnerID   string
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type HouseholdInvitation struct {
+	Code        string
+	HouseholdID int32
+	Role        string
+	CreatedBy   string
+	ExpiresAt   time.Time
+	CreatedAt   time.Time
+}
+
+type HouseholdMember struct {
+	HouseholdID int32
+	UserID      string
+	Role        string
+	JoinedAt    time.Time
+}
+
+type IdempotencyKey struct {
+	UserID         string
+	IdempotencyKey string
+	RequestHash    string
+	StatusCode     sql.NullInt32
+	ContentType    sql.NullString
+	Etag           sql.NullString
+	ResponseBody   []byte
+	CreatedAt      time.Time
+}
+
+type RecurringOccurrence struct {
+	RuleID    int32
+	OccursOn  time.Time
+	ExpenseID sql.NullInt32
+}
+
+type RecurringRule struct {
+	ID             int32
+	UserID         string
+	Amount         int32
+	CategoryID     int32
+	Memo           sql.NullString
+	Frequency      string
+	DayOfMonth     sql.NullInt32
+	StartDate      time.Time
+	EndDate        sql.NullTime
+	GeneratedUntil sql.NullTime
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+type Tag struct {
 	ID        int32
 	UserID    string
 	Name      string
-	Amount    int32
-	CreatedAt sql.NullTime
-	UpdatedAt sql.NullTime
+	CreatedAt time.Time
 }
 
 type User struct {
-	ID         string
-	Income     int32
-	SavingGoal int32
-	CreatedAt  sql.NullTime
-	UpdatedAt  sql.NullTime
+	ID                 string
+	Income             int32
+	SavingGoal         int32
+	CreatedAt          sql.NullTime
+	UpdatedAt          sql.NullTime
+	CurrentHouseholdID sql.NullInt32
+}
+
+type UserSettingsHistory struct {
+	UserID        string
+	EffectiveFrom time.Time
+	Income        int32
+	SavingGoal    int32
+	CreatedAt     sql.NullTime
 }
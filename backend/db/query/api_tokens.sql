@@ -0,0 +1,39 @@
+-- name: CreateAPIToken :one
+-- 有効期限は作成時点から expires_in_days 日後です。
+INSERT INTO api_tokens (
+  user_id,
+  name,
+  token_hash,
+  scopes,
+  expires_at
+) VALUES (
+  sqlc.arg(user_id),
+  sqlc.arg(name),
+  sqlc.arg(token_hash),
+  sqlc.arg(scopes)::text[],
+  now() + make_interval(days => sqlc.arg(expires_in_days)::int)
+)
+RETURNING id, name, scopes, expires_at, last_used_at, created_at;
+
+-- name: ListAPITokensByUser :many
+SELECT
+  id,
+  name,
+  scopes,
+  expires_at,
+  last_used_at,
+  created_at
+FROM api_tokens
+WHERE user_id = $1
+ORDER BY id ASC;
+
+-- name: DeleteAPIToken :execrows
+DELETE FROM api_tokens
+WHERE id = $1 AND user_id = $2;
+
+-- name: AuthenticateAPIToken :one
+-- 有効期限内のトークンであれば最終利用日時を更新し、所有ユーザーと権限を返します。
+UPDATE api_tokens
+SET last_used_at = now()
+WHERE token_hash = $1 AND expires_at > now()
+RETURNING user_id, scopes;
@@ -0,0 +1,59 @@
+-- name: CreateAuditEvent :exec
+-- 変更履歴を1件追記します。before / after は JSON 文字列で渡し、記録しない側は 'null' を渡します。
+INSERT INTO audit_events (
+  user_id,
+  actor_id,
+  entity_type,
+  entity_id,
+  action,
+  before,
+  after,
+  request_id
+) VALUES (
+  sqlc.arg(user_id),
+  sqlc.arg(actor_id),
+  sqlc.arg(entity_type),
+  sqlc.narg(entity_id),
+  sqlc.arg(action),
+  sqlc.arg(before)::text::jsonb,
+  sqlc.arg(after)::text::jsonb,
+  sqlc.arg(request_id)
+);
+
+-- name: ListAuditEvents :many
+-- 変更履歴を新しいものから順に返します。entity_type が NULL の場合はすべての種類を返し、
+-- before_id を指定した場合はその ID より前の履歴を返します（カーソルページング）。
+SELECT
+  id,
+  actor_id,
+  entity_type,
+  entity_id,
+  action,
+  before::text AS before,
+  after::text AS after,
+  request_id,
+  created_at
+FROM audit_events
+WHERE user_id = sqlc.arg(user_id)
+  AND (sqlc.narg(entity_type)::text IS NULL OR entity_type = sqlc.narg(entity_type)::text)
+  AND (sqlc.narg(before_id)::bigint IS NULL OR id < sqlc.narg(before_id)::bigint)
+ORDER BY id DESC
+LIMIT sqlc.arg(page_limit);
+
+-- name: ListEntityAuditEvents :many
+-- 1件のデータ（支出・固定費など）の変更履歴を古いものから順に返します。
+SELECT
+  id,
+  actor_id,
+  entity_type,
+  entity_id,
+  action,
+  before::text AS before,
+  after::text AS after,
+  request_id,
+  created_at
+FROM audit_events
+WHERE user_id = sqlc.arg(user_id)
+  AND entity_type = sqlc.arg(entity_type)
+  AND entity_id = sqlc.arg(entity_id)
+ORDER BY id ASC;
@@ -0,0 +1,26 @@
+-- name: ListBudgets :many
+SELECT
+  b.category_id,
+  c.name AS category_name,
+  b.monthly_limit
+FROM category_budgets b
+JOIN categories c ON c.id = b.category_id
+WHERE b.user_id = $1
+ORDER BY b.category_id ASC;
+
+-- name: UpsertBudget :exec
+INSERT INTO category_budgets (
+  user_id,
+  category_id,
+  monthly_limit
+) VALUES (
+  $1, $2, $3
+)
+ON CONFLICT (user_id, category_id) DO UPDATE
+SET
+  monthly_limit = EXCLUDED.monthly_limit,
+  updated_at = now();
+
+-- name: DeleteBudget :execrows
+DELETE FROM category_budgets
+WHERE user_id = $1 AND category_id = $2;
@@ -1,13 +1,146 @@
 -- name: ListCategories :many
+SELECT
+  c.id,
+  c.name,
+  (c.user_id IS NOT NULL)::boolean AS is_custom
+FROM categories c
+WHERE (c.user_id IS NULL OR c.user_id = sqlc.arg(user_id)::text)
+  AND NOT EXISTS (
+    SELECT 1
+    FROM hidden_categories h
+    WHERE h.user_id = sqlc.arg(user_id)::text AND h.category_id = c.id
+  )
+ORDER BY c.user_id IS NOT NULL, c.id;
+
+-- name: CategoryExists :one
+SELECT EXISTS (
+  SELECT 1
+  FROM categories c
+  WHERE c.id = sqlc.arg(id)
+    AND (c.user_id IS NULL OR c.user_id = sqlc.arg(user_id)::text)
+    AND NOT EXISTS (
+      SELECT 1
+      FROM hidden_categories h
+      WHERE h.user_id = sqlc.arg(user_id)::text AND h.category_id = c.id
+    )
+);
+
+-- name: GetCategoryForUser :one
 SELECT
   id,
+  name,
+  (user_id IS NOT NULL)::boolean AS is_custom
+FROM categories
+WHERE id = sqlc.arg(id)
+  AND (user_id IS NULL OR user_id = sqlc.arg(user_id)::text);
+
+-- name: CreateCategory :one
+INSERT INTO categories (
+  user_id,
   name
+) VALUES (
+  sqlc.arg(user_id)::text, sqlc.arg(name)
+)
+RETURNING id, name;
+
+-- name: UpdateCategory :exec
+UPDATE categories
+SET
+  name = sqlc.arg(name)
+WHERE id = sqlc.arg(id) AND user_id = sqlc.arg(user_id)::text;
+
+-- name: LockCategory :exec
+-- 削除中のカテゴリを参照する支出が並行して登録・更新されないよう、行をロックします。
+-- 支出・明細の category_id の外部キーの確認はこのロックを待つため、削除の後に確認した登録・更新は外部キー制約違反になります。
+SELECT id
 FROM categories
-ORDER BY id;
+WHERE id = sqlc.arg(id) AND user_id = sqlc.arg(user_id)::text
+FOR UPDATE;
 
--- name: CategoryExists :one
-SELECT EXISTS (
-  SELECT 1 
-  FROM categories 
-  WHERE id = $1
-);
\ No newline at end of file
+-- name: DeleteCategory :exec
+DELETE FROM categories
+WHERE id = sqlc.arg(id) AND user_id = sqlc.arg(user_id)::text;
+
+-- name: HideCategory :exec
+INSERT INTO hidden_categories (
+  user_id,
+  category_id
+) VALUES (
+  $1, $2
+)
+ON CONFLICT DO NOTHING;
+
+-- name: UnhideCategory :exec
+DELETE FROM hidden_categories
+WHERE user_id = $1 AND category_id = $2;
+
+-- name: CountExpensesByCategory :one
+-- 明細のカテゴリとして使われている支出も数えます。
+-- ゴミ箱の支出も数えます（復元したときにカテゴリが残っているようにするため）。
+-- 繰り返しルールもカテゴリを参照するため、あわせて数えます。
+SELECT COUNT(*)
+FROM (
+  SELECT e.id
+  FROM expenses e
+  WHERE e.user_id = $1
+    AND (
+      e.category_id = $2
+      OR EXISTS (
+        SELECT 1 FROM expense_items i
+        WHERE i.expense_id = e.id AND i.category_id = $2
+      )
+    )
+  UNION ALL
+  SELECT r.id
+  FROM recurring_rules r
+  WHERE r.user_id = $1 AND r.category_id = $2
+) used;
+
+-- name: MoveExpensesToCategory :exec
+-- 明細のカテゴリも移動します。ゴミ箱の支出も移動します。
+-- 繰り返しルールのカテゴリも移動し、以降に生成する予定支出も移動先のカテゴリにします。
+-- 明細のみが対象の支出も含め、移動した支出のバージョンを進めます。
+WITH moved_items AS (
+  UPDATE expense_items i
+  SET category_id = sqlc.arg(to_category_id)
+  FROM expenses x
+  WHERE x.id = i.expense_id
+    AND x.user_id = sqlc.arg(user_id)
+    AND i.category_id = sqlc.arg(from_category_id)
+),
+moved_rules AS (
+  UPDATE recurring_rules r
+  SET
+    category_id = sqlc.arg(to_category_id),
+    updated_at = now()
+  WHERE r.user_id = sqlc.arg(user_id)
+    AND r.category_id = sqlc.arg(from_category_id)
+)
+UPDATE expenses e
+SET
+  category_id = CASE WHEN e.category_id = sqlc.arg(from_category_id) THEN sqlc.arg(to_category_id) ELSE e.category_id END,
+  updated_at = now(),
+  version = e.version + 1
+WHERE e.user_id = sqlc.arg(user_id)
+  AND (
+    e.category_id = sqlc.arg(from_category_id)
+    OR EXISTS (
+      SELECT 1 FROM expense_items i
+      WHERE i.expense_id = e.id AND i.category_id = sqlc.arg(from_category_id)
+    )
+  );
+
+-- name: ListExpenseIDsByCategory :many
+-- 支出または明細のカテゴリが category_id の支出の ID を返します。ゴミ箱の支出は含めません。
+SELECT e.id
+FROM expenses e
+WHERE e.user_id = $1
+  AND e.deleted_at IS NULL
+  AND (
+    e.category_id = $2
+    OR EXISTS (
+      SELECT 1 FROM expense_items i
+      WHERE i.expense_id = e.id AND i.category_id = $2
+    )
+  )
+ORDER BY e.id;
@@ -1,42 +1,144 @@
 -- name: CreateExpense :one
-INSERT INTO expenses (
-  user_id,
-  amount,
-  category_id,
-  memo,
-  spent_at,
-  status
-) VALUES (
-  $1, $2, $3, $4, $5, $6
+-- 明細（item_*、同じ順序の配列）があれば支出と同時に登録します。明細がない場合は空配列を指定します。
+-- tag_names のタグを付けます。ユーザーにまだないタグはここで作成します（tag_names に重複がないこと）。
+WITH created AS (
+  INSERT INTO expenses (
+    user_id,
+    amount,
+    category_id,
+    memo,
+    spent_at,
+    status,
+    created_by
+  ) VALUES (
+    sqlc.arg(user_id), sqlc.arg(amount), sqlc.arg(category_id), sqlc.arg(memo), sqlc.arg(spent_at), sqlc.arg(status), sqlc.arg(created_by)
+  )
+  RETURNING id
+), created_items AS (
+  INSERT INTO expense_items (
+    expense_id,
+    position,
+    category_id,
+    amount,
+    memo
+  )
+  SELECT created.id, a.ord, c.category_id, a.amount, NULLIF(m.memo, '')
+  FROM created
+  CROSS JOIN UNNEST(sqlc.arg(item_amounts)::int[]) WITH ORDINALITY AS a(amount, ord)
+  JOIN UNNEST(sqlc.arg(item_category_ids)::int[]) WITH ORDINALITY AS c(category_id, ord) USING (ord)
+  JOIN UNNEST(sqlc.arg(item_memos)::text[]) WITH ORDINALITY AS m(memo, ord) USING (ord)
+), tagged AS (
+  INSERT INTO tags (user_id, name)
+  SELECT sqlc.arg(user_id), t.name
+  FROM UNNEST(sqlc.arg(tag_names)::text[]) AS t(name)
+  ON CONFLICT (user_id, name) DO UPDATE SET name = EXCLUDED.name
+  RETURNING id
+), created_tags AS (
+  INSERT INTO expense_tags (expense_id, tag_id)
+  SELECT created.id, tagged.id
+  FROM created
+  CROSS JOIN tagged
 )
-RETURNING id;
+SELECT id FROM created;
+
+-- name: BulkCreateExpenses :many
+-- 登録した支出を GetExpenseWithCategoryByID と同じ列で返します。
+-- ID は行の順（ORDER BY ord）に採番されるため、ID の昇順が入力の順になります。
+WITH created AS (
+  INSERT INTO expenses (
+    user_id,
+    amount,
+    category_id,
+    memo,
+    spent_at,
+    status,
+    created_by
+  )
+  SELECT sqlc.arg(user_id)::text, a.amount, c.category_id, NULLIF(m.memo, ''), d.spent_at, st.status, cb.created_by
+  FROM UNNEST(sqlc.arg(amounts)::int[]) WITH ORDINALITY AS a(amount, ord)
+  JOIN UNNEST(sqlc.arg(category_ids)::int[]) WITH ORDINALITY AS c(category_id, ord) USING (ord)
+  JOIN UNNEST(sqlc.arg(memos)::text[]) WITH ORDINALITY AS m(memo, ord) USING (ord)
+  JOIN UNNEST(sqlc.arg(spent_ats)::date[]) WITH ORDINALITY AS d(spent_at, ord) USING (ord)
+  JOIN UNNEST(sqlc.arg(statuses)::text[]) WITH ORDINALITY AS st(status, ord) USING (ord)
+  JOIN UNNEST(sqlc.arg(created_bys)::text[]) WITH ORDINALITY AS cb(created_by, ord) USING (ord)
+  ORDER BY ord
+  RETURNING id, amount, memo, spent_at, status, created_by, version, planned_amount, category_id
+)
+SELECT
+  e.id,
+  e.amount,
+  e.memo,
+  e.spent_at,
+  e.status,
+  e.created_by,
+  e.version,
+  e.planned_amount,
+  c.id AS category_id,
+  c.name AS category_name
+FROM created e
+JOIN categories c ON e.category_id = c.id
+ORDER BY e.id ASC;
 
 -- name: ListExpenses :many
-SELECT 
+SELECT
   e.id,
   e.amount,
   e.memo,
   e.spent_at,
   e.status,
+  e.created_by,
+  e.version,
+  e.planned_amount,
   c.id AS category_id,
   c.name AS category_name
 FROM expenses e
 JOIN categories c ON e.category_id = c.id
-WHERE e.user_id = $1
-ORDER BY spent_at DESC;
+WHERE e.user_id = sqlc.arg(user_id)
+  AND e.deleted_at IS NULL
+  AND (sqlc.narg(from_date)::date IS NULL OR e.spent_at >= sqlc.narg(from_date)::date)
+  AND (sqlc.narg(to_date)::date IS NULL OR e.spent_at <= sqlc.narg(to_date)::date)
+  AND (
+    cardinality(sqlc.arg(category_ids)::int[]) = 0
+    OR e.category_id = ANY(sqlc.arg(category_ids)::int[])
+    OR EXISTS (
+      SELECT 1 FROM expense_items i
+      WHERE i.expense_id = e.id AND i.category_id = ANY(sqlc.arg(category_ids)::int[])
+    )
+  )
+  AND (
+    cardinality(sqlc.arg(tag_ids)::int[]) = 0
+    OR EXISTS (
+      SELECT 1 FROM expense_tags et
+      WHERE et.expense_id = e.id AND et.tag_id = ANY(sqlc.arg(tag_ids)::int[])
+    )
+  )
+  AND (sqlc.narg(status)::text IS NULL OR e.status = sqlc.narg(status)::text)
+  AND (sqlc.narg(amount_min)::int IS NULL OR e.amount >= sqlc.narg(amount_min)::int)
+  AND (sqlc.narg(amount_max)::int IS NULL OR e.amount <= sqlc.narg(amount_max)::int)
+  AND (sqlc.narg(memo)::text IS NULL OR e.memo ILIKE '%' || sqlc.narg(memo)::text || '%')
+  AND (
+    sqlc.narg(cursor_spent_at)::date IS NULL
+    OR (e.spent_at, e.id) < (sqlc.narg(cursor_spent_at)::date, sqlc.arg(cursor_id)::int)
+  )
+ORDER BY e.spent_at DESC, e.id DESC
+LIMIT sqlc.arg(page_limit);
 
 -- name: GetExpenseWithCategoryByID :one
+-- ゴミ箱の支出は返しません。
 SELECT
   e.id,
   e.amount,
   e.memo,
   e.spent_at,
   e.status,
+  e.created_by,
+  e.version,
+  e.planned_amount,
   c.id AS category_id,
   c.name AS category_name
 FROM expenses e
 JOIN categories c ON e.category_id = c.id
-WHERE e.user_id = $1 AND e.id = $2;
+WHERE e.user_id = $1 AND e.id = $2 AND e.deleted_at IS NULL;
 
 -- name: GetExpenseByID :one
 SELECT
@@ -47,26 +149,116 @@ SELECT
   spent_at,
   status
 FROM expenses
-WHERE user_id = $1 AND id = $2;
+WHERE user_id = $1 AND id = $2 AND deleted_at IS NULL;
 
--- name: UpdateExpense :exec
+-- name: UpdateExpense :execrows
+-- version が一致する場合のみ更新します（楽観的排他制御）。
+-- planned_amount は NULL の場合は変更しません。
+-- replace_items が true の場合は明細を item_* の内容に置き換えます（空配列の場合は明細を削除します）。
+-- replace_tags が true の場合はタグを tag_names に置き換えます（ユーザーにまだないタグは作成します）。
+WITH target AS (
+  SELECT id
+  FROM expenses
+  WHERE id = sqlc.arg(id) AND user_id = sqlc.arg(user_id) AND version = sqlc.arg(version) AND deleted_at IS NULL
+  FOR UPDATE
+), deleted_items AS (
+  DELETE FROM expense_items
+  WHERE sqlc.arg(replace_items)::boolean AND expense_id IN (SELECT id FROM target)
+), created_items AS (
+  INSERT INTO expense_items (
+    expense_id,
+    position,
+    category_id,
+    amount,
+    memo
+  )
+  SELECT target.id, a.ord, c.category_id, a.amount, NULLIF(m.memo, '')
+  FROM target
+  CROSS JOIN UNNEST(sqlc.arg(item_amounts)::int[]) WITH ORDINALITY AS a(amount, ord)
+  JOIN UNNEST(sqlc.arg(item_category_ids)::int[]) WITH ORDINALITY AS c(category_id, ord) USING (ord)
+  JOIN UNNEST(sqlc.arg(item_memos)::text[]) WITH ORDINALITY AS m(memo, ord) USING (ord)
+  WHERE sqlc.arg(replace_items)::boolean
+), tagged AS (
+  INSERT INTO tags (user_id, name)
+  SELECT sqlc.arg(user_id), t.name
+  FROM UNNEST(sqlc.arg(tag_names)::text[]) AS t(name)
+  WHERE sqlc.arg(replace_tags)::boolean AND EXISTS (SELECT 1 FROM target)
+  ON CONFLICT (user_id, name) DO UPDATE SET name = EXCLUDED.name
+  RETURNING id
+), deleted_tags AS (
+  -- 付け直すタグは削除せずに残し、同じ文の中で同じ行を削除・追加しないようにする
+  DELETE FROM expense_tags
+  WHERE sqlc.arg(replace_tags)::boolean
+    AND expense_id IN (SELECT id FROM target)
+    AND tag_id NOT IN (SELECT id FROM tagged)
+), created_tags AS (
+  INSERT INTO expense_tags (expense_id, tag_id)
+  SELECT target.id, tagged.id
+  FROM target
+  CROSS JOIN tagged
+  ON CONFLICT DO NOTHING
+)
 UPDATE expenses
 SET
-  amount = $2,
-  category_id = $3,
-  memo = $4,
-  spent_at = $5,
-  status = $6,
-  updated_at = now()
-WHERE id = $1 AND user_id = $7;
+  amount = sqlc.arg(amount),
+  category_id = sqlc.arg(category_id),
+  memo = sqlc.arg(memo),
+  spent_at = sqlc.arg(spent_at),
+  status = sqlc.arg(status),
+  planned_amount = COALESCE(sqlc.narg(planned_amount), planned_amount),
+  updated_at = now(),
+  version = version + 1
+WHERE id IN (SELECT id FROM target);
+
+-- name: ListExpenseTags :many
+-- expense_ids の支出に付けたタグを、支出ごとにタグ名順で返します。
+SELECT
+  et.expense_id,
+  t.id AS tag_id,
+  t.name AS tag_name
+FROM expense_tags et
+JOIN tags t ON t.id = et.tag_id
+WHERE et.expense_id = ANY(sqlc.arg(expense_ids)::int[])
+ORDER BY et.expense_id ASC, t.name ASC;
+
+-- name: ListExpenseItems :many
+-- expense_ids の支出の明細を、支出ごとに登録順で返します。
+SELECT
+  i.expense_id,
+  i.amount,
+  i.memo,
+  c.id AS category_id,
+  c.name AS category_name
+FROM expense_items i
+JOIN categories c ON c.id = i.category_id
+WHERE i.expense_id = ANY(sqlc.arg(expense_ids)::int[])
+ORDER BY i.expense_id ASC, i.position ASC;
 
--- name: UpdateExpenseStatus :exec
+-- name: UpdateExpenseStatus :execrows
+-- ステータスのみを変更します。version が $4 と一致する場合のみ更新します（楽観的排他制御）。
+-- planned_amount は $5 が NULL の場合は変更しません。
 UPDATE expenses
 SET
   status = $2,
-  updated_at = now()
-WHERE id = $1 AND user_id = $3;
+  planned_amount = COALESCE($5, planned_amount),
+  updated_at = now(),
+  version = version + 1
+WHERE id = $1 AND user_id = $3 AND version = $4 AND deleted_at IS NULL;
 
 -- name: DeleteExpense :exec
-DELETE FROM expenses
-WHERE id = $1 AND user_id = $2;
\ No newline at end of file
+-- 支出をゴミ箱に移します。明細・タグは残し、復元すると元に戻ります。
+UPDATE expenses
+SET
+  deleted_at = now()
+WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL;
+
+-- name: RestoreExpense :execrows
+-- deleted_after より後にゴミ箱に移した支出を元に戻します。
+UPDATE expenses
+SET
+  deleted_at = NULL,
+  updated_at = now(),
+  version = version + 1
+WHERE id = sqlc.arg(id)
+  AND user_id = sqlc.arg(user_id)
+  AND deleted_at > sqlc.arg(deleted_after)::timestamp;
\ No newline at end of file
@@ -1,12 +1,30 @@
 -- name: CreateFixedCost :one
-INSERT INTO fixed_costs (
-  user_id,
-  name,
-  amount
-) VALUES (
-  $1, $2, $3
+-- 固定費の作成と同時に、$7 の月から適用する版を登録します。
+WITH created AS (
+  INSERT INTO fixed_costs (
+    user_id,
+    name,
+    amount,
+    frequency,
+    billing_month,
+    billing_day
+  ) VALUES (
+    $1, $2, $3, $4, $5, $6
+  )
+  RETURNING *
+), version AS (
+  INSERT INTO fixed_cost_versions (
+    fixed_cost_id,
+    effective_from,
+    amount,
+    frequency,
+    billing_month,
+    billing_day
+  )
+  SELECT id, $7, amount, frequency, billing_month, billing_day
+  FROM created
 )
-RETURNING *;
+SELECT * FROM created;
 
 -- name: ListFixedCostsByUser :many
 SELECT 
@@ -14,35 +32,124 @@ SELECT
   user_id,
   name,
   amount,
+  frequency,
+  billing_month,
+  billing_day,
+  ended_from,
   created_at,
-  updated_at
+  updated_at,
+  version,
+  deleted_at
 FROM fixed_costs
-WHERE user_id = $1
+WHERE user_id = $1 AND ended_from IS NULL
 ORDER BY id ASC;
 
--- name: DeleteFixedCostsByUser :exec
-DELETE FROM fixed_costs
-WHERE user_id = $1;
+-- name: EndFixedCostsByUser :exec
+-- 過去月の集計に影響しないよう、削除せずに ended_from の月以降を計上対象外にします。
+UPDATE fixed_costs
+SET
+  ended_from = sqlc.arg(ended_from)::date,
+  updated_at = now()
+WHERE user_id = sqlc.arg(user_id) AND ended_from IS NULL;
 
 -- name: BulkCreateFixedCosts :exec
-INSERT INTO fixed_costs (
-  user_id,
-  name,
-  amount
+-- billing_months・billing_days の 0 は未指定（NULL）として扱います。
+-- 作成した固定費ごとに、$7 の月から適用する版を登録します。
+WITH created AS (
+  INSERT INTO fixed_costs (
+    user_id,
+    name,
+    amount,
+    frequency,
+    billing_month,
+    billing_day
+  )
+  SELECT u.user_id, n.name, a.amount, f.frequency, NULLIF(bm.billing_month, 0), NULLIF(bd.billing_day, 0)
+  FROM UNNEST($1::text[]) WITH ORDINALITY AS u(user_id, ord)
+  JOIN UNNEST($2::text[]) WITH ORDINALITY AS n(name, ord) USING (ord)
+  JOIN UNNEST($3::int[]) WITH ORDINALITY AS a(amount, ord) USING (ord)
+  JOIN UNNEST($4::text[]) WITH ORDINALITY AS f(frequency, ord) USING (ord)
+  JOIN UNNEST($5::int[]) WITH ORDINALITY AS bm(billing_month, ord) USING (ord)
+  JOIN UNNEST($6::int[]) WITH ORDINALITY AS bd(billing_day, ord) USING (ord)
+  RETURNING id, amount, frequency, billing_month, billing_day
 )
-SELECT u.user_id, n.name, a.amount
-FROM UNNEST($1::text[]) WITH ORDINALITY AS u(user_id, ord)
-JOIN UNNEST($2::text[]) WITH ORDINALITY AS n(name, ord) USING (ord)
-JOIN UNNEST($3::int[]) WITH ORDINALITY AS a(amount, ord) USING (ord);
+INSERT INTO fixed_cost_versions (
+  fixed_cost_id,
+  effective_from,
+  amount,
+  frequency,
+  billing_month,
+  billing_day
+)
+SELECT id, $7::date, amount, frequency, billing_month, billing_day
+FROM created;
 
--- name: UpdateFixedCost :exec
+-- name: UpdateFixedCost :execrows
+-- version が $9 と一致する場合のみ更新します（楽観的排他制御）。一致しない場合は版も登録しません。
+-- $8 の月から適用する版を登録します（同じ月の版は上書き）。
+-- fixed_costs の金額・支払いスケジュールは現在有効な値を保持するため、
+-- $8 より後に当月以前から適用済みの版がある場合は名前のみ更新します。
+WITH target AS (
+  SELECT id
+  FROM fixed_costs
+  WHERE id = $1 AND user_id = $4 AND ended_from IS NULL AND version = $9
+  FOR UPDATE
+), version AS (
+  INSERT INTO fixed_cost_versions (
+    fixed_cost_id,
+    effective_from,
+    amount,
+    frequency,
+    billing_month,
+    billing_day
+  )
+  SELECT id, $8, $3, $5, $6, $7
+  FROM target
+  ON CONFLICT (fixed_cost_id, effective_from) DO UPDATE
+  SET
+    amount = EXCLUDED.amount,
+    frequency = EXCLUDED.frequency,
+    billing_month = EXCLUDED.billing_month,
+    billing_day = EXCLUDED.billing_day
+), cur AS (
+  SELECT NOT EXISTS (
+    SELECT 1
+    FROM fixed_cost_versions v
+    WHERE v.fixed_cost_id = $1
+      AND v.effective_from > $8
+      AND v.effective_from <= date_trunc('month', now())
+  ) AS is_current
+)
 UPDATE fixed_costs
 SET
   name = $2,
-  amount = $3,
+  amount = CASE WHEN cur.is_current THEN $3 ELSE fixed_costs.amount END,
+  frequency = CASE WHEN cur.is_current THEN $5 ELSE fixed_costs.frequency END,
+  billing_month = CASE WHEN cur.is_current THEN $6 ELSE fixed_costs.billing_month END,
+  billing_day = CASE WHEN cur.is_current THEN $7 ELSE fixed_costs.billing_day END,
+  updated_at = now(),
+  version = fixed_costs.version + 1
+FROM cur, target
+WHERE fixed_costs.id = target.id;
+
+-- name: EndFixedCost :exec
+-- 過去月の集計に影響しないよう、削除せずに ended_from の月以降を計上対象外にします。
+-- ユーザーが削除した固定費として deleted_at を記録し、ゴミ箱から復元できるようにします。
+UPDATE fixed_costs
+SET
+  ended_from = sqlc.arg(ended_from)::date,
+  deleted_at = now(),
   updated_at = now()
-WHERE id = $1 AND user_id = $4;
+WHERE id = sqlc.arg(id) AND user_id = sqlc.arg(user_id) AND ended_from IS NULL;
 
--- name: DeleteFixedCost :exec
-DELETE FROM fixed_costs
-WHERE id = $1 AND user_id = $2;
\ No newline at end of file
+-- name: RestoreFixedCost :execrows
+-- deleted_after より後にゴミ箱に移した固定費の解約を取り消します。
+UPDATE fixed_costs
+SET
+  ended_from = NULL,
+  deleted_at = NULL,
+  updated_at = now(),
+  version = version + 1
+WHERE id = sqlc.arg(id)
+  AND user_id = sqlc.arg(user_id)
+  AND deleted_at > sqlc.arg(deleted_after)::timestamp;
\ No newline at end of file
@@ -0,0 +1,122 @@
+-- name: GetCurrentHouseholdMembership :one
+-- 選択中の世帯と、その世帯での役割を返します。
+-- 世帯を選択していない場合や、選択中の世帯のメンバーでなくなった場合は行を返しません。
+SELECT
+  h.id,
+  h.owner_id,
+  m.role
+FROM users u
+JOIN households h ON h.id = u.current_household_id
+JOIN household_members m ON m.household_id = h.id AND m.user_id = u.id
+WHERE u.id = $1;
+
+-- name: ListHouseholdsByUser :many
+SELECT
+  h.id,
+  h.name,
+  h.owner_id,
+  m.role,
+  COALESCE(u.current_household_id = h.id, false)::boolean AS is_current,
+  h.created_at
+FROM household_members m
+JOIN households h ON h.id = m.household_id
+JOIN users u ON u.id = m.user_id
+WHERE m.user_id = $1
+ORDER BY h.id ASC;
+
+-- name: GetHouseholdForMember :one
+SELECT
+  h.id,
+  h.name,
+  h.owner_id,
+  m.role,
+  COALESCE(u.current_household_id = h.id, false)::boolean AS is_current,
+  h.created_at
+FROM household_members m
+JOIN households h ON h.id = m.household_id
+JOIN users u ON u.id = m.user_id
+WHERE m.user_id = $1 AND h.id = $2;
+
+-- name: ListHouseholdMembers :many
+SELECT
+  user_id,
+  role,
+  joined_at
+FROM household_members
+WHERE household_id = $1
+ORDER BY joined_at ASC, user_id ASC;
+
+-- name: HouseholdExistsByOwner :one
+SELECT EXISTS (
+  SELECT 1
+  FROM households
+  WHERE owner_id = $1
+);
+
+-- name: CreateHousehold :one
+INSERT INTO households (
+  owner_id,
+  name
+) VALUES (
+  $1, $2
+)
+RETURNING id, owner_id, name, created_at, updated_at;
+
+-- name: AddHouseholdMember :execrows
+-- すでにメンバーの場合は何もしません（影響行数 0）。
+INSERT INTO household_members (
+  household_id,
+  user_id,
+  role
+) VALUES (
+  $1, $2, $3
+)
+ON CONFLICT (household_id, user_id) DO NOTHING;
+
+-- name: SetCurrentHousehold :execrows
+UPDATE users
+SET current_household_id = sqlc.narg(household_id)
+WHERE id = sqlc.arg(user_id);
+
+-- name: UpdateHouseholdMemberRole :execrows
+-- オーナーの役割は変更しません。
+UPDATE household_members
+SET role = $3
+WHERE household_id = $1 AND user_id = $2 AND role <> 'owner';
+
+-- name: DeleteHouseholdMember :execrows
+-- メンバーを世帯から外し、その世帯を選択していた場合は選択を解除します。オーナーは外しません。
+WITH cleared AS (
+  UPDATE users
+  SET current_household_id = NULL
+  WHERE id = sqlc.arg(user_id) AND current_household_id = sqlc.arg(household_id)::int
+)
+DELETE FROM household_members
+WHERE household_id = sqlc.arg(household_id) AND user_id = sqlc.arg(user_id) AND role <> 'owner';
+
+-- name: DeleteHousehold :execrows
+-- メンバー・招待コードも削除され、この世帯を選択していたユーザーは自分の家計簿に戻ります。
+DELETE FROM households
+WHERE id = $1 AND owner_id = $2;
+
+-- name: CreateHouseholdInvitation :one
+INSERT INTO household_invitations (
+  code,
+  household_id,
+  role,
+  created_by,
+  expires_at
+) VALUES (
+  sqlc.arg(code),
+  sqlc.arg(household_id),
+  sqlc.arg(role),
+  sqlc.arg(created_by),
+  now() + make_interval(days => sqlc.arg(expires_in_days)::int)
+)
+RETURNING code, household_id, role, created_by, expires_at, created_at;
+
+-- name: ConsumeHouseholdInvitation :one
+-- 有効期限内の招待コードを削除し、招待先の世帯と役割を返します。
+DELETE FROM household_invitations
+WHERE code = $1 AND expires_at > now()
+RETURNING household_id, role;
@@ -0,0 +1,53 @@
+-- name: ReserveIdempotencyKey :execrows
+-- キーを処理中として保存します。有効なキーがすでにある場合は何もせず 0 件を返します。
+-- 24時間を過ぎたキーと、同じリクエストのまま5分以上処理中のキー（処理中にサーバーが停止したもの）は置き換えます。
+INSERT INTO idempotency_keys (
+  user_id,
+  idempotency_key,
+  request_hash
+) VALUES (
+  $1, $2, $3
+)
+ON CONFLICT (user_id, idempotency_key) DO UPDATE
+SET
+  request_hash = EXCLUDED.request_hash,
+  status_code = NULL,
+  content_type = NULL,
+  etag = NULL,
+  response_body = NULL,
+  created_at = now()
+WHERE idempotency_keys.created_at < now() - interval '24 hours'
+  OR (
+    idempotency_keys.status_code IS NULL
+    AND idempotency_keys.request_hash = EXCLUDED.request_hash
+    AND idempotency_keys.created_at < now() - interval '5 minutes'
+  );
+
+-- name: GetIdempotencyKey :one
+SELECT
+  request_hash,
+  status_code,
+  content_type,
+  etag,
+  response_body
+FROM idempotency_keys
+WHERE user_id = $1
+  AND idempotency_key = $2
+  AND created_at >= now() - interval '24 hours';
+
+-- name: SaveIdempotencyResponse :exec
+UPDATE idempotency_keys
+SET
+  status_code = $3,
+  content_type = $4,
+  etag = $5,
+  response_body = $6
+WHERE user_id = $1 AND idempotency_key = $2;
+
+-- name: DeleteIdempotencyKey :exec
+DELETE FROM idempotency_keys
+WHERE user_id = $1 AND idempotency_key = $2;
+
+-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_keys
+WHERE created_at < now() - interval '24 hours';
@@ -0,0 +1,111 @@
+-- name: ListRecurringRules :many
+SELECT
+  r.id,
+  r.amount,
+  r.category_id,
+  c.name AS category_name,
+  r.memo,
+  r.frequency,
+  r.day_of_month,
+  r.start_date,
+  r.end_date,
+  r.generated_until
+FROM recurring_rules r
+JOIN categories c ON c.id = r.category_id
+WHERE r.user_id = $1
+ORDER BY r.id ASC;
+
+-- name: ListRecurringRuleUserIDs :many
+-- 定期的な予定支出の生成で、ルールを持つユーザーを列挙します。
+SELECT DISTINCT user_id
+FROM recurring_rules
+ORDER BY user_id ASC;
+
+-- name: GetRecurringRule :one
+SELECT
+  r.id,
+  r.amount,
+  r.category_id,
+  c.name AS category_name,
+  r.memo,
+  r.frequency,
+  r.day_of_month,
+  r.start_date,
+  r.end_date,
+  r.generated_until
+FROM recurring_rules r
+JOIN categories c ON c.id = r.category_id
+WHERE r.user_id = $1 AND r.id = $2;
+
+-- name: CreateRecurringRule :one
+INSERT INTO recurring_rules (
+  user_id,
+  amount,
+  category_id,
+  memo,
+  frequency,
+  day_of_month,
+  start_date,
+  end_date
+) VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING id;
+
+-- name: UpdateRecurringRule :exec
+UPDATE recurring_rules
+SET
+  amount = $3,
+  category_id = $4,
+  memo = $5,
+  frequency = $6,
+  day_of_month = $7,
+  start_date = $8,
+  end_date = $9,
+  updated_at = now()
+WHERE user_id = $1 AND id = $2;
+
+-- name: SetRecurringRuleGeneratedUntil :exec
+UPDATE recurring_rules
+SET generated_until = $3
+WHERE user_id = $1 AND id = $2;
+
+-- name: DeleteRecurringRule :exec
+DELETE FROM recurring_rules
+WHERE user_id = $1 AND id = $2;
+
+-- name: ListRecurringOccurrencesFrom :many
+-- ゴミ箱の支出が紐付く回は、支出が削除された回（expense_id が NULL）として返します。
+SELECT
+  o.occurs_on,
+  e.id AS expense_id,
+  e.status
+FROM recurring_occurrences o
+LEFT JOIN expenses e ON e.id = o.expense_id AND e.deleted_at IS NULL
+WHERE o.rule_id = $1 AND o.occurs_on >= $2
+ORDER BY o.occurs_on ASC;
+
+-- name: GetRecurringOccurrence :one
+-- ゴミ箱の支出の扱いは ListRecurringOccurrencesFrom と同じです。
+SELECT
+  o.occurs_on,
+  e.id AS expense_id,
+  e.status
+FROM recurring_occurrences o
+LEFT JOIN expenses e ON e.id = o.expense_id AND e.deleted_at IS NULL
+WHERE o.rule_id = $1 AND o.occurs_on = $2;
+
+-- name: ClaimRecurringOccurrence :execrows
+INSERT INTO recurring_occurrences (rule_id, occurs_on)
+VALUES ($1, $2)
+ON CONFLICT (rule_id, occurs_on) DO NOTHING;
+
+-- name: SetRecurringOccurrenceExpense :exec
+INSERT INTO recurring_occurrences (rule_id, occurs_on, expense_id)
+VALUES ($1, $2, $3)
+ON CONFLICT (rule_id, occurs_on) DO UPDATE
+SET expense_id = EXCLUDED.expense_id;
+
+-- name: DeleteRecurringOccurrence :exec
+DELETE FROM recurring_occurrences
+WHERE rule_id = $1 AND occurs_on = $2;
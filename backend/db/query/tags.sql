@@ -0,0 +1,23 @@
+-- name: ListTags :many
+-- タグを名前順に、付けられている支出（ゴミ箱の支出を除く）の件数とあわせて返します。
+SELECT
+  t.id,
+  t.name,
+  COUNT(e.id)::bigint AS expense_count
+FROM tags t
+LEFT JOIN expense_tags et ON et.tag_id = t.id
+LEFT JOIN expenses e ON e.id = et.expense_id AND e.deleted_at IS NULL
+WHERE t.user_id = $1
+GROUP BY t.id, t.name
+ORDER BY t.name ASC, t.id ASC;
+
+-- name: RenameTag :execrows
+UPDATE tags
+SET
+  name = sqlc.arg(name)
+WHERE id = sqlc.arg(id) AND user_id = sqlc.arg(user_id);
+
+-- name: DeleteTag :execrows
+-- タグを削除します。支出に付けていたタグも外れます（支出は削除しません）。
+DELETE FROM tags
+WHERE id = $1 AND user_id = $2;
@@ -0,0 +1,57 @@
+-- name: ListTrashedExpenses :many
+-- deleted_after より後にゴミ箱に移した支出を、新しく削除したものから順に返します。
+SELECT
+  e.id,
+  e.amount,
+  e.memo,
+  e.spent_at,
+  e.status,
+  e.created_by,
+  e.version,
+  e.planned_amount,
+  e.deleted_at,
+  c.id AS category_id,
+  c.name AS category_name
+FROM expenses e
+JOIN categories c ON e.category_id = c.id
+WHERE e.user_id = sqlc.arg(user_id)
+  AND e.deleted_at > sqlc.arg(deleted_after)::timestamp
+ORDER BY e.deleted_at DESC, e.id DESC;
+
+-- name: ListTrashedFixedCosts :many
+-- deleted_after より後にゴミ箱に移した固定費を、新しく削除したものから順に返します。
+SELECT
+  id,
+  user_id,
+  name,
+  amount,
+  frequency,
+  billing_month,
+  billing_day,
+  ended_from,
+  created_at,
+  updated_at,
+  version,
+  deleted_at
+FROM fixed_costs
+WHERE user_id = sqlc.arg(user_id)
+  AND deleted_at > sqlc.arg(deleted_after)::timestamp
+ORDER BY deleted_at DESC, id DESC;
+
+-- name: PurgeTrashedExpenses :execrows
+-- deleted_before 以前にゴミ箱に移した支出を完全に削除します（全ユーザーが対象）。明細・タグの付与も削除されます。
+DELETE FROM expenses
+WHERE deleted_at <= sqlc.arg(deleted_before)::timestamp;
+
+-- name: PurgeTrashedFixedCosts :execrows
+-- deleted_before 以前にゴミ箱に移した固定費のうち、どの月にも計上されていないもの
+-- （解約月より前に適用した版がないもの）を完全に削除します（全ユーザーが対象）。
+-- 計上された月がある固定費は、過去月の集計のために解約済みとして残します。
+DELETE FROM fixed_costs fc
+WHERE fc.deleted_at <= sqlc.arg(deleted_before)::timestamp
+  AND NOT EXISTS (
+    SELECT 1
+    FROM fixed_cost_versions v
+    WHERE v.fixed_cost_id = fc.id
+      AND v.effective_from < fc.ended_from
+  );
@@ -1,11 +1,23 @@
 -- name: CreateUser :exec
-INSERT INTO users (
-    id,
+-- ユーザーの作成と同時に、$4 の月から適用する収入・貯金目標の履歴を登録します。
+WITH created AS (
+    INSERT INTO users (
+        id,
+        income,
+        saving_goal
+    ) VALUES (
+        $1,$2,$3
+    )
+    RETURNING id, income, saving_goal
+)
+INSERT INTO user_settings_history (
+    user_id,
+    effective_from,
     income,
     saving_goal
-) VALUES (
-    $1,$2,$3
-);
+)
+SELECT id, $4, income, saving_goal
+FROM created;
 
 -- name: GetUserByID :one
 SELECT
@@ -13,14 +25,38 @@ SELECT
     income,
     saving_goal,
     created_at,
-    updated_at
+    updated_at,
+    current_household_id
 FROM users
 WHERE id = $1;
 
 -- name: UpdateUserSettings :exec
+-- $4 の月から適用する収入・貯金目標を履歴に登録します（同じ月の履歴は上書き）。
+-- users には現在有効な値を保持するため、$4 より後に当月以前から適用済みの履歴がある場合は更新しません。
+WITH upserted AS (
+    INSERT INTO user_settings_history (
+        user_id,
+        effective_from,
+        income,
+        saving_goal
+    ) VALUES (
+        $1,$4,$2,$3
+    )
+    ON CONFLICT (user_id, effective_from) DO UPDATE
+    SET
+        income = EXCLUDED.income,
+        saving_goal = EXCLUDED.saving_goal
+)
 UPDATE users
 SET
     income = $2,
     saving_goal = $3,
     updated_at = now()
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1
+  AND NOT EXISTS (
+    SELECT 1
+    FROM user_settings_history h
+    WHERE h.user_id = $1
+      AND h.effective_from > $4
+      AND h.effective_from <= date_trunc('month', now())
+  );
\ No newline at end of file
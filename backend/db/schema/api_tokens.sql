@@ -0,0 +1,17 @@
+-- パーソナルアクセストークン（スクリプト・ショートカットからのAPI利用向け）
+-- トークン本体は保存せず、SHA-256 のハッシュのみを保存する
+CREATE TABLE api_tokens (
+  id SERIAL PRIMARY KEY,
+  user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  token_hash TEXT NOT NULL UNIQUE,
+  scopes TEXT[] NOT NULL,
+  expires_at TIMESTAMP NOT NULL,
+  last_used_at TIMESTAMP,
+  created_at TIMESTAMP NOT NULL DEFAULT now(),
+  CONSTRAINT api_tokens_scopes_check
+    CHECK (cardinality(scopes) > 0 AND scopes <@ ARRAY['read', 'write:expenses']::text[])
+);
+
+CREATE INDEX api_tokens_user_id_idx
+ON api_tokens (user_id, id);
@@ -0,0 +1,20 @@
+-- 支出・固定費・設定の変更履歴（監査ログ）。追記のみで、更新・削除は行わない。
+-- 変更と同じトランザクションで記録し、変更前後のスナップショットを JSON で保存する。
+CREATE TABLE audit_events (
+  id BIGSERIAL PRIMARY KEY,
+  user_id TEXT NOT NULL REFERENCES users(id), -- 家計簿の所有者（世帯の場合はオーナー）
+  actor_id TEXT NOT NULL,                     -- 変更を行ったユーザー（世帯メンバーの場合はオーナーと異なる）
+  entity_type TEXT NOT NULL CHECK (entity_type IN ('expense', 'fixed_cost', 'user_settings', 'initial_setup')),
+  entity_id INTEGER,                          -- user_settings / initial_setup では NULL
+  action TEXT NOT NULL CHECK (action IN ('create', 'update', 'delete', 'restore', 'import')),
+  before JSONB NOT NULL DEFAULT 'null',       -- 作成時は null
+  after JSONB NOT NULL DEFAULT 'null',        -- 削除時は null
+  request_id TEXT NOT NULL DEFAULT '',        -- X-Request-ID（ログとの突き合わせ用）
+  created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX audit_events_user_id_idx
+ON audit_events (user_id, id DESC);
+
+CREATE INDEX audit_events_entity_idx
+ON audit_events (user_id, entity_type, entity_id);
@@ -0,0 +1,9 @@
+-- カテゴリ別の月次予算（上限額）
+CREATE TABLE category_budgets (
+  user_id TEXT NOT NULL REFERENCES users(id),
+  category_id INTEGER NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+  monthly_limit INT NOT NULL CHECK (monthly_limit > 0),
+  created_at TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at TIMESTAMP NOT NULL DEFAULT now(),
+  PRIMARY KEY (user_id, category_id)
+);
@@ -1,5 +1,14 @@
 CREATE TABLE categories (
   id SERIAL PRIMARY KEY,
+  user_id TEXT REFERENCES users(id), -- NULL の場合は全ユーザー共通のデフォルトカテゴリ
   name TEXT NOT NULL,
   created_at TIMESTAMP NOT NULL DEFAULT now()
 );
+
+-- ユーザーが非表示にしたデフォルトカテゴリ
+CREATE TABLE hidden_categories (
+  user_id TEXT NOT NULL REFERENCES users(id),
+  category_id INTEGER NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+  created_at TIMESTAMP NOT NULL DEFAULT now(),
+  PRIMARY KEY (user_id, category_id)
+);
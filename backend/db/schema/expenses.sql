@@ -1,15 +1,56 @@
 CREATE TABLE expenses (
   id SERIAL PRIMARY KEY,
-  user_id TEXT NOT NULL REFERENCES users(id),
+  user_id TEXT NOT NULL REFERENCES users(id), -- 家計簿の所有者（世帯の場合はオーナー）
+  created_by TEXT NOT NULL REFERENCES users(id), -- 登録したユーザー（世帯のメンバー）
   amount INTEGER NOT NULL,
-  category_id INTEGER NOT NULL,
+  category_id INTEGER NOT NULL REFERENCES categories(id),
   memo TEXT,
   spent_at DATE NOT NULL,
   status TEXT NOT NULL DEFAULT 'confirmed',
   created_at TIMESTAMP NOT NULL DEFAULT now(),
-  updated_at TIMESTAMP NOT NULL DEFAULT now()
+  updated_at TIMESTAMP NOT NULL DEFAULT now(),
+  version INTEGER NOT NULL DEFAULT 1, -- 更新ごとに加算する楽観的排他制御用のバージョン（ETag）
+  planned_amount INTEGER, -- 予定から確定にしたときの予定金額（見積もり）。予定を経ずに登録した支出は NULL
+  deleted_at TIMESTAMP -- ゴミ箱に移した日時。ゴミ箱の支出は一覧・集計に含めず、30日を過ぎると完全に削除します
 );
 
+-- planned: 予定 / confirmed: 確定 / cancelled: 取りやめ / reimbursable: 立替中 / reimbursed: 精算済み
 ALTER TABLE expenses
 ADD CONSTRAINT expenses_status_check
-CHECK (status IN ('planned', 'confirmed'));
+CHECK (status IN ('planned', 'confirmed', 'cancelled', 'reimbursable', 'reimbursed'));
+
+CREATE INDEX expenses_user_spent_at_id_idx
+ON expenses (user_id, spent_at DESC, id DESC);
+
+-- ゴミ箱の一覧・期限切れの削除用
+CREATE INDEX expenses_deleted_at_idx
+ON expenses (user_id, deleted_at)
+WHERE deleted_at IS NOT NULL;
+
+-- 支出の明細（1回の支払いを複数のカテゴリに分割したもの）。
+-- 明細の金額の合計は expenses.amount と一致させます。明細がない支出は expenses の category_id・amount をそのまま使います。
+CREATE TABLE expense_items (
+  id SERIAL PRIMARY KEY,
+  expense_id INTEGER NOT NULL REFERENCES expenses(id) ON DELETE CASCADE,
+  position INTEGER NOT NULL, -- 支出内での並び順（1始まり）
+  category_id INTEGER NOT NULL REFERENCES categories(id),
+  amount INTEGER NOT NULL CHECK (amount > 0),
+  memo TEXT
+);
+
+CREATE INDEX expense_items_expense_id_idx
+ON expense_items (expense_id, position);
+
+-- カテゴリ別の集計に使う支出の内訳。明細がある支出は明細ごと、明細がない支出は支出ごとに1行になります。
+-- ゴミ箱の支出は含みません。
+CREATE VIEW expense_lines AS
+SELECT
+  e.id AS expense_id,
+  e.user_id,
+  e.spent_at,
+  e.status,
+  COALESCE(i.category_id, e.category_id) AS category_id,
+  COALESCE(i.amount, e.amount) AS amount
+FROM expenses e
+LEFT JOIN expense_items i ON i.expense_id = e.id
+WHERE e.deleted_at IS NULL;
@@ -3,6 +3,29 @@ CREATE TABLE fixed_costs (
   user_id TEXT NOT NULL REFERENCES users(id),
   name TEXT NOT NULL,
   amount INT NOT NULL,
+  frequency TEXT NOT NULL DEFAULT 'monthly'
+    CHECK (frequency IN ('monthly', 'bimonthly', 'quarterly', 'yearly')),
+  billing_month INT CHECK (billing_month BETWEEN 1 AND 12), -- 請求月（毎月以外の場合の請求周期の起点）
+  billing_day INT CHECK (billing_day BETWEEN 1 AND 31),     -- 請求日
+  ended_from DATE,                                          -- 解約月（この月以降は計上しない）
   created_at TIMESTAMP DEFAULT now(),
-  updated_at TIMESTAMP DEFAULT now()
+  updated_at TIMESTAMP DEFAULT now(),
+  version INT NOT NULL DEFAULT 1,                           -- 更新ごとに加算する楽観的排他制御用のバージョン（ETag）
+  deleted_at TIMESTAMP,                                     -- ゴミ箱に移した日時（削除で解約した固定費のみ。30日以内なら復元可能）
+  CHECK (frequency = 'monthly' OR billing_month IS NOT NULL)
+);
+
+-- 固定費の金額・支払いスケジュールの履歴。fixed_costs には現在有効な値を保持し、
+-- 対象月の集計には適用開始月が対象月以前で最も新しい行を使う
+CREATE TABLE fixed_cost_versions (
+  fixed_cost_id INT NOT NULL REFERENCES fixed_costs(id) ON DELETE CASCADE,
+  effective_from DATE NOT NULL, -- 適用開始月（月初日）
+  amount INT NOT NULL,
+  frequency TEXT NOT NULL
+    CHECK (frequency IN ('monthly', 'bimonthly', 'quarterly', 'yearly')),
+  billing_month INT CHECK (billing_month BETWEEN 1 AND 12),
+  billing_day INT CHECK (billing_day BETWEEN 1 AND 31),
+  created_at TIMESTAMP DEFAULT now(),
+  PRIMARY KEY (fixed_cost_id, effective_from),
+  CHECK (frequency = 'monthly' OR billing_month IS NOT NULL)
 );
\ No newline at end of file
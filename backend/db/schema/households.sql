@@ -0,0 +1,37 @@
+-- 世帯（複数のユーザーで共有する家計簿）
+-- 世帯の家計簿は作成者（オーナー）のデータ（支出・固定費・カテゴリ・予算・収入など）をメンバーで共有する。
+-- そのため1人のユーザーが作成できる世帯は1つまで
+CREATE TABLE households (
+  id SERIAL PRIMARY KEY,
+  owner_id TEXT NOT NULL UNIQUE REFERENCES users(id),
+  name TEXT NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+-- 世帯のメンバー（オーナーも role = 'owner' として含む）
+-- owner: すべての操作、member: 家計簿の参照・変更、viewer: 家計簿の参照のみ
+CREATE TABLE household_members (
+  household_id INTEGER NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+  user_id TEXT NOT NULL REFERENCES users(id),
+  role TEXT NOT NULL CHECK (role IN ('owner', 'member', 'viewer')),
+  joined_at TIMESTAMP NOT NULL DEFAULT now(),
+  PRIMARY KEY (household_id, user_id)
+);
+
+CREATE INDEX household_members_user_id_idx
+ON household_members (user_id);
+
+-- 世帯への招待コード（1回使うと削除する）
+CREATE TABLE household_invitations (
+  code TEXT PRIMARY KEY,
+  household_id INTEGER NOT NULL REFERENCES households(id) ON DELETE CASCADE,
+  role TEXT NOT NULL CHECK (role IN ('member', 'viewer')),
+  created_by TEXT NOT NULL REFERENCES users(id),
+  expires_at TIMESTAMP NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+-- 現在選択している世帯（NULL の場合は自分の家計簿）
+ALTER TABLE users
+ADD COLUMN current_household_id INTEGER REFERENCES households(id) ON DELETE SET NULL;
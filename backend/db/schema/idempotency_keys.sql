@@ -0,0 +1,17 @@
+-- POST リクエストの Idempotency-Key（通信が不安定な端末の再送による二重登録を防ぐ）
+-- ユーザーごとにキー・リクエストのハッシュ・レスポンスを24時間保存する
+-- 初期設定（POST /setup）は users の作成前に呼ばれるため、users への外部キーは付けない
+CREATE TABLE idempotency_keys (
+  user_id TEXT NOT NULL,
+  idempotency_key TEXT NOT NULL,
+  request_hash TEXT NOT NULL,        -- メソッド・パス・リクエストボディの SHA-256
+  status_code INT,                   -- NULL は最初のリクエストを処理中
+  content_type TEXT,
+  etag TEXT,
+  response_body BYTEA,
+  created_at TIMESTAMP NOT NULL DEFAULT now(),
+  PRIMARY KEY (user_id, idempotency_key)
+);
+
+CREATE INDEX idempotency_keys_created_at_idx
+ON idempotency_keys (created_at);
@@ -0,0 +1,35 @@
+-- 繰り返しの予定支出ルール（支出のテンプレート + 繰り返し条件）
+CREATE TABLE recurring_rules (
+  id SERIAL PRIMARY KEY,
+  user_id TEXT NOT NULL REFERENCES users(id),
+  amount INTEGER NOT NULL,
+  category_id INTEGER NOT NULL REFERENCES categories(id),
+  memo TEXT,
+  frequency TEXT NOT NULL,
+  day_of_month INTEGER, -- frequency = 'monthly' の場合のみ使用
+  start_date DATE NOT NULL, -- 繰り返しの起点（weekly / biweekly / yearly の基準日）
+  end_date DATE, -- NULL の場合は終了日なし
+  generated_until DATE, -- この日付までの予定支出を生成済み
+  created_at TIMESTAMP NOT NULL DEFAULT now(),
+  updated_at TIMESTAMP NOT NULL DEFAULT now()
+);
+
+ALTER TABLE recurring_rules
+ADD CONSTRAINT recurring_rules_frequency_check
+CHECK (frequency IN ('weekly', 'biweekly', 'monthly', 'yearly'));
+
+ALTER TABLE recurring_rules
+ADD CONSTRAINT recurring_rules_day_of_month_check
+CHECK (
+  (frequency = 'monthly' AND day_of_month BETWEEN 1 AND 31)
+  OR (frequency <> 'monthly' AND day_of_month IS NULL)
+);
+
+-- ルールから生成した（または「この回のみ削除」でスキップした）日付
+-- expense_id が NULL の行はスキップされた回を表し、再生成されません。
+CREATE TABLE recurring_occurrences (
+  rule_id INTEGER NOT NULL REFERENCES recurring_rules(id) ON DELETE CASCADE,
+  occurs_on DATE NOT NULL,
+  expense_id INTEGER REFERENCES expenses(id) ON DELETE SET NULL,
+  PRIMARY KEY (rule_id, occurs_on)
+);
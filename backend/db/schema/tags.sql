@@ -0,0 +1,19 @@
+-- 支出に付けるタグ（ユーザーごと）。カテゴリをまたいで「旅行」「イベント」などの単位で支出をまとめるために使います。
+-- タグは支出の登録・更新時に名前で指定し、存在しない場合はその場で作成します。
+CREATE TABLE tags (
+  id SERIAL PRIMARY KEY,
+  user_id TEXT NOT NULL REFERENCES users(id), -- 家計簿の所有者（世帯の場合はオーナー）
+  name TEXT NOT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT now(),
+  UNIQUE (user_id, name)
+);
+
+-- 支出とタグの対応（多対多）
+CREATE TABLE expense_tags (
+  expense_id INTEGER NOT NULL REFERENCES expenses(id) ON DELETE CASCADE,
+  tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+  PRIMARY KEY (expense_id, tag_id)
+);
+
+CREATE INDEX expense_tags_tag_id_idx
+ON expense_tags (tag_id);
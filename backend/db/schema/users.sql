@@ -5,3 +5,13 @@ CREATE TABLE users (
   created_at TIMESTAMP DEFAULT now(),
   updated_at TIMESTAMP DEFAULT now()
 );
+
+-- 収入・貯金目標の履歴。対象月の集計には、適用開始月が対象月以前で最も新しい行を使う
+CREATE TABLE user_settings_history (
+  user_id TEXT NOT NULL REFERENCES users(id),
+  effective_from DATE NOT NULL,  -- 適用開始月（月初日）
+  income INT NOT NULL,
+  saving_goal INT NOT NULL,
+  created_at TIMESTAMP DEFAULT now(),
+  PRIMARY KEY (user_id, effective_from)
+);
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	db "money-buddy-backend/db/generated"
+	"money-buddy-backend/infra/transaction"
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/repositories"
+)
+
+type apiTokenRepositorySQLC struct {
+	q *db.Queries
+}
+
+func NewAPITokenRepositorySQLC(q *db.Queries) repositories.APITokenRepository {
+	return &apiTokenRepositorySQLC{q: q}
+}
+
+func (r *apiTokenRepositorySQLC) queries(ctx context.Context) *db.Queries {
+	if tx, ok := transaction.TxFromContext(ctx); ok {
+		return r.q.WithTx(tx)
+	}
+	return r.q
+}
+
+func (r *apiTokenRepositorySQLC) CreateAPIToken(ctx context.Context, userID string, name string, tokenHash string, scopes []string, expiresInDays int) (models.APIToken, error) {
+	row, err := r.queries(ctx).CreateAPIToken(ctx, db.CreateAPITokenParams{
+		UserID:        userID,
+		Name:          name,
+		TokenHash:     tokenHash,
+		Scopes:        scopes,
+		ExpiresInDays: int32(expiresInDays),
+	})
+	if err != nil {
+		return models.APIToken{}, err
+	}
+	return toModelAPIToken(row.ID, row.Name, row.Scopes, row.ExpiresAt, row.LastUsedAt, row.CreatedAt), nil
+}
+
+func (r *apiTokenRepositorySQLC) ListAPITokens(ctx context.Context, userID string) ([]models.APIToken, error) {
+	rows, err := r.queries(ctx).ListAPITokensByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]models.APIToken, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, toModelAPIToken(row.ID, row.Name, row.Scopes, row.ExpiresAt, row.LastUsedAt, row.CreatedAt))
+	}
+	return out, nil
+}
+
+func (r *apiTokenRepositorySQLC) DeleteAPIToken(ctx context.Context, userID string, id int32) (bool, error) {
+	n, err := r.queries(ctx).DeleteAPIToken(ctx, db.DeleteAPITokenParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (r *apiTokenRepositorySQLC) AuthenticateAPIToken(ctx context.Context, tokenHash string) (string, []string, error) {
+	row, err := r.queries(ctx).AuthenticateAPIToken(ctx, tokenHash)
+	if err != nil {
+		return "", nil, err
+	}
+	return row.UserID, row.Scopes, nil
+}
+
+func toModelAPIToken(id int32, name string, scopes []string, expiresAt time.Time, lastUsedAt sql.NullTime, createdAt time.Time) models.APIToken {
+	var lastUsed *string
+	if lastUsedAt.Valid {
+		s := lastUsedAt.Time.Format(time.RFC3339)
+		lastUsed = &s
+	}
+	return models.APIToken{
+		ID:         int(id),
+		Name:       name,
+		Scopes:     scopes,
+		ExpiresAt:  expiresAt.Format(time.RFC3339),
+		LastUsedAt: lastUsed,
+		CreatedAt:  createdAt.Format(time.RFC3339),
+	}
+}
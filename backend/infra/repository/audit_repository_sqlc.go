@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	db "money-buddy-backend/db/generated"
+	"money-buddy-backend/infra/transaction"
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/repositories"
+)
+
+type auditRepositorySQLC struct {
+	q *db.Queries
+}
+
+func NewAuditRepositorySQLC(q *db.Queries) repositories.AuditRepository {
+	return &auditRepositorySQLC{q: q}
+}
+
+func (r *auditRepositorySQLC) queries(ctx context.Context) *db.Queries {
+	if tx, ok := transaction.TxFromContext(ctx); ok {
+		return r.q.WithTx(tx)
+	}
+	return r.q
+}
+
+func (r *auditRepositorySQLC) CreateAuditEvent(ctx context.Context, event repositories.AuditEventRecord) error {
+	return r.queries(ctx).CreateAuditEvent(ctx, db.CreateAuditEventParams{
+		UserID:     event.UserID,
+		ActorID:    event.ActorID,
+		EntityType: string(event.EntityType),
+		EntityID:   nullInt32(event.EntityID),
+		Action:     string(event.Action),
+		Before:     event.Before,
+		After:      event.After,
+		RequestID:  event.RequestID,
+	})
+}
+
+func (r *auditRepositorySQLC) ListAuditEvents(ctx context.Context, userID string, query repositories.AuditListQuery) ([]models.AuditEvent, error) {
+	params := db.ListAuditEventsParams{
+		UserID:    userID,
+		PageLimit: query.Limit,
+	}
+	if query.EntityType != nil {
+		params.EntityType = sql.NullString{String: *query.EntityType, Valid: true}
+	}
+	if query.BeforeID != nil {
+		params.BeforeID = sql.NullInt64{Int64: *query.BeforeID, Valid: true}
+	}
+
+	rows, err := r.queries(ctx).ListAuditEvents(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]models.AuditEvent, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, dbAuditEventToModel(row))
+	}
+	return out, nil
+}
+
+func (r *auditRepositorySQLC) ListEntityAuditEvents(ctx context.Context, userID string, entityType models.AuditEntityType, entityID int32) ([]models.AuditEvent, error) {
+	rows, err := r.queries(ctx).ListEntityAuditEvents(ctx, db.ListEntityAuditEventsParams{
+		UserID:     userID,
+		EntityType: string(entityType),
+		EntityID:   sql.NullInt32{Int32: entityID, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]models.AuditEvent, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, dbAuditEventToModel(db.ListAuditEventsRow(row)))
+	}
+	return out, nil
+}
+
+func dbAuditEventToModel(row db.ListAuditEventsRow) models.AuditEvent {
+	return models.AuditEvent{
+		ID:         row.ID,
+		EntityType: row.EntityType,
+		EntityID:   intPtrFromNull(row.EntityID),
+		Action:     row.Action,
+		ActorID:    row.ActorID,
+		RequestID:  row.RequestID,
+		Before:     json.RawMessage(row.Before),
+		After:      json.RawMessage(row.After),
+		CreatedAt:  row.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+
+	db "money-buddy-backend/db/generated"
+	"money-buddy-backend/infra/transaction"
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/repositories"
+)
+
+type budgetRepositorySQLC struct {
+	q *db.Queries
+}
+
+func NewBudgetRepositorySQLC(q *db.Queries) repositories.BudgetRepository {
+	return &budgetRepositorySQLC{q: q}
+}
+
+func (r *budgetRepositorySQLC) queries(ctx context.Context) *db.Queries {
+	if tx, ok := transaction.TxFromContext(ctx); ok {
+		return r.q.WithTx(tx)
+	}
+	return r.q
+}
+
+func (r *budgetRepositorySQLC) ListBudgets(ctx context.Context, userID string) ([]models.Budget, error) {
+	rows, err := r.queries(ctx).ListBudgets(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]models.Budget, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, models.Budget{
+			CategoryID:   int(row.CategoryID),
+			CategoryName: row.CategoryName,
+			MonthlyLimit: int(row.MonthlyLimit),
+		})
+	}
+
+	return out, nil
+}
+
+func (r *budgetRepositorySQLC) UpsertBudget(ctx context.Context, userID string, categoryID int32, monthlyLimit int) error {
+	return r.queries(ctx).UpsertBudget(ctx, db.UpsertBudgetParams{
+		UserID:       userID,
+		CategoryID:   categoryID,
+		MonthlyLimit: int32(monthlyLimit),
+	})
+}
+
+func (r *budgetRepositorySQLC) DeleteBudget(ctx context.Context, userID string, categoryID int32) (bool, error) {
+	n, err := r.queries(ctx).DeleteBudget(ctx, db.DeleteBudgetParams{
+		UserID:     userID,
+		CategoryID: categoryID,
+	})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
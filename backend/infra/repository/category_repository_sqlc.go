@@ -4,6 +4,7 @@ import (
 	"context"
 
 	db "money-buddy-backend/db/generated"
+	"money-buddy-backend/infra/transaction"
 	"money-buddy-backend/internal/models"
 	"money-buddy-backend/internal/repositories"
 )
@@ -16,8 +17,15 @@ func NewCategoryRepositorySQLC(q *db.Queries) repositories.CategoryRepository {
 	return &categoryRepositorySQLC{q: q}
 }
 
-func (r *categoryRepositorySQLC) ListCategories(ctx context.Context) ([]models.Category, error) {
-	items, err := r.q.ListCategories(ctx)
+func (r *categoryRepositorySQLC) queries(ctx context.Context) *db.Queries {
+	if tx, ok := transaction.TxFromContext(ctx); ok {
+		return r.q.WithTx(tx)
+	}
+	return r.q
+}
+
+func (r *categoryRepositorySQLC) ListCategories(ctx context.Context, userID string) ([]models.Category, error) {
+	items, err := r.queries(ctx).ListCategories(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -32,11 +40,105 @@ func (r *categoryRepositorySQLC) ListCategories(ctx context.Context) ([]models.C
 
 func dbCategoryToModel(c db.ListCategoriesRow) models.Category {
 	return models.Category{
-		ID:   int(c.ID),
-		Name: c.Name,
+		ID:       int(c.ID),
+		Name:     c.Name,
+		IsCustom: c.IsCustom,
+	}
+}
+
+func (r *categoryRepositorySQLC) CategoryExists(ctx context.Context, userID string, id int32) (bool, error) {
+	return r.queries(ctx).CategoryExists(ctx, db.CategoryExistsParams{
+		ID:     id,
+		UserID: userID,
+	})
+}
+
+func (r *categoryRepositorySQLC) GetCategory(ctx context.Context, userID string, id int32) (models.Category, error) {
+	row, err := r.queries(ctx).GetCategoryForUser(ctx, db.GetCategoryForUserParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		return models.Category{}, err
+	}
+
+	return models.Category{
+		ID:       int(row.ID),
+		Name:     row.Name,
+		IsCustom: row.IsCustom,
+	}, nil
+}
+
+func (r *categoryRepositorySQLC) CreateCategory(ctx context.Context, userID string, name string) (models.Category, error) {
+	row, err := r.queries(ctx).CreateCategory(ctx, db.CreateCategoryParams{
+		UserID: userID,
+		Name:   name,
+	})
+	if err != nil {
+		return models.Category{}, err
 	}
+
+	return models.Category{
+		ID:       int(row.ID),
+		Name:     row.Name,
+		IsCustom: true,
+	}, nil
+}
+
+func (r *categoryRepositorySQLC) UpdateCategory(ctx context.Context, userID string, id int32, name string) error {
+	return r.queries(ctx).UpdateCategory(ctx, db.UpdateCategoryParams{
+		Name:   name,
+		ID:     id,
+		UserID: userID,
+	})
+}
+
+func (r *categoryRepositorySQLC) DeleteCategory(ctx context.Context, userID string, id int32) error {
+	return r.queries(ctx).DeleteCategory(ctx, db.DeleteCategoryParams{
+		ID:     id,
+		UserID: userID,
+	})
+}
+
+func (r *categoryRepositorySQLC) LockCategory(ctx context.Context, userID string, id int32) error {
+	return r.queries(ctx).LockCategory(ctx, db.LockCategoryParams{
+		ID:     id,
+		UserID: userID,
+	})
+}
+
+func (r *categoryRepositorySQLC) HideCategory(ctx context.Context, userID string, id int32) error {
+	return r.queries(ctx).HideCategory(ctx, db.HideCategoryParams{
+		UserID:     userID,
+		CategoryID: id,
+	})
+}
+
+func (r *categoryRepositorySQLC) UnhideCategory(ctx context.Context, userID string, id int32) error {
+	return r.queries(ctx).UnhideCategory(ctx, db.UnhideCategoryParams{
+		UserID:     userID,
+		CategoryID: id,
+	})
+}
+
+func (r *categoryRepositorySQLC) CountExpenses(ctx context.Context, userID string, id int32) (int64, error) {
+	return r.queries(ctx).CountExpensesByCategory(ctx, db.CountExpensesByCategoryParams{
+		UserID:     userID,
+		CategoryID: id,
+	})
+}
+
+func (r *categoryRepositorySQLC) ListExpenseIDs(ctx context.Context, userID string, id int32) ([]int32, error) {
+	return r.queries(ctx).ListExpenseIDsByCategory(ctx, db.ListExpenseIDsByCategoryParams{
+		UserID:     userID,
+		CategoryID: id,
+	})
 }
 
-func (r *categoryRepositorySQLC) CategoryExists(ctx context.Context, id int32) (bool, error) {
-	return r.q.CategoryExists(ctx, id)
+func (r *categoryRepositorySQLC) MoveExpenses(ctx context.Context, userID string, fromID int32, toID int32) error {
+	return r.queries(ctx).MoveExpensesToCategory(ctx, db.MoveExpensesToCategoryParams{
+		ToCategoryID:   toID,
+		UserID:         userID,
+		FromCategoryID: fromID,
+	})
 }
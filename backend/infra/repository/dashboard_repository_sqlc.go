@@ -2,8 +2,11 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"time"
 
 	db "money-buddy-backend/db/generated"
+	"money-buddy-backend/internal/models"
 	"money-buddy-backend/internal/repositories"
 )
 
@@ -15,8 +18,12 @@ func NewDashboardRepositorySQLC(q *db.Queries) repositories.DashboardRepository
 	return &dashboardRepositorySQLC{q: q}
 }
 
-func (r *dashboardRepositorySQLC) GetMonthlySummary(ctx context.Context, userID string) (*repositories.MonthlySummary, error) {
-	row, err := r.q.GetMonthlySummary(ctx, userID)
+func (r *dashboardRepositorySQLC) GetMonthlySummary(ctx context.Context, userID string, month time.Time, mode models.FixedCostMode) (*repositories.MonthlySummary, error) {
+	row, err := r.q.GetMonthlySummary(ctx, db.GetMonthlySummaryParams{
+		FixedCostMode: string(mode),
+		MonthStart:    month,
+		UserID:        userID,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -28,8 +35,11 @@ func (r *dashboardRepositorySQLC) GetMonthlySummary(ctx context.Context, userID
 	}, nil
 }
 
-func (r *dashboardRepositorySQLC) GetMonthlyExpensesSummary(ctx context.Context, userID string) (*repositories.MonthlyExpensesSummary, error) {
-	row, err := r.q.GetMonthlyExpensesSummary(ctx, userID)
+func (r *dashboardRepositorySQLC) GetMonthlyExpensesSummary(ctx context.Context, userID string, month time.Time) (*repositories.MonthlyExpensesSummary, error) {
+	row, err := r.q.GetMonthlyExpensesSummary(ctx, db.GetMonthlyExpensesSummaryParams{
+		UserID:     userID,
+		MonthStart: month,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -39,3 +49,186 @@ func (r *dashboardRepositorySQLC) GetMonthlyExpensesSummary(ctx context.Context,
 		PlannedExpenses:   row.PendingExpenses,
 	}, nil
 }
+
+func (r *dashboardRepositorySQLC) GetMonthlyCategoryExpensesSummary(ctx context.Context, userID string, month time.Time) ([]repositories.CategoryExpensesSummary, error) {
+	rows, err := r.q.GetMonthlyCategoryExpensesSummary(ctx, db.GetMonthlyCategoryExpensesSummaryParams{
+		UserID:     userID,
+		MonthStart: month,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]repositories.CategoryExpensesSummary, 0, len(rows))
+	for _, row := range rows {
+		var limit *int64
+		if row.MonthlyLimit.Valid {
+			v := int64(row.MonthlyLimit.Int32)
+			limit = &v
+		}
+		out = append(out, repositories.CategoryExpensesSummary{
+			CategoryID:        row.CategoryID,
+			CategoryName:      row.CategoryName,
+			MonthlyLimit:      limit,
+			ConfirmedExpenses: row.ConfirmedExpenses,
+			PlannedExpenses:   row.PendingExpenses,
+		})
+	}
+
+	return out, nil
+}
+
+func (r *dashboardRepositorySQLC) GetMonthlyMemberExpensesSummary(ctx context.Context, userID string, month time.Time) ([]repositories.MemberExpensesSummary, error) {
+	rows, err := r.q.GetMonthlyMemberExpensesSummary(ctx, db.GetMonthlyMemberExpensesSummaryParams{
+		UserID:     userID,
+		MonthStart: month,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]repositories.MemberExpensesSummary, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, repositories.MemberExpensesSummary{
+			UserID:            row.MemberID,
+			ConfirmedExpenses: row.ConfirmedExpenses,
+			PlannedExpenses:   row.PendingExpenses,
+		})
+	}
+
+	return out, nil
+}
+
+func (r *dashboardRepositorySQLC) ListMonthlySummaries(ctx context.Context, userID string, from, to time.Time, mode models.FixedCostMode) ([]repositories.MonthlyTrendSummary, error) {
+	rows, err := r.q.ListMonthlySummaries(ctx, db.ListMonthlySummariesParams{
+		FromMonth:     from,
+		ToMonth:       to,
+		UserID:        userID,
+		FixedCostMode: string(mode),
+	})
+	if err != nil {
+		return nil, err
+	}
+	// 期間は1か月以上あるため、行がない場合はユーザーが存在しない
+	if len(rows) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	out := make([]repositories.MonthlyTrendSummary, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, repositories.MonthlyTrendSummary{
+			Month: row.MonthStart,
+			Summary: repositories.MonthlySummary{
+				Income:     int64(row.Income),
+				SavingGoal: int64(row.SavingGoal),
+				FixedCosts: row.FixedCosts,
+			},
+			Expenses: repositories.MonthlyExpensesSummary{
+				ConfirmedExpenses: row.ConfirmedExpenses,
+				PlannedExpenses:   row.PendingExpenses,
+			},
+		})
+	}
+
+	return out, nil
+}
+
+func (r *dashboardRepositorySQLC) ListMonthlyCategoryExpenses(ctx context.Context, userID string, from, to time.Time) ([]repositories.MonthlyCategoryExpenses, error) {
+	rows, err := r.q.ListMonthlyCategoryExpenses(ctx, db.ListMonthlyCategoryExpensesParams{
+		UserID:    userID,
+		FromMonth: from,
+		ToMonth:   to,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]repositories.MonthlyCategoryExpenses, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, repositories.MonthlyCategoryExpenses{
+			Month:             row.MonthStart,
+			CategoryID:        row.CategoryID,
+			CategoryName:      row.CategoryName,
+			ConfirmedExpenses: row.ConfirmedExpenses,
+			PlannedExpenses:   row.PendingExpenses,
+		})
+	}
+
+	return out, nil
+}
+
+func (r *dashboardRepositorySQLC) ListMonthlyCategoryEstimateAccuracy(ctx context.Context, userID string, from, to time.Time) ([]repositories.MonthlyCategoryEstimateAccuracy, error) {
+	rows, err := r.q.ListMonthlyCategoryEstimateAccuracy(ctx, db.ListMonthlyCategoryEstimateAccuracyParams{
+		UserID:    userID,
+		FromMonth: from,
+		ToMonth:   to,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]repositories.MonthlyCategoryEstimateAccuracy, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, repositories.MonthlyCategoryEstimateAccuracy{
+			Month:               row.MonthStart,
+			CategoryID:          row.CategoryID,
+			CategoryName:        row.CategoryName,
+			ExpenseCount:        row.ExpenseCount,
+			PlannedTotal:        row.PlannedTotal,
+			ActualTotal:         row.ActualTotal,
+			AbsoluteVariance:    row.AbsoluteVariance,
+			UnderestimatedCount: row.UnderestimatedCount,
+			OverestimatedCount:  row.OverestimatedCount,
+		})
+	}
+
+	return out, nil
+}
+
+func (r *dashboardRepositorySQLC) ListTagTotals(ctx context.Context, userID string, from, to time.Time) ([]repositories.TagTotal, error) {
+	rows, err := r.q.ListTagTotals(ctx, db.ListTagTotalsParams{
+		UserID:   userID,
+		FromDate: from,
+		ToDate:   to,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]repositories.TagTotal, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, repositories.TagTotal{
+			TagID:             row.TagID,
+			TagName:           row.TagName,
+			ExpenseCount:      row.ExpenseCount,
+			ConfirmedExpenses: row.ConfirmedExpenses,
+			PlannedExpenses:   row.PendingExpenses,
+		})
+	}
+
+	return out, nil
+}
+
+func (r *dashboardRepositorySQLC) ListTagCategoryExpenses(ctx context.Context, userID string, from, to time.Time) ([]repositories.TagCategoryExpenses, error) {
+	rows, err := r.q.ListTagCategoryExpenses(ctx, db.ListTagCategoryExpensesParams{
+		UserID:   userID,
+		FromDate: from,
+		ToDate:   to,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]repositories.TagCategoryExpenses, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, repositories.TagCategoryExpenses{
+			TagID:             row.TagID,
+			CategoryID:        row.CategoryID,
+			CategoryName:      row.CategoryName,
+			ConfirmedExpenses: row.ConfirmedExpenses,
+			PlannedExpenses:   row.PendingExpenses,
+		})
+	}
+
+	return out, nil
+}
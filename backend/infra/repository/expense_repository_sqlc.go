@@ -3,9 +3,11 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"strings"
 	"time"
 
 	db "money-buddy-backend/db/generated"
+	"money-buddy-backend/infra/transaction"
 	"money-buddy-backend/internal/models"
 	"money-buddy-backend/internal/repositories"
 )
@@ -19,7 +21,14 @@ func NewExpenseRepositorySQLC(q *db.Queries) repositories.ExpenseRepository {
 	return &expenseRepositorySQLC{q: q}
 }
 
-func (r *expenseRepositorySQLC) CreateExpense(userID string, input models.CreateExpenseInput) (models.Expense, error) {
+func (r *expenseRepositorySQLC) queries(ctx context.Context) *db.Queries {
+	if tx, ok := transaction.TxFromContext(ctx); ok {
+		return r.q.WithTx(tx)
+	}
+	return r.q
+}
+
+func (r *expenseRepositorySQLC) CreateExpense(ctx context.Context, userID string, input models.CreateExpenseInput) (models.Expense, error) {
 	// 複数フォーマットに対応するため、RFC3339 をまず試し、失敗したら日付のみ (2006-01-02) を試す
 	var spentAt time.Time
 	var err error
@@ -42,26 +51,54 @@ func (r *expenseRepositorySQLC) CreateExpense(userID string, input models.Create
 		Memo:       sql.NullString{String: input.Memo, Valid: input.Memo != ""},
 		SpentAt:    spentAt,
 		Status:     defaultStatus(input.Status),
+		CreatedBy:  expenseCreatedBy(userID, input.CreatedBy),
 	}
+	// 明細は支出と同じ文で登録する
+	params.ItemAmounts, params.ItemCategoryIds, params.ItemMemos = expenseItemArrays(input.Items)
+	params.TagNames = expenseTagNames(input.Tags)
 
-	id, err := r.q.CreateExpense(context.Background(), params)
-	if err != nil {
-		return models.Expense{}, err
-	}
-
-	row, err := r.q.GetExpenseWithCategoryByID(context.Background(), db.GetExpenseWithCategoryByIDParams{
-		UserID: userID,
-		ID:     id,
-	})
+	id, err := r.queries(ctx).CreateExpense(ctx, params)
 	if err != nil {
 		return models.Expense{}, err
 	}
 
-	return dbExpenseToModel(row), nil
+	return r.GetExpenseByID(ctx, userID, id)
 }
 
-func (r *expenseRepositorySQLC) FindAll(userID string) ([]models.Expense, error) {
-	items, err := r.q.ListExpenses(context.Background(), userID)
+func (r *expenseRepositorySQLC) FindAll(ctx context.Context, userID string, query repositories.ExpenseListQuery) ([]models.Expense, error) {
+	params := db.ListExpensesParams{
+		UserID:      userID,
+		CategoryIds: query.CategoryIDs,
+		Status:      sql.NullString{String: query.Status, Valid: query.Status != ""},
+		Memo:        sql.NullString{String: escapeLike(query.Memo), Valid: query.Memo != ""},
+		CursorID:    query.CursorID,
+		PageLimit:   query.Limit,
+	}
+	// cardinality(NULL) は NULL になるため、未指定時も空配列を渡す
+	if params.CategoryIds == nil {
+		params.CategoryIds = []int32{}
+	}
+	params.TagIds = query.TagIDs
+	if params.TagIds == nil {
+		params.TagIds = []int32{}
+	}
+	if query.From != nil {
+		params.FromDate = sql.NullTime{Time: *query.From, Valid: true}
+	}
+	if query.To != nil {
+		params.ToDate = sql.NullTime{Time: *query.To, Valid: true}
+	}
+	if query.AmountMin != nil {
+		params.AmountMin = sql.NullInt32{Int32: *query.AmountMin, Valid: true}
+	}
+	if query.AmountMax != nil {
+		params.AmountMax = sql.NullInt32{Int32: *query.AmountMax, Valid: true}
+	}
+	if query.CursorSpentAt != nil {
+		params.CursorSpentAt = sql.NullTime{Time: *query.CursorSpentAt, Valid: true}
+	}
+
+	items, err := r.queries(ctx).ListExpenses(ctx, params)
 	if err != nil {
 		return nil, err
 	}
@@ -70,10 +107,91 @@ func (r *expenseRepositorySQLC) FindAll(userID string) ([]models.Expense, error)
 	for _, it := range items {
 		out = append(out, dbListExpenseRowToModel(it))
 	}
+	if err := r.attachExpenseDetails(ctx, out); err != nil {
+		return nil, err
+	}
 
 	return out, nil
 }
 
+// attachExpenseDetails は支出の明細とタグをそれぞれ1回のクエリでまとめて取得し、それぞれの支出に設定します。
+func (r *expenseRepositorySQLC) attachExpenseDetails(ctx context.Context, expenses []models.Expense) error {
+	if len(expenses) == 0 {
+		return nil
+	}
+
+	ids := make([]int32, 0, len(expenses))
+	index := make(map[int32]int, len(expenses))
+	for i, e := range expenses {
+		ids = append(ids, int32(e.ID))
+		index[int32(e.ID)] = i
+	}
+
+	rows, err := r.queries(ctx).ListExpenseItems(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		i, ok := index[row.ExpenseID]
+		if !ok {
+			continue
+		}
+		expenses[i].Items = append(expenses[i].Items, models.ExpenseItem{
+			Amount:   int(row.Amount),
+			Memo:     row.Memo.String,
+			Category: models.Category{ID: int(row.CategoryID), Name: row.CategoryName},
+		})
+	}
+
+	tags, err := r.queries(ctx).ListExpenseTags(ctx, ids)
+	if err != nil {
+		return err
+	}
+	for _, row := range tags {
+		i, ok := index[row.ExpenseID]
+		if !ok {
+			continue
+		}
+		expenses[i].Tags = append(expenses[i].Tags, models.Tag{ID: int(row.TagID), Name: row.TagName})
+	}
+	return nil
+}
+
+// expenseTagNames は CreateExpense / UpdateExpense に渡すタグ名の配列を返します。UNNEST(NULL) を避けるため nil は空配列にします。
+func expenseTagNames(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
+
+// expenseItemArrays は明細を CreateExpense / UpdateExpense に渡す同じ順序の配列に変換します。
+// UNNEST(NULL) を避けるため、明細がない場合も空配列を返します。
+func expenseItemArrays(items []models.ExpenseItemInput) (amounts, categoryIDs []int32, memos []string) {
+	amounts = make([]int32, 0, len(items))
+	categoryIDs = make([]int32, 0, len(items))
+	memos = make([]string, 0, len(items))
+	for _, it := range items {
+		amounts = append(amounts, int32(*it.Amount))
+		categoryIDs = append(categoryIDs, int32(*it.CategoryID))
+		memos = append(memos, it.Memo)
+	}
+	return amounts, categoryIDs, memos
+}
+
+// expenseCreatedBy は登録したユーザーIDを返します。指定がない場合は家計簿の所有者（userID）とします。
+func expenseCreatedBy(userID, createdBy string) string {
+	if createdBy == "" {
+		return userID
+	}
+	return createdBy
+}
+
+// escapeLike は ILIKE のワイルドカード（%, _）とエスケープ文字をリテラルとして扱えるようにエスケープします。
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
 func dbExpenseToModel(e db.GetExpenseWithCategoryByIDRow) models.Expense {
 	memo := ""
 	if e.Memo.Valid {
@@ -81,12 +199,16 @@ func dbExpenseToModel(e db.GetExpenseWithCategoryByIDRow) models.Expense {
 	}
 
 	return models.Expense{
-		ID:       int(e.ID),
-		Amount:   int(e.Amount),
-		Memo:     memo,
-		SpentAt:  e.SpentAt.Format(time.RFC3339),
-		Status:   e.Status,
-		Category: models.Category{ID: int(e.CategoryID), Name: e.CategoryName},
+		ID:            int(e.ID),
+		Amount:        int(e.Amount),
+		Memo:          memo,
+		SpentAt:       e.SpentAt.Format(time.RFC3339),
+		Status:        e.Status,
+		CreatedBy:     e.CreatedBy,
+		Version:       int(e.Version),
+		Category:      models.Category{ID: int(e.CategoryID), Name: e.CategoryName},
+		PlannedAmount: intPtrFromNull(e.PlannedAmount),
+		Variance:      expenseVariance(e.Amount, e.PlannedAmount),
 	}
 }
 
@@ -97,17 +219,30 @@ func dbListExpenseRowToModel(e db.ListExpensesRow) models.Expense {
 	}
 
 	return models.Expense{
-		ID:       int(e.ID),
-		Amount:   int(e.Amount),
-		Memo:     memo,
-		SpentAt:  e.SpentAt.Format(time.RFC3339),
-		Status:   e.Status,
-		Category: models.Category{ID: int(e.CategoryID), Name: e.CategoryName},
+		ID:            int(e.ID),
+		Amount:        int(e.Amount),
+		Memo:          memo,
+		SpentAt:       e.SpentAt.Format(time.RFC3339),
+		Status:        e.Status,
+		CreatedBy:     e.CreatedBy,
+		Version:       int(e.Version),
+		Category:      models.Category{ID: int(e.CategoryID), Name: e.CategoryName},
+		PlannedAmount: intPtrFromNull(e.PlannedAmount),
+		Variance:      expenseVariance(e.Amount, e.PlannedAmount),
+	}
+}
+
+// expenseVariance は確定した金額と予定金額の差（実績 - 見積もり）を返します。予定金額がない場合は nil です。
+func expenseVariance(amount int32, plannedAmount sql.NullInt32) *int {
+	if !plannedAmount.Valid {
+		return nil
 	}
+	v := int(amount - plannedAmount.Int32)
+	return &v
 }
 
-func (r *expenseRepositorySQLC) GetExpenseByID(userID string, id int32) (models.Expense, error) {
-	row, err := r.q.GetExpenseWithCategoryByID(context.Background(), db.GetExpenseWithCategoryByIDParams{
+func (r *expenseRepositorySQLC) GetExpenseByID(ctx context.Context, userID string, id int32) (models.Expense, error) {
+	row, err := r.queries(ctx).GetExpenseWithCategoryByID(ctx, db.GetExpenseWithCategoryByIDParams{
 		UserID: userID,
 		ID:     id,
 	})
@@ -115,17 +250,37 @@ func (r *expenseRepositorySQLC) GetExpenseByID(userID string, id int32) (models.
 		return models.Expense{}, err
 	}
 
-	return dbExpenseToModel(row), nil
+	expenses := []models.Expense{dbExpenseToModel(row)}
+	if err := r.attachExpenseDetails(ctx, expenses); err != nil {
+		return models.Expense{}, err
+	}
+	return expenses[0], nil
 }
 
-func (r *expenseRepositorySQLC) DeleteExpense(userID string, id int32) error {
-	return r.q.DeleteExpense(context.Background(), db.DeleteExpenseParams{
+func (r *expenseRepositorySQLC) DeleteExpense(ctx context.Context, userID string, id int32) error {
+	return r.queries(ctx).DeleteExpense(ctx, db.DeleteExpenseParams{
 		ID:     id,
 		UserID: userID,
 	})
 }
 
-func (r *expenseRepositorySQLC) UpdateExpense(userID string, input models.UpdateExpenseInput) (models.Expense, error) {
+func (r *expenseRepositorySQLC) RestoreExpense(ctx context.Context, userID string, id int32, deletedAfter time.Time) (models.Expense, error) {
+	rows, err := r.queries(ctx).RestoreExpense(ctx, db.RestoreExpenseParams{
+		ID:           id,
+		UserID:       userID,
+		DeletedAfter: deletedAfter,
+	})
+	if err != nil {
+		return models.Expense{}, err
+	}
+	if rows == 0 {
+		return models.Expense{}, sql.ErrNoRows
+	}
+
+	return r.GetExpenseByID(ctx, userID, id)
+}
+
+func (r *expenseRepositorySQLC) UpdateExpense(ctx context.Context, userID string, input models.UpdateExpenseInput) (models.Expense, error) {
 	// 複数フォーマットに対応するため、RFC3339 をまず試し、失敗したら日付のみ (2006-01-02) を試す
 	var spentAt time.Time
 	var err error
@@ -142,19 +297,100 @@ func (r *expenseRepositorySQLC) UpdateExpense(userID string, input models.Update
 	}
 
 	params := db.UpdateExpenseParams{
-		ID:         int32(input.ID),
-		Amount:     int32(*input.Amount),
-		CategoryID: int32(*input.CategoryID),
-		Memo:       sql.NullString{String: input.Memo, Valid: input.Memo != ""},
-		SpentAt:    spentAt,
-		Status:     defaultStatus(input.Status),
-		UserID:     userID,
+		ID:            int32(input.ID),
+		Amount:        int32(*input.Amount),
+		CategoryID:    int32(*input.CategoryID),
+		Memo:          sql.NullString{String: input.Memo, Valid: input.Memo != ""},
+		SpentAt:       spentAt,
+		Status:        defaultStatus(input.Status),
+		UserID:        userID,
+		Version:       int32(input.Version),
+		PlannedAmount: nullIntFromPtr(input.PlannedAmount),
+	}
+	if input.Items != nil {
+		// 明細の置き換えは支出の更新と同じ文で行う
+		params.ReplaceItems = true
+		params.ItemAmounts, params.ItemCategoryIds, params.ItemMemos = expenseItemArrays(*input.Items)
+	} else {
+		params.ItemAmounts, params.ItemCategoryIds, params.ItemMemos = expenseItemArrays(nil)
+	}
+	if input.Tags != nil {
+		// タグの付け直しも支出の更新と同じ文で行う
+		params.ReplaceTags = true
+		params.TagNames = expenseTagNames(*input.Tags)
+	} else {
+		params.TagNames = expenseTagNames(nil)
 	}
-	err = r.q.UpdateExpense(context.Background(), params)
+	rows, err := r.queries(ctx).UpdateExpense(ctx, params)
+	if err != nil {
+		return models.Expense{}, err
+	}
+	if rows == 0 {
+		return models.Expense{}, sql.ErrNoRows
+	}
+
+	return r.GetExpenseByID(ctx, userID, int32(input.ID))
+}
 
+func (r *expenseRepositorySQLC) UpdateExpenseStatus(ctx context.Context, userID string, id int32, status string, version int, plannedAmount *int) (models.Expense, error) {
+	rows, err := r.queries(ctx).UpdateExpenseStatus(ctx, db.UpdateExpenseStatusParams{
+		ID:            id,
+		Status:        status,
+		UserID:        userID,
+		Version:       int32(version),
+		PlannedAmount: nullIntFromPtr(plannedAmount),
+	})
 	if err != nil {
 		return models.Expense{}, err
 	}
+	if rows == 0 {
+		return models.Expense{}, sql.ErrNoRows
+	}
+
+	return r.GetExpenseByID(ctx, userID, id)
+}
+
+func (r *expenseRepositorySQLC) BulkCreateExpenses(ctx context.Context, userID string, inputs []models.CreateExpenseInput) ([]models.Expense, error) {
+	if len(inputs) == 0 {
+		return nil, nil
+	}
 
-	return r.GetExpenseByID(userID, int32(input.ID))
+	params := db.BulkCreateExpensesParams{
+		UserID:      userID,
+		Amounts:     make([]int32, 0, len(inputs)),
+		CategoryIds: make([]int32, 0, len(inputs)),
+		Memos:       make([]string, 0, len(inputs)),
+		SpentAts:    make([]time.Time, 0, len(inputs)),
+		Statuses:    make([]string, 0, len(inputs)),
+		CreatedBys:  make([]string, 0, len(inputs)),
+	}
+	for _, in := range inputs {
+		spentAt, err := time.Parse(time.RFC3339, in.SpentAt)
+		if err != nil {
+			spentAt, err = time.Parse("2006-01-02", in.SpentAt)
+			if err != nil {
+				return nil, err
+			}
+		}
+		params.Amounts = append(params.Amounts, int32(*in.Amount))
+		params.CategoryIds = append(params.CategoryIds, int32(*in.CategoryID))
+		params.Memos = append(params.Memos, in.Memo)
+		params.SpentAts = append(params.SpentAts, spentAt)
+		params.Statuses = append(params.Statuses, defaultStatus(in.Status))
+		params.CreatedBys = append(params.CreatedBys, expenseCreatedBy(userID, in.CreatedBy))
+	}
+
+	// 行は ID の昇順（inputs と同じ順）で返る
+	rows, err := r.queries(ctx).BulkCreateExpenses(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	expenses := make([]models.Expense, 0, len(rows))
+	for _, row := range rows {
+		expenses = append(expenses, dbExpenseToModel(db.GetExpenseWithCategoryByIDRow(row)))
+	}
+	if err := r.attachExpenseDetails(ctx, expenses); err != nil {
+		return nil, err
+	}
+	return expenses, nil
 }
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	db "money-buddy-backend/db/generated"
@@ -25,18 +26,22 @@ func (r *fixedCostRepositorySQLC) queries(ctx context.Context) *db.Queries {
 	return r.q
 }
 
-func (r *fixedCostRepositorySQLC) CreateFixedCost(ctx context.Context, userID string, name string, amount int) (models.FixedCost, error) {
+func (r *fixedCostRepositorySQLC) CreateFixedCost(ctx context.Context, userID string, name string, amount int, schedule models.FixedCostSchedule, effectiveFrom time.Time) (models.FixedCost, error) {
 	params := db.CreateFixedCostParams{
-		UserID: userID,
-		Name:   name,
-		Amount: int32(amount),
+		UserID:        userID,
+		Name:          name,
+		Amount:        int32(amount),
+		Frequency:     schedule.Frequency,
+		BillingMonth:  nullIntFromPtr(schedule.BillingMonth),
+		BillingDay:    nullIntFromPtr(schedule.BillingDay),
+		EffectiveFrom: effectiveFrom,
 	}
 	row, err := r.queries(ctx).CreateFixedCost(ctx, params)
 	if err != nil {
 		return models.FixedCost{}, err
 	}
 
-	return dbFixedCostToModel(row), nil
+	return dbFixedCostToModel(db.FixedCost(row)), nil
 }
 
 func (r *fixedCostRepositorySQLC) ListFixedCostsByUser(ctx context.Context, userID string) ([]models.FixedCost, error) {
@@ -53,11 +58,14 @@ func (r *fixedCostRepositorySQLC) ListFixedCostsByUser(ctx context.Context, user
 	return out, nil
 }
 
-func (r *fixedCostRepositorySQLC) DeleteFixedCostsByUser(ctx context.Context, userID string) error {
-	return r.queries(ctx).DeleteFixedCostsByUser(ctx, userID)
+func (r *fixedCostRepositorySQLC) EndFixedCostsByUser(ctx context.Context, userID string, endedFrom time.Time) error {
+	return r.queries(ctx).EndFixedCostsByUser(ctx, db.EndFixedCostsByUserParams{
+		EndedFrom: endedFrom,
+		UserID:    userID,
+	})
 }
 
-func (r *fixedCostRepositorySQLC) BulkCreateFixedCosts(ctx context.Context, userID string, fixedCosts []models.FixedCostInput) error {
+func (r *fixedCostRepositorySQLC) BulkCreateFixedCosts(ctx context.Context, userID string, fixedCosts []models.FixedCostInput, effectiveFrom time.Time) error {
 	if len(fixedCosts) == 0 {
 		return nil
 	}
@@ -65,37 +73,70 @@ func (r *fixedCostRepositorySQLC) BulkCreateFixedCosts(ctx context.Context, user
 	userIDs := make([]string, 0, len(fixedCosts))
 	names := make([]string, 0, len(fixedCosts))
 	amounts := make([]int32, 0, len(fixedCosts))
+	frequencies := make([]string, 0, len(fixedCosts))
+	// 請求月・請求日の未指定は 0 として渡す（クエリ側で NULL に変換される）
+	billingMonths := make([]int32, 0, len(fixedCosts))
+	billingDays := make([]int32, 0, len(fixedCosts))
 	for _, fc := range fixedCosts {
 		userIDs = append(userIDs, userID)
 		names = append(names, fc.Name)
 		amounts = append(amounts, int32(fc.Amount))
+		frequencies = append(frequencies, fc.Frequency)
+		billingMonths = append(billingMonths, int32OrZero(fc.BillingMonth))
+		billingDays = append(billingDays, int32OrZero(fc.BillingDay))
 	}
 
 	params := db.BulkCreateFixedCostsParams{
 		Column1: userIDs,
 		Column2: names,
 		Column3: amounts,
+		Column4: frequencies,
+		Column5: billingMonths,
+		Column6: billingDays,
+		Column7: effectiveFrom,
 	}
 	return r.queries(ctx).BulkCreateFixedCosts(ctx, params)
 }
 
-func (r *fixedCostRepositorySQLC) UpdateFixedCost(ctx context.Context, id int32, userID string, name string, amount int) error {
+func (r *fixedCostRepositorySQLC) UpdateFixedCost(ctx context.Context, id int32, userID string, name string, amount int, schedule models.FixedCostSchedule, effectiveFrom time.Time, version int) (bool, error) {
 	params := db.UpdateFixedCostParams{
-		ID:     id,
-		Name:   name,
-		Amount: int32(amount),
-		UserID: userID,
+		ID:            id,
+		Name:          name,
+		Amount:        int32(amount),
+		UserID:        userID,
+		Frequency:     schedule.Frequency,
+		BillingMonth:  nullIntFromPtr(schedule.BillingMonth),
+		BillingDay:    nullIntFromPtr(schedule.BillingDay),
+		EffectiveFrom: effectiveFrom,
+		Version:       int32(version),
+	}
+	rows, err := r.queries(ctx).UpdateFixedCost(ctx, params)
+	if err != nil {
+		return false, err
 	}
-	return r.queries(ctx).UpdateFixedCost(ctx, params)
+	return rows > 0, nil
 }
 
-func (r *fixedCostRepositorySQLC) DeleteFixedCost(ctx context.Context, id int32, userID string) error {
-	return r.queries(ctx).DeleteFixedCost(ctx, db.DeleteFixedCostParams{
-		ID:     id,
-		UserID: userID,
+func (r *fixedCostRepositorySQLC) EndFixedCost(ctx context.Context, id int32, userID string, endedFrom time.Time) error {
+	return r.queries(ctx).EndFixedCost(ctx, db.EndFixedCostParams{
+		EndedFrom: endedFrom,
+		ID:        id,
+		UserID:    userID,
 	})
 }
 
+func (r *fixedCostRepositorySQLC) RestoreFixedCost(ctx context.Context, id int32, userID string, deletedAfter time.Time) (bool, error) {
+	rows, err := r.queries(ctx).RestoreFixedCost(ctx, db.RestoreFixedCostParams{
+		ID:           id,
+		UserID:       userID,
+		DeletedAfter: deletedAfter,
+	})
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
 func dbFixedCostToModel(fc db.FixedCost) models.FixedCost {
 	createdAt := ""
 	if fc.CreatedAt.Valid {
@@ -107,11 +148,42 @@ func dbFixedCostToModel(fc db.FixedCost) models.FixedCost {
 	}
 
 	return models.FixedCost{
-		ID:        int(fc.ID),
-		UserID:    fc.UserID,
-		Name:      fc.Name,
-		Amount:    int(fc.Amount),
+		ID:     int(fc.ID),
+		UserID: fc.UserID,
+		Name:   fc.Name,
+		Amount: int(fc.Amount),
+		FixedCostSchedule: models.FixedCostSchedule{
+			Frequency:    fc.Frequency,
+			BillingMonth: intPtrFromNull(fc.BillingMonth),
+			BillingDay:   intPtrFromNull(fc.BillingDay),
+		},
 		CreatedAt: createdAt,
 		UpdatedAt: updatedAt,
+		Version:   int(fc.Version),
+	}
+}
+
+// nullIntFromPtr は *int を sql.NullInt32 に変換します。
+func nullIntFromPtr(v *int) sql.NullInt32 {
+	if v == nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: int32(*v), Valid: true}
+}
+
+// intPtrFromNull は sql.NullInt32 を *int に変換します。
+func intPtrFromNull(n sql.NullInt32) *int {
+	if !n.Valid {
+		return nil
+	}
+	v := int(n.Int32)
+	return &v
+}
+
+// int32OrZero は v が nil の場合に 0 を返します。
+func int32OrZero(v *int) int32 {
+	if v == nil {
+		return 0
 	}
+	return int32(*v)
 }
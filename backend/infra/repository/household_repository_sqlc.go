@@ -0,0 +1,193 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	db "money-buddy-backend/db/generated"
+	"money-buddy-backend/infra/transaction"
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/repositories"
+)
+
+type householdRepositorySQLC struct {
+	q *db.Queries
+}
+
+func NewHouseholdRepositorySQLC(q *db.Queries) repositories.HouseholdRepository {
+	return &householdRepositorySQLC{q: q}
+}
+
+func (r *householdRepositorySQLC) queries(ctx context.Context) *db.Queries {
+	if tx, ok := transaction.TxFromContext(ctx); ok {
+		return r.q.WithTx(tx)
+	}
+	return r.q
+}
+
+func (r *householdRepositorySQLC) GetCurrentMembership(ctx context.Context, userID string) (models.HouseholdMembership, error) {
+	row, err := r.queries(ctx).GetCurrentHouseholdMembership(ctx, userID)
+	if err != nil {
+		return models.HouseholdMembership{}, err
+	}
+	return models.HouseholdMembership{
+		HouseholdID: int(row.ID),
+		OwnerID:     row.OwnerID,
+		Role:        row.Role,
+	}, nil
+}
+
+func (r *householdRepositorySQLC) ListHouseholds(ctx context.Context, userID string) ([]models.Household, error) {
+	rows, err := r.queries(ctx).ListHouseholdsByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]models.Household, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, toModelHousehold(row.ID, row.Name, row.OwnerID, row.Role, row.IsCurrent, row.CreatedAt))
+	}
+	return out, nil
+}
+
+func (r *householdRepositorySQLC) GetHousehold(ctx context.Context, userID string, id int32) (models.Household, error) {
+	row, err := r.queries(ctx).GetHouseholdForMember(ctx, db.GetHouseholdForMemberParams{
+		UserID: userID,
+		ID:     id,
+	})
+	if err != nil {
+		return models.Household{}, err
+	}
+	return toModelHousehold(row.ID, row.Name, row.OwnerID, row.Role, row.IsCurrent, row.CreatedAt), nil
+}
+
+func (r *householdRepositorySQLC) ListMembers(ctx context.Context, id int32) ([]models.HouseholdMember, error) {
+	rows, err := r.queries(ctx).ListHouseholdMembers(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]models.HouseholdMember, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, models.HouseholdMember{
+			UserID:   row.UserID,
+			Role:     row.Role,
+			JoinedAt: row.JoinedAt.Format(time.RFC3339),
+		})
+	}
+	return out, nil
+}
+
+func (r *householdRepositorySQLC) OwnsHousehold(ctx context.Context, userID string) (bool, error) {
+	return r.queries(ctx).HouseholdExistsByOwner(ctx, userID)
+}
+
+func (r *householdRepositorySQLC) CreateHousehold(ctx context.Context, ownerID string, name string) (int32, error) {
+	h, err := r.queries(ctx).CreateHousehold(ctx, db.CreateHouseholdParams{
+		OwnerID: ownerID,
+		Name:    name,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return h.ID, nil
+}
+
+func (r *householdRepositorySQLC) AddMember(ctx context.Context, id int32, userID string, role string) (bool, error) {
+	n, err := r.queries(ctx).AddHouseholdMember(ctx, db.AddHouseholdMemberParams{
+		HouseholdID: id,
+		UserID:      userID,
+		Role:        role,
+	})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (r *householdRepositorySQLC) SetCurrentHousehold(ctx context.Context, userID string, id *int32) error {
+	params := db.SetCurrentHouseholdParams{UserID: userID}
+	if id != nil {
+		params.HouseholdID = sql.NullInt32{Int32: *id, Valid: true}
+	}
+	n, err := r.queries(ctx).SetCurrentHousehold(ctx, params)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *householdRepositorySQLC) UpdateMemberRole(ctx context.Context, id int32, userID string, role string) (bool, error) {
+	n, err := r.queries(ctx).UpdateHouseholdMemberRole(ctx, db.UpdateHouseholdMemberRoleParams{
+		HouseholdID: id,
+		UserID:      userID,
+		Role:        role,
+	})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (r *householdRepositorySQLC) DeleteMember(ctx context.Context, id int32, userID string) (bool, error) {
+	n, err := r.queries(ctx).DeleteHouseholdMember(ctx, db.DeleteHouseholdMemberParams{
+		UserID:      userID,
+		HouseholdID: id,
+	})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (r *householdRepositorySQLC) DeleteHousehold(ctx context.Context, ownerID string, id int32) (bool, error) {
+	n, err := r.queries(ctx).DeleteHousehold(ctx, db.DeleteHouseholdParams{
+		ID:      id,
+		OwnerID: ownerID,
+	})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (r *householdRepositorySQLC) CreateInvitation(ctx context.Context, id int32, createdBy string, code string, role string, expiresInDays int) (models.HouseholdInvitation, error) {
+	inv, err := r.queries(ctx).CreateHouseholdInvitation(ctx, db.CreateHouseholdInvitationParams{
+		Code:          code,
+		HouseholdID:   id,
+		Role:          role,
+		CreatedBy:     createdBy,
+		ExpiresInDays: int32(expiresInDays),
+	})
+	if err != nil {
+		return models.HouseholdInvitation{}, err
+	}
+	return models.HouseholdInvitation{
+		Code:      inv.Code,
+		Role:      inv.Role,
+		ExpiresAt: inv.ExpiresAt.Format(time.RFC3339),
+	}, nil
+}
+
+func (r *householdRepositorySQLC) ConsumeInvitation(ctx context.Context, code string) (int32, string, error) {
+	row, err := r.queries(ctx).ConsumeHouseholdInvitation(ctx, code)
+	if err != nil {
+		return 0, "", err
+	}
+	return row.HouseholdID, row.Role, nil
+}
+
+func toModelHousehold(id int32, name string, ownerID string, role string, current bool, createdAt time.Time) models.Household {
+	return models.Household{
+		ID:        int(id),
+		Name:      name,
+		OwnerID:   ownerID,
+		Role:      role,
+		Current:   current,
+		CreatedAt: createdAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	db "money-buddy-backend/db/generated"
+	"money-buddy-backend/infra/transaction"
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/repositories"
+)
+
+type idempotencyRepositorySQLC struct {
+	q *db.Queries
+}
+
+func NewIdempotencyRepositorySQLC(q *db.Queries) repositories.IdempotencyRepository {
+	return &idempotencyRepositorySQLC{q: q}
+}
+
+func (r *idempotencyRepositorySQLC) queries(ctx context.Context) *db.Queries {
+	if tx, ok := transaction.TxFromContext(ctx); ok {
+		return r.q.WithTx(tx)
+	}
+	return r.q
+}
+
+func (r *idempotencyRepositorySQLC) ReserveIdempotencyKey(ctx context.Context, userID string, key string, requestHash string) (bool, error) {
+	n, err := r.queries(ctx).ReserveIdempotencyKey(ctx, db.ReserveIdempotencyKeyParams{
+		UserID:         userID,
+		IdempotencyKey: key,
+		RequestHash:    requestHash,
+	})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (r *idempotencyRepositorySQLC) GetIdempotencyKey(ctx context.Context, userID string, key string) (models.IdempotencyRecord, error) {
+	row, err := r.queries(ctx).GetIdempotencyKey(ctx, db.GetIdempotencyKeyParams{
+		UserID:         userID,
+		IdempotencyKey: key,
+	})
+	if err != nil {
+		return models.IdempotencyRecord{}, err
+	}
+	return models.IdempotencyRecord{
+		RequestHash: row.RequestHash,
+		StatusCode:  int(row.StatusCode.Int32),
+		ContentType: row.ContentType.String,
+		ETag:        row.Etag.String,
+		Body:        row.ResponseBody,
+	}, nil
+}
+
+func (r *idempotencyRepositorySQLC) SaveIdempotencyResponse(ctx context.Context, userID string, key string, record models.IdempotencyRecord) error {
+	return r.queries(ctx).SaveIdempotencyResponse(ctx, db.SaveIdempotencyResponseParams{
+		UserID:         userID,
+		IdempotencyKey: key,
+		StatusCode:     sql.NullInt32{Int32: int32(record.StatusCode), Valid: true},
+		ContentType:    sql.NullString{String: record.ContentType, Valid: record.ContentType != ""},
+		Etag:           sql.NullString{String: record.ETag, Valid: record.ETag != ""},
+		ResponseBody:   record.Body,
+	})
+}
+
+func (r *idempotencyRepositorySQLC) DeleteIdempotencyKey(ctx context.Context, userID string, key string) error {
+	return r.queries(ctx).DeleteIdempotencyKey(ctx, db.DeleteIdempotencyKeyParams{
+		UserID:         userID,
+		IdempotencyKey: key,
+	})
+}
+
+func (r *idempotencyRepositorySQLC) DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	return r.queries(ctx).DeleteExpiredIdempotencyKeys(ctx)
+}
@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	db "money-buddy-backend/db/generated"
+	"money-buddy-backend/infra/transaction"
+	"money-buddy-backend/internal/repositories"
+)
+
+type recurringRepositorySQLC struct {
+	q *db.Queries
+}
+
+func NewRecurringRepositorySQLC(q *db.Queries) repositories.RecurringRepository {
+	return &recurringRepositorySQLC{q: q}
+}
+
+func (r *recurringRepositorySQLC) queries(ctx context.Context) *db.Queries {
+	if tx, ok := transaction.TxFromContext(ctx); ok {
+		return r.q.WithTx(tx)
+	}
+	return r.q
+}
+
+func (r *recurringRepositorySQLC) ListRules(ctx context.Context, userID string) ([]repositories.RecurringRule, error) {
+	rows, err := r.queries(ctx).ListRecurringRules(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]repositories.RecurringRule, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, dbRecurringRuleToRepo(db.GetRecurringRuleRow(row)))
+	}
+
+	return out, nil
+}
+
+func (r *recurringRepositorySQLC) ListRuleOwners(ctx context.Context) ([]string, error) {
+	return r.queries(ctx).ListRecurringRuleUserIDs(ctx)
+}
+
+func (r *recurringRepositorySQLC) GetRule(ctx context.Context, userID string, id int32) (repositories.RecurringRule, error) {
+	row, err := r.queries(ctx).GetRecurringRule(ctx, db.GetRecurringRuleParams{
+		UserID: userID,
+		ID:     id,
+	})
+	if err != nil {
+		return repositories.RecurringRule{}, err
+	}
+
+	return dbRecurringRuleToRepo(row), nil
+}
+
+func (r *recurringRepositorySQLC) CreateRule(ctx context.Context, userID string, rule repositories.RecurringRule) (int32, error) {
+	return r.queries(ctx).CreateRecurringRule(ctx, db.CreateRecurringRuleParams{
+		UserID:     userID,
+		Amount:     rule.Amount,
+		CategoryID: rule.CategoryID,
+		Memo:       sql.NullString{String: rule.Memo, Valid: rule.Memo != ""},
+		Frequency:  rule.Frequency,
+		DayOfMonth: nullInt32(rule.DayOfMonth),
+		StartDate:  rule.StartDate,
+		EndDate:    nullTime(rule.EndDate),
+	})
+}
+
+func (r *recurringRepositorySQLC) UpdateRule(ctx context.Context, userID string, rule repositories.RecurringRule) error {
+	return r.queries(ctx).UpdateRecurringRule(ctx, db.UpdateRecurringRuleParams{
+		UserID:     userID,
+		ID:         rule.ID,
+		Amount:     rule.Amount,
+		CategoryID: rule.CategoryID,
+		Memo:       sql.NullString{String: rule.Memo, Valid: rule.Memo != ""},
+		Frequency:  rule.Frequency,
+		DayOfMonth: nullInt32(rule.DayOfMonth),
+		StartDate:  rule.StartDate,
+		EndDate:    nullTime(rule.EndDate),
+	})
+}
+
+func (r *recurringRepositorySQLC) DeleteRule(ctx context.Context, userID string, id int32) error {
+	return r.queries(ctx).DeleteRecurringRule(ctx, db.DeleteRecurringRuleParams{
+		UserID: userID,
+		ID:     id,
+	})
+}
+
+func (r *recurringRepositorySQLC) SetGeneratedUntil(ctx context.Context, userID string, id int32, until time.Time) error {
+	return r.queries(ctx).SetRecurringRuleGeneratedUntil(ctx, db.SetRecurringRuleGeneratedUntilParams{
+		UserID:         userID,
+		ID:             id,
+		GeneratedUntil: sql.NullTime{Time: until, Valid: true},
+	})
+}
+
+func (r *recurringRepositorySQLC) ListOccurrencesFrom(ctx context.Context, ruleID int32, from time.Time) ([]repositories.RecurringOccurrence, error) {
+	rows, err := r.queries(ctx).ListRecurringOccurrencesFrom(ctx, db.ListRecurringOccurrencesFromParams{
+		RuleID:   ruleID,
+		OccursOn: from,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]repositories.RecurringOccurrence, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, dbRecurringOccurrenceToRepo(db.GetRecurringOccurrenceRow(row)))
+	}
+
+	return out, nil
+}
+
+func (r *recurringRepositorySQLC) GetOccurrence(ctx context.Context, ruleID int32, on time.Time) (repositories.RecurringOccurrence, error) {
+	row, err := r.queries(ctx).GetRecurringOccurrence(ctx, db.GetRecurringOccurrenceParams{
+		RuleID:   ruleID,
+		OccursOn: on,
+	})
+	if err != nil {
+		return repositories.RecurringOccurrence{}, err
+	}
+
+	return dbRecurringOccurrenceToRepo(row), nil
+}
+
+func (r *recurringRepositorySQLC) ClaimOccurrence(ctx context.Context, ruleID int32, on time.Time) (bool, error) {
+	n, err := r.queries(ctx).ClaimRecurringOccurrence(ctx, db.ClaimRecurringOccurrenceParams{
+		RuleID:   ruleID,
+		OccursOn: on,
+	})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (r *recurringRepositorySQLC) SetOccurrenceExpense(ctx context.Context, ruleID int32, on time.Time, expenseID *int32) error {
+	return r.queries(ctx).SetRecurringOccurrenceExpense(ctx, db.SetRecurringOccurrenceExpenseParams{
+		RuleID:    ruleID,
+		OccursOn:  on,
+		ExpenseID: nullInt32(expenseID),
+	})
+}
+
+func (r *recurringRepositorySQLC) DeleteOccurrence(ctx context.Context, ruleID int32, on time.Time) error {
+	return r.queries(ctx).DeleteRecurringOccurrence(ctx, db.DeleteRecurringOccurrenceParams{
+		RuleID:   ruleID,
+		OccursOn: on,
+	})
+}
+
+func dbRecurringRuleToRepo(row db.GetRecurringRuleRow) repositories.RecurringRule {
+	rule := repositories.RecurringRule{
+		ID:           row.ID,
+		Amount:       row.Amount,
+		CategoryID:   row.CategoryID,
+		CategoryName: row.CategoryName,
+		Frequency:    row.Frequency,
+		StartDate:    row.StartDate,
+	}
+	if row.Memo.Valid {
+		rule.Memo = row.Memo.String
+	}
+	if row.DayOfMonth.Valid {
+		v := row.DayOfMonth.Int32
+		rule.DayOfMonth = &v
+	}
+	if row.EndDate.Valid {
+		v := row.EndDate.Time
+		rule.EndDate = &v
+	}
+	if row.GeneratedUntil.Valid {
+		v := row.GeneratedUntil.Time
+		rule.GeneratedUntil = &v
+	}
+	return rule
+}
+
+func dbRecurringOccurrenceToRepo(row db.GetRecurringOccurrenceRow) repositories.RecurringOccurrence {
+	occ := repositories.RecurringOccurrence{OccursOn: row.OccursOn}
+	if row.ExpenseID.Valid {
+		v := row.ExpenseID.Int32
+		occ.ExpenseID = &v
+	}
+	if row.Status.Valid {
+		occ.Status = row.Status.String
+	}
+	return occ
+}
+
+func nullInt32(v *int32) sql.NullInt32 {
+	if v == nil {
+		return sql.NullInt32{}
+	}
+	return sql.NullInt32{Int32: *v, Valid: true}
+}
+
+func nullTime(v *time.Time) sql.NullTime {
+	if v == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *v, Valid: true}
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+
+	db "money-buddy-backend/db/generated"
+	"money-buddy-backend/infra/transaction"
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/repositories"
+)
+
+type tagRepositorySQLC struct {
+	q *db.Queries
+}
+
+func NewTagRepositorySQLC(q *db.Queries) repositories.TagRepository {
+	return &tagRepositorySQLC{q: q}
+}
+
+func (r *tagRepositorySQLC) queries(ctx context.Context) *db.Queries {
+	if tx, ok := transaction.TxFromContext(ctx); ok {
+		return r.q.WithTx(tx)
+	}
+	return r.q
+}
+
+func (r *tagRepositorySQLC) ListTags(ctx context.Context, userID string) ([]models.TagSummary, error) {
+	rows, err := r.queries(ctx).ListTags(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]models.TagSummary, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, models.TagSummary{
+			Tag:          models.Tag{ID: int(row.ID), Name: row.Name},
+			ExpenseCount: int(row.ExpenseCount),
+		})
+	}
+
+	return out, nil
+}
+
+func (r *tagRepositorySQLC) RenameTag(ctx context.Context, userID string, id int32, name string) (bool, error) {
+	n, err := r.queries(ctx).RenameTag(ctx, db.RenameTagParams{
+		Name:   name,
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (r *tagRepositorySQLC) DeleteTag(ctx context.Context, userID string, id int32) (bool, error) {
+	n, err := r.queries(ctx).DeleteTag(ctx, db.DeleteTagParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	db "money-buddy-backend/db/generated"
+	"money-buddy-backend/infra/transaction"
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/repositories"
+)
+
+type trashRepositorySQLC struct {
+	q *db.Queries
+}
+
+func NewTrashRepositorySQLC(q *db.Queries) repositories.TrashRepository {
+	return &trashRepositorySQLC{q: q}
+}
+
+func (r *trashRepositorySQLC) queries(ctx context.Context) *db.Queries {
+	if tx, ok := transaction.TxFromContext(ctx); ok {
+		return r.q.WithTx(tx)
+	}
+	return r.q
+}
+
+func (r *trashRepositorySQLC) ListTrashedExpenses(ctx context.Context, userID string, deletedAfter time.Time) ([]repositories.TrashedExpense, error) {
+	rows, err := r.queries(ctx).ListTrashedExpenses(ctx, db.ListTrashedExpensesParams{
+		UserID:       userID,
+		DeletedAfter: deletedAfter,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]repositories.TrashedExpense, 0, len(rows))
+	for _, row := range rows {
+		memo := ""
+		if row.Memo.Valid {
+			memo = row.Memo.String
+		}
+		out = append(out, repositories.TrashedExpense{
+			Expense: models.Expense{
+				ID:            int(row.ID),
+				Amount:        int(row.Amount),
+				Memo:          memo,
+				SpentAt:       row.SpentAt.Format(time.RFC3339),
+				Status:        row.Status,
+				CreatedBy:     row.CreatedBy,
+				Version:       int(row.Version),
+				Category:      models.Category{ID: int(row.CategoryID), Name: row.CategoryName},
+				PlannedAmount: intPtrFromNull(row.PlannedAmount),
+				Variance:      expenseVariance(row.Amount, row.PlannedAmount),
+			},
+			DeletedAt: row.DeletedAt.Time,
+		})
+	}
+
+	return out, nil
+}
+
+func (r *trashRepositorySQLC) ListTrashedFixedCosts(ctx context.Context, userID string, deletedAfter time.Time) ([]repositories.TrashedFixedCost, error) {
+	rows, err := r.queries(ctx).ListTrashedFixedCosts(ctx, db.ListTrashedFixedCostsParams{
+		UserID:       userID,
+		DeletedAfter: deletedAfter,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]repositories.TrashedFixedCost, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, repositories.TrashedFixedCost{
+			FixedCost: dbFixedCostToModel(row),
+			EndedFrom: row.EndedFrom.Time,
+			DeletedAt: row.DeletedAt.Time,
+		})
+	}
+
+	return out, nil
+}
+
+func (r *trashRepositorySQLC) PurgeTrashedExpenses(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	return r.queries(ctx).PurgeTrashedExpenses(ctx, deletedBefore)
+}
+
+func (r *trashRepositorySQLC) PurgeTrashedFixedCosts(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	return r.queries(ctx).PurgeTrashedFixedCosts(ctx, deletedBefore)
+}
@@ -25,11 +25,12 @@ func (r *userRepositorySQLC) queries(ctx context.Context) *db.Queries {
 	return r.q
 }
 
-func (r *userRepositorySQLC) CreateUser(ctx context.Context, id string, income int, savingGoal int) error {
+func (r *userRepositorySQLC) CreateUser(ctx context.Context, id string, income int, savingGoal int, effectiveFrom time.Time) error {
 	params := db.CreateUserParams{
-		ID:         id,
-		Income:     int32(income),
-		SavingGoal: int32(savingGoal),
+		ID:            id,
+		Income:        int32(income),
+		SavingGoal:    int32(savingGoal),
+		EffectiveFrom: effectiveFrom,
 	}
 	return r.queries(ctx).CreateUser(ctx, params)
 }
@@ -43,11 +44,12 @@ func (r *userRepositorySQLC) GetUserByID(ctx context.Context, id string) (models
 	return dbUserToModel(row), nil
 }
 
-func (r *userRepositorySQLC) UpdateUserSettings(ctx context.Context, id string, income int, savingGoal int) error {
+func (r *userRepositorySQLC) UpdateUserSettings(ctx context.Context, id string, income int, savingGoal int, effectiveFrom time.Time) error {
 	params := db.UpdateUserSettingsParams{
-		ID:         id,
-		Income:     int32(income),
-		SavingGoal: int32(savingGoal),
+		ID:            id,
+		Income:        int32(income),
+		SavingGoal:    int32(savingGoal),
+		EffectiveFrom: effectiveFrom,
 	}
 	return r.queries(ctx).UpdateUserSettings(ctx, params)
 }
@@ -62,11 +64,18 @@ func dbUserToModel(u db.User) models.User {
 		updatedAt = u.UpdatedAt.Time.Format(time.RFC3339)
 	}
 
+	var currentHouseholdID *int
+	if u.CurrentHouseholdID.Valid {
+		id := int(u.CurrentHouseholdID.Int32)
+		currentHouseholdID = &id
+	}
+
 	return models.User{
-		ID:         u.ID,
-		Income:     int(u.Income),
-		SavingGoal: int(u.SavingGoal),
-		CreatedAt:  createdAt,
-		UpdatedAt:  updatedAt,
+		ID:                 u.ID,
+		Income:             int(u.Income),
+		SavingGoal:         int(u.SavingGoal),
+		CurrentHouseholdID: currentHouseholdID,
+		CreatedAt:          createdAt,
+		UpdatedAt:          updatedAt,
 	}
 }
@@ -34,6 +34,17 @@ func (t *sqlTx) Context(ctx context.Context) context.Context {
 	return withTx(ctx, t.tx)
 }
 
+// Savepoint と RollbackToSavepoint の name はサービス層で生成した識別子のみを受け取ります。
+func (t *sqlTx) Savepoint(ctx context.Context, name string) error {
+	_, err := t.tx.ExecContext(ctx, "SAVEPOINT "+name)
+	return err
+}
+
+func (t *sqlTx) RollbackToSavepoint(ctx context.Context, name string) error {
+	_, err := t.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name)
+	return err
+}
+
 type sqlTxManager struct {
 	db *sql.DB
 }
@@ -0,0 +1,34 @@
+// Package audit は変更履歴（監査ログ）に記録する、リクエストごとの操作者とリクエストIDをコンテキストで受け渡します。
+// HTTP リクエストではミドルウェアが設定し、サービス層が変更履歴を記録するときに参照します。
+package audit
+
+import "context"
+
+type contextKey int
+
+const (
+	actorKey contextKey = iota
+	requestIDKey
+)
+
+// WithActor は変更を行ったユーザーのIDをコンテキストに設定します。
+func WithActor(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, actorKey, userID)
+}
+
+// ActorFromContext は変更を行ったユーザーのIDを返します。設定されていない場合は空文字を返します。
+func ActorFromContext(ctx context.Context) string {
+	actor, _ := ctx.Value(actorKey).(string)
+	return actor
+}
+
+// WithRequestID はリクエストIDをコンテキストに設定します。
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey, requestID)
+}
+
+// RequestIDFromContext はリクエストIDを返します。設定されていない場合は空文字を返します。
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	return requestID
+}
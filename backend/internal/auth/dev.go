@@ -0,0 +1,18 @@
+package auth
+
+import "context"
+
+// devVerifier はトークンを検証せず、常に固定のユーザーとして扱う開発用の TokenVerifier です。
+type devVerifier struct {
+	userID string
+}
+
+// NewDevVerifier は任意のトークンを userID のユーザーとして受け付ける TokenVerifier を作成します。
+// ローカル開発・E2E テスト専用で、本番環境では使用しないでください。
+func NewDevVerifier(userID string) TokenVerifier {
+	return &devVerifier{userID: userID}
+}
+
+func (v *devVerifier) VerifyIDToken(ctx context.Context, idToken string) (*Token, error) {
+	return &Token{UID: v.userID}, nil
+}
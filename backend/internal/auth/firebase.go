@@ -10,11 +10,13 @@ import (
 	"google.golang.org/api/option"
 )
 
-var FirebaseAuth *auth.Client
-
-func InitFirebase() error {
-	ctx := context.Background()
+// firebaseVerifier は Firebase Auth の ID トークンを検証する TokenVerifier です。
+type firebaseVerifier struct {
+	client *auth.Client
+}
 
+// NewFirebaseVerifier は Firebase Admin を初期化し、Firebase Auth の ID トークンを検証する TokenVerifier を作成します。
+func NewFirebaseVerifier(ctx context.Context) (TokenVerifier, error) {
 	// 環境変数から認証情報を取得
 	credentialsPath := os.Getenv("FIREBASE_CREDENTIALS_PATH")
 	credentialsJSON := os.Getenv("FIREBASE_CREDENTIALS_JSON")
@@ -36,14 +38,22 @@ func InitFirebase() error {
 
 	app, err := firebase.NewApp(ctx, nil, opt)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	FirebaseAuth, err = app.Auth(ctx)
+	client, err := app.Auth(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	log.Println("Firebase Admin initialized successfully")
-	return nil
+	return &firebaseVerifier{client: client}, nil
+}
+
+func (v *firebaseVerifier) VerifyIDToken(ctx context.Context, idToken string) (*Token, error) {
+	token, err := v.client.VerifyIDToken(ctx, idToken)
+	if err != nil {
+		return nil, err
+	}
+	return &Token{UID: token.UID}, nil
 }
@@ -0,0 +1,156 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// JWTConfig はローカルで JWT を検証するための設定です。
+// HMACSecret・PublicKeyPath・JWKSPath のいずれか1つを指定します。
+type JWTConfig struct {
+	HMACSecret    string // HS256 / HS384 / HS512 の共有鍵
+	PublicKeyPath string // RS256 / RS384 / RS512 の公開鍵（PEM）のファイルパス
+	JWKSPath      string // RSA 公開鍵を含む JWKS のファイルパス（kid で鍵を選択）
+	Issuer        string // 指定した場合は iss を検証
+	Audience      string // 指定した場合は aud を検証
+}
+
+var (
+	hmacMethods = []string{"HS256", "HS384", "HS512"}
+	rsaMethods  = []string{"RS256", "RS384", "RS512"}
+)
+
+// jwtVerifier は設定した鍵で JWT の署名と有効期限を検証する TokenVerifier です。
+// sub クレームをユーザーIDとして扱います。
+type jwtVerifier struct {
+	keyFunc  jwt.Keyfunc
+	methods  []string
+	issuer   string
+	audience string
+	now      func() time.Time
+}
+
+// NewJWTVerifier は cfg の鍵で JWT を検証する TokenVerifier を作成します。
+func NewJWTVerifier(cfg JWTConfig) (TokenVerifier, error) {
+	v := &jwtVerifier{issuer: cfg.Issuer, audience: cfg.Audience, now: time.Now}
+
+	configured := 0
+	for _, s := range []string{cfg.HMACSecret, cfg.PublicKeyPath, cfg.JWKSPath} {
+		if s != "" {
+			configured++
+		}
+	}
+	if configured != 1 {
+		return nil, errors.New("AUTH_JWT_HMAC_SECRET・AUTH_JWT_PUBLIC_KEY_PATH・AUTH_JWT_JWKS_PATH のいずれか1つを指定してください")
+	}
+
+	switch {
+	case cfg.HMACSecret != "":
+		secret := []byte(cfg.HMACSecret)
+		v.methods = hmacMethods
+		v.keyFunc = func(*jwt.Token) (interface{}, error) { return secret, nil }
+	case cfg.PublicKeyPath != "":
+		pem, err := os.ReadFile(cfg.PublicKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("公開鍵の読み込みに失敗しました: %w", err)
+		}
+		key, err := jwt.ParseRSAPublicKeyFromPEM(pem)
+		if err != nil {
+			return nil, fmt.Errorf("公開鍵の解析に失敗しました: %w", err)
+		}
+		v.methods = rsaMethods
+		v.keyFunc = func(*jwt.Token) (interface{}, error) { return key, nil }
+	default:
+		data, err := os.ReadFile(cfg.JWKSPath)
+		if err != nil {
+			return nil, fmt.Errorf("JWKS の読み込みに失敗しました: %w", err)
+		}
+		keys, err := parseJWKS(data)
+		if err != nil {
+			return nil, err
+		}
+		v.methods = rsaMethods
+		v.keyFunc = func(token *jwt.Token) (interface{}, error) {
+			kid, _ := token.Header["kid"].(string)
+			if key, ok := keys[kid]; ok {
+				return key, nil
+			}
+			// kid のない鍵が1つだけの場合は kid を省略したトークンも受け付ける
+			if len(keys) == 1 && kid == "" {
+				for _, key := range keys {
+					return key, nil
+				}
+			}
+			return nil, fmt.Errorf("unknown key id %q", kid)
+		}
+	}
+	return v, nil
+}
+
+func (v *jwtVerifier) VerifyIDToken(ctx context.Context, idToken string) (*Token, error) {
+	var claims jwt.RegisteredClaims
+	parser := jwt.NewParser(jwt.WithValidMethods(v.methods))
+	if _, err := parser.ParseWithClaims(idToken, &claims, v.keyFunc); err != nil {
+		return nil, err
+	}
+
+	now := v.now()
+	if !claims.VerifyExpiresAt(now, true) {
+		return nil, errors.New("token is expired or has no exp claim")
+	}
+	if v.issuer != "" && !claims.VerifyIssuer(v.issuer, true) {
+		return nil, errors.New("token has invalid issuer")
+	}
+	if v.audience != "" && !claims.VerifyAudience(v.audience, true) {
+		return nil, errors.New("token has invalid audience")
+	}
+	return &Token{UID: claims.Subject}, nil
+}
+
+// parseJWKS は JWKS から RSA 公開鍵を kid ごとに取り出します。RSA 以外の鍵は無視します。
+func parseJWKS(data []byte) (map[string]*rsa.PublicKey, error) {
+	var set struct {
+		Keys []struct {
+			Kty string `json:"kty"`
+			Kid string `json:"kid"`
+			Use string `json:"use"`
+			N   string `json:"n"`
+			E   string `json:"e"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("JWKS の解析に失敗しました: %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey)
+	for _, k := range set.Keys {
+		if k.Kty != "RSA" || (k.Use != "" && k.Use != "sig") {
+			continue
+		}
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, fmt.Errorf("JWKS の鍵 %q の n が不正です: %w", k.Kid, err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, fmt.Errorf("JWKS の鍵 %q の e が不正です: %w", k.Kid, err)
+		}
+		keys[k.Kid] = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("JWKS に RSA の署名鍵がありません")
+	}
+	return keys, nil
+}
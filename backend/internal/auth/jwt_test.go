@@ -0,0 +1,218 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func signToken(t *testing.T, method jwt.SigningMethod, key interface{}, kid string, claims jwt.RegisteredClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func validClaims() jwt.RegisteredClaims {
+	return jwt.RegisteredClaims{
+		Subject:   "user-123",
+		Issuer:    "money-buddy-test",
+		Audience:  jwt.ClaimStrings{"money-buddy"},
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+}
+
+func writeFile(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+// TestJWTVerifier_HMAC は共有鍵で署名されたトークンの検証のテストです
+func TestJWTVerifier_HMAC(t *testing.T) {
+	verifier, err := NewJWTVerifier(JWTConfig{HMACSecret: "secret", Issuer: "money-buddy-test", Audience: "money-buddy"})
+	require.NoError(t, err)
+
+	token, err := verifier.VerifyIDToken(context.Background(), signToken(t, jwt.SigningMethodHS256, []byte("secret"), "", validClaims()))
+	require.NoError(t, err)
+	assert.Equal(t, "user-123", token.UID)
+
+	cases := []struct {
+		name  string
+		token func() string
+	}{
+		{name: "鍵が異なる", token: func() string {
+			return signToken(t, jwt.SigningMethodHS256, []byte("other"), "", validClaims())
+		}},
+		{name: "有効期限切れ", token: func() string {
+			c := validClaims()
+			c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Minute))
+			return signToken(t, jwt.SigningMethodHS256, []byte("secret"), "", c)
+		}},
+		{name: "有効期限なし", token: func() string {
+			c := validClaims()
+			c.ExpiresAt = nil
+			return signToken(t, jwt.SigningMethodHS256, []byte("secret"), "", c)
+		}},
+		{name: "発行者が異なる", token: func() string {
+			c := validClaims()
+			c.Issuer = "someone-else"
+			return signToken(t, jwt.SigningMethodHS256, []byte("secret"), "", c)
+		}},
+		{name: "対象者が異なる", token: func() string {
+			c := validClaims()
+			c.Audience = jwt.ClaimStrings{"other-app"}
+			return signToken(t, jwt.SigningMethodHS256, []byte("secret"), "", c)
+		}},
+		{name: "署名なし", token: func() string {
+			return signToken(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, "", validClaims())
+		}},
+		{name: "形式が不正", token: func() string { return "not-a-jwt" }},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := verifier.VerifyIDToken(context.Background(), tc.token())
+			assert.Error(t, err)
+		})
+	}
+}
+
+// TestJWTVerifier_RSAPublicKey は PEM の公開鍵による検証のテストです
+func TestJWTVerifier_RSAPublicKey(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	path := writeFile(t, "public.pem", pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+
+	verifier, err := NewJWTVerifier(JWTConfig{PublicKeyPath: path})
+	require.NoError(t, err)
+
+	token, err := verifier.VerifyIDToken(context.Background(), signToken(t, jwt.SigningMethodRS256, key, "", validClaims()))
+	require.NoError(t, err)
+	assert.Equal(t, "user-123", token.UID)
+
+	// RSA の公開鍵を HMAC の共有鍵として使う署名は受け付けない
+	_, err = verifier.VerifyIDToken(context.Background(), signToken(t, jwt.SigningMethodHS256, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), "", validClaims()))
+	assert.Error(t, err)
+}
+
+// TestJWTVerifier_JWKS は JWKS ファイルの鍵を kid で選択する検証のテストです
+func TestJWTVerifier_JWKS(t *testing.T) {
+	key1, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	key2, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	jwk := func(kid string, pub *rsa.PublicKey) map[string]string {
+		return map[string]string{
+			"kty": "RSA",
+			"kid": kid,
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+		}
+	}
+	data, err := json.Marshal(map[string]any{"keys": []any{jwk("k1", &key1.PublicKey), jwk("k2", &key2.PublicKey)}})
+	require.NoError(t, err)
+	verifier, err := NewJWTVerifier(JWTConfig{JWKSPath: writeFile(t, "jwks.json", data)})
+	require.NoError(t, err)
+
+	token, err := verifier.VerifyIDToken(context.Background(), signToken(t, jwt.SigningMethodRS256, key2, "k2", validClaims()))
+	require.NoError(t, err)
+	assert.Equal(t, "user-123", token.UID)
+
+	// kid と署名鍵が一致しない
+	_, err = verifier.VerifyIDToken(context.Background(), signToken(t, jwt.SigningMethodRS256, key1, "k2", validClaims()))
+	assert.Error(t, err)
+	// 未知の kid
+	_, err = verifier.VerifyIDToken(context.Background(), signToken(t, jwt.SigningMethodRS256, key1, "k3", validClaims()))
+	assert.Error(t, err)
+}
+
+// TestNewJWTVerifier_InvalidConfig は鍵の設定が不正な場合のテストです
+func TestNewJWTVerifier_InvalidConfig(t *testing.T) {
+	cases := []struct {
+		name string
+		cfg  JWTConfig
+	}{
+		{name: "鍵が未設定", cfg: JWTConfig{}},
+		{name: "鍵が複数", cfg: JWTConfig{HMACSecret: "secret", JWKSPath: "jwks.json"}},
+		{name: "公開鍵ファイルがない", cfg: JWTConfig{PublicKeyPath: filepath.Join(t.TempDir(), "missing.pem")}},
+		{name: "JWKS に RSA の鍵がない", cfg: JWTConfig{JWKSPath: writeFile(t, "jwks.json", []byte(`{"keys":[{"kty":"EC","kid":"k1"}]}`))}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewJWTVerifier(tc.cfg)
+			assert.Error(t, err)
+		})
+	}
+}
+
+// TestNewTokenVerifierFromEnv は AUTH_MODE による認証方式の選択のテストです
+func TestNewTokenVerifierFromEnv(t *testing.T) {
+	t.Run("dev モードは固定のユーザーとして扱う", func(t *testing.T) {
+		t.Setenv("AUTH_MODE", ModeDev)
+		t.Setenv("AUTH_DEV_USER_ID", "e2e-user")
+
+		verifier, err := NewTokenVerifierFromEnv(context.Background(), "development")
+		require.NoError(t, err)
+		token, err := verifier.VerifyIDToken(context.Background(), "anything")
+		require.NoError(t, err)
+		assert.Equal(t, "e2e-user", token.UID)
+	})
+
+	t.Run("dev モードのユーザーIDの既定値", func(t *testing.T) {
+		t.Setenv("AUTH_MODE", ModeDev)
+		t.Setenv("AUTH_DEV_USER_ID", "")
+
+		verifier, err := NewTokenVerifierFromEnv(context.Background(), "development")
+		require.NoError(t, err)
+		token, err := verifier.VerifyIDToken(context.Background(), "anything")
+		require.NoError(t, err)
+		assert.Equal(t, DefaultDevUserID, token.UID)
+	})
+
+	t.Run("本番環境では dev モードを使用できない", func(t *testing.T) {
+		t.Setenv("AUTH_MODE", ModeDev)
+
+		_, err := NewTokenVerifierFromEnv(context.Background(), "production")
+		assert.Error(t, err)
+	})
+
+	t.Run("jwt モード", func(t *testing.T) {
+		t.Setenv("AUTH_MODE", ModeJWT)
+		t.Setenv("AUTH_JWT_HMAC_SECRET", "secret")
+
+		verifier, err := NewTokenVerifierFromEnv(context.Background(), "development")
+		require.NoError(t, err)
+		token, err := verifier.VerifyIDToken(context.Background(), signToken(t, jwt.SigningMethodHS256, []byte("secret"), "", validClaims()))
+		require.NoError(t, err)
+		assert.Equal(t, "user-123", token.UID)
+	})
+
+	t.Run("不明な認証方式", func(t *testing.T) {
+		t.Setenv("AUTH_MODE", "basic")
+
+		_, err := NewTokenVerifierFromEnv(context.Background(), "development")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"money-buddy-backend/internal/models"
+)
+
+// Token は検証済みの ID トークンから取り出したユーザー情報です。
+type Token struct {
+	UID string
+	// Scopes はパーソナルアクセストークンの権限です。ID トークンの場合は nil で、権限の制限はありません。
+	Scopes []string
+}
+
+// TokenVerifier は Authorization ヘッダーの ID トークンを検証します。
+type TokenVerifier interface {
+	// VerifyIDToken はトークンを検証し、トークンが示すユーザーを返します。
+	VerifyIDToken(ctx context.Context, idToken string) (*Token, error)
+}
+
+// APITokenAuthenticator はパーソナルアクセストークンを検証します。
+type APITokenAuthenticator interface {
+	// AuthenticateAPIToken はトークンを検証し、所有ユーザーと権限を返します。
+	AuthenticateAPIToken(ctx context.Context, token string) (userID string, scopes []string, err error)
+}
+
+// WithAPITokens は models.APITokenPrefix で始まるトークンを tokens で、それ以外のトークンを next で検証する TokenVerifier を返します。
+func WithAPITokens(next TokenVerifier, tokens APITokenAuthenticator) TokenVerifier {
+	return &apiTokenVerifier{next: next, tokens: tokens}
+}
+
+type apiTokenVerifier struct {
+	next   TokenVerifier
+	tokens APITokenAuthenticator
+}
+
+func (v *apiTokenVerifier) VerifyIDToken(ctx context.Context, idToken string) (*Token, error) {
+	if !strings.HasPrefix(idToken, models.APITokenPrefix) {
+		return v.next.VerifyIDToken(ctx, idToken)
+	}
+	userID, scopes, err := v.tokens.AuthenticateAPIToken(ctx, idToken)
+	if err != nil {
+		return nil, err
+	}
+	// 権限が空でも制限なし（nil）として扱わない
+	if scopes == nil {
+		scopes = []string{}
+	}
+	return &Token{UID: userID, Scopes: scopes}, nil
+}
+
+// 認証方式（AUTH_MODE）
+const (
+	ModeFirebase = "firebase" // Firebase Auth の ID トークンを検証する（デフォルト）
+	ModeJWT      = "jwt"      // 設定した鍵（HMAC / RSA / JWKS ファイル）で JWT を検証する
+	ModeDev      = "dev"      // 検証せず固定のテストユーザーとして扱う（開発環境専用）
+)
+
+// DefaultDevUserID は dev モードで AUTH_DEV_USER_ID が未設定の場合のユーザーIDです。
+const DefaultDevUserID = "dev-user"
+
+// NewTokenVerifierFromEnv は環境変数 AUTH_MODE に従って TokenVerifier を作成します。
+// dev モードは env が production の場合は使用できません。
+func NewTokenVerifierFromEnv(ctx context.Context, env string) (TokenVerifier, error) {
+	mode := os.Getenv("AUTH_MODE")
+	if mode == "" {
+		mode = ModeFirebase
+	}
+
+	switch mode {
+	case ModeFirebase:
+		return NewFirebaseVerifier(ctx)
+	case ModeJWT:
+		return NewJWTVerifier(JWTConfig{
+			HMACSecret:    os.Getenv("AUTH_JWT_HMAC_SECRET"),
+			PublicKeyPath: os.Getenv("AUTH_JWT_PUBLIC_KEY_PATH"),
+			JWKSPath:      os.Getenv("AUTH_JWT_JWKS_PATH"),
+			Issuer:        os.Getenv("AUTH_JWT_ISSUER"),
+			Audience:      os.Getenv("AUTH_JWT_AUDIENCE"),
+		})
+	case ModeDev:
+		if env == "production" {
+			return nil, fmt.Errorf("AUTH_MODE=dev は本番環境では使用できません")
+		}
+		userID := os.Getenv("AUTH_DEV_USER_ID")
+		if userID == "" {
+			userID = DefaultDevUserID
+		}
+		log.Printf("WARNING: AUTH_MODE=dev: all requests are authenticated as %q", userID)
+		return NewDevVerifier(userID), nil
+	default:
+		return nil, fmt.Errorf("AUTH_MODE は firebase・jwt・dev のいずれかを指定してください: %q", mode)
+	}
+}
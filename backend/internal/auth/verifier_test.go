@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubAPITokens struct {
+	userID string
+	scopes []string
+	err    error
+}
+
+func (s *stubAPITokens) AuthenticateAPIToken(ctx context.Context, token string) (string, []string, error) {
+	return s.userID, s.scopes, s.err
+}
+
+// TestWithAPITokens は接頭辞でパーソナルアクセストークンと ID トークンを振り分けることのテストです
+func TestWithAPITokens(t *testing.T) {
+	verifier := WithAPITokens(NewDevVerifier("firebase-user"), &stubAPITokens{userID: "token-user", scopes: []string{"read"}})
+
+	token, err := verifier.VerifyIDToken(context.Background(), "mb_pat_abc")
+	require.NoError(t, err)
+	assert.Equal(t, "token-user", token.UID)
+	assert.Equal(t, []string{"read"}, token.Scopes)
+
+	token, err = verifier.VerifyIDToken(context.Background(), "id-token")
+	require.NoError(t, err)
+	assert.Equal(t, "firebase-user", token.UID)
+	assert.Nil(t, token.Scopes)
+
+	// 無効なパーソナルアクセストークンは ID トークンとして検証し直さない
+	verifier = WithAPITokens(NewDevVerifier("firebase-user"), &stubAPITokens{err: errors.New("invalid api token")})
+	_, err = verifier.VerifyIDToken(context.Background(), "mb_pat_abc")
+	assert.Error(t, err)
+}
@@ -0,0 +1,53 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+)
+
+// utf8BOM は Excel で UTF-8 の CSV を文字化けせずに開くために先頭へ付与します。
+const utf8BOM = "\ufeff"
+
+// formulaPrefixes で始まる文字列は、表計算ソフトで開いたときに数式として実行されます。
+const formulaPrefixes = "=+-@\t\r"
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer, columns []string) (*csvWriter, error) {
+	if _, err := io.WriteString(w, utf8BOM); err != nil {
+		return nil, err
+	}
+	cw := &csvWriter{w: csv.NewWriter(w)}
+	if err := cw.w.Write(columns); err != nil {
+		return nil, err
+	}
+	return cw, nil
+}
+
+func (cw *csvWriter) WriteRow(values []any) error {
+	record := make([]string, len(values))
+	for i, v := range values {
+		record[i] = formatValue(v)
+		// 世帯の他のメンバーが登録したメモなどが数式として実行されないよう、文字列の値は先頭に ' を付ける
+		if s, ok := v.(string); ok {
+			record[i] = escapeFormula(s)
+		}
+	}
+	return cw.w.Write(record)
+}
+
+// escapeFormula は s が数式として解釈される文字で始まる場合に、先頭へ ' を付けて文字列として扱わせます。
+func escapeFormula(s string) string {
+	if s != "" && strings.ContainsRune(formulaPrefixes, rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+func (cw *csvWriter) Close() error {
+	cw.w.Flush()
+	return cw.w.Error()
+}
@@ -0,0 +1,78 @@
+// Package export は一覧データを CSV・JSON・XLSX 形式で逐次書き出す機能を提供します。
+// いずれの形式も 1 行ずつ出力先に書き込み、全体をメモリ上に保持しません。
+package export
+
+import (
+	"fmt"
+	"io"
+)
+
+// Format は書き出し形式です。
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+	FormatXLSX Format = "xlsx"
+)
+
+// ParseFormat は文字列を書き出し形式に変換します。空文字の場合は CSV とします。
+func ParseFormat(s string) (Format, bool) {
+	switch Format(s) {
+	case "", FormatCSV:
+		return FormatCSV, true
+	case FormatJSON:
+		return FormatJSON, true
+	case FormatXLSX:
+		return FormatXLSX, true
+	default:
+		return "", false
+	}
+}
+
+// ContentType は HTTP レスポンスの Content-Type を返します。
+func (f Format) ContentType() string {
+	switch f {
+	case FormatJSON:
+		return "application/json; charset=utf-8"
+	case FormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+// Writer は表形式のデータを 1 行ずつ書き出します。
+// 値には string・int・int64 と、空欄を表す nil を指定できます。
+type Writer interface {
+	WriteRow(values []any) error
+	// Close は末尾の出力を書き込みます。出力先自体は閉じません。
+	Close() error
+}
+
+// NewWriter は format 形式で w に書き出す Writer を作成します。
+// columns は CSV・XLSX のヘッダー行、JSON の各オブジェクトのキーになります。
+func NewWriter(format Format, w io.Writer, columns []string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w, columns)
+	case FormatJSON:
+		return newJSONWriter(w, columns)
+	case FormatXLSX:
+		return newXLSXWriter(w, columns)
+	default:
+		return nil, fmt.Errorf("unsupported export format: %q", format)
+	}
+}
+
+// formatValue は値を文字列に変換します。nil は空文字になります。
+func formatValue(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	default:
+		return fmt.Sprint(x)
+	}
+}
@@ -0,0 +1,109 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testColumns = []string{"id", "memo", "amount"}
+
+func writeAll(t *testing.T, format Format, rows [][]any) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewWriter(format, &buf, testColumns)
+	require.NoError(t, err)
+	for _, row := range rows {
+		require.NoError(t, w.WriteRow(row))
+	}
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestParseFormat(t *testing.T) {
+	for in, want := range map[string]Format{"": FormatCSV, "csv": FormatCSV, "json": FormatJSON, "xlsx": FormatXLSX} {
+		got, ok := ParseFormat(in)
+		assert.True(t, ok, in)
+		assert.Equal(t, want, got, in)
+	}
+	_, ok := ParseFormat("pdf")
+	assert.False(t, ok)
+}
+
+func TestCSVWriter(t *testing.T) {
+	out := writeAll(t, FormatCSV, [][]any{{1, "スーパー, 駅前", int64(1200)}, {2, nil, 300}})
+
+	assert.Equal(t, "\ufeffid,memo,amount\n1,\"スーパー, 駅前\",1200\n2,,300\n", string(out))
+}
+
+func TestCSVWriter_EscapesFormulas(t *testing.T) {
+	out := writeAll(t, FormatCSV, [][]any{
+		{1, `=HYPERLINK("http://example.com")`, -300},
+		{2, "+cmd|' /C calc'!A0", 0},
+		{3, "-1+1", 0},
+		{4, "@SUM(A1)", 0},
+		{5, "\tタブ", 0},
+		{6, "スーパー=駅前", 0},
+	})
+
+	lines := strings.Split(strings.TrimSuffix(string(out), "\n"), "\n")
+	require.Len(t, lines, 7)
+	assert.Equal(t, `1,"'=HYPERLINK(""http://example.com"")",-300`, lines[1]) // 数値の負数はそのまま
+	assert.Equal(t, `2,'+cmd|' /C calc'!A0,0`, lines[2])
+	assert.Equal(t, `3,'-1+1,0`, lines[3])
+	assert.Equal(t, `4,'@SUM(A1),0`, lines[4])
+	assert.Equal(t, "5,'\tタブ,0", lines[5])
+	assert.Equal(t, "6,スーパー=駅前,0", lines[6])
+}
+
+func TestJSONWriter(t *testing.T) {
+	out := writeAll(t, FormatJSON, [][]any{{1, "スーパー", int64(1200)}, {2, nil, 300}})
+
+	var got []map[string]any
+	require.NoError(t, json.Unmarshal(out, &got))
+	require.Len(t, got, 2)
+	assert.Equal(t, map[string]any{"id": float64(1), "memo": "スーパー", "amount": float64(1200)}, got[0])
+	assert.Nil(t, got[1]["memo"])
+	// キーは列の順に並ぶ
+	assert.True(t, strings.HasPrefix(string(out), `[{"id":1,"memo":"スーパー","amount":1200}`))
+}
+
+func TestJSONWriter_Empty(t *testing.T) {
+	out := writeAll(t, FormatJSON, nil)
+
+	var got []map[string]any
+	require.NoError(t, json.Unmarshal(out, &got))
+	assert.Empty(t, got)
+}
+
+func TestXLSXWriter(t *testing.T) {
+	out := writeAll(t, FormatXLSX, [][]any{{1, "<特売> & 割引", int64(1200)}, {2, nil, 300}})
+
+	zr, err := zip.NewReader(bytes.NewReader(out), int64(len(out)))
+	require.NoError(t, err)
+
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		b, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		files[f.Name] = string(b)
+	}
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels"} {
+		assert.Contains(t, files, name)
+	}
+
+	sheet := files["xl/worksheets/sheet1.xml"]
+	assert.Contains(t, sheet, `<row><c t="inlineStr"><is><t xml:space="preserve">id</t></is></c>`)
+	assert.Contains(t, sheet, `<row><c><v>1</v></c><c t="inlineStr"><is><t xml:space="preserve">&lt;特売&gt; &amp; 割引</t></is></c><c><v>1200</v></c></row>`)
+	assert.Contains(t, sheet, `<row><c><v>2</v></c><c/><c><v>300</v></c></row>`)
+	assert.True(t, strings.HasSuffix(sheet, "</sheetData></worksheet>"))
+}
@@ -0,0 +1,64 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+)
+
+// jsonWriter は行をオブジェクトとする JSON 配列を書き出します。キーの順序は columns の順です。
+type jsonWriter struct {
+	w       *bufio.Writer
+	keys    [][]byte
+	written bool
+}
+
+func newJSONWriter(w io.Writer, columns []string) (*jsonWriter, error) {
+	keys := make([][]byte, len(columns))
+	for i, c := range columns {
+		k, err := json.Marshal(c)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = k
+	}
+	jw := &jsonWriter{w: bufio.NewWriter(w), keys: keys}
+	if _, err := jw.w.WriteString("["); err != nil {
+		return nil, err
+	}
+	return jw, nil
+}
+
+func (jw *jsonWriter) WriteRow(values []any) error {
+	if jw.written {
+		jw.w.WriteByte(',')
+	}
+	jw.written = true
+
+	jw.w.WriteByte('{')
+	for i, key := range jw.keys {
+		if i > 0 {
+			jw.w.WriteByte(',')
+		}
+		var v any
+		if i < len(values) {
+			v = values[i]
+		}
+		b, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		jw.w.Write(key)
+		jw.w.WriteByte(':')
+		jw.w.Write(b)
+	}
+	_, err := jw.w.WriteString("}\n")
+	return err
+}
+
+func (jw *jsonWriter) Close() error {
+	if _, err := jw.w.WriteString("]\n"); err != nil {
+		return err
+	}
+	return jw.w.Flush()
+}
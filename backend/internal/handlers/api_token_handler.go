@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"money-buddy-backend/internal/middleware"
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/services"
+)
+
+type APITokenHandler struct {
+	service services.APITokenService
+}
+
+// NewAPITokenHandler はパーソナルアクセストークンの管理エンドポイントを登録します。
+// トークンの管理はログイン中のユーザー本人に限り、パーソナルアクセストークンでは実行できません。
+func NewAPITokenHandler(r gin.IRouter, service services.APITokenService) {
+	h := &APITokenHandler{service: service}
+	session := middleware.RequireSession()
+	r.GET("/user/me/tokens", session, h.ListTokens)
+	r.POST("/user/me/tokens", session, h.CreateToken)
+	r.DELETE("/user/me/tokens/:id", session, h.RevokeToken)
+}
+
+// ListTokens はパーソナルアクセストークンの一覧を取得します（トークン本体は含みません）
+func (h *APITokenHandler) ListTokens(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	tokens, err := h.service.ListTokens(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "トークンの取得に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tokens": tokens})
+}
+
+// CreateToken はパーソナルアクセストークンを作成します。
+// レスポンスの token は作成時の一度だけ返し、以降は取得できません。
+func (h *APITokenHandler) CreateToken(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	var req models.APITokenInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "リクエストの形式が正しくありません"})
+		return
+	}
+
+	token, err := h.service.CreateToken(c.Request.Context(), userID, req)
+	if err != nil {
+		var ve *services.ValidationError
+		if errors.As(err, &ve) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ve.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "トークンの作成に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, token)
+}
+
+// RevokeToken はパーソナルアクセストークンを失効（削除）させます
+func (h *APITokenHandler) RevokeToken(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "トークンIDが正しくありません"})
+		return
+	}
+
+	if err := h.service.RevokeToken(c.Request.Context(), userID, id); err != nil {
+		var ne *services.NotFoundError
+		if errors.As(err, &ne) {
+			c.JSON(http.StatusNotFound, gin.H{"error": ne.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "トークンの削除に失敗しました"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"money-buddy-backend/internal/middleware"
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/services"
+)
+
+// apiTokenServiceMock は APITokenService のモック実装です
+type apiTokenServiceMock struct {
+	ListTokensFunc  func(ctx context.Context, userID string) ([]models.APIToken, error)
+	CreateTokenFunc func(ctx context.Context, userID string, input models.APITokenInput) (models.CreatedAPIToken, error)
+	RevokeTokenFunc func(ctx context.Context, userID string, id int) error
+}
+
+func (m *apiTokenServiceMock) ListTokens(ctx context.Context, userID string) ([]models.APIToken, error) {
+	if m.ListTokensFunc != nil {
+		return m.ListTokensFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *apiTokenServiceMock) CreateToken(ctx context.Context, userID string, input models.APITokenInput) (models.CreatedAPIToken, error) {
+	if m.CreateTokenFunc != nil {
+		return m.CreateTokenFunc(ctx, userID, input)
+	}
+	return models.CreatedAPIToken{}, nil
+}
+
+func (m *apiTokenServiceMock) RevokeToken(ctx context.Context, userID string, id int) error {
+	if m.RevokeTokenFunc != nil {
+		return m.RevokeTokenFunc(ctx, userID, id)
+	}
+	return nil
+}
+
+func (m *apiTokenServiceMock) AuthenticateAPIToken(ctx context.Context, token string) (string, []string, error) {
+	return "", nil, services.ErrInvalidAPIToken
+}
+
+// newAPITokenRouter はパーソナルアクセストークンで認証済みの状態を再現するルーターを返します
+func newAPITokenRouter(scopes ...string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(string(middleware.UserIDKey), DummyUserID)
+		c.Set(string(middleware.ScopesKey), scopes)
+		c.Next()
+	})
+	return router
+}
+
+// TestCreateTokenHandler はトークン作成の正常系のテストです
+func TestCreateTokenHandler(t *testing.T) {
+	router := newAuthedRouter()
+	var got models.APITokenInput
+	svc := &apiTokenServiceMock{
+		CreateTokenFunc: func(ctx context.Context, userID string, input models.APITokenInput) (models.CreatedAPIToken, error) {
+			assert.Equal(t, DummyUserID, userID)
+			got = input
+			return models.CreatedAPIToken{
+				APIToken: models.APIToken{ID: 1, Name: input.Name, Scopes: input.Scopes, ExpiresAt: "2025-09-01T00:00:00Z", CreatedAt: "2025-06-03T00:00:00Z"},
+				Token:    "mb_pat_secret",
+			}, nil
+		},
+	}
+	NewAPITokenHandler(router, svc)
+
+	body := `{"name":"iOS ショートカット","scopes":["read","write:expenses"],"expires_in_days":90}`
+	req := httptest.NewRequest(http.MethodPost, "/user/me/tokens", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.NotNil(t, got.ExpiresInDays)
+	assert.Equal(t, 90, *got.ExpiresInDays)
+	var resp map[string]any
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "mb_pat_secret", resp["token"])
+	assert.Equal(t, "iOS ショートカット", resp["name"])
+	assert.Nil(t, resp["last_used_at"])
+}
+
+// TestCreateTokenHandler_ValidationError は入力エラー時のテストです
+func TestCreateTokenHandler_ValidationError(t *testing.T) {
+	router := newAuthedRouter()
+	svc := &apiTokenServiceMock{
+		CreateTokenFunc: func(ctx context.Context, userID string, input models.APITokenInput) (models.CreatedAPIToken, error) {
+			return models.CreatedAPIToken{}, &services.ValidationError{Message: "権限を1つ以上指定してください"}
+		},
+	}
+	NewAPITokenHandler(router, svc)
+
+	req := httptest.NewRequest(http.MethodPost, "/user/me/tokens", strings.NewReader(`{"name":"cron"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "権限を1つ以上指定してください")
+}
+
+// TestRevokeTokenHandler はトークン削除のテストです
+func TestRevokeTokenHandler(t *testing.T) {
+	cases := []struct {
+		name       string
+		path       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "削除成功", path: "/user/me/tokens/1", wantStatus: http.StatusNoContent},
+		{name: "IDが不正", path: "/user/me/tokens/abc", wantStatus: http.StatusBadRequest},
+		{name: "存在しない", path: "/user/me/tokens/2", svcErr: &services.NotFoundError{Message: "トークンが見つかりません"}, wantStatus: http.StatusNotFound},
+		{name: "内部エラー", path: "/user/me/tokens/3", svcErr: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := newAuthedRouter()
+			NewAPITokenHandler(router, &apiTokenServiceMock{
+				RevokeTokenFunc: func(ctx context.Context, userID string, id int) error { return tc.svcErr },
+			})
+
+			req := httptest.NewRequest(http.MethodDelete, tc.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.wantStatus, w.Code)
+		})
+	}
+}
+
+// TestAPITokenHandler_RejectsAPIToken はパーソナルアクセストークンでトークンを管理できないことのテストです
+func TestAPITokenHandler_RejectsAPIToken(t *testing.T) {
+	router := newAPITokenRouter(models.APITokenScopeRead, models.APITokenScopeWriteExpenses)
+	NewAPITokenHandler(router, &apiTokenServiceMock{})
+
+	req := httptest.NewRequest(http.MethodGet, "/user/me/tokens", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+// TestAPITokenScopes はパーソナルアクセストークンの権限による支出・固定費・ダッシュボードのアクセス制御のテストです
+func TestAPITokenScopes(t *testing.T) {
+	cases := []struct {
+		name       string
+		scopes     []string
+		method     string
+		path       string
+		body       string
+		wantStatus int
+	}{
+		{name: "read で支出一覧", scopes: []string{"read"}, method: http.MethodGet, path: "/expenses", wantStatus: http.StatusOK},
+		{name: "read のみで支出登録", scopes: []string{"read"}, method: http.MethodPost, path: "/expenses", body: `{"amount":100,"category_id":1,"spent_at":"2025-06-01"}`, wantStatus: http.StatusForbidden},
+		{name: "write:expenses で支出削除", scopes: []string{"write:expenses"}, method: http.MethodDelete, path: "/expenses/1", wantStatus: http.StatusNoContent},
+		{name: "write:expenses のみで支出一覧", scopes: []string{"write:expenses"}, method: http.MethodGet, path: "/expenses", wantStatus: http.StatusForbidden},
+		{name: "read で固定費一覧", scopes: []string{"read"}, method: http.MethodGet, path: "/fixed-costs", wantStatus: http.StatusOK},
+		{name: "固定費の削除は不可", scopes: []string{"read", "write:expenses"}, method: http.MethodDelete, path: "/fixed-costs/1", wantStatus: http.StatusForbidden},
+		{name: "read でダッシュボード", scopes: []string{"read"}, method: http.MethodGet, path: "/dashboard", wantStatus: http.StatusOK},
+		{name: "write:expenses のみでダッシュボード", scopes: []string{"write:expenses"}, method: http.MethodGet, path: "/dashboard", wantStatus: http.StatusForbidden},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := newAPITokenRouter(tc.scopes...)
+			NewExpenseHandler(router, &expenseServiceMock{})
+			NewFixedCostHandler(router, &fixedCostServiceMock{})
+			NewDashboardHandler(router, &dashboardServiceMock{
+				GetDashboardFunc: func(ctx context.Context, userID string, month string, fixedCostMode string) (*services.Dashboard, error) {
+					return &services.Dashboard{}, nil
+				},
+			})
+
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.wantStatus, w.Code)
+		})
+	}
+}
+
+// TestAPITokenScopes_Settings はパーソナルアクセストークンで家計簿の設定を参照のみできることのテストです
+func TestAPITokenScopes_Settings(t *testing.T) {
+	cases := []struct {
+		name       string
+		scopes     []string
+		method     string
+		path       string
+		wantStatus int
+	}{
+		{name: "read でカテゴリ一覧", scopes: []string{"read"}, method: http.MethodGet, path: "/categories", wantStatus: http.StatusOK},
+		{name: "read でレポート", scopes: []string{"read"}, method: http.MethodGet, path: "/reports/trends", wantStatus: http.StatusOK},
+		{name: "write:expenses のみでカテゴリ一覧", scopes: []string{"write:expenses"}, method: http.MethodGet, path: "/categories", wantStatus: http.StatusForbidden},
+		{name: "write:expenses のみでレポート", scopes: []string{"write:expenses"}, method: http.MethodGet, path: "/reports/trends", wantStatus: http.StatusForbidden},
+		{name: "write:expenses のみでユーザー情報", scopes: []string{"write:expenses"}, method: http.MethodGet, path: "/user/me", wantStatus: http.StatusForbidden},
+		{name: "ユーザー設定の変更は不可", scopes: []string{"read"}, method: http.MethodPut, path: "/user/me", wantStatus: http.StatusForbidden},
+		{name: "初期設定は不可", scopes: []string{"read"}, method: http.MethodPost, path: "/setup", wantStatus: http.StatusForbidden},
+		{name: "カテゴリの作成は不可", scopes: []string{"read"}, method: http.MethodPost, path: "/categories", wantStatus: http.StatusForbidden},
+		{name: "カテゴリの更新は不可", scopes: []string{"read"}, method: http.MethodPut, path: "/categories/1", wantStatus: http.StatusForbidden},
+		{name: "カテゴリの削除は不可", scopes: []string{"read", "write:expenses"}, method: http.MethodDelete, path: "/categories/1", wantStatus: http.StatusForbidden},
+		{name: "カテゴリの非表示は不可", scopes: []string{"read"}, method: http.MethodPost, path: "/categories/1/hide", wantStatus: http.StatusForbidden},
+		{name: "予算の設定は不可", scopes: []string{"read"}, method: http.MethodPut, path: "/budgets/1", wantStatus: http.StatusForbidden},
+		{name: "予算の削除は不可", scopes: []string{"read"}, method: http.MethodDelete, path: "/budgets/1", wantStatus: http.StatusForbidden},
+		{name: "繰り返しルールの作成は不可", scopes: []string{"read"}, method: http.MethodPost, path: "/recurring-expenses", wantStatus: http.StatusForbidden},
+		{name: "繰り返しルールの更新は不可", scopes: []string{"read"}, method: http.MethodPut, path: "/recurring-expenses/1", wantStatus: http.StatusForbidden},
+		{name: "繰り返しルールの削除は不可", scopes: []string{"read", "write:expenses"}, method: http.MethodDelete, path: "/recurring-expenses/1", wantStatus: http.StatusForbidden},
+		{name: "予定支出の生成は不可", scopes: []string{"read"}, method: http.MethodPost, path: "/recurring-expenses/materialize", wantStatus: http.StatusForbidden},
+		{name: "タグ名の変更は不可", scopes: []string{"read"}, method: http.MethodPut, path: "/tags/1", wantStatus: http.StatusForbidden},
+		{name: "タグの削除は不可", scopes: []string{"read"}, method: http.MethodDelete, path: "/tags/1", wantStatus: http.StatusForbidden},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := newAPITokenRouter(tc.scopes...)
+			NewUserHandler(router, &userServiceMock{})
+			NewInitialSetupHandler(router, &initialSetupServiceMock{})
+			NewCategoryHandler(router, &categoryServiceMock{})
+			NewBudgetHandler(router, nil)
+			NewRecurringExpenseHandler(router, &recurringExpenseServiceMock{})
+			NewReportHandler(router, &reportServiceMock{
+				GetTrendsFunc: func(ctx context.Context, userID string, from, to string, fixedCostMode string) (*services.Trends, error) {
+					return &services.Trends{}, nil
+				},
+			})
+			NewTagHandler(router, &tagServiceMock{})
+
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(`{}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.wantStatus, w.Code)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"money-buddy-backend/internal/middleware"
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/services"
+)
+
+type AuditHandler struct {
+	service services.AuditService
+}
+
+func NewAuditHandler(r gin.IRouter, service services.AuditService) {
+	h := &AuditHandler{service: service}
+	read := middleware.RequireScope(models.APITokenScopeRead)
+	r.GET("/audit", read, h.ListAuditEvents)
+	r.GET("/expenses/:id/history", read, h.GetExpenseHistory)
+}
+
+// ListAuditEvents は家計簿の変更履歴を新しいものから順に取得します（entity_type で絞り込み、cursor・limit でページング）
+func (h *AuditHandler) ListAuditEvents(c *gin.Context) {
+	userID, ok := middleware.GetDataOwnerID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	filter := models.AuditFilter{
+		EntityType: c.Query("entity_type"),
+		Cursor:     c.Query("cursor"),
+	}
+	if v := c.Query("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit の形式が正しくありません"})
+			return
+		}
+		filter.Limit = limit
+	}
+
+	page, err := h.service.ListAuditEvents(c.Request.Context(), userID, filter)
+	if err != nil {
+		var ve *services.ValidationError
+		if errors.As(err, &ve) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ve.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "変更履歴の取得に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// GetExpenseHistory は支出の変更履歴を古いものから順に取得します（ゴミ箱の支出も対象）
+func (h *AuditHandler) GetExpenseHistory(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "支出IDが正しくありません"})
+		return
+	}
+
+	userID, ok := middleware.GetDataOwnerID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	events, err := h.service.GetExpenseHistory(c.Request.Context(), userID, id)
+	if err != nil {
+		var ne *services.NotFoundError
+		if errors.As(err, &ne) {
+			c.JSON(http.StatusNotFound, gin.H{"error": ne.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "変更履歴の取得に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"events": events})
+}
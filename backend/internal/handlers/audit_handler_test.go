@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/services"
+)
+
+// auditServiceMock is a mock implementing services.AuditService
+type auditServiceMock struct {
+	ListAuditEventsFunc   func(ctx context.Context, userID string, filter models.AuditFilter) (models.AuditPage, error)
+	GetExpenseHistoryFunc func(ctx context.Context, userID string, id int) ([]models.AuditEvent, error)
+}
+
+func (m *auditServiceMock) ListAuditEvents(ctx context.Context, userID string, filter models.AuditFilter) (models.AuditPage, error) {
+	if m.ListAuditEventsFunc != nil {
+		return m.ListAuditEventsFunc(ctx, userID, filter)
+	}
+	return models.AuditPage{}, nil
+}
+
+func (m *auditServiceMock) GetExpenseHistory(ctx context.Context, userID string, id int) ([]models.AuditEvent, error) {
+	if m.GetExpenseHistoryFunc != nil {
+		return m.GetExpenseHistoryFunc(ctx, userID, id)
+	}
+	return nil, nil
+}
+
+// TestListAuditEvents は絞り込み条件を渡して変更履歴の一覧が返ることをテストします
+func TestListAuditEvents(t *testing.T) {
+	router := newAuthedRouter()
+	NewAuditHandler(router, &auditServiceMock{
+		ListAuditEventsFunc: func(ctx context.Context, userID string, filter models.AuditFilter) (models.AuditPage, error) {
+			require.Equal(t, DummyUserID, userID)
+			require.Equal(t, models.AuditFilter{EntityType: "expense", Cursor: "abc", Limit: 10}, filter)
+			next := "next"
+			entityID := 4
+			return models.AuditPage{
+				Events: []models.AuditEvent{{
+					ID: 12, EntityType: "expense", EntityID: &entityID, Action: "update", ActorID: DummyUserID, RequestID: "req-1",
+					Before: json.RawMessage(`{"amount":100}`), After: json.RawMessage(`{"amount":200}`), CreatedAt: "2025-06-01T12:30:00Z",
+				}},
+				NextCursor: &next,
+			}, nil
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/audit?entity_type=expense&cursor=abc&limit=10", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{
+		"events": [{
+			"id": 12, "entity_type": "expense", "entity_id": 4, "action": "update", "actor_id": "`+DummyUserID+`", "request_id": "req-1",
+			"before": {"amount": 100}, "after": {"amount": 200}, "created_at": "2025-06-01T12:30:00Z"
+		}],
+		"next_cursor": "next"
+	}`, w.Body.String())
+}
+
+// TestListAuditEvents_Errors は不正な条件で400、取得失敗で500を返すことをテストします
+func TestListAuditEvents_Errors(t *testing.T) {
+	cases := []struct {
+		name     string
+		query    string
+		err      error
+		wantCode int
+	}{
+		{name: "limit が数値でない", query: "?limit=abc", wantCode: http.StatusBadRequest},
+		{name: "サービスの検証エラー", query: "?entity_type=budget", err: &services.ValidationError{Message: "種類が正しくありません"}, wantCode: http.StatusBadRequest},
+		{name: "取得失敗", err: errors.New("db error"), wantCode: http.StatusInternalServerError},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := newAuthedRouter()
+			NewAuditHandler(router, &auditServiceMock{
+				ListAuditEventsFunc: func(ctx context.Context, userID string, filter models.AuditFilter) (models.AuditPage, error) {
+					return models.AuditPage{}, tc.err
+				},
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/audit"+tc.query, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tc.wantCode, w.Code)
+		})
+	}
+}
+
+// TestGetExpenseHistory は支出の変更履歴が返ることと、履歴がない場合に404を返すことをテストします
+func TestGetExpenseHistory(t *testing.T) {
+	router := newAuthedRouter()
+	NewAuditHandler(router, &auditServiceMock{
+		GetExpenseHistoryFunc: func(ctx context.Context, userID string, id int) ([]models.AuditEvent, error) {
+			if id == 404 {
+				return nil, &services.NotFoundError{Message: "支出の変更履歴が見つかりません"}
+			}
+			require.Equal(t, 7, id)
+			return []models.AuditEvent{{ID: 1, Action: "create"}, {ID: 2, Action: "delete"}}, nil
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/expenses/7/history", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	var body struct {
+		Events []models.AuditEvent `json:"events"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	require.Len(t, body.Events, 2)
+
+	req = httptest.NewRequest(http.MethodGet, "/expenses/404/history", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"money-buddy-backend/internal/middleware"
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/services"
+)
+
+type BudgetHandler struct {
+	service services.BudgetService
+}
+
+func NewBudgetHandler(r gin.IRouter, service services.BudgetService) {
+	h := &BudgetHandler{service: service}
+	// パーソナルアクセストークンでは read で参照のみ許可し、変更はログイン中のユーザー（世帯の閲覧者を除く）に限る
+	read := middleware.RequireScope(models.APITokenScopeRead)
+	session := middleware.RequireSession()
+	editor := middleware.RequireEditor()
+	r.GET("/budgets", read, h.ListBudgets)
+	r.PUT("/budgets/:category_id", session, editor, h.SetBudget)
+	r.DELETE("/budgets/:category_id", session, editor, h.DeleteBudget)
+}
+
+// ListBudgets はカテゴリ別の月次予算一覧を取得します
+func (h *BudgetHandler) ListBudgets(c *gin.Context) {
+	userID, ok := middleware.GetDataOwnerID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	budgets, err := h.service.ListBudgets(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "予算の取得に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"budgets": budgets})
+}
+
+// SetBudgetRequest は予算設定のリクエストボディです
+type SetBudgetRequest struct {
+	MonthlyLimit int `json:"monthly_limit"`
+}
+
+// SetBudget はカテゴリの月次予算を設定します
+func (h *BudgetHandler) SetBudget(c *gin.Context) {
+	userID, ok := middleware.GetDataOwnerID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	categoryID, err := strconv.Atoi(c.Param("category_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "カテゴリIDが正しくありません"})
+		return
+	}
+
+	var req SetBudgetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	budget, err := h.service.SetBudget(c.Request.Context(), userID, categoryID, req.MonthlyLimit)
+	if err != nil {
+		var ve *services.ValidationError
+		var ne *services.NotFoundError
+		if errors.As(err, &ve) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ve.Message})
+			return
+		}
+		if errors.As(err, &ne) {
+			c.JSON(http.StatusNotFound, gin.H{"error": ne.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "予算の設定に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"budget": budget})
+}
+
+// DeleteBudget はカテゴリの月次予算を削除します
+func (h *BudgetHandler) DeleteBudget(c *gin.Context) {
+	userID, ok := middleware.GetDataOwnerID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	categoryID, err := strconv.Atoi(c.Param("category_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "カテゴリIDが正しくありません"})
+		return
+	}
+
+	if err := h.service.DeleteBudget(c.Request.Context(), userID, categoryID); err != nil {
+		var ne *services.NotFoundError
+		if errors.As(err, &ne) {
+			c.JSON(http.StatusNotFound, gin.H{"error": ne.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "予算の削除に失敗しました"})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -1,10 +1,14 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 
+	"money-buddy-backend/internal/middleware"
+	"money-buddy-backend/internal/models"
 	"money-buddy-backend/internal/services"
 )
 
@@ -14,11 +18,26 @@ type CategoryHandler struct {
 
 func NewCategoryHandler(r gin.IRouter, service services.CategoryService) {
 	h := &CategoryHandler{service: service}
-	r.GET("/categories", h.ListCategories)
+	// パーソナルアクセストークンでは read で参照のみ許可し、変更はログイン中のユーザー（世帯の閲覧者を除く）に限る
+	read := middleware.RequireScope(models.APITokenScopeRead)
+	session := middleware.RequireSession()
+	editor := middleware.RequireEditor()
+	r.GET("/categories", read, h.ListCategories)
+	r.POST("/categories", session, editor, h.CreateCategory)
+	r.PUT("/categories/:id", session, editor, h.UpdateCategory)
+	r.DELETE("/categories/:id", session, editor, h.DeleteCategory)
+	r.POST("/categories/:id/hide", session, editor, h.HideCategory)
+	r.DELETE("/categories/:id/hide", session, editor, h.UnhideCategory)
 }
 
 func (h *CategoryHandler) ListCategories(c *gin.Context) {
-	categories, err := h.service.ListCategories(c.Request.Context())
+	userID, ok := middleware.GetDataOwnerID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	categories, err := h.service.ListCategories(c.Request.Context(), userID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "カテゴリの取得に失敗しました"})
 		return
@@ -26,3 +45,148 @@ func (h *CategoryHandler) ListCategories(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"categories": categories})
 }
+
+// CategoryRequest はカテゴリ作成・更新のリクエストボディです
+type CategoryRequest struct {
+	Name string `json:"name"`
+}
+
+// CreateCategory はユーザー独自のカテゴリを作成します
+func (h *CategoryHandler) CreateCategory(c *gin.Context) {
+	userID, ok := middleware.GetDataOwnerID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	var req CategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	category, err := h.service.CreateCategory(c.Request.Context(), userID, req.Name)
+	if err != nil {
+		h.handleError(c, err, "カテゴリの作成に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"category": category})
+}
+
+// UpdateCategory はユーザー独自のカテゴリ名を更新します
+func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
+	userID, ok := middleware.GetDataOwnerID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "IDが正しくありません"})
+		return
+	}
+
+	var req CategoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	category, err := h.service.UpdateCategory(c.Request.Context(), userID, id, req.Name)
+	if err != nil {
+		h.handleError(c, err, "カテゴリの更新に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"category": category})
+}
+
+// DeleteCategory はユーザー独自のカテゴリを削除します。
+// 支出で使用中の場合は move_to クエリで移動先カテゴリを指定します。
+func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
+	userID, ok := middleware.GetDataOwnerID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "IDが正しくありません"})
+		return
+	}
+
+	var moveTo *int
+	if v := c.Query("move_to"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "移動先のカテゴリIDが正しくありません"})
+			return
+		}
+		moveTo = &n
+	}
+
+	if err := h.service.DeleteCategory(c.Request.Context(), userID, id, moveTo); err != nil {
+		h.handleError(c, err, "カテゴリの削除に失敗しました")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// HideCategory はデフォルトカテゴリを非表示にします
+func (h *CategoryHandler) HideCategory(c *gin.Context) {
+	h.setHidden(c, true)
+}
+
+// UnhideCategory は非表示にしたデフォルトカテゴリを再表示します
+func (h *CategoryHandler) UnhideCategory(c *gin.Context) {
+	h.setHidden(c, false)
+}
+
+func (h *CategoryHandler) setHidden(c *gin.Context, hidden bool) {
+	userID, ok := middleware.GetDataOwnerID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "IDが正しくありません"})
+		return
+	}
+
+	if hidden {
+		err = h.service.HideCategory(c.Request.Context(), userID, id)
+	} else {
+		err = h.service.UnhideCategory(c.Request.Context(), userID, id)
+	}
+	if err != nil {
+		h.handleError(c, err, "カテゴリの表示設定の変更に失敗しました")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// handleError はサービス層のエラーをHTTPレスポンスに変換します
+func (h *CategoryHandler) handleError(c *gin.Context, err error, internalMessage string) {
+	var ve *services.ValidationError
+	if errors.As(err, &ve) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": ve.Message})
+		return
+	}
+	var ne *services.NotFoundError
+	if errors.As(err, &ne) {
+		c.JSON(http.StatusNotFound, gin.H{"error": ne.Message})
+		return
+	}
+	if errors.Is(err, services.ErrCategoryInUse) {
+		c.JSON(http.StatusConflict, gin.H{"error": "このカテゴリは支出または繰り返しの予定支出で使用されています。移動先のカテゴリを指定してください"})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": internalMessage})
+}
@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/services"
+)
+
+// categoryServiceMock is a mock implementing services.CategoryService
+type categoryServiceMock struct {
+	ListCategoriesFunc func(ctx context.Context, userID string) ([]models.Category, error)
+	CreateCategoryFunc func(ctx context.Context, userID string, name string) (models.Category, error)
+	UpdateCategoryFunc func(ctx context.Context, userID string, id int, name string) (models.Category, error)
+	DeleteCategoryFunc func(ctx context.Context, userID string, id int, moveTo *int) error
+	HideCategoryFunc   func(ctx context.Context, userID string, id int) error
+	UnhideCategoryFunc func(ctx context.Context, userID string, id int) error
+}
+
+func (m *categoryServiceMock) ListCategories(ctx context.Context, userID string) ([]models.Category, error) {
+	if m.ListCategoriesFunc != nil {
+		return m.ListCategoriesFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *categoryServiceMock) CreateCategory(ctx context.Context, userID string, name string) (models.Category, error) {
+	if m.CreateCategoryFunc != nil {
+		return m.CreateCategoryFunc(ctx, userID, name)
+	}
+	return models.Category{}, nil
+}
+
+func (m *categoryServiceMock) UpdateCategory(ctx context.Context, userID string, id int, name string) (models.Category, error) {
+	if m.UpdateCategoryFunc != nil {
+		return m.UpdateCategoryFunc(ctx, userID, id, name)
+	}
+	return models.Category{}, nil
+}
+
+func (m *categoryServiceMock) DeleteCategory(ctx context.Context, userID string, id int, moveTo *int) error {
+	if m.DeleteCategoryFunc != nil {
+		return m.DeleteCategoryFunc(ctx, userID, id, moveTo)
+	}
+	return nil
+}
+
+func (m *categoryServiceMock) HideCategory(ctx context.Context, userID string, id int) error {
+	if m.HideCategoryFunc != nil {
+		return m.HideCategoryFunc(ctx, userID, id)
+	}
+	return nil
+}
+
+func (m *categoryServiceMock) UnhideCategory(ctx context.Context, userID string, id int) error {
+	if m.UnhideCategoryFunc != nil {
+		return m.UnhideCategoryFunc(ctx, userID, id)
+	}
+	return nil
+}
+
+func intPtr(v int) *int { return &v }
+
+// TestCreateCategory_Success はカテゴリ作成の成功ケースをテストします
+func TestCreateCategory_Success(t *testing.T) {
+	router := newAuthedRouter()
+	svc := &categoryServiceMock{
+		CreateCategoryFunc: func(ctx context.Context, userID string, name string) (models.Category, error) {
+			require.Equal(t, DummyUserID, userID)
+			require.Equal(t, "ペット", name)
+			return models.Category{ID: 10, Name: name, IsCustom: true}, nil
+		},
+	}
+	NewCategoryHandler(router, svc)
+
+	req := httptest.NewRequest(http.MethodPost, "/categories", strings.NewReader(`{"name":"ペット"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	var resp struct {
+		Category models.Category `json:"category"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, 10, resp.Category.ID)
+	require.True(t, resp.Category.IsCustom)
+}
+
+// TestDeleteCategory_MoveTo は move_to クエリがサービスに渡されることと、エラーの変換をテストします
+func TestDeleteCategory_MoveTo(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		svcErr     error
+		wantMoveTo *int
+		wantStatus int
+	}{
+		{name: "移動先なしで削除", url: "/categories/10", wantStatus: http.StatusNoContent},
+		{name: "移動先を指定して削除", url: "/categories/10?move_to=2", wantMoveTo: intPtr(2), wantStatus: http.StatusNoContent},
+		{name: "使用中は409", url: "/categories/10", svcErr: services.ErrCategoryInUse, wantStatus: http.StatusConflict},
+		{name: "存在しないカテゴリは404", url: "/categories/10", svcErr: &services.NotFoundError{Message: "カテゴリが見つかりません"}, wantStatus: http.StatusNotFound},
+		{name: "move_to が数値でない場合は400", url: "/categories/10?move_to=abc", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newAuthedRouter()
+			svc := &categoryServiceMock{
+				DeleteCategoryFunc: func(ctx context.Context, userID string, id int, moveTo *int) error {
+					require.Equal(t, 10, id)
+					require.Equal(t, tt.wantMoveTo, moveTo)
+					return tt.svcErr
+				},
+			}
+			NewCategoryHandler(router, svc)
+
+			req := httptest.NewRequest(http.MethodDelete, tt.url, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
@@ -7,19 +7,62 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"money-buddy-backend/internal/export"
 	"money-buddy-backend/internal/middleware"
+	"money-buddy-backend/internal/models"
 	"money-buddy-backend/internal/services"
 )
 
 // DashboardResponse はダッシュボードAPIのレスポンス構造です。
 type DashboardResponse struct {
-	Income            int64 `json:"income"`
-	SavingGoal        int64 `json:"saving_goal"`
-	FixedCosts        int64 `json:"fixed_costs"`
-	VariableBudget    int64 `json:"variable_budget"`
-	ConfirmedExpenses int64 `json:"confirmed_expenses"`
-	PlannedExpenses   int64 `json:"planned_expenses"`
-	Remaining         int64 `json:"remaining"`
+	Month             string `json:"month"`
+	PeriodStart       string `json:"period_start"`
+	PeriodEnd         string `json:"period_end"`
+	FixedCostMode     string `json:"fixed_cost_mode"`
+	Income            int64  `json:"income"`
+	SavingGoal        int64  `json:"saving_goal"`
+	FixedCosts        int64  `json:"fixed_costs"`
+	VariableBudget    int64  `json:"variable_budget"`
+	ConfirmedExpenses int64  `json:"confirmed_expenses"`
+	PlannedExpenses   int64  `json:"planned_expenses"`
+	Remaining         int64  `json:"remaining"`
+	// Categories はカテゴリ別の予算消化状況です
+	Categories []CategoryBudgetResponse `json:"categories"`
+	// Members は登録したメンバー別の支出です（世帯を作成していない場合は本人のみ）
+	Members []MemberExpensesResponse `json:"members"`
+	// Pace は今日までの利用ペースと月末の見込みです
+	Pace DashboardPaceResponse `json:"pace"`
+}
+
+// DashboardPaceResponse は利用ペースと月末の見込みのレスポンス構造です。
+// days_elapsed・days_remaining はどちらも今日を含みます。
+type DashboardPaceResponse struct {
+	DaysInMonth        int   `json:"days_in_month"`
+	DaysElapsed        int   `json:"days_elapsed"`
+	DaysRemaining      int   `json:"days_remaining"`
+	IdealSpendToDate   int64 `json:"ideal_spend_to_date"`
+	ActualSpendToDate  int64 `json:"actual_spend_to_date"`
+	SafeToSpendPerDay  int64 `json:"safe_to_spend_per_day"`
+	ProjectedRemaining int64 `json:"projected_remaining"`
+}
+
+// CategoryBudgetResponse はカテゴリ別の予算消化状況のレスポンス構造です。
+// 予算が未設定のカテゴリでは limit・remaining・level が null になります。
+type CategoryBudgetResponse struct {
+	CategoryID        int     `json:"category_id"`
+	CategoryName      string  `json:"category_name"`
+	Limit             *int64  `json:"limit"`
+	ConfirmedExpenses int64   `json:"confirmed_expenses"`
+	PlannedExpenses   int64   `json:"planned_expenses"`
+	Remaining         *int64  `json:"remaining"`
+	Level             *string `json:"level"`
+}
+
+// MemberExpensesResponse は登録したメンバー別の支出のレスポンス構造です。
+type MemberExpensesResponse struct {
+	UserID            string `json:"user_id"`
+	ConfirmedExpenses int64  `json:"confirmed_expenses"`
+	PlannedExpenses   int64  `json:"planned_expenses"`
 }
 
 type DashboardHandler struct {
@@ -28,18 +71,26 @@ type DashboardHandler struct {
 
 func NewDashboardHandler(r gin.IRouter, service services.DashboardService) {
 	h := &DashboardHandler{service: service}
-	r.GET("/dashboard", h.GetDashboard)
+	read := middleware.RequireScope(models.APITokenScopeRead)
+	r.GET("/dashboard", read, h.GetDashboard)
+	r.GET("/dashboard/export", read, h.ExportMonthlySummaries)
 }
 
 func (h *DashboardHandler) GetDashboard(c *gin.Context) {
-	userID, ok := middleware.GetUserID(c)
+	userID, ok := middleware.GetDataOwnerID(c)
 	if !ok {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
 		return
 	}
 
-	dashboard, err := h.service.GetDashboard(c.Request.Context(), userID)
+	dashboard, err := h.service.GetDashboard(c.Request.Context(), userID, c.Query("month"), c.Query("fixed_cost_mode"))
 	if err != nil {
+		// 対象月・固定費の計上方法が不正な場合
+		var ve *services.ValidationError
+		if errors.As(err, &ve) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ve.Message})
+			return
+		}
 		// ユーザーが存在しない場合
 		if errors.Is(err, sql.ErrNoRows) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "ユーザーが見つかりません"})
@@ -52,6 +103,10 @@ func (h *DashboardHandler) GetDashboard(c *gin.Context) {
 
 	// レスポンスを構築
 	response := DashboardResponse{
+		Month:             dashboard.Month,
+		PeriodStart:       dashboard.PeriodStart,
+		PeriodEnd:         dashboard.PeriodEnd,
+		FixedCostMode:     string(dashboard.FixedCostMode),
 		Income:            dashboard.Income,
 		SavingGoal:        dashboard.SavingGoal,
 		FixedCosts:        dashboard.FixedCosts,
@@ -59,7 +114,96 @@ func (h *DashboardHandler) GetDashboard(c *gin.Context) {
 		ConfirmedExpenses: dashboard.ConfirmedExpenses,
 		PlannedExpenses:   dashboard.PlannedExpenses,
 		Remaining:         dashboard.Remaining,
+		Categories:        make([]CategoryBudgetResponse, 0, len(dashboard.Categories)),
+		Members:           make([]MemberExpensesResponse, 0, len(dashboard.Members)),
+		Pace: DashboardPaceResponse{
+			DaysInMonth:        dashboard.Pace.DaysInMonth,
+			DaysElapsed:        dashboard.Pace.DaysElapsed,
+			DaysRemaining:      dashboard.Pace.DaysRemaining,
+			IdealSpendToDate:   dashboard.Pace.IdealSpendToDate,
+			ActualSpendToDate:  dashboard.Pace.ActualSpendToDate,
+			SafeToSpendPerDay:  dashboard.Pace.SafeToSpendPerDay,
+			ProjectedRemaining: dashboard.Pace.ProjectedRemaining,
+		},
+	}
+	for _, cb := range dashboard.Categories {
+		item := CategoryBudgetResponse{
+			CategoryID:        cb.CategoryID,
+			CategoryName:      cb.CategoryName,
+			Limit:             cb.Limit,
+			ConfirmedExpenses: cb.ConfirmedExpenses,
+			PlannedExpenses:   cb.PlannedExpenses,
+			Remaining:         cb.Remaining,
+		}
+		if cb.Level != "" {
+			level := string(cb.Level)
+			item.Level = &level
+		}
+		response.Categories = append(response.Categories, item)
+	}
+	for _, m := range dashboard.Members {
+		response.Members = append(response.Members, MemberExpensesResponse{
+			UserID:            m.UserID,
+			ConfirmedExpenses: m.ConfirmedExpenses,
+			PlannedExpenses:   m.PlannedExpenses,
+		})
 	}
 
 	c.JSON(http.StatusOK, response)
 }
+
+// monthlySummaryExportColumns は月次集計の書き出しの列です。
+var monthlySummaryExportColumns = []string{
+	"month", "income", "saving_goal", "fixed_costs", "variable_budget",
+	"confirmed_expenses", "planned_expenses", "remaining",
+}
+
+// ExportMonthlySummaries handles GET /dashboard/export.
+// from から to（YYYY-MM）までの 1 か月 1 行の集計を format（csv / json / xlsx）で書き出します。
+func (h *DashboardHandler) ExportMonthlySummaries(c *gin.Context) {
+	userID, ok := middleware.GetDataOwnerID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	format, ok := export.ParseFormat(c.Query("format"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format は csv・json・xlsx のいずれかを指定してください"})
+		return
+	}
+
+	stream := newExportStream(c, format, "monthly-summary", monthlySummaryExportColumns)
+	err := h.service.ExportMonthlySummaries(c.Request.Context(), userID, c.Query("from"), c.Query("to"), c.Query("fixed_cost_mode"), func(d *services.Dashboard) error {
+		return stream.writeRow([]any{
+			d.Month,
+			d.Income,
+			d.SavingGoal,
+			d.FixedCosts,
+			d.VariableBudget,
+			d.ConfirmedExpenses,
+			d.PlannedExpenses,
+			d.Remaining,
+		})
+	})
+	if err == nil {
+		err = stream.close()
+	}
+	if err != nil {
+		// 送信開始後はステータスを変更できないため、出力を打ち切る
+		if stream.started() {
+			c.Abort()
+			return
+		}
+		var ve *services.ValidationError
+		if errors.As(err, &ve) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ve.Message})
+			return
+		}
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "ユーザーが見つかりません"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "月次集計の書き出しに失敗しました"})
+	}
+}
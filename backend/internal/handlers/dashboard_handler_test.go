@@ -13,28 +13,37 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"money-buddy-backend/internal/models"
 	"money-buddy-backend/internal/services"
 )
 
 // dashboardServiceMock は DashboardService のモック実装です
 type dashboardServiceMock struct {
-	GetDashboardFunc func(ctx context.Context, userID string) (*services.Dashboard, error)
+	GetDashboardFunc           func(ctx context.Context, userID string, month string, fixedCostMode string) (*services.Dashboard, error)
+	ExportMonthlySummariesFunc func(ctx context.Context, userID string, from, to string, fixedCostMode string, fn func(*services.Dashboard) error) error
 }
 
-func (m *dashboardServiceMock) GetDashboard(ctx context.Context, userID string) (*services.Dashboard, error) {
+func (m *dashboardServiceMock) GetDashboard(ctx context.Context, userID string, month string, fixedCostMode string) (*services.Dashboard, error) {
 	if m.GetDashboardFunc != nil {
-		return m.GetDashboardFunc(ctx, userID)
+		return m.GetDashboardFunc(ctx, userID, month, fixedCostMode)
 	}
 	return nil, nil
 }
 
+func (m *dashboardServiceMock) ExportMonthlySummaries(ctx context.Context, userID string, from, to string, fixedCostMode string, fn func(*services.Dashboard) error) error {
+	if m.ExportMonthlySummariesFunc != nil {
+		return m.ExportMonthlySummariesFunc(ctx, userID, from, to, fixedCostMode, fn)
+	}
+	return nil
+}
+
 // TestDashboardHandler_GetDashboard_Success は正常系のテストです
 func TestDashboardHandler_GetDashboard_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
 
 	svc := &dashboardServiceMock{
-		GetDashboardFunc: func(ctx context.Context, userID string) (*services.Dashboard, error) {
+		GetDashboardFunc: func(ctx context.Context, userID string, month string, fixedCostMode string) (*services.Dashboard, error) {
 			require.Equal(t, DummyUserID, userID)
 			return &services.Dashboard{
 				Income:            300000,
@@ -74,7 +83,7 @@ func TestDashboardHandler_GetDashboard_UserNotFound(t *testing.T) {
 	router := gin.New()
 
 	svc := &dashboardServiceMock{
-		GetDashboardFunc: func(ctx context.Context, userID string) (*services.Dashboard, error) {
+		GetDashboardFunc: func(ctx context.Context, userID string, month string, fixedCostMode string) (*services.Dashboard, error) {
 			return nil, sql.ErrNoRows
 		},
 	}
@@ -98,7 +107,7 @@ func TestDashboardHandler_GetDashboard_ServiceError(t *testing.T) {
 	router := gin.New()
 
 	svc := &dashboardServiceMock{
-		GetDashboardFunc: func(ctx context.Context, userID string) (*services.Dashboard, error) {
+		GetDashboardFunc: func(ctx context.Context, userID string, month string, fixedCostMode string) (*services.Dashboard, error) {
 			return nil, errors.New("database connection error")
 		},
 	}
@@ -122,7 +131,7 @@ func TestDashboardHandler_GetDashboard_NegativeRemaining(t *testing.T) {
 	router := gin.New()
 
 	svc := &dashboardServiceMock{
-		GetDashboardFunc: func(ctx context.Context, userID string) (*services.Dashboard, error) {
+		GetDashboardFunc: func(ctx context.Context, userID string, month string, fixedCostMode string) (*services.Dashboard, error) {
 			return &services.Dashboard{
 				Income:            300000,
 				SavingGoal:        50000,
@@ -149,3 +158,187 @@ func TestDashboardHandler_GetDashboard_NegativeRemaining(t *testing.T) {
 	// マイナスの残額も正しくレスポンスに含まれることを確認
 	assert.Equal(t, int64(-50000), resp.Remaining)
 }
+
+// TestDashboardHandler_GetDashboard_WithMonth は対象月を指定した場合のテストです
+func TestDashboardHandler_GetDashboard_WithMonth(t *testing.T) {
+	router := newAuthedRouter()
+
+	svc := &dashboardServiceMock{
+		GetDashboardFunc: func(ctx context.Context, userID string, month string, fixedCostMode string) (*services.Dashboard, error) {
+			require.Equal(t, "2025-10", month)
+			require.Equal(t, "billing_month", fixedCostMode)
+			return &services.Dashboard{
+				Month:         "2025-10",
+				PeriodStart:   "2025-10-01",
+				PeriodEnd:     "2025-10-31",
+				FixedCostMode: models.FixedCostModeBillingMonth,
+				Remaining:     1000,
+				Pace: services.DashboardPace{
+					DaysInMonth:        31,
+					DaysElapsed:        10,
+					DaysRemaining:      22,
+					IdealSpendToDate:   3000,
+					ActualSpendToDate:  2500,
+					SafeToSpendPerDay:  45,
+					ProjectedRemaining: -750,
+				},
+			}, nil
+		},
+	}
+	NewDashboardHandler(router, svc)
+
+	req := httptest.NewRequest(http.MethodGet, "/dashboard?month=2025-10&fixed_cost_mode=billing_month", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp DashboardResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "2025-10", resp.Month)
+	assert.Equal(t, "2025-10-01", resp.PeriodStart)
+	assert.Equal(t, "2025-10-31", resp.PeriodEnd)
+	assert.Equal(t, "billing_month", resp.FixedCostMode)
+	assert.Equal(t, int64(1000), resp.Remaining)
+	assert.Equal(t, DashboardPaceResponse{
+		DaysInMonth:        31,
+		DaysElapsed:        10,
+		DaysRemaining:      22,
+		IdealSpendToDate:   3000,
+		ActualSpendToDate:  2500,
+		SafeToSpendPerDay:  45,
+		ProjectedRemaining: -750,
+	}, resp.Pace)
+}
+
+// TestDashboardHandler_GetDashboard_InvalidMonth は対象月の形式が不正な場合のテストです
+func TestDashboardHandler_GetDashboard_InvalidMonth(t *testing.T) {
+	router := newAuthedRouter()
+
+	svc := &dashboardServiceMock{
+		GetDashboardFunc: func(ctx context.Context, userID string, month string, fixedCostMode string) (*services.Dashboard, error) {
+			return nil, &services.ValidationError{Message: "対象月は YYYY-MM 形式で指定してください"}
+		},
+	}
+	NewDashboardHandler(router, svc)
+
+	req := httptest.NewRequest(http.MethodGet, "/dashboard?month=2025-13", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	var resp map[string]string
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, "対象月は YYYY-MM 形式で指定してください", resp["error"])
+}
+
+// TestDashboardHandler_GetDashboard_Categories はカテゴリ別予算がレスポンスに含まれることのテストです
+func TestDashboardHandler_GetDashboard_Categories(t *testing.T) {
+	router := newAuthedRouter()
+
+	limit := int64(50000)
+	remaining := int64(-1000)
+	svc := &dashboardServiceMock{
+		GetDashboardFunc: func(ctx context.Context, userID string, month string, fixedCostMode string) (*services.Dashboard, error) {
+			return &services.Dashboard{
+				Categories: []services.CategoryBudgetStatus{
+					{CategoryID: 1, CategoryName: "食費", Limit: &limit, ConfirmedExpenses: 41000, PlannedExpenses: 10000, Remaining: &remaining, Level: services.BudgetLevelRed},
+					{CategoryID: 5, CategoryName: "交通費", ConfirmedExpenses: 3000},
+				},
+			}, nil
+		},
+	}
+	NewDashboardHandler(router, svc)
+
+	req := httptest.NewRequest(http.MethodGet, "/dashboard", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var resp struct {
+		Categories []map[string]any `json:"categories"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Categories, 2)
+
+	assert.Equal(t, float64(50000), resp.Categories[0]["limit"])
+	assert.Equal(t, float64(-1000), resp.Categories[0]["remaining"])
+	assert.Equal(t, "red", resp.Categories[0]["level"])
+
+	// 予算未設定のカテゴリは limit・remaining・level が null
+	assert.Contains(t, resp.Categories[1], "limit")
+	assert.Nil(t, resp.Categories[1]["limit"])
+	assert.Nil(t, resp.Categories[1]["remaining"])
+	assert.Nil(t, resp.Categories[1]["level"])
+	assert.Equal(t, float64(3000), resp.Categories[1]["confirmed_expenses"])
+}
+
+// TestDashboardHandler_ExportMonthlySummaries は月次集計の書き出しのテストです
+func TestDashboardHandler_ExportMonthlySummaries(t *testing.T) {
+	router := newAuthedRouter()
+	var gotFrom, gotTo string
+	svc := &dashboardServiceMock{
+		ExportMonthlySummariesFunc: func(ctx context.Context, userID string, from, to string, fixedCostMode string, fn func(*services.Dashboard) error) error {
+			gotFrom, gotTo = from, to
+			return fn(&services.Dashboard{
+				Month:             "2025-01",
+				Income:            300000,
+				SavingGoal:        50000,
+				FixedCosts:        100000,
+				VariableBudget:    150000,
+				ConfirmedExpenses: 80000,
+				PlannedExpenses:   20000,
+				Remaining:         50000,
+			})
+		},
+	}
+	NewDashboardHandler(router, svc)
+
+	req := httptest.NewRequest(http.MethodGet, "/dashboard/export?from=2025-01&to=2025-01&format=json", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "2025-01", gotFrom)
+	assert.Equal(t, "2025-01", gotTo)
+	assert.Equal(t, `attachment; filename="monthly-summary.json"`, w.Header().Get("Content-Disposition"))
+	assert.JSONEq(t, `[{
+		"month": "2025-01", "income": 300000, "saving_goal": 50000, "fixed_costs": 100000,
+		"variable_budget": 150000, "confirmed_expenses": 80000, "planned_expenses": 20000, "remaining": 50000
+	}]`, w.Body.String())
+}
+
+// TestDashboardHandler_ExportMonthlySummaries_Errors は月次集計の書き出しのエラー時のテストです
+func TestDashboardHandler_ExportMonthlySummaries_Errors(t *testing.T) {
+	cases := []struct {
+		name       string
+		path       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "format が不正", path: "/dashboard/export?format=pdf", wantStatus: http.StatusBadRequest},
+		{name: "期間が不正", path: "/dashboard/export?from=2025-13", svcErr: &services.ValidationError{Message: "対象月は YYYY-MM 形式で指定してください"}, wantStatus: http.StatusBadRequest},
+		{name: "ユーザーが存在しない", path: "/dashboard/export", svcErr: sql.ErrNoRows, wantStatus: http.StatusNotFound},
+		{name: "内部エラー", path: "/dashboard/export", svcErr: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := newAuthedRouter()
+			svc := &dashboardServiceMock{
+				ExportMonthlySummariesFunc: func(ctx context.Context, userID string, from, to string, fixedCostMode string, fn func(*services.Dashboard) error) error {
+					return tc.svcErr
+				},
+			}
+			NewDashboardHandler(router, svc)
+
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.wantStatus, w.Code)
+		})
+	}
+}
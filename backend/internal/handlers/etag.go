@@ -0,0 +1,66 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// formatETag はリソースのバージョンを ETag の値（"3" の形式）にします。
+func formatETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// setETag はリソースのバージョンを ETag ヘッダーに設定します。
+func setETag(c *gin.Context, version int) {
+	c.Header("ETag", formatETag(version))
+}
+
+// ifMatchAny は If-Match: * を表す requireIfMatch の戻り値です。
+// 現在のどのバージョンとも一致するため、呼び出し元は現在のバージョンを取得して更新します。
+const ifMatchAny = -1
+
+// requireIfMatch は If-Match ヘッダーで指定された更新前のバージョンを返します。
+// ヘッダーがない場合は 428、ETag の形式でない場合は 400 を返し、第2戻り値を false にします。
+// * の場合は ifMatchAny を返します。弱い ETag やバージョンでない ETag は強い比較では一致しないため、
+// どのバージョンとも一致しない 0 として扱い、412 で現在の状態を返せるようにします。
+func requireIfMatch(c *gin.Context) (int, bool) {
+	value := strings.TrimSpace(c.GetHeader("If-Match"))
+	if value == "" {
+		c.JSON(http.StatusPreconditionRequired, gin.H{"error": "If-Match ヘッダーに取得時の ETag を指定してください"})
+		return 0, false
+	}
+	if value == "*" {
+		return ifMatchAny, true
+	}
+
+	tag, weak := strings.CutPrefix(value, "W/")
+	if !isQuotedETag(tag) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "If-Match ヘッダーの形式が正しくありません"})
+		return 0, false
+	}
+	if weak {
+		return 0, true
+	}
+	version, err := strconv.Atoi(tag[1 : len(tag)-1])
+	if err != nil || version < 1 {
+		return 0, true
+	}
+	return version, true
+}
+
+// isQuotedETag は tag が引用符で囲まれた1つの ETag（RFC 9110 の entity-tag）かどうかを返します。
+func isQuotedETag(tag string) bool {
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return false
+	}
+	for _, r := range tag[1 : len(tag)-1] {
+		// etagc = %x21 / %x23-7E / obs-text
+		if r == '"' || r < 0x21 || r == 0x7f {
+			return false
+		}
+	}
+	return true
+}
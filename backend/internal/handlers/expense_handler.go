@@ -1,15 +1,18 @@
 package handlers
 
 import (
+	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
+	"money-buddy-backend/internal/export"
 	"money-buddy-backend/internal/middleware"
 	"money-buddy-backend/internal/models"
 	"money-buddy-backend/internal/services"
-	"strconv"
 )
 
 type ExpenseHandler struct {
@@ -19,10 +22,22 @@ type ExpenseHandler struct {
 func NewExpenseHandler(r gin.IRouter, service services.ExpenseService) {
 	handler := &ExpenseHandler{service: service}
 
-	r.POST("/expenses", handler.CreateExpense)
-	r.GET("/expenses", handler.ListExpenses)
-	r.PUT("/expenses/:id", handler.UpdateExpense)
-	r.DELETE("/expenses/:id", handler.DeleteExpense)
+	// パーソナルアクセストークンでは read で参照、write:expenses で登録・更新・削除を許可する
+	// 世帯の閲覧者（viewer）は参照のみ
+	read := middleware.RequireScope(models.APITokenScopeRead)
+	write := middleware.RequireScope(models.APITokenScopeWriteExpenses)
+	editor := middleware.RequireEditor()
+
+	r.POST("/expenses", write, editor, handler.CreateExpense)
+	r.GET("/expenses", read, handler.ListExpenses)
+	r.POST("/expenses/import", write, editor, handler.ImportExpenses)
+	r.POST("/expenses/batch", write, editor, handler.BatchExpenses)
+	r.GET("/expenses/export", read, handler.ExportExpenses)
+	r.GET("/expenses/:id", read, handler.GetExpense)
+	r.PUT("/expenses/:id", write, editor, handler.UpdateExpense)
+	r.PATCH("/expenses/:id", write, editor, handler.PatchExpense)
+	r.DELETE("/expenses/:id", write, editor, handler.DeleteExpense)
+	r.POST("/expenses/:id/restore", write, editor, handler.RestoreExpense)
 }
 
 func (h *ExpenseHandler) CreateExpense(c *gin.Context) {
@@ -33,12 +48,14 @@ func (h *ExpenseHandler) CreateExpense(c *gin.Context) {
 		return
 	}
 
-	userID, ok := middleware.GetUserID(c)
+	userID, ok := middleware.GetDataOwnerID(c)
 	if !ok {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
 		return
 	}
-	expense, err := h.service.CreateExpense(userID, input)
+	// 世帯の家計簿では登録したメンバーを記録する
+	input.CreatedBy, _ = middleware.GetUserID(c)
+	expense, err := h.service.CreateExpense(c.Request.Context(), userID, input)
 	if err != nil {
 		var ve *services.ValidationError
 		if errors.As(err, &ve) {
@@ -49,22 +66,302 @@ func (h *ExpenseHandler) CreateExpense(c *gin.Context) {
 		return
 	}
 
+	setETag(c, expense.Version)
 	c.JSON(http.StatusCreated, gin.H{"expense": expense})
 }
 
+// GetExpense handles GET /expenses/:id. The ETag header carries the version required by PUT /expenses/:id.
+func (h *ExpenseHandler) GetExpense(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "支出IDが正しくありません"})
+		return
+	}
+
+	userID, ok := middleware.GetDataOwnerID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	expense, err := h.service.GetExpense(c.Request.Context(), userID, id)
+	if err != nil {
+		var ne *services.NotFoundError
+		if errors.As(err, &ne) {
+			c.JSON(http.StatusNotFound, gin.H{"error": ne.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "サーバーエラーが発生しました"})
+		return
+	}
+
+	setETag(c, expense.Version)
+	c.JSON(http.StatusOK, gin.H{"expense": expense})
+}
+
+// ListExpenses handles GET /expenses with optional filters and cursor pagination.
 func (h *ExpenseHandler) ListExpenses(c *gin.Context) {
-	userID, ok := middleware.GetUserID(c)
+	userID, ok := middleware.GetDataOwnerID(c)
 	if !ok {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
 		return
 	}
-	expenses, err := h.service.ListExpenses(userID)
+
+	filter, err := parseExpenseFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	page, err := h.service.ListExpenses(c.Request.Context(), userID, filter)
 	if err != nil {
+		var ve *services.ValidationError
+		if errors.As(err, &ve) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ve.Message})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "支出の取得に失敗しました"})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"expenses": expenses})
+	c.JSON(http.StatusOK, gin.H{"expenses": page.Expenses, "next_cursor": page.NextCursor})
+}
+
+// expenseExportColumns は支出の書き出しの列です。
+var expenseExportColumns = []string{"id", "spent_at", "amount", "category_id", "category_name", "memo", "status"}
+
+// ExportExpenses handles GET /expenses/export.
+// 一覧と同じ絞り込み条件に一致するすべての支出を format（csv / json / xlsx）で書き出します。
+func (h *ExpenseHandler) ExportExpenses(c *gin.Context) {
+	userID, ok := middleware.GetDataOwnerID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	format, ok := export.ParseFormat(c.Query("format"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format は csv・json・xlsx のいずれかを指定してください"})
+		return
+	}
+
+	filter, err := parseExpenseFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	stream := newExportStream(c, format, "expenses", expenseExportColumns)
+	err = h.service.ExportExpenses(c.Request.Context(), userID, filter, func(e models.Expense) error {
+		return stream.writeRow([]any{
+			e.ID,
+			dateOnly(e.SpentAt),
+			e.Amount,
+			e.Category.ID,
+			e.Category.Name,
+			e.Memo,
+			e.Status,
+		})
+	})
+	if err == nil {
+		err = stream.close()
+	}
+	if err != nil {
+		// 送信開始後はステータスを変更できないため、出力を打ち切る
+		if stream.started() {
+			c.Abort()
+			return
+		}
+		var ve *services.ValidationError
+		if errors.As(err, &ve) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ve.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "支出の書き出しに失敗しました"})
+	}
+}
+
+// dateOnly は RFC3339 形式の日時から日付部分（YYYY-MM-DD）を返します。
+func dateOnly(s string) string {
+	if len(s) > len("2006-01-02") {
+		return s[:len("2006-01-02")]
+	}
+	return s
+}
+
+// parseExpenseFilter はクエリパラメータから一覧の絞り込み条件を組み立てます。
+// ここでは数値の形式のみを確認し、値の妥当性はサービス層で検証します。
+func parseExpenseFilter(c *gin.Context) (models.ExpenseFilter, error) {
+	filter := models.ExpenseFilter{
+		From:   c.Query("from"),
+		To:     c.Query("to"),
+		Status: c.Query("status"),
+		Memo:   c.Query("memo"),
+		Cursor: c.Query("cursor"),
+	}
+
+	// category_ids=1,2 と category_ids=1&category_ids=2 の両方を受け付ける（tag_ids も同じ）
+	var err error
+	if filter.CategoryIDs, err = parseIDList(c, "category_ids"); err != nil {
+		return filter, err
+	}
+	if filter.TagIDs, err = parseIDList(c, "tag_ids"); err != nil {
+		return filter, err
+	}
+
+	if v := c.Query("amount_min"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return filter, errors.New("amount_min の形式が正しくありません")
+		}
+		filter.AmountMin = &n
+	}
+	if v := c.Query("amount_max"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return filter, errors.New("amount_max の形式が正しくありません")
+		}
+		filter.AmountMax = &n
+	}
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return filter, errors.New("limit の形式が正しくありません")
+		}
+		filter.Limit = n
+	}
+
+	return filter, nil
+}
+
+// parseIDList はカンマ区切り・繰り返しのどちらで指定された ID の一覧も読み込みます。
+func parseIDList(c *gin.Context, key string) ([]int, error) {
+	var ids []int
+	for _, v := range c.QueryArray(key) {
+		for _, part := range strings.Split(v, ",") {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			id, err := strconv.Atoi(part)
+			if err != nil {
+				return nil, errors.New(key + " の形式が正しくありません")
+			}
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+// expenseImportMaxBytes は取り込む CSV ファイルの最大サイズ
+const expenseImportMaxBytes = 5 << 20
+
+// ImportExpenses handles POST /expenses/import.
+// multipart/form-data の file に CSV、mapping に列の対応（JSON）を指定します。
+// mode=preview（既定）は検証結果のみを返し、mode=commit は全行を登録します。
+func (h *ExpenseHandler) ImportExpenses(c *gin.Context) {
+	userID, ok := middleware.GetDataOwnerID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, expenseImportMaxBytes)
+
+	var commit bool
+	switch c.DefaultPostForm("mode", "preview") {
+	case "preview":
+	case "commit":
+		commit = true
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "mode は preview または commit を指定してください"})
+		return
+	}
+
+	var mapping models.ExpenseImportMapping
+	if err := json.Unmarshal([]byte(c.PostForm("mapping")), &mapping); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "mapping の形式が正しくありません"})
+		return
+	}
+
+	var defaultCategoryID *int
+	if v := c.PostForm("default_category_id"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "default_category_id の形式が正しくありません"})
+			return
+		}
+		defaultCategoryID = &n
+	}
+
+	fh, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "CSVファイルを指定してください"})
+		return
+	}
+	file, err := fh.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "CSVファイルを読み込めませんでした"})
+		return
+	}
+	defer file.Close()
+
+	createdBy, _ := middleware.GetUserID(c)
+	result, err := h.service.ImportExpenses(c.Request.Context(), userID, createdBy, file, mapping, defaultCategoryID, commit)
+	if err != nil {
+		var ve *services.ValidationError
+		if errors.As(err, &ve) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ve.Message})
+			return
+		}
+		if errors.Is(err, services.ErrImportHasRejectedRows) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "取り込めない行があるため登録できません", "import": result})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "支出の取り込みに失敗しました"})
+		return
+	}
+
+	status := http.StatusOK
+	if result.Committed {
+		status = http.StatusCreated
+	}
+	c.JSON(status, gin.H{"import": result})
+}
+
+// BatchExpenses handles POST /expenses/batch.
+// operations の登録・更新・削除・確定を1つのトランザクションで実行し、操作ごとの結果を返します。
+// mode=atomic（既定）は1件でも失敗すると何も登録せず 422、mode=best_effort は成功した操作のみを登録します。
+func (h *ExpenseHandler) BatchExpenses(c *gin.Context) {
+	var input models.ExpenseBatchInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userID, ok := middleware.GetDataOwnerID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+	// 世帯の家計簿では登録したメンバーを記録する
+	createdBy, _ := middleware.GetUserID(c)
+	result, err := h.service.BatchExpenses(c.Request.Context(), userID, createdBy, input)
+	if err != nil {
+		var ve *services.ValidationError
+		if errors.As(err, &ve) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ve.Message})
+			return
+		}
+		if errors.Is(err, services.ErrBatchHasFailedOperations) {
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "失敗した操作があるため、すべての操作を取り消しました", "batch": result})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "支出の一括操作に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"batch": result})
 }
 
 func (h *ExpenseHandler) DeleteExpense(c *gin.Context) {
@@ -74,12 +371,12 @@ func (h *ExpenseHandler) DeleteExpense(c *gin.Context) {
 		return
 	}
 
-	userID, ok := middleware.GetUserID(c)
+	userID, ok := middleware.GetDataOwnerID(c)
 	if !ok {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
 		return
 	}
-	err = h.service.DeleteExpense(userID, int(id))
+	err = h.service.DeleteExpense(c.Request.Context(), userID, int(id))
 	if err != nil {
 		var ve *services.ValidationError
 		if errors.As(err, &ve) {
@@ -93,6 +390,35 @@ func (h *ExpenseHandler) DeleteExpense(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// RestoreExpense handles POST /expenses/:id/restore to restore an expense from the trash.
+func (h *ExpenseHandler) RestoreExpense(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "支出IDが正しくありません"})
+		return
+	}
+
+	userID, ok := middleware.GetDataOwnerID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	expense, err := h.service.RestoreExpense(c.Request.Context(), userID, id)
+	if err != nil {
+		var ne *services.NotFoundError
+		if errors.As(err, &ne) {
+			c.JSON(http.StatusNotFound, gin.H{"error": ne.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "サーバーエラーが発生しました"})
+		return
+	}
+
+	setETag(c, expense.Version)
+	c.JSON(http.StatusOK, gin.H{"expense": expense})
+}
+
 // UpdateExpense handles PUT /expenses/:id to update an expense.
 func (h *ExpenseHandler) UpdateExpense(c *gin.Context) {
 	// Path param ID
@@ -102,13 +428,22 @@ func (h *ExpenseHandler) UpdateExpense(c *gin.Context) {
 		return
 	}
 
+	// If-Match carries the version the client last saw
+	version, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
 	// Bind JSON body without ID (ID comes from path)
+	// Items and Tags are pointers so that omitting them keeps the current line items / tags
 	type updateBody struct {
-		Amount     *int   `json:"amount"`
-		CategoryID *int   `json:"category_id"`
-		Memo       string `json:"memo"`
-		SpentAt    string `json:"spent_at"`
-		Status     string `json:"status"`
+		Amount     *int                       `json:"amount"`
+		CategoryID *int                       `json:"category_id"`
+		Memo       string                     `json:"memo"`
+		SpentAt    string                     `json:"spent_at"`
+		Status     string                     `json:"status"`
+		Items      *[]models.ExpenseItemInput `json:"items"`
+		Tags       *[]string                  `json:"tags"`
 	}
 	var body updateBody
 	if err := c.ShouldBindJSON(&body); err != nil {
@@ -124,30 +459,109 @@ func (h *ExpenseHandler) UpdateExpense(c *gin.Context) {
 		Memo:       body.Memo,
 		SpentAt:    body.SpentAt,
 		Status:     body.Status,
+		Version:    version,
+		Items:      body.Items,
+		Tags:       body.Tags,
 	}
 
-	userID, ok := middleware.GetUserID(c)
+	userID, ok := middleware.GetDataOwnerID(c)
 	if !ok {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
 		return
 	}
-	exp, err := h.service.UpdateExpense(userID, input)
+	if input.Version, ok = h.resolveIfMatchAny(c, userID, input.ID, input.Version); !ok {
+		return
+	}
+	exp, err := h.service.UpdateExpense(c.Request.Context(), userID, input)
 	if err != nil {
-		// Validation errors -> 400
-		var ve *services.ValidationError
-		if errors.As(err, &ve) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": ve.Message})
-			return
-		}
-		// Status transition error -> 409
-		if errors.Is(err, services.ErrInvalidStatusTransition) {
-			c.JSON(http.StatusConflict, gin.H{"error": "ステータスの変更ができません（確定済みは予定に戻せません）"})
-			return
-		}
-		// Others -> 500
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "サーバーエラーが発生しました"})
+		writeUpdateExpenseError(c, exp, err)
+		return
+	}
+
+	setETag(c, exp.Version)
+	c.JSON(http.StatusOK, gin.H{"expense": exp})
+}
+
+// PatchExpense handles PATCH /expenses/:id with JSON Merge Patch semantics:
+// absent fields are left untouched and a null memo clears it.
+func (h *ExpenseHandler) PatchExpense(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "支出IDが正しくありません"})
+		return
+	}
+
+	version, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+
+	var input models.PatchExpenseInput
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	input.ID = id
+	input.Version = version
+
+	userID, ok := middleware.GetDataOwnerID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+	if input.Version, ok = h.resolveIfMatchAny(c, userID, input.ID, input.Version); !ok {
+		return
+	}
+	exp, err := h.service.PatchExpense(c.Request.Context(), userID, input)
+	if err != nil {
+		writeUpdateExpenseError(c, exp, err)
 		return
 	}
 
+	setETag(c, exp.Version)
 	c.JSON(http.StatusOK, gin.H{"expense": exp})
 }
+
+// resolveIfMatchAny returns the current version of the expense when If-Match is "*",
+// and version unchanged otherwise. On failure it writes the error response and returns false.
+func (h *ExpenseHandler) resolveIfMatchAny(c *gin.Context, userID string, id int, version int) (int, bool) {
+	if version != ifMatchAny {
+		return version, true
+	}
+	current, err := h.service.GetExpense(c.Request.Context(), userID, id)
+	if err != nil {
+		writeUpdateExpenseError(c, current, err)
+		return 0, false
+	}
+	return current.Version, true
+}
+
+// writeUpdateExpenseError maps errors from UpdateExpense / PatchExpense to responses.
+// exp is the current state returned together with ErrVersionConflict.
+func writeUpdateExpenseError(c *gin.Context, exp models.Expense, err error) {
+	// Validation errors -> 400
+	var ve *services.ValidationError
+	if errors.As(err, &ve) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": ve.Message})
+		return
+	}
+	// Missing expense -> 404
+	var ne *services.NotFoundError
+	if errors.As(err, &ne) {
+		c.JSON(http.StatusNotFound, gin.H{"error": ne.Message})
+		return
+	}
+	// Status transition error -> 409
+	if errors.Is(err, services.ErrInvalidStatusTransition) {
+		c.JSON(http.StatusConflict, gin.H{"error": "このステータスには変更できません"})
+		return
+	}
+	// Version mismatch -> 412 with the current state
+	if errors.Is(err, services.ErrVersionConflict) {
+		setETag(c, exp.Version)
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": "他の端末で更新されています。最新の内容を確認してください", "expense": exp})
+		return
+	}
+	// Others -> 500
+	c.JSON(http.StatusInternalServerError, gin.H{"error": "サーバーエラーが発生しました"})
+}
@@ -1,9 +1,13 @@
 package handlers
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -12,6 +16,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/require"
 
+	"money-buddy-backend/internal/middleware"
 	"money-buddy-backend/internal/models"
 	"money-buddy-backend/internal/services"
 )
@@ -20,35 +25,77 @@ import (
 // with configurable function fields for each method.
 type expenseServiceMock struct {
 	CreateExpenseFunc func(userID string, input models.CreateExpenseInput) (models.Expense, error)
-	ListExpensesFunc  func(userID string) ([]models.Expense, error)
+	ListExpensesFunc  func(userID string, filter models.ExpenseFilter) (models.ExpensePage, error)
+	ExportExpensesFunc func(userID string, filter models.ExpenseFilter, fn func(models.Expense) error) error
 	DeleteExpenseFunc func(userID string, id int) error
+	RestoreExpenseFunc func(userID string, id int) (models.Expense, error)
+	GetExpenseFunc    func(userID string, id int) (models.Expense, error)
 	UpdateExpenseFunc func(userID string, input models.UpdateExpenseInput) (models.Expense, error)
+	PatchExpenseFunc  func(userID string, input models.PatchExpenseInput) (models.Expense, error)
+	ImportExpensesFunc func(ctx context.Context, userID string, r io.Reader, mapping models.ExpenseImportMapping, defaultCategoryID *int, commit bool) (models.ExpenseImportResult, error)
+	BatchExpensesFunc  func(userID string, createdBy string, input models.ExpenseBatchInput) (models.ExpenseBatchResult, error)
 }
 
-func (m *expenseServiceMock) CreateExpense(userID string, input models.CreateExpenseInput) (models.Expense, error) {
+func (m *expenseServiceMock) CreateExpense(ctx context.Context, userID string, input models.CreateExpenseInput) (models.Expense, error) {
 	if m.CreateExpenseFunc != nil {
 		return m.CreateExpenseFunc(userID, input)
 	}
 	return models.Expense{}, nil
 }
-func (m *expenseServiceMock) ListExpenses(userID string) ([]models.Expense, error) {
+func (m *expenseServiceMock) ListExpenses(ctx context.Context, userID string, filter models.ExpenseFilter) (models.ExpensePage, error) {
 	if m.ListExpensesFunc != nil {
-		return m.ListExpensesFunc(userID)
+		return m.ListExpensesFunc(userID, filter)
 	}
-	return nil, nil
+	return models.ExpensePage{}, nil
 }
-func (m *expenseServiceMock) DeleteExpense(userID string, id int) error {
+func (m *expenseServiceMock) ExportExpenses(ctx context.Context, userID string, filter models.ExpenseFilter, fn func(models.Expense) error) error {
+	if m.ExportExpensesFunc != nil {
+		return m.ExportExpensesFunc(userID, filter, fn)
+	}
+	return nil
+}
+func (m *expenseServiceMock) DeleteExpense(ctx context.Context, userID string, id int) error {
 	if m.DeleteExpenseFunc != nil {
 		return m.DeleteExpenseFunc(userID, id)
 	}
 	return nil
 }
-func (m *expenseServiceMock) UpdateExpense(userID string, input models.UpdateExpenseInput) (models.Expense, error) {
+func (m *expenseServiceMock) RestoreExpense(ctx context.Context, userID string, id int) (models.Expense, error) {
+	if m.RestoreExpenseFunc != nil {
+		return m.RestoreExpenseFunc(userID, id)
+	}
+	return models.Expense{}, nil
+}
+func (m *expenseServiceMock) GetExpense(ctx context.Context, userID string, id int) (models.Expense, error) {
+	if m.GetExpenseFunc != nil {
+		return m.GetExpenseFunc(userID, id)
+	}
+	return models.Expense{}, nil
+}
+func (m *expenseServiceMock) UpdateExpense(ctx context.Context, userID string, input models.UpdateExpenseInput) (models.Expense, error) {
 	if m.UpdateExpenseFunc != nil {
 		return m.UpdateExpenseFunc(userID, input)
 	}
 	return models.Expense{}, nil
 }
+func (m *expenseServiceMock) PatchExpense(ctx context.Context, userID string, input models.PatchExpenseInput) (models.Expense, error) {
+	if m.PatchExpenseFunc != nil {
+		return m.PatchExpenseFunc(userID, input)
+	}
+	return models.Expense{}, nil
+}
+func (m *expenseServiceMock) ImportExpenses(ctx context.Context, userID string, createdBy string, r io.Reader, mapping models.ExpenseImportMapping, defaultCategoryID *int, commit bool) (models.ExpenseImportResult, error) {
+	if m.ImportExpensesFunc != nil {
+		return m.ImportExpensesFunc(ctx, userID, r, mapping, defaultCategoryID, commit)
+	}
+	return models.ExpenseImportResult{}, nil
+}
+func (m *expenseServiceMock) BatchExpenses(ctx context.Context, userID string, createdBy string, input models.ExpenseBatchInput) (models.ExpenseBatchResult, error) {
+	if m.BatchExpensesFunc != nil {
+		return m.BatchExpensesFunc(userID, createdBy, input)
+	}
+	return models.ExpenseBatchResult{}, nil
+}
 
 func TestCreateExpenseHandler_Created(t *testing.T) {
 	gin.SetMode(gin.TestMode)
@@ -212,59 +259,131 @@ type mockExpenseServiceUpdateSuccess struct {
 	ret models.Expense
 }
 
-func (m *mockExpenseServiceUpdateSuccess) CreateExpense(userID string, input models.CreateExpenseInput) (models.Expense, error) {
+func (m *mockExpenseServiceUpdateSuccess) CreateExpense(ctx context.Context, userID string, input models.CreateExpenseInput) (models.Expense, error) {
 	return models.Expense{}, nil
 }
-func (m *mockExpenseServiceUpdateSuccess) ListExpenses(userID string) ([]models.Expense, error) {
-	return nil, nil
+func (m *mockExpenseServiceUpdateSuccess) ListExpenses(ctx context.Context, userID string, filter models.ExpenseFilter) (models.ExpensePage, error) {
+	return models.ExpensePage{}, nil
 }
-func (m *mockExpenseServiceUpdateSuccess) DeleteExpense(userID string, id int) error { return nil }
-func (m *mockExpenseServiceUpdateSuccess) UpdateExpense(userID string, input models.UpdateExpenseInput) (models.Expense, error) {
+func (m *mockExpenseServiceUpdateSuccess) DeleteExpense(ctx context.Context, userID string, id int) error { return nil }
+func (m *mockExpenseServiceUpdateSuccess) GetExpense(ctx context.Context, userID string, id int) (models.Expense, error) {
+	return models.Expense{}, nil
+}
+func (m *mockExpenseServiceUpdateSuccess) UpdateExpense(ctx context.Context, userID string, input models.UpdateExpenseInput) (models.Expense, error) {
 	return m.ret, nil
 }
+func (m *mockExpenseServiceUpdateSuccess) RestoreExpense(ctx context.Context, userID string, id int) (models.Expense, error) {
+	return models.Expense{}, nil
+}
+func (m *mockExpenseServiceUpdateSuccess) PatchExpense(ctx context.Context, userID string, input models.PatchExpenseInput) (models.Expense, error) {
+	return models.Expense{}, nil
+}
+func (m *mockExpenseServiceUpdateSuccess) ExportExpenses(ctx context.Context, userID string, filter models.ExpenseFilter, fn func(models.Expense) error) error {
+	return nil
+}
+func (m *mockExpenseServiceUpdateSuccess) ImportExpenses(ctx context.Context, userID string, createdBy string, r io.Reader, mapping models.ExpenseImportMapping, defaultCategoryID *int, commit bool) (models.ExpenseImportResult, error) {
+	return models.ExpenseImportResult{}, nil
+}
+func (m *mockExpenseServiceUpdateSuccess) BatchExpenses(ctx context.Context, userID string, createdBy string, input models.ExpenseBatchInput) (models.ExpenseBatchResult, error) {
+	return models.ExpenseBatchResult{}, nil
+}
 
 type mockExpenseServiceUpdateValidationErr struct{ msg string }
 
-func (m *mockExpenseServiceUpdateValidationErr) CreateExpense(userID string, input models.CreateExpenseInput) (models.Expense, error) {
+func (m *mockExpenseServiceUpdateValidationErr) CreateExpense(ctx context.Context, userID string, input models.CreateExpenseInput) (models.Expense, error) {
 	return models.Expense{}, nil
 }
-func (m *mockExpenseServiceUpdateValidationErr) ListExpenses(userID string) ([]models.Expense, error) {
-	return nil, nil
+func (m *mockExpenseServiceUpdateValidationErr) ListExpenses(ctx context.Context, userID string, filter models.ExpenseFilter) (models.ExpensePage, error) {
+	return models.ExpensePage{}, nil
 }
-func (m *mockExpenseServiceUpdateValidationErr) DeleteExpense(userID string, id int) error {
+func (m *mockExpenseServiceUpdateValidationErr) DeleteExpense(ctx context.Context, userID string, id int) error {
 	return nil
 }
-func (m *mockExpenseServiceUpdateValidationErr) UpdateExpense(userID string, input models.UpdateExpenseInput) (models.Expense, error) {
+func (m *mockExpenseServiceUpdateValidationErr) GetExpense(ctx context.Context, userID string, id int) (models.Expense, error) {
+	return models.Expense{}, nil
+}
+func (m *mockExpenseServiceUpdateValidationErr) UpdateExpense(ctx context.Context, userID string, input models.UpdateExpenseInput) (models.Expense, error) {
 	return models.Expense{}, &services.ValidationError{Message: m.msg}
 }
+func (m *mockExpenseServiceUpdateValidationErr) RestoreExpense(ctx context.Context, userID string, id int) (models.Expense, error) {
+	return models.Expense{}, nil
+}
+func (m *mockExpenseServiceUpdateValidationErr) PatchExpense(ctx context.Context, userID string, input models.PatchExpenseInput) (models.Expense, error) {
+	return models.Expense{}, nil
+}
+func (m *mockExpenseServiceUpdateValidationErr) ExportExpenses(ctx context.Context, userID string, filter models.ExpenseFilter, fn func(models.Expense) error) error {
+	return nil
+}
+func (m *mockExpenseServiceUpdateValidationErr) ImportExpenses(ctx context.Context, userID string, createdBy string, r io.Reader, mapping models.ExpenseImportMapping, defaultCategoryID *int, commit bool) (models.ExpenseImportResult, error) {
+	return models.ExpenseImportResult{}, nil
+}
+func (m *mockExpenseServiceUpdateValidationErr) BatchExpenses(ctx context.Context, userID string, createdBy string, input models.ExpenseBatchInput) (models.ExpenseBatchResult, error) {
+	return models.ExpenseBatchResult{}, nil
+}
 
 type mockExpenseServiceUpdateTransitionErr struct{}
 
-func (m *mockExpenseServiceUpdateTransitionErr) CreateExpense(userID string, input models.CreateExpenseInput) (models.Expense, error) {
+func (m *mockExpenseServiceUpdateTransitionErr) CreateExpense(ctx context.Context, userID string, input models.CreateExpenseInput) (models.Expense, error) {
 	return models.Expense{}, nil
 }
-func (m *mockExpenseServiceUpdateTransitionErr) ListExpenses(userID string) ([]models.Expense, error) {
-	return nil, nil
+func (m *mockExpenseServiceUpdateTransitionErr) ListExpenses(ctx context.Context, userID string, filter models.ExpenseFilter) (models.ExpensePage, error) {
+	return models.ExpensePage{}, nil
 }
-func (m *mockExpenseServiceUpdateTransitionErr) DeleteExpense(userID string, id int) error {
+func (m *mockExpenseServiceUpdateTransitionErr) DeleteExpense(ctx context.Context, userID string, id int) error {
 	return nil
 }
-func (m *mockExpenseServiceUpdateTransitionErr) UpdateExpense(userID string, input models.UpdateExpenseInput) (models.Expense, error) {
+func (m *mockExpenseServiceUpdateTransitionErr) GetExpense(ctx context.Context, userID string, id int) (models.Expense, error) {
+	return models.Expense{}, nil
+}
+func (m *mockExpenseServiceUpdateTransitionErr) UpdateExpense(ctx context.Context, userID string, input models.UpdateExpenseInput) (models.Expense, error) {
 	return models.Expense{}, services.ErrInvalidStatusTransition
 }
+func (m *mockExpenseServiceUpdateTransitionErr) RestoreExpense(ctx context.Context, userID string, id int) (models.Expense, error) {
+	return models.Expense{}, nil
+}
+func (m *mockExpenseServiceUpdateTransitionErr) PatchExpense(ctx context.Context, userID string, input models.PatchExpenseInput) (models.Expense, error) {
+	return models.Expense{}, nil
+}
+func (m *mockExpenseServiceUpdateTransitionErr) ExportExpenses(ctx context.Context, userID string, filter models.ExpenseFilter, fn func(models.Expense) error) error {
+	return nil
+}
+func (m *mockExpenseServiceUpdateTransitionErr) ImportExpenses(ctx context.Context, userID string, createdBy string, r io.Reader, mapping models.ExpenseImportMapping, defaultCategoryID *int, commit bool) (models.ExpenseImportResult, error) {
+	return models.ExpenseImportResult{}, nil
+}
+func (m *mockExpenseServiceUpdateTransitionErr) BatchExpenses(ctx context.Context, userID string, createdBy string, input models.ExpenseBatchInput) (models.ExpenseBatchResult, error) {
+	return models.ExpenseBatchResult{}, nil
+}
 
 type mockExpenseServiceUpdateInternalErr struct{ err error }
 
-func (m *mockExpenseServiceUpdateInternalErr) CreateExpense(userID string, input models.CreateExpenseInput) (models.Expense, error) {
+func (m *mockExpenseServiceUpdateInternalErr) CreateExpense(ctx context.Context, userID string, input models.CreateExpenseInput) (models.Expense, error) {
 	return models.Expense{}, nil
 }
-func (m *mockExpenseServiceUpdateInternalErr) ListExpenses(userID string) ([]models.Expense, error) {
-	return nil, nil
+func (m *mockExpenseServiceUpdateInternalErr) ListExpenses(ctx context.Context, userID string, filter models.ExpenseFilter) (models.ExpensePage, error) {
+	return models.ExpensePage{}, nil
 }
-func (m *mockExpenseServiceUpdateInternalErr) DeleteExpense(userID string, id int) error { return nil }
-func (m *mockExpenseServiceUpdateInternalErr) UpdateExpense(userID string, input models.UpdateExpenseInput) (models.Expense, error) {
+func (m *mockExpenseServiceUpdateInternalErr) DeleteExpense(ctx context.Context, userID string, id int) error { return nil }
+func (m *mockExpenseServiceUpdateInternalErr) GetExpense(ctx context.Context, userID string, id int) (models.Expense, error) {
+	return models.Expense{}, nil
+}
+func (m *mockExpenseServiceUpdateInternalErr) UpdateExpense(ctx context.Context, userID string, input models.UpdateExpenseInput) (models.Expense, error) {
 	return models.Expense{}, m.err
 }
+func (m *mockExpenseServiceUpdateInternalErr) RestoreExpense(ctx context.Context, userID string, id int) (models.Expense, error) {
+	return models.Expense{}, nil
+}
+func (m *mockExpenseServiceUpdateInternalErr) PatchExpense(ctx context.Context, userID string, input models.PatchExpenseInput) (models.Expense, error) {
+	return models.Expense{}, nil
+}
+func (m *mockExpenseServiceUpdateInternalErr) ExportExpenses(ctx context.Context, userID string, filter models.ExpenseFilter, fn func(models.Expense) error) error {
+	return nil
+}
+func (m *mockExpenseServiceUpdateInternalErr) ImportExpenses(ctx context.Context, userID string, createdBy string, r io.Reader, mapping models.ExpenseImportMapping, defaultCategoryID *int, commit bool) (models.ExpenseImportResult, error) {
+	return models.ExpenseImportResult{}, nil
+}
+func (m *mockExpenseServiceUpdateInternalErr) BatchExpenses(ctx context.Context, userID string, createdBy string, input models.ExpenseBatchInput) (models.ExpenseBatchResult, error) {
+	return models.ExpenseBatchResult{}, nil
+}
 
 func TestUpdateExpenseHandler_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
@@ -276,6 +395,7 @@ func TestUpdateExpenseHandler_Success(t *testing.T) {
 
 	body := `{"amount":700,"category_id":5,"memo":"updated","spent_at":"2025-07-01","status":"confirmed"}`
 	req := httptest.NewRequest(http.MethodPut, "/expenses/42", strings.NewReader(body))
+	req.Header.Set("If-Match", `"1"`)
 	req.Header.Set("Content-Type", "application/json")
 
 	w := httptest.NewRecorder()
@@ -312,6 +432,7 @@ func TestUpdateExpenseHandler_ValidationError(t *testing.T) {
 
 		body := fmt.Sprintf(`{"amount":%d,"category_id":2,"memo":"x","spent_at":"2025-01-01"}`, amount)
 		req := httptest.NewRequest(http.MethodPut, "/expenses/1", strings.NewReader(body))
+		req.Header.Set("If-Match", `"1"`)
 		req.Header.Set("Content-Type", "application/json")
 
 		w := httptest.NewRecorder()
@@ -344,6 +465,7 @@ func TestUpdateExpenseHandler_ValidationError(t *testing.T) {
 
 		body := fmt.Sprintf(`{"amount":%d,"category_id":2,"memo":"x","spent_at":"2025-01-01"}`, amount)
 		req := httptest.NewRequest(http.MethodPut, "/expenses/1", strings.NewReader(body))
+		req.Header.Set("If-Match", `"1"`)
 		req.Header.Set("Content-Type", "application/json")
 
 		w := httptest.NewRecorder()
@@ -376,6 +498,7 @@ func TestUpdateExpenseHandler_ValidationError(t *testing.T) {
 
 		body := fmt.Sprintf(`{"amount":%d,"category_id":2,"memo":"x","spent_at":"2025-01-01"}`, amount)
 		req := httptest.NewRequest(http.MethodPut, "/expenses/1", strings.NewReader(body))
+		req.Header.Set("If-Match", `"1"`)
 		req.Header.Set("Content-Type", "application/json")
 
 		w := httptest.NewRecorder()
@@ -400,6 +523,7 @@ func TestUpdateExpenseHandler_StatusTransitionConflict(t *testing.T) {
 
 	body := `{"amount":100,"category_id":1,"memo":"x","spent_at":"2025-01-01","status":"planned"}`
 	req := httptest.NewRequest(http.MethodPut, "/expenses/1", strings.NewReader(body))
+	req.Header.Set("If-Match", `"1"`)
 	req.Header.Set("Content-Type", "application/json")
 
 	w := httptest.NewRecorder()
@@ -408,7 +532,7 @@ func TestUpdateExpenseHandler_StatusTransitionConflict(t *testing.T) {
 	require.Equal(t, http.StatusConflict, w.Code)
 	var resp map[string]string
 	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
-	require.Equal(t, "ステータスの変更ができません（確定済みは予定に戻せません）", resp["error"])
+	require.Equal(t, "このステータスには変更できません", resp["error"])
 }
 
 func TestUpdateExpenseHandler_InternalError(t *testing.T) {
@@ -420,6 +544,7 @@ func TestUpdateExpenseHandler_InternalError(t *testing.T) {
 
 	body := `{"amount":100,"category_id":1,"memo":"x","spent_at":"2025-01-01"}`
 	req := httptest.NewRequest(http.MethodPut, "/expenses/1", strings.NewReader(body))
+	req.Header.Set("If-Match", `"1"`)
 	req.Header.Set("Content-Type", "application/json")
 
 	w := httptest.NewRecorder()
@@ -440,6 +565,7 @@ func TestUpdateExpenseHandler_InvalidID(t *testing.T) {
 
 	body := `{"amount":100,"category_id":1,"memo":"x","spent_at":"2025-01-01"}`
 	req := httptest.NewRequest(http.MethodPut, "/expenses/abc", strings.NewReader(body))
+	req.Header.Set("If-Match", `"1"`)
 	req.Header.Set("Content-Type", "application/json")
 
 	w := httptest.NewRecorder()
@@ -623,6 +749,7 @@ func TestUpdateExpenseHandler_Cases(t *testing.T) {
 			NewExpenseHandler(router, svc)
 
 			req := httptest.NewRequest(http.MethodPut, tc.path, strings.NewReader(tc.body))
+			req.Header.Set("If-Match", `"1"`)
 			req.Header.Set("Content-Type", "application/json")
 			w := httptest.NewRecorder()
 			router.ServeHTTP(w, req)
@@ -634,3 +761,715 @@ func TestUpdateExpenseHandler_Cases(t *testing.T) {
 		})
 	}
 }
+
+// newAuthedRouter は認証ミドルウェア通過後と同様に DummyUserID をコンテキストへ設定したルーターを返します。
+func newAuthedRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(string(middleware.UserIDKey), DummyUserID)
+		c.Next()
+	})
+	return router
+}
+
+// --- GET /expenses handler tests ---
+
+func TestListExpensesHandler_ParsesFilter(t *testing.T) {
+	router := newAuthedRouter()
+
+	var got models.ExpenseFilter
+	cursor := "next-page"
+	svc := &expenseServiceMock{
+		ListExpensesFunc: func(userID string, filter models.ExpenseFilter) (models.ExpensePage, error) {
+			require.Equal(t, DummyUserID, userID)
+			got = filter
+			return models.ExpensePage{
+				Expenses:   []models.Expense{{ID: 1, Amount: 1000}},
+				NextCursor: &cursor,
+			}, nil
+		},
+	}
+	NewExpenseHandler(router, svc)
+
+	path := "/expenses?from=2025-01-01&to=2025-01-31&category_ids=1,2&category_ids=3&tag_ids=7,8&status=planned" +
+		"&amount_min=100&amount_max=5000&memo=lunch&cursor=abc&limit=20"
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "2025-01-01", got.From)
+	require.Equal(t, "2025-01-31", got.To)
+	require.Equal(t, []int{1, 2, 3}, got.CategoryIDs)
+	require.Equal(t, []int{7, 8}, got.TagIDs)
+	require.Equal(t, "planned", got.Status)
+	require.Equal(t, 100, *got.AmountMin)
+	require.Equal(t, 5000, *got.AmountMax)
+	require.Equal(t, "lunch", got.Memo)
+	require.Equal(t, "abc", got.Cursor)
+	require.Equal(t, 20, got.Limit)
+
+	var resp models.ExpensePage
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Len(t, resp.Expenses, 1)
+	require.NotNil(t, resp.NextCursor)
+	require.Equal(t, "next-page", *resp.NextCursor)
+}
+
+func TestListExpensesHandler_Errors(t *testing.T) {
+	cases := []struct {
+		name       string
+		path       string
+		svcErr     error
+		wantStatus int
+		wantCalled bool
+	}{
+		{name: "category_ids が数値でない", path: "/expenses?category_ids=a", wantStatus: http.StatusBadRequest},
+		{name: "tag_ids が数値でない", path: "/expenses?tag_ids=1,x", wantStatus: http.StatusBadRequest},
+		{name: "amount_min が数値でない", path: "/expenses?amount_min=x", wantStatus: http.StatusBadRequest},
+		{name: "limit が数値でない", path: "/expenses?limit=ten", wantStatus: http.StatusBadRequest},
+		{name: "サービスのバリデーションエラー", path: "/expenses?from=bad", svcErr: &services.ValidationError{Message: "開始日の形式が正しくありません"}, wantStatus: http.StatusBadRequest, wantCalled: true},
+		{name: "内部エラー", path: "/expenses", svcErr: errors.New("db down"), wantStatus: http.StatusInternalServerError, wantCalled: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := newAuthedRouter()
+			called := false
+			svc := &expenseServiceMock{
+				ListExpensesFunc: func(userID string, filter models.ExpenseFilter) (models.ExpensePage, error) {
+					called = true
+					return models.ExpensePage{}, tc.svcErr
+				},
+			}
+			NewExpenseHandler(router, svc)
+
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tc.wantStatus, w.Code)
+			require.Equal(t, tc.wantCalled, called)
+		})
+	}
+}
+
+// --- POST /expenses/import handler tests ---
+
+func newImportRequest(t *testing.T, fields map[string]string, csvData string) *http.Request {
+	t.Helper()
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	for k, v := range fields {
+		require.NoError(t, mw.WriteField(k, v))
+	}
+	if csvData != "" {
+		fw, err := mw.CreateFormFile("file", "expenses.csv")
+		require.NoError(t, err)
+		_, err = fw.Write([]byte(csvData))
+		require.NoError(t, err)
+	}
+	require.NoError(t, mw.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/expenses/import", &body)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	return req
+}
+
+func TestImportExpensesHandler(t *testing.T) {
+	mapping := `{"date":"日付","amount":"金額","memo":"内容","category":"カテゴリ"}`
+
+	cases := []struct {
+		name       string
+		fields     map[string]string
+		csv        string
+		result     models.ExpenseImportResult
+		svcErr     error
+		wantStatus int
+		wantCalled bool
+		wantCommit bool
+	}{
+		{name: "プレビュー", fields: map[string]string{"mapping": mapping}, csv: "日付,金額\n", result: models.ExpenseImportResult{Total: 1, Accepted: 1}, wantStatus: http.StatusOK, wantCalled: true},
+		{name: "登録", fields: map[string]string{"mapping": mapping, "mode": "commit"}, csv: "日付,金額\n", result: models.ExpenseImportResult{Committed: true, Total: 1, Accepted: 1}, wantStatus: http.StatusCreated, wantCalled: true, wantCommit: true},
+		{name: "取り込めない行がある", fields: map[string]string{"mapping": mapping, "mode": "commit"}, csv: "日付,金額\n", result: models.ExpenseImportResult{Total: 1, Rejected: 1}, svcErr: services.ErrImportHasRejectedRows, wantStatus: http.StatusUnprocessableEntity, wantCalled: true, wantCommit: true},
+		{name: "サービスのバリデーションエラー", fields: map[string]string{"mapping": mapping}, csv: "日付,金額\n", svcErr: &services.ValidationError{Message: "CSVに列「内容」がありません"}, wantStatus: http.StatusBadRequest, wantCalled: true},
+		{name: "mode が不正", fields: map[string]string{"mapping": mapping, "mode": "dry"}, csv: "日付,金額\n", wantStatus: http.StatusBadRequest},
+		{name: "mapping が JSON でない", fields: map[string]string{"mapping": "date"}, csv: "日付,金額\n", wantStatus: http.StatusBadRequest},
+		{name: "ファイルがない", fields: map[string]string{"mapping": mapping}, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := newAuthedRouter()
+			called := false
+			var gotCommit bool
+			svc := &expenseServiceMock{
+				ImportExpensesFunc: func(ctx context.Context, userID string, r io.Reader, m models.ExpenseImportMapping, defaultCategoryID *int, commit bool) (models.ExpenseImportResult, error) {
+					called = true
+					gotCommit = commit
+					require.Equal(t, "日付", m.Date)
+					return tc.result, tc.svcErr
+				},
+			}
+			NewExpenseHandler(router, svc)
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, newImportRequest(t, tc.fields, tc.csv))
+
+			require.Equal(t, tc.wantStatus, w.Code)
+			require.Equal(t, tc.wantCalled, called)
+			require.Equal(t, tc.wantCommit, gotCommit)
+			if tc.wantStatus == http.StatusUnprocessableEntity {
+				var resp struct {
+					Import models.ExpenseImportResult `json:"import"`
+				}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				require.Equal(t, 1, resp.Import.Rejected)
+			}
+		})
+	}
+}
+
+// --- POST /expenses/batch handler tests ---
+
+func TestBatchExpensesHandler(t *testing.T) {
+	body := `{"mode":"best_effort","operations":[` +
+		`{"op":"create","expense":{"amount":1200,"category_id":1,"spent_at":"2025-01-03"}},` +
+		`{"op":"update","id":2,"version":3,"expense":{"memo":null}},` +
+		`{"op":"confirm","id":3,"version":1,"amount":4800},` +
+		`{"op":"delete","id":4}]}`
+
+	cases := []struct {
+		name       string
+		body       string
+		result     models.ExpenseBatchResult
+		svcErr     error
+		wantStatus int
+		wantCalled bool
+	}{
+		{name: "成功", body: body, result: models.ExpenseBatchResult{Committed: true, Total: 4, Succeeded: 4}, wantStatus: http.StatusOK, wantCalled: true},
+		{name: "atomic で失敗した操作がある", body: body, result: models.ExpenseBatchResult{Total: 4, Failed: 1}, svcErr: services.ErrBatchHasFailedOperations, wantStatus: http.StatusUnprocessableEntity, wantCalled: true},
+		{name: "サービスのバリデーションエラー", body: body, svcErr: &services.ValidationError{Message: "操作を1件以上指定してください"}, wantStatus: http.StatusBadRequest, wantCalled: true},
+		{name: "サービスの内部エラー", body: body, svcErr: &services.InternalError{Message: "internal error"}, wantStatus: http.StatusInternalServerError, wantCalled: true},
+		{name: "JSON が不正", body: `{"operations":`, wantStatus: http.StatusBadRequest},
+		{name: "delete に expense を指定", body: `{"operations":[{"op":"delete","id":1,"expense":{"amount":1}}]}`, wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := newAuthedRouter()
+			called := false
+			var got models.ExpenseBatchInput
+			svc := &expenseServiceMock{
+				BatchExpensesFunc: func(userID string, createdBy string, input models.ExpenseBatchInput) (models.ExpenseBatchResult, error) {
+					called = true
+					got = input
+					return tc.result, tc.svcErr
+				},
+			}
+			NewExpenseHandler(router, svc)
+
+			req := httptest.NewRequest(http.MethodPost, "/expenses/batch", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tc.wantStatus, w.Code)
+			require.Equal(t, tc.wantCalled, called)
+			if !called {
+				return
+			}
+
+			require.Equal(t, models.ExpenseBatchBestEffort, got.Mode)
+			require.Len(t, got.Operations, 4)
+			require.NotNil(t, got.Operations[0].Create)
+			require.Equal(t, 1200, *got.Operations[0].Create.Amount)
+			require.NotNil(t, got.Operations[1].Patch)
+			require.Equal(t, "", *got.Operations[1].Patch.Memo)
+			require.Equal(t, 3, got.Operations[1].Version)
+			require.Equal(t, 4800, *got.Operations[2].Amount)
+			require.Equal(t, models.ExpenseBatchDelete, got.Operations[3].Op)
+			if tc.wantStatus == http.StatusUnprocessableEntity {
+				var resp struct {
+					Batch models.ExpenseBatchResult `json:"batch"`
+				}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				require.Equal(t, 1, resp.Batch.Failed)
+			}
+		})
+	}
+}
+
+// --- GET /expenses/export handler tests ---
+
+func TestExportExpensesHandler_CSV(t *testing.T) {
+	router := newAuthedRouter()
+	var gotFilter models.ExpenseFilter
+	svc := &expenseServiceMock{
+		ExportExpensesFunc: func(userID string, filter models.ExpenseFilter, fn func(models.Expense) error) error {
+			gotFilter = filter
+			for _, e := range []models.Expense{
+				{ID: 2, Amount: 1200, Memo: "スーパー", SpentAt: "2025-01-03T00:00:00Z", Status: "confirmed", Category: models.Category{ID: 1, Name: "食費"}},
+				{ID: 1, Amount: 300, SpentAt: "2025-01-02T00:00:00Z", Status: "planned", Category: models.Category{ID: 2, Name: "日用品"}},
+			} {
+				if err := fn(e); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+	NewExpenseHandler(router, svc)
+
+	req := httptest.NewRequest(http.MethodGet, "/expenses/export?from=2025-01-01&category_ids=1,2", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	require.Equal(t, `attachment; filename="expenses.csv"`, w.Header().Get("Content-Disposition"))
+	require.Equal(t, "2025-01-01", gotFilter.From)
+	require.Equal(t, []int{1, 2}, gotFilter.CategoryIDs)
+	require.Equal(t, "\ufeffid,spent_at,amount,category_id,category_name,memo,status\n"+
+		"2,2025-01-03,1200,1,食費,スーパー,confirmed\n"+
+		"1,2025-01-02,300,2,日用品,,planned\n", w.Body.String())
+}
+
+func TestExportExpensesHandler_JSONEmpty(t *testing.T) {
+	router := newAuthedRouter()
+	NewExpenseHandler(router, &expenseServiceMock{})
+
+	req := httptest.NewRequest(http.MethodGet, "/expenses/export?format=json", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+	require.JSONEq(t, `[]`, w.Body.String())
+}
+
+func TestExportExpensesHandler_Errors(t *testing.T) {
+	cases := []struct {
+		name       string
+		path       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "format が不正", path: "/expenses/export?format=pdf", wantStatus: http.StatusBadRequest},
+		{name: "amount_min が数値でない", path: "/expenses/export?amount_min=x", wantStatus: http.StatusBadRequest},
+		{name: "サービスのバリデーションエラー", path: "/expenses/export?from=bad", svcErr: &services.ValidationError{Message: "開始日の形式が正しくありません"}, wantStatus: http.StatusBadRequest},
+		{name: "内部エラー", path: "/expenses/export", svcErr: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := newAuthedRouter()
+			svc := &expenseServiceMock{
+				ExportExpensesFunc: func(userID string, filter models.ExpenseFilter, fn func(models.Expense) error) error {
+					return tc.svcErr
+				},
+			}
+			NewExpenseHandler(router, svc)
+
+			req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tc.wantStatus, w.Code)
+			require.Contains(t, w.Header().Get("Content-Type"), "application/json")
+		})
+	}
+}
+
+// --- ETag / If-Match tests ---
+
+func TestGetExpenseHandler_ETag(t *testing.T) {
+	router := newAuthedRouter()
+	svc := &expenseServiceMock{
+		GetExpenseFunc: func(userID string, id int) (models.Expense, error) {
+			return models.Expense{ID: id, Amount: 500, Version: 2}, nil
+		},
+	}
+	NewExpenseHandler(router, svc)
+
+	req := httptest.NewRequest(http.MethodGet, "/expenses/7", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, `"2"`, w.Header().Get("ETag"))
+}
+
+func TestGetExpenseHandler_NotFound(t *testing.T) {
+	router := newAuthedRouter()
+	svc := &expenseServiceMock{
+		GetExpenseFunc: func(userID string, id int) (models.Expense, error) {
+			return models.Expense{}, &services.NotFoundError{Message: "支出が見つかりません"}
+		},
+	}
+	NewExpenseHandler(router, svc)
+
+	req := httptest.NewRequest(http.MethodGet, "/expenses/7", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestRestoreExpenseHandler(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		url        string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "ゴミ箱から復元できる", url: "/expenses/7/restore", wantStatus: http.StatusOK},
+		{name: "ゴミ箱にない支出は404", url: "/expenses/7/restore", svcErr: &services.NotFoundError{Message: "ゴミ箱に支出が見つかりません"}, wantStatus: http.StatusNotFound},
+		{name: "IDが数値でない場合は400", url: "/expenses/abc/restore", wantStatus: http.StatusBadRequest},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newAuthedRouter()
+			svc := &expenseServiceMock{
+				RestoreExpenseFunc: func(userID string, id int) (models.Expense, error) {
+					require.Equal(t, 7, id)
+					if tt.svcErr != nil {
+						return models.Expense{}, tt.svcErr
+					}
+					return models.Expense{ID: id, Amount: 500, Version: 3}, nil
+				},
+			}
+			NewExpenseHandler(router, svc)
+
+			req := httptest.NewRequest(http.MethodPost, tt.url, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				require.Equal(t, `"3"`, w.Header().Get("ETag"))
+			}
+		})
+	}
+}
+
+func TestUpdateExpenseHandler_Items(t *testing.T) {
+	cases := []struct {
+		name      string
+		body      string
+		wantNil   bool
+		wantItems int
+	}{
+		{name: "items を省略した場合は明細を変更しない", body: `{"amount":3000,"category_id":1,"spent_at":"2025-01-10"}`, wantNil: true},
+		{name: "items を指定した場合は明細を置き換える", body: `{"amount":3000,"category_id":1,"spent_at":"2025-01-10","items":[{"amount":2000,"category_id":1},{"amount":1000,"category_id":2,"memo":"ビール"}]}`, wantItems: 2},
+		{name: "items に空配列を指定した場合は明細を削除する", body: `{"amount":3000,"category_id":1,"spent_at":"2025-01-10","items":[]}`, wantItems: 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := newAuthedRouter()
+			var got models.UpdateExpenseInput
+			svc := &expenseServiceMock{
+				UpdateExpenseFunc: func(userID string, input models.UpdateExpenseInput) (models.Expense, error) {
+					got = input
+					return models.Expense{ID: 1, Version: 2}, nil
+				},
+			}
+			NewExpenseHandler(router, svc)
+
+			req := httptest.NewRequest(http.MethodPut, "/expenses/1", strings.NewReader(tc.body))
+			req.Header.Set("If-Match", `"1"`)
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+			if tc.wantNil {
+				require.Nil(t, got.Items)
+				return
+			}
+			require.NotNil(t, got.Items)
+			require.Len(t, *got.Items, tc.wantItems)
+		})
+	}
+}
+
+func TestUpdateExpenseHandler_Precondition(t *testing.T) {
+	body := `{"amount":700,"category_id":5,"memo":"updated","spent_at":"2025-07-01"}`
+
+	t.Run("If-Match がない場合は428", func(t *testing.T) {
+		router := newAuthedRouter()
+		called := false
+		svc := &expenseServiceMock{
+			UpdateExpenseFunc: func(userID string, input models.UpdateExpenseInput) (models.Expense, error) {
+				called = true
+				return models.Expense{}, nil
+			},
+		}
+		NewExpenseHandler(router, svc)
+
+		req := httptest.NewRequest(http.MethodPut, "/expenses/1", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusPreconditionRequired, w.Code)
+		require.False(t, called)
+	})
+
+	t.Run("If-Match のバージョンを渡し、成功時は新しい ETag を返す", func(t *testing.T) {
+		router := newAuthedRouter()
+		var got models.UpdateExpenseInput
+		svc := &expenseServiceMock{
+			UpdateExpenseFunc: func(userID string, input models.UpdateExpenseInput) (models.Expense, error) {
+				got = input
+				return models.Expense{ID: input.ID, Version: input.Version + 1}, nil
+			},
+		}
+		NewExpenseHandler(router, svc)
+
+		req := httptest.NewRequest(http.MethodPut, "/expenses/1", strings.NewReader(body))
+		req.Header.Set("If-Match", `"5"`)
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, 5, got.Version)
+		require.Equal(t, `"6"`, w.Header().Get("ETag"))
+	})
+
+	t.Run("弱い ETag は一致しないものとして扱う", func(t *testing.T) {
+		router := newAuthedRouter()
+		var got models.UpdateExpenseInput
+		svc := &expenseServiceMock{
+			UpdateExpenseFunc: func(userID string, input models.UpdateExpenseInput) (models.Expense, error) {
+				got = input
+				return models.Expense{ID: input.ID, Version: 5}, services.ErrVersionConflict
+			},
+		}
+		NewExpenseHandler(router, svc)
+
+		req := httptest.NewRequest(http.MethodPut, "/expenses/1", strings.NewReader(body))
+		req.Header.Set("If-Match", `W/"5"`)
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, 0, got.Version)
+		require.Equal(t, http.StatusPreconditionFailed, w.Code)
+	})
+
+	t.Run("If-Match: * は現在のバージョンで更新する", func(t *testing.T) {
+		router := newAuthedRouter()
+		var got models.UpdateExpenseInput
+		svc := &expenseServiceMock{
+			GetExpenseFunc: func(userID string, id int) (models.Expense, error) {
+				return models.Expense{ID: id, Version: 7}, nil
+			},
+			UpdateExpenseFunc: func(userID string, input models.UpdateExpenseInput) (models.Expense, error) {
+				got = input
+				return models.Expense{ID: input.ID, Version: input.Version + 1}, nil
+			},
+		}
+		NewExpenseHandler(router, svc)
+
+		req := httptest.NewRequest(http.MethodPut, "/expenses/1", strings.NewReader(body))
+		req.Header.Set("If-Match", "*")
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, 7, got.Version)
+		require.Equal(t, `"8"`, w.Header().Get("ETag"))
+	})
+
+	t.Run("If-Match: * で支出がない場合は404", func(t *testing.T) {
+		router := newAuthedRouter()
+		called := false
+		svc := &expenseServiceMock{
+			GetExpenseFunc: func(userID string, id int) (models.Expense, error) {
+				return models.Expense{}, &services.NotFoundError{Message: "支出が見つかりません"}
+			},
+			UpdateExpenseFunc: func(userID string, input models.UpdateExpenseInput) (models.Expense, error) {
+				called = true
+				return models.Expense{}, nil
+			},
+		}
+		NewExpenseHandler(router, svc)
+
+		req := httptest.NewRequest(http.MethodPut, "/expenses/1", strings.NewReader(body))
+		req.Header.Set("If-Match", "*")
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusNotFound, w.Code)
+		require.False(t, called)
+	})
+
+	t.Run("ETag の形式でない If-Match は400", func(t *testing.T) {
+		for _, ifMatch := range []string{"5", `"5`, `W/5`, `"5", "6"`} {
+			router := newAuthedRouter()
+			called := false
+			svc := &expenseServiceMock{
+				UpdateExpenseFunc: func(userID string, input models.UpdateExpenseInput) (models.Expense, error) {
+					called = true
+					return models.Expense{}, nil
+				},
+			}
+			NewExpenseHandler(router, svc)
+
+			req := httptest.NewRequest(http.MethodPut, "/expenses/1", strings.NewReader(body))
+			req.Header.Set("If-Match", ifMatch)
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusBadRequest, w.Code, ifMatch)
+			require.False(t, called, ifMatch)
+		}
+	})
+
+	t.Run("バージョン不一致の場合は412と現在の状態", func(t *testing.T) {
+		router := newAuthedRouter()
+		svc := &expenseServiceMock{
+			UpdateExpenseFunc: func(userID string, input models.UpdateExpenseInput) (models.Expense, error) {
+				return models.Expense{ID: input.ID, Amount: 900, Memo: "other device", Version: 3}, services.ErrVersionConflict
+			},
+		}
+		NewExpenseHandler(router, svc)
+
+		req := httptest.NewRequest(http.MethodPut, "/expenses/1", strings.NewReader(body))
+		req.Header.Set("If-Match", `"2"`)
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusPreconditionFailed, w.Code)
+		require.Equal(t, `"3"`, w.Header().Get("ETag"))
+
+		var resp struct {
+			Error   string         `json:"error"`
+			Expense models.Expense `json:"expense"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Equal(t, "他の端末で更新されています。最新の内容を確認してください", resp.Error)
+		require.Equal(t, 900, resp.Expense.Amount)
+		require.Equal(t, 3, resp.Expense.Version)
+	})
+}
+
+// --- PATCH /expenses/:id handler tests ---
+
+func TestPatchExpenseHandler(t *testing.T) {
+	patch := func(t *testing.T, svc *expenseServiceMock, body string, ifMatch string) *httptest.ResponseRecorder {
+		t.Helper()
+		router := newAuthedRouter()
+		NewExpenseHandler(router, svc)
+
+		req := httptest.NewRequest(http.MethodPatch, "/expenses/1", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/merge-patch+json")
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("指定した項目のみを渡す", func(t *testing.T) {
+		var got models.PatchExpenseInput
+		svc := &expenseServiceMock{
+			PatchExpenseFunc: func(userID string, input models.PatchExpenseInput) (models.Expense, error) {
+				got = input
+				return models.Expense{ID: input.ID, Status: "confirmed", Version: 4}, nil
+			},
+		}
+
+		w := patch(t, svc, `{"status":"confirmed"}`, `"3"`)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.Equal(t, `"4"`, w.Header().Get("ETag"))
+		require.Equal(t, 1, got.ID)
+		require.Equal(t, 3, got.Version)
+		require.NotNil(t, got.Status)
+		require.Equal(t, "confirmed", *got.Status)
+		require.Nil(t, got.Amount)
+		require.Nil(t, got.CategoryID)
+		require.Nil(t, got.Memo)
+		require.Nil(t, got.SpentAt)
+	})
+
+	t.Run("memo に null を指定するとメモを削除する", func(t *testing.T) {
+		var got models.PatchExpenseInput
+		svc := &expenseServiceMock{
+			PatchExpenseFunc: func(userID string, input models.PatchExpenseInput) (models.Expense, error) {
+				got = input
+				return models.Expense{ID: input.ID}, nil
+			},
+		}
+
+		w := patch(t, svc, `{"memo":null}`, `"1"`)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		require.NotNil(t, got.Memo)
+		require.Equal(t, "", *got.Memo)
+		require.Nil(t, got.Status)
+	})
+
+	t.Run("必須の項目に null を指定した場合は400", func(t *testing.T) {
+		called := false
+		svc := &expenseServiceMock{
+			PatchExpenseFunc: func(userID string, input models.PatchExpenseInput) (models.Expense, error) {
+				called = true
+				return models.Expense{}, nil
+			},
+		}
+
+		w := patch(t, svc, `{"amount":null}`, `"1"`)
+
+		require.Equal(t, http.StatusBadRequest, w.Code)
+		require.False(t, called)
+		var resp map[string]string
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Equal(t, "金額は削除できません", resp["error"])
+	})
+
+	t.Run("JSON オブジェクト以外は400", func(t *testing.T) {
+		w := patch(t, &expenseServiceMock{}, `null`, `"1"`)
+		require.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("If-Match がない場合は428", func(t *testing.T) {
+		w := patch(t, &expenseServiceMock{}, `{"status":"confirmed"}`, "")
+		require.Equal(t, http.StatusPreconditionRequired, w.Code)
+	})
+
+	t.Run("サービスのエラーをステータスコードに変換する", func(t *testing.T) {
+		cases := []struct {
+			name       string
+			err        error
+			wantStatus int
+		}{
+			{name: "検証エラー", err: &services.ValidationError{Message: "金額は1円以上で入力してください"}, wantStatus: http.StatusBadRequest},
+			{name: "存在しない", err: &services.NotFoundError{Message: "支出が見つかりません"}, wantStatus: http.StatusNotFound},
+			{name: "遷移エラー", err: services.ErrInvalidStatusTransition, wantStatus: http.StatusConflict},
+			{name: "バージョン不一致", err: services.ErrVersionConflict, wantStatus: http.StatusPreconditionFailed},
+			{name: "内部エラー", err: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				svc := &expenseServiceMock{
+					PatchExpenseFunc: func(userID string, input models.PatchExpenseInput) (models.Expense, error) {
+						return models.Expense{ID: input.ID, Version: 5}, tc.err
+					},
+				}
+
+				w := patch(t, svc, `{"status":"planned"}`, `"1"`)
+
+				require.Equal(t, tc.wantStatus, w.Code)
+			})
+		}
+	})
+}
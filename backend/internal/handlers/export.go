@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"money-buddy-backend/internal/export"
+)
+
+// exportStream は書き出しデータを HTTP レスポンスへ逐次出力します。
+// 最初の行を書き込むまでレスポンスヘッダーを送信しないため、それまでに発生したエラーは通常の JSON エラーとして返せます。
+type exportStream struct {
+	c        *gin.Context
+	format   export.Format
+	filename string // 拡張子を除くファイル名
+	columns  []string
+	w        export.Writer
+}
+
+func newExportStream(c *gin.Context, format export.Format, filename string, columns []string) *exportStream {
+	return &exportStream{c: c, format: format, filename: filename, columns: columns}
+}
+
+// started はレスポンスの送信を開始したかを返します。開始後はステータスコードを変更できません。
+func (s *exportStream) started() bool {
+	return s.w != nil
+}
+
+func (s *exportStream) start() error {
+	s.c.Header("Content-Type", s.format.ContentType())
+	s.c.Header("Content-Disposition", `attachment; filename="`+s.filename+"."+string(s.format)+`"`)
+	s.c.Status(http.StatusOK)
+
+	w, err := export.NewWriter(s.format, s.c.Writer, s.columns)
+	if err != nil {
+		return err
+	}
+	s.w = w
+	return nil
+}
+
+func (s *exportStream) writeRow(values []any) error {
+	if !s.started() {
+		if err := s.start(); err != nil {
+			return err
+		}
+	}
+	return s.w.WriteRow(values)
+}
+
+// close は末尾を書き込みます。1 行も書き込んでいない場合はヘッダーのみを出力します。
+func (s *exportStream) close() error {
+	if !s.started() {
+		if err := s.start(); err != nil {
+			return err
+		}
+	}
+	return s.w.Close()
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"money-buddy-backend/internal/middleware"
+	"money-buddy-backend/internal/models"
 	"money-buddy-backend/internal/services"
 )
 
@@ -18,21 +19,32 @@ type FixedCostHandler struct {
 func NewFixedCostHandler(r gin.IRouter, service services.FixedCostService) {
 	handler := &FixedCostHandler{service: service}
 
-	r.POST("/fixed-costs", handler.CreateFixedCost)
-	r.GET("/fixed-costs", handler.ListFixedCosts)
-	r.PUT("/fixed-costs/:id", handler.UpdateFixedCost)
-	r.DELETE("/fixed-costs/:id", handler.DeleteFixedCost)
+	// パーソナルアクセストークンでは read で参照のみ許可し、変更はログイン中のユーザー（世帯の閲覧者を除く）に限る
+	read := middleware.RequireScope(models.APITokenScopeRead)
+	session := middleware.RequireSession()
+	editor := middleware.RequireEditor()
+
+	r.POST("/fixed-costs", session, editor, handler.CreateFixedCost)
+	r.GET("/fixed-costs", read, handler.ListFixedCosts)
+	r.GET("/fixed-costs/:id", read, handler.GetFixedCost)
+	r.PUT("/fixed-costs/:id", session, editor, handler.UpdateFixedCost)
+	r.DELETE("/fixed-costs/:id", session, editor, handler.DeleteFixedCost)
+	r.POST("/fixed-costs/:id/restore", session, editor, handler.RestoreFixedCost)
 }
 
 // CreateFixedCostRequest は固定費作成のリクエストボディです
+// 支払いスケジュール（frequency・billing_month・billing_day）は省略時に毎月として扱います
+// effective_from（YYYY-MM）は計上を始める月で、省略時は当月です
 type CreateFixedCostRequest struct {
 	Name   string `json:"name"`
 	Amount int    `json:"amount"`
+	models.FixedCostSchedule
+	EffectiveFrom string `json:"effective_from"`
 }
 
 // CreateFixedCost は固定費を作成します
 func (h *FixedCostHandler) CreateFixedCost(c *gin.Context) {
-	userID, ok := middleware.GetUserID(c)
+	userID, ok := middleware.GetDataOwnerID(c)
 	if !ok {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
 		return
@@ -46,7 +58,7 @@ func (h *FixedCostHandler) CreateFixedCost(c *gin.Context) {
 	}
 
 	// 作成実行
-	fixedCost, err := h.service.CreateFixedCost(c.Request.Context(), userID, req.Name, req.Amount)
+	fixedCost, err := h.service.CreateFixedCost(c.Request.Context(), userID, req.Name, req.Amount, req.FixedCostSchedule, req.EffectiveFrom)
 	if err != nil {
 		var ve *services.ValidationError
 		if errors.As(err, &ve) {
@@ -57,12 +69,13 @@ func (h *FixedCostHandler) CreateFixedCost(c *gin.Context) {
 		return
 	}
 
+	setETag(c, fixedCost.Version)
 	c.JSON(http.StatusCreated, gin.H{"fixed_cost": fixedCost})
 }
 
 // ListFixedCosts は固定費一覧を取得します
 func (h *FixedCostHandler) ListFixedCosts(c *gin.Context) {
-	userID, ok := middleware.GetUserID(c)
+	userID, ok := middleware.GetDataOwnerID(c)
 	if !ok {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
 		return
@@ -77,15 +90,50 @@ func (h *FixedCostHandler) ListFixedCosts(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"fixed_costs": fixedCosts})
 }
 
+// GetFixedCost は固定費を取得します
+// ETag ヘッダーに更新時の If-Match に指定するバージョンを返します
+func (h *FixedCostHandler) GetFixedCost(c *gin.Context) {
+	userID, ok := middleware.GetDataOwnerID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	// IDパラメータ取得
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "IDが正しくありません"})
+		return
+	}
+
+	fixedCost, err := h.service.GetFixedCost(c.Request.Context(), userID, id)
+	if err != nil {
+		var ne *services.NotFoundError
+		if errors.As(err, &ne) {
+			c.JSON(http.StatusNotFound, gin.H{"error": ne.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "固定費の取得に失敗しました"})
+		return
+	}
+
+	setETag(c, fixedCost.Version)
+	c.JSON(http.StatusOK, gin.H{"fixed_cost": fixedCost})
+}
+
 // UpdateFixedCostRequest は固定費更新のリクエストボディです
+// effective_from（YYYY-MM）は変更後の金額・支払いスケジュールを適用する最初の月で、省略時は当月です
 type UpdateFixedCostRequest struct {
 	Name   string `json:"name"`
 	Amount int    `json:"amount"`
+	models.FixedCostSchedule
+	EffectiveFrom string `json:"effective_from"`
 }
 
 // UpdateFixedCost は固定費を更新します
+// If-Match ヘッダーに取得時の ETag が必要で、一致しない場合は 412 と現在の固定費を返します
 func (h *FixedCostHandler) UpdateFixedCost(c *gin.Context) {
-	userID, ok := middleware.GetUserID(c)
+	userID, ok := middleware.GetDataOwnerID(c)
 	if !ok {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
 		return
@@ -99,6 +147,26 @@ func (h *FixedCostHandler) UpdateFixedCost(c *gin.Context) {
 		return
 	}
 
+	// 更新前のバージョン取得
+	version, ok := requireIfMatch(c)
+	if !ok {
+		return
+	}
+	// If-Match: * は現在のバージョンで更新する
+	if version == ifMatchAny {
+		current, err := h.service.GetFixedCost(c.Request.Context(), userID, id)
+		if err != nil {
+			var ne *services.NotFoundError
+			if errors.As(err, &ne) {
+				c.JSON(http.StatusNotFound, gin.H{"error": ne.Message})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "固定費の更新に失敗しました"})
+			return
+		}
+		version = current.Version
+	}
+
 	// リクエストボディ取得
 	var req UpdateFixedCostRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -107,7 +175,7 @@ func (h *FixedCostHandler) UpdateFixedCost(c *gin.Context) {
 	}
 
 	// 更新実行
-	fixedCost, err := h.service.UpdateFixedCost(c.Request.Context(), userID, id, req.Name, req.Amount)
+	fixedCost, err := h.service.UpdateFixedCost(c.Request.Context(), userID, id, version, req.Name, req.Amount, req.FixedCostSchedule, req.EffectiveFrom)
 	if err != nil {
 		var ve *services.ValidationError
 		var ne *services.NotFoundError
@@ -119,16 +187,23 @@ func (h *FixedCostHandler) UpdateFixedCost(c *gin.Context) {
 			c.JSON(http.StatusNotFound, gin.H{"error": ne.Message})
 			return
 		}
+		if errors.Is(err, services.ErrVersionConflict) {
+			setETag(c, fixedCost.Version)
+			c.JSON(http.StatusPreconditionFailed, gin.H{"error": "他の端末で更新されています。最新の内容を確認してください", "fixed_cost": fixedCost})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "固定費の更新に失敗しました"})
 		return
 	}
 
+	setETag(c, fixedCost.Version)
 	c.JSON(http.StatusOK, gin.H{"fixed_cost": fixedCost})
 }
 
-// DeleteFixedCost は固定費を削除します
+// DeleteFixedCost は固定費を解約します
+// ?effective_from=YYYY-MM で計上しなくなる最初の月を指定します（省略時は当月）
 func (h *FixedCostHandler) DeleteFixedCost(c *gin.Context) {
-	userID, ok := middleware.GetUserID(c)
+	userID, ok := middleware.GetDataOwnerID(c)
 	if !ok {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
 		return
@@ -143,9 +218,14 @@ func (h *FixedCostHandler) DeleteFixedCost(c *gin.Context) {
 	}
 
 	// 削除実行
-	err = h.service.DeleteFixedCost(c.Request.Context(), userID, id)
+	err = h.service.DeleteFixedCost(c.Request.Context(), userID, id, c.Query("effective_from"))
 	if err != nil {
+		var ve *services.ValidationError
 		var ne *services.NotFoundError
+		if errors.As(err, &ve) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ve.Message})
+			return
+		}
 		if errors.As(err, &ne) {
 			c.JSON(http.StatusNotFound, gin.H{"error": ne.Message})
 			return
@@ -156,3 +236,31 @@ func (h *FixedCostHandler) DeleteFixedCost(c *gin.Context) {
 
 	c.Status(http.StatusNoContent)
 }
+
+// RestoreFixedCost はゴミ箱の固定費の解約を取り消します
+func (h *FixedCostHandler) RestoreFixedCost(c *gin.Context) {
+	userID, ok := middleware.GetDataOwnerID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "IDが正しくありません"})
+		return
+	}
+
+	fixedCost, err := h.service.RestoreFixedCost(c.Request.Context(), userID, id)
+	if err != nil {
+		var ne *services.NotFoundError
+		if errors.As(err, &ne) {
+			c.JSON(http.StatusNotFound, gin.H{"error": ne.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "固定費の復元に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"fixed_cost": fixedCost})
+}
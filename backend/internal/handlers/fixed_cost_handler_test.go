@@ -17,15 +17,17 @@ import (
 
 // fixedCostServiceMock is a mock implementing services.FixedCostService
 type fixedCostServiceMock struct {
-	CreateFixedCostFunc func(ctx context.Context, userID string, name string, amount int) (models.FixedCost, error)
-	ListFixedCostsFunc  func(ctx context.Context, userID string) ([]models.FixedCost, error)
-	UpdateFixedCostFunc func(ctx context.Context, userID string, id int, name string, amount int) (models.FixedCost, error)
-	DeleteFixedCostFunc func(ctx context.Context, userID string, id int) error
+	CreateFixedCostFunc  func(ctx context.Context, userID string, name string, amount int, schedule models.FixedCostSchedule, effectiveFrom string) (models.FixedCost, error)
+	ListFixedCostsFunc   func(ctx context.Context, userID string) ([]models.FixedCost, error)
+	GetFixedCostFunc     func(ctx context.Context, userID string, id int) (models.FixedCost, error)
+	UpdateFixedCostFunc  func(ctx context.Context, userID string, id int, version int, name string, amount int, schedule models.FixedCostSchedule, effectiveFrom string) (models.FixedCost, error)
+	DeleteFixedCostFunc  func(ctx context.Context, userID string, id int, effectiveFrom string) error
+	RestoreFixedCostFunc func(ctx context.Context, userID string, id int) (models.FixedCost, error)
 }
 
-func (m *fixedCostServiceMock) CreateFixedCost(ctx context.Context, userID string, name string, amount int) (models.FixedCost, error) {
+func (m *fixedCostServiceMock) CreateFixedCost(ctx context.Context, userID string, name string, amount int, schedule models.FixedCostSchedule, effectiveFrom string) (models.FixedCost, error) {
 	if m.CreateFixedCostFunc != nil {
-		return m.CreateFixedCostFunc(ctx, userID, name, amount)
+		return m.CreateFixedCostFunc(ctx, userID, name, amount, schedule, effectiveFrom)
 	}
 	return models.FixedCost{}, nil
 }
@@ -37,20 +39,34 @@ func (m *fixedCostServiceMock) ListFixedCosts(ctx context.Context, userID string
 	return nil, nil
 }
 
-func (m *fixedCostServiceMock) UpdateFixedCost(ctx context.Context, userID string, id int, name string, amount int) (models.FixedCost, error) {
+func (m *fixedCostServiceMock) GetFixedCost(ctx context.Context, userID string, id int) (models.FixedCost, error) {
+	if m.GetFixedCostFunc != nil {
+		return m.GetFixedCostFunc(ctx, userID, id)
+	}
+	return models.FixedCost{}, nil
+}
+
+func (m *fixedCostServiceMock) UpdateFixedCost(ctx context.Context, userID string, id int, version int, name string, amount int, schedule models.FixedCostSchedule, effectiveFrom string) (models.FixedCost, error) {
 	if m.UpdateFixedCostFunc != nil {
-		return m.UpdateFixedCostFunc(ctx, userID, id, name, amount)
+		return m.UpdateFixedCostFunc(ctx, userID, id, version, name, amount, schedule, effectiveFrom)
 	}
 	return models.FixedCost{}, nil
 }
 
-func (m *fixedCostServiceMock) DeleteFixedCost(ctx context.Context, userID string, id int) error {
+func (m *fixedCostServiceMock) DeleteFixedCost(ctx context.Context, userID string, id int, effectiveFrom string) error {
 	if m.DeleteFixedCostFunc != nil {
-		return m.DeleteFixedCostFunc(ctx, userID, id)
+		return m.DeleteFixedCostFunc(ctx, userID, id, effectiveFrom)
 	}
 	return nil
 }
 
+func (m *fixedCostServiceMock) RestoreFixedCost(ctx context.Context, userID string, id int) (models.FixedCost, error) {
+	if m.RestoreFixedCostFunc != nil {
+		return m.RestoreFixedCostFunc(ctx, userID, id)
+	}
+	return models.FixedCost{}, nil
+}
+
 // TestListFixedCosts_Success は固定費一覧取得の成功ケースをテストします
 func TestListFixedCosts_Success(t *testing.T) {
 	gin.SetMode(gin.TestMode)
@@ -108,7 +124,7 @@ func TestUpdateFixedCost_Success(t *testing.T) {
 	router := gin.New()
 
 	svc := &fixedCostServiceMock{
-		UpdateFixedCostFunc: func(ctx context.Context, userID string, id int, name string, amount int) (models.FixedCost, error) {
+		UpdateFixedCostFunc: func(ctx context.Context, userID string, id int, version int, name string, amount int, schedule models.FixedCostSchedule, effectiveFrom string) (models.FixedCost, error) {
 			return models.FixedCost{
 				ID:     id,
 				UserID: userID,
@@ -121,6 +137,7 @@ func TestUpdateFixedCost_Success(t *testing.T) {
 
 	body := `{"name":"家賃（更新）","amount":85000}`
 	req := httptest.NewRequest(http.MethodPut, "/fixed-costs/1", strings.NewReader(body))
+	req.Header.Set("If-Match", `"1"`)
 	req.Header.Set("Content-Type", "application/json")
 
 	w := httptest.NewRecorder()
@@ -149,6 +166,7 @@ func TestUpdateFixedCost_InvalidID(t *testing.T) {
 
 	body := `{"name":"家賃","amount":80000}`
 	req := httptest.NewRequest(http.MethodPut, "/fixed-costs/abc", strings.NewReader(body))
+	req.Header.Set("If-Match", `"1"`)
 	req.Header.Set("Content-Type", "application/json")
 
 	w := httptest.NewRecorder()
@@ -157,6 +175,35 @@ func TestUpdateFixedCost_InvalidID(t *testing.T) {
 	require.Equal(t, http.StatusBadRequest, w.Code)
 }
 
+// TestUpdateFixedCost_IfMatchAny は If-Match: * の場合に現在のバージョンで更新することをテストします
+func TestUpdateFixedCost_IfMatchAny(t *testing.T) {
+	router := newAuthedRouter()
+
+	var gotVersion int
+	svc := &fixedCostServiceMock{
+		GetFixedCostFunc: func(ctx context.Context, userID string, id int) (models.FixedCost, error) {
+			return models.FixedCost{ID: id, Version: 4}, nil
+		},
+		UpdateFixedCostFunc: func(ctx context.Context, userID string, id int, version int, name string, amount int, schedule models.FixedCostSchedule, effectiveFrom string) (models.FixedCost, error) {
+			gotVersion = version
+			return models.FixedCost{ID: id, Name: name, Amount: amount, Version: version + 1}, nil
+		},
+	}
+	NewFixedCostHandler(router, svc)
+
+	body := `{"name":"家賃","amount":80000}`
+	req := httptest.NewRequest(http.MethodPut, "/fixed-costs/1", strings.NewReader(body))
+	req.Header.Set("If-Match", "*")
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, 4, gotVersion)
+	require.Equal(t, `"5"`, w.Header().Get("ETag"))
+}
+
 // TestUpdateFixedCost_ValidationError はバリデーションエラーをテストします
 func TestUpdateFixedCost_ValidationError(t *testing.T) {
 	t.Run("名前が空白のみの場合", func(t *testing.T) {
@@ -165,7 +212,7 @@ func TestUpdateFixedCost_ValidationError(t *testing.T) {
 
 		called := false
 		svc := &fixedCostServiceMock{
-			UpdateFixedCostFunc: func(ctx context.Context, userID string, id int, name string, amount int) (models.FixedCost, error) {
+			UpdateFixedCostFunc: func(ctx context.Context, userID string, id int, version int, name string, amount int, schedule models.FixedCostSchedule, effectiveFrom string) (models.FixedCost, error) {
 				called = true
 				require.Equal(t, "   ", name)
 				return models.FixedCost{}, &services.ValidationError{Message: "name is required"}
@@ -175,6 +222,7 @@ func TestUpdateFixedCost_ValidationError(t *testing.T) {
 
 		body := `{"name":"   ","amount":80000}`
 		req := httptest.NewRequest(http.MethodPut, "/fixed-costs/1", strings.NewReader(body))
+		req.Header.Set("If-Match", `"1"`)
 		req.Header.Set("Content-Type", "application/json")
 
 		w := httptest.NewRecorder()
@@ -196,7 +244,7 @@ func TestUpdateFixedCost_ValidationError(t *testing.T) {
 		called := false
 		longName := strings.Repeat("あ", 101)
 		svc := &fixedCostServiceMock{
-			UpdateFixedCostFunc: func(ctx context.Context, userID string, id int, name string, amount int) (models.FixedCost, error) {
+			UpdateFixedCostFunc: func(ctx context.Context, userID string, id int, version int, name string, amount int, schedule models.FixedCostSchedule, effectiveFrom string) (models.FixedCost, error) {
 				called = true
 				return models.FixedCost{}, &services.ValidationError{Message: "name is too long"}
 			},
@@ -205,6 +253,7 @@ func TestUpdateFixedCost_ValidationError(t *testing.T) {
 
 		body := `{"name":"` + longName + `","amount":80000}`
 		req := httptest.NewRequest(http.MethodPut, "/fixed-costs/1", strings.NewReader(body))
+		req.Header.Set("If-Match", `"1"`)
 		req.Header.Set("Content-Type", "application/json")
 
 		w := httptest.NewRecorder()
@@ -225,7 +274,7 @@ func TestUpdateFixedCost_ValidationError(t *testing.T) {
 
 		called := false
 		svc := &fixedCostServiceMock{
-			UpdateFixedCostFunc: func(ctx context.Context, userID string, id int, name string, amount int) (models.FixedCost, error) {
+			UpdateFixedCostFunc: func(ctx context.Context, userID string, id int, version int, name string, amount int, schedule models.FixedCostSchedule, effectiveFrom string) (models.FixedCost, error) {
 				called = true
 				require.Equal(t, 1000000001, amount)
 				return models.FixedCost{}, &services.ValidationError{Message: "amount exceeds maximum allowed"}
@@ -235,6 +284,7 @@ func TestUpdateFixedCost_ValidationError(t *testing.T) {
 
 		body := `{"name":"家賃","amount":1000000001}`
 		req := httptest.NewRequest(http.MethodPut, "/fixed-costs/1", strings.NewReader(body))
+		req.Header.Set("If-Match", `"1"`)
 		req.Header.Set("Content-Type", "application/json")
 
 		w := httptest.NewRecorder()
@@ -256,7 +306,7 @@ func TestUpdateFixedCost_NotFound(t *testing.T) {
 	router := gin.New()
 
 	svc := &fixedCostServiceMock{
-		UpdateFixedCostFunc: func(ctx context.Context, userID string, id int, name string, amount int) (models.FixedCost, error) {
+		UpdateFixedCostFunc: func(ctx context.Context, userID string, id int, version int, name string, amount int, schedule models.FixedCostSchedule, effectiveFrom string) (models.FixedCost, error) {
 			return models.FixedCost{}, &services.NotFoundError{Message: "固定費が見つかりません"}
 		},
 	}
@@ -264,6 +314,7 @@ func TestUpdateFixedCost_NotFound(t *testing.T) {
 
 	body := `{"name":"家賃","amount":80000}`
 	req := httptest.NewRequest(http.MethodPut, "/fixed-costs/999", strings.NewReader(body))
+	req.Header.Set("If-Match", `"1"`)
 	req.Header.Set("Content-Type", "application/json")
 
 	w := httptest.NewRecorder()
@@ -278,7 +329,7 @@ func TestDeleteFixedCost_Success(t *testing.T) {
 	router := gin.New()
 
 	svc := &fixedCostServiceMock{
-		DeleteFixedCostFunc: func(ctx context.Context, userID string, id int) error {
+		DeleteFixedCostFunc: func(ctx context.Context, userID string, id int, effectiveFrom string) error {
 			return nil
 		},
 	}
@@ -291,6 +342,77 @@ func TestDeleteFixedCost_Success(t *testing.T) {
 	require.Equal(t, http.StatusNoContent, w.Code)
 }
 
+// TestRestoreFixedCost はゴミ箱の固定費の復元とエラーの変換をテストします
+func TestRestoreFixedCost(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "ゴミ箱から復元できる", wantStatus: http.StatusOK},
+		{name: "ゴミ箱にない固定費は404", svcErr: &services.NotFoundError{Message: "ゴミ箱に固定費が見つかりません"}, wantStatus: http.StatusNotFound},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newAuthedRouter()
+			NewFixedCostHandler(router, &fixedCostServiceMock{
+				RestoreFixedCostFunc: func(ctx context.Context, userID string, id int) (models.FixedCost, error) {
+					require.Equal(t, 1, id)
+					return models.FixedCost{ID: id, Name: "家賃"}, tt.svcErr
+				},
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/fixed-costs/1/restore", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
+
+// TestDeleteFixedCost_EffectiveFrom は解約月がサービスに渡されることをテストします
+func TestDeleteFixedCost_EffectiveFrom(t *testing.T) {
+	router := newAuthedRouter()
+
+	var got string
+	svc := &fixedCostServiceMock{
+		DeleteFixedCostFunc: func(ctx context.Context, userID string, id int, effectiveFrom string) error {
+			got = effectiveFrom
+			return nil
+		},
+	}
+	NewFixedCostHandler(router, svc)
+
+	req := httptest.NewRequest(http.MethodDelete, "/fixed-costs/1?effective_from=2025-05", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNoContent, w.Code)
+	require.Equal(t, "2025-05", got)
+}
+
+// TestDeleteFixedCost_InvalidEffectiveFrom は解約月が不正な場合をテストします
+func TestDeleteFixedCost_InvalidEffectiveFrom(t *testing.T) {
+	router := newAuthedRouter()
+
+	svc := &fixedCostServiceMock{
+		DeleteFixedCostFunc: func(ctx context.Context, userID string, id int, effectiveFrom string) error {
+			return &services.ValidationError{Message: "適用開始月に未来の月は指定できません"}
+		},
+	}
+	NewFixedCostHandler(router, svc)
+
+	req := httptest.NewRequest(http.MethodDelete, "/fixed-costs/1?effective_from=2999-01", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+
+	var resp map[string]string
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, "適用開始月に未来の月は指定できません", resp["error"])
+}
+
 // TestDeleteFixedCost_InvalidID はIDが不正な場合をテストします
 func TestDeleteFixedCost_InvalidID(t *testing.T) {
 	gin.SetMode(gin.TestMode)
@@ -312,7 +434,7 @@ func TestDeleteFixedCost_NotFound(t *testing.T) {
 	router := gin.New()
 
 	svc := &fixedCostServiceMock{
-		DeleteFixedCostFunc: func(ctx context.Context, userID string, id int) error {
+		DeleteFixedCostFunc: func(ctx context.Context, userID string, id int, effectiveFrom string) error {
 			return &services.NotFoundError{Message: "固定費が見つかりません"}
 		},
 	}
@@ -337,7 +459,7 @@ func TestCreateFixedCost_Success(t *testing.T) {
 		Amount: 80000,
 	}
 	svc := &fixedCostServiceMock{
-		CreateFixedCostFunc: func(ctx context.Context, userID string, name string, amount int) (models.FixedCost, error) {
+		CreateFixedCostFunc: func(ctx context.Context, userID string, name string, amount int, schedule models.FixedCostSchedule, effectiveFrom string) (models.FixedCost, error) {
 			require.Equal(t, DummyUserID, userID)
 			require.Equal(t, "家賃", name)
 			require.Equal(t, 80000, amount)
@@ -360,6 +482,41 @@ func TestCreateFixedCost_Success(t *testing.T) {
 	require.Equal(t, expected, resp["fixed_cost"])
 }
 
+// TestCreateFixedCost_WithSchedule は支払いスケジュールがサービスに渡されることをテストします
+func TestCreateFixedCost_WithSchedule(t *testing.T) {
+	router := newAuthedRouter()
+
+	var got models.FixedCostSchedule
+	var gotEffectiveFrom string
+	svc := &fixedCostServiceMock{
+		CreateFixedCostFunc: func(ctx context.Context, userID string, name string, amount int, schedule models.FixedCostSchedule, effectiveFrom string) (models.FixedCost, error) {
+			got = schedule
+			gotEffectiveFrom = effectiveFrom
+			return models.FixedCost{ID: 1, UserID: userID, Name: name, Amount: amount, FixedCostSchedule: schedule}, nil
+		},
+	}
+	NewFixedCostHandler(router, svc)
+
+	body := `{"name":"自動車税","amount":34500,"frequency":"yearly","billing_month":5,"billing_day":31,"effective_from":"2025-04"}`
+	req := httptest.NewRequest(http.MethodPost, "/fixed-costs", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	require.Equal(t, "yearly", got.Frequency)
+	require.NotNil(t, got.BillingMonth)
+	require.Equal(t, 5, *got.BillingMonth)
+	require.NotNil(t, got.BillingDay)
+	require.Equal(t, 31, *got.BillingDay)
+	require.Equal(t, "2025-04", gotEffectiveFrom)
+
+	var resp map[string]models.FixedCost
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	require.Equal(t, "yearly", resp["fixed_cost"].Frequency)
+	require.Equal(t, 5, *resp["fixed_cost"].BillingMonth)
+}
+
 // TestCreateFixedCost_InvalidJSON はリクエストボディが不正な場合をテストします
 func TestCreateFixedCost_InvalidJSON(t *testing.T) {
 	gin.SetMode(gin.TestMode)
@@ -385,7 +542,7 @@ func TestCreateFixedCost_ValidationError(t *testing.T) {
 
 		called := false
 		svc := &fixedCostServiceMock{
-			CreateFixedCostFunc: func(ctx context.Context, userID string, name string, amount int) (models.FixedCost, error) {
+			CreateFixedCostFunc: func(ctx context.Context, userID string, name string, amount int, schedule models.FixedCostSchedule, effectiveFrom string) (models.FixedCost, error) {
 				called = true
 				require.Equal(t, "   ", name)
 				return models.FixedCost{}, &services.ValidationError{Message: "name is required"}
@@ -415,7 +572,7 @@ func TestCreateFixedCost_ValidationError(t *testing.T) {
 		called := false
 		longName := strings.Repeat("あ", 101)
 		svc := &fixedCostServiceMock{
-			CreateFixedCostFunc: func(ctx context.Context, userID string, name string, amount int) (models.FixedCost, error) {
+			CreateFixedCostFunc: func(ctx context.Context, userID string, name string, amount int, schedule models.FixedCostSchedule, effectiveFrom string) (models.FixedCost, error) {
 				called = true
 				return models.FixedCost{}, &services.ValidationError{Message: "name is too long"}
 			},
@@ -443,7 +600,7 @@ func TestCreateFixedCost_ValidationError(t *testing.T) {
 
 		called := false
 		svc := &fixedCostServiceMock{
-			CreateFixedCostFunc: func(ctx context.Context, userID string, name string, amount int) (models.FixedCost, error) {
+			CreateFixedCostFunc: func(ctx context.Context, userID string, name string, amount int, schedule models.FixedCostSchedule, effectiveFrom string) (models.FixedCost, error) {
 				called = true
 				require.Equal(t, 1000000001, amount)
 				return models.FixedCost{}, &services.ValidationError{Message: "amount exceeds maximum allowed"}
@@ -473,7 +630,7 @@ func TestCreateFixedCost_ServiceError(t *testing.T) {
 	router := gin.New()
 
 	svc := &fixedCostServiceMock{
-		CreateFixedCostFunc: func(ctx context.Context, userID string, name string, amount int) (models.FixedCost, error) {
+		CreateFixedCostFunc: func(ctx context.Context, userID string, name string, amount int, schedule models.FixedCostSchedule, effectiveFrom string) (models.FixedCost, error) {
 			return models.FixedCost{}, &services.InternalError{Message: "database error"}
 		},
 	}
@@ -487,3 +644,73 @@ func TestCreateFixedCost_ServiceError(t *testing.T) {
 
 	require.Equal(t, http.StatusInternalServerError, w.Code)
 }
+
+// TestUpdateFixedCost_Precondition は If-Match による楽観的排他制御をテストします
+func TestUpdateFixedCost_Precondition(t *testing.T) {
+	t.Run("If-Match がない場合は428", func(t *testing.T) {
+		router := newAuthedRouter()
+		called := false
+		svc := &fixedCostServiceMock{
+			UpdateFixedCostFunc: func(ctx context.Context, userID string, id int, version int, name string, amount int, schedule models.FixedCostSchedule, effectiveFrom string) (models.FixedCost, error) {
+				called = true
+				return models.FixedCost{}, nil
+			},
+		}
+		NewFixedCostHandler(router, svc)
+
+		req := httptest.NewRequest(http.MethodPut, "/fixed-costs/1", strings.NewReader(`{"name":"家賃","amount":85000}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusPreconditionRequired, w.Code)
+		require.False(t, called)
+	})
+
+	t.Run("バージョン不一致の場合は412と現在の状態", func(t *testing.T) {
+		router := newAuthedRouter()
+		var gotVersion int
+		svc := &fixedCostServiceMock{
+			UpdateFixedCostFunc: func(ctx context.Context, userID string, id int, version int, name string, amount int, schedule models.FixedCostSchedule, effectiveFrom string) (models.FixedCost, error) {
+				gotVersion = version
+				return models.FixedCost{ID: id, Name: "家賃", Amount: 90000, Version: 3}, services.ErrVersionConflict
+			},
+		}
+		NewFixedCostHandler(router, svc)
+
+		req := httptest.NewRequest(http.MethodPut, "/fixed-costs/1", strings.NewReader(`{"name":"家賃","amount":85000}`))
+		req.Header.Set("If-Match", `"2"`)
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, 2, gotVersion)
+		require.Equal(t, http.StatusPreconditionFailed, w.Code)
+		require.Equal(t, `"3"`, w.Header().Get("ETag"))
+
+		var resp struct {
+			FixedCost models.FixedCost `json:"fixed_cost"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		require.Equal(t, 90000, resp.FixedCost.Amount)
+		require.Equal(t, 3, resp.FixedCost.Version)
+	})
+}
+
+// TestGetFixedCost_ETag は固定費取得時に ETag が返ることをテストします
+func TestGetFixedCost_ETag(t *testing.T) {
+	router := newAuthedRouter()
+	svc := &fixedCostServiceMock{
+		GetFixedCostFunc: func(ctx context.Context, userID string, id int) (models.FixedCost, error) {
+			return models.FixedCost{ID: id, UserID: userID, Name: "家賃", Amount: 80000, Version: 4}, nil
+		},
+	}
+	NewFixedCostHandler(router, svc)
+
+	req := httptest.NewRequest(http.MethodGet, "/fixed-costs/1", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, `"4"`, w.Header().Get("ETag"))
+}
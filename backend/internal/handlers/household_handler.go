@@ -0,0 +1,267 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"money-buddy-backend/internal/middleware"
+	"money-buddy-backend/internal/services"
+)
+
+type HouseholdHandler struct {
+	service services.HouseholdService
+}
+
+// NewHouseholdHandler は世帯の管理エンドポイントを登録します。
+// 世帯の管理はログイン中のユーザー本人に限り、パーソナルアクセストークンでは実行できません。
+func NewHouseholdHandler(r gin.IRouter, service services.HouseholdService) {
+	h := &HouseholdHandler{service: service}
+	session := middleware.RequireSession()
+	r.GET("/households", session, h.ListHouseholds)
+	r.POST("/households", session, h.CreateHousehold)
+	r.PUT("/households/current", session, h.SwitchHousehold)
+	r.POST("/households/join", session, h.JoinHousehold)
+	r.GET("/households/:id", session, h.GetHousehold)
+	r.DELETE("/households/:id", session, h.DeleteHousehold)
+	r.POST("/households/:id/invitations", session, h.CreateInvitation)
+	r.PUT("/households/:id/members/:user_id", session, h.UpdateMemberRole)
+	r.DELETE("/households/:id/members/:user_id", session, h.RemoveMember)
+}
+
+// CreateHouseholdRequest は世帯作成のリクエストボディです
+type CreateHouseholdRequest struct {
+	Name string `json:"name"`
+}
+
+// SwitchHouseholdRequest は選択中の世帯の切り替えのリクエストボディです
+// household_id が null の場合は自分の家計簿に戻します
+type SwitchHouseholdRequest struct {
+	HouseholdID *int `json:"household_id"`
+}
+
+// JoinHouseholdRequest は招待コードによる世帯への参加のリクエストボディです
+type JoinHouseholdRequest struct {
+	Code string `json:"code"`
+}
+
+// HouseholdRoleRequest は招待・役割変更のリクエストボディです（member / viewer）
+type HouseholdRoleRequest struct {
+	Role string `json:"role"`
+}
+
+// ListHouseholds は所属している世帯の一覧を取得します
+func (h *HouseholdHandler) ListHouseholds(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	households, err := h.service.ListHouseholds(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "世帯の取得に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"households": households})
+}
+
+// CreateHousehold は自分をオーナーとする世帯を作成し、選択中の世帯にします
+func (h *HouseholdHandler) CreateHousehold(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	var req CreateHouseholdRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "リクエストの形式が正しくありません"})
+		return
+	}
+
+	household, err := h.service.CreateHousehold(c.Request.Context(), userID, req.Name)
+	if err != nil {
+		writeHouseholdError(c, err, "世帯の作成に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusCreated, household)
+}
+
+// SwitchHousehold は選択中の世帯を切り替えます
+func (h *HouseholdHandler) SwitchHousehold(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	var req SwitchHouseholdRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "リクエストの形式が正しくありません"})
+		return
+	}
+
+	if err := h.service.SwitchHousehold(c.Request.Context(), userID, req.HouseholdID); err != nil {
+		writeHouseholdError(c, err, "世帯の切り替えに失敗しました")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// JoinHousehold は招待コードの世帯に参加し、選択中の世帯にします
+func (h *HouseholdHandler) JoinHousehold(c *gin.Context) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	var req JoinHouseholdRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "リクエストの形式が正しくありません"})
+		return
+	}
+
+	household, err := h.service.JoinHousehold(c.Request.Context(), userID, req.Code)
+	if err != nil {
+		writeHouseholdError(c, err, "世帯への参加に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, household)
+}
+
+// GetHousehold はメンバー一覧を含む世帯を取得します
+func (h *HouseholdHandler) GetHousehold(c *gin.Context) {
+	userID, id, ok := householdParams(c)
+	if !ok {
+		return
+	}
+
+	household, err := h.service.GetHousehold(c.Request.Context(), userID, id)
+	if err != nil {
+		writeHouseholdError(c, err, "世帯の取得に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, household)
+}
+
+// DeleteHousehold は世帯を削除します（オーナーのみ）
+func (h *HouseholdHandler) DeleteHousehold(c *gin.Context) {
+	userID, id, ok := householdParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.DeleteHousehold(c.Request.Context(), userID, id); err != nil {
+		writeHouseholdError(c, err, "世帯の削除に失敗しました")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// CreateInvitation は世帯への招待コードを発行します（オーナーのみ）
+func (h *HouseholdHandler) CreateInvitation(c *gin.Context) {
+	userID, id, ok := householdParams(c)
+	if !ok {
+		return
+	}
+
+	var req HouseholdRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "リクエストの形式が正しくありません"})
+		return
+	}
+
+	invitation, err := h.service.CreateInvitation(c.Request.Context(), userID, id, req.Role)
+	if err != nil {
+		writeHouseholdError(c, err, "招待コードの発行に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusCreated, invitation)
+}
+
+// UpdateMemberRole はメンバーの役割を変更します（オーナーのみ）
+func (h *HouseholdHandler) UpdateMemberRole(c *gin.Context) {
+	userID, id, ok := householdParams(c)
+	if !ok {
+		return
+	}
+
+	var req HouseholdRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "リクエストの形式が正しくありません"})
+		return
+	}
+
+	if err := h.service.UpdateMemberRole(c.Request.Context(), userID, id, c.Param("user_id"), req.Role); err != nil {
+		writeHouseholdError(c, err, "役割の変更に失敗しました")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RemoveMember はメンバーを世帯から外します。自分自身を指定した場合は世帯から退出します
+func (h *HouseholdHandler) RemoveMember(c *gin.Context) {
+	userID, id, ok := householdParams(c)
+	if !ok {
+		return
+	}
+
+	if err := h.service.RemoveMember(c.Request.Context(), userID, id, c.Param("user_id")); err != nil {
+		writeHouseholdError(c, err, "メンバーの削除に失敗しました")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// householdParams はユーザーIDとパスパラメータの世帯IDを取得します。
+// 取得できない場合はエラーレスポンスを書き込み、第3戻り値に false を返します。
+func householdParams(c *gin.Context) (string, int, bool) {
+	userID, ok := middleware.GetUserID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return "", 0, false
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "世帯IDが正しくありません"})
+		return "", 0, false
+	}
+	return userID, id, true
+}
+
+// writeHouseholdError は世帯サービスのエラーをレスポンスに変換します。
+func writeHouseholdError(c *gin.Context, err error, internalMessage string) {
+	var ve *services.ValidationError
+	var ne *services.NotFoundError
+	switch {
+	case errors.As(err, &ve):
+		c.JSON(http.StatusBadRequest, gin.H{"error": ve.Message})
+	case errors.As(err, &ne):
+		c.JSON(http.StatusNotFound, gin.H{"error": ne.Message})
+	case errors.Is(err, sql.ErrNoRows):
+		c.JSON(http.StatusNotFound, gin.H{"error": "ユーザーが見つかりません"})
+	case errors.Is(err, services.ErrHouseholdOwnerOnly):
+		c.JSON(http.StatusForbidden, gin.H{"error": "この操作は世帯のオーナーのみ実行できます"})
+	case errors.Is(err, services.ErrHouseholdAlreadyOwned):
+		c.JSON(http.StatusConflict, gin.H{"error": "作成できる世帯は1つまでです"})
+	case errors.Is(err, services.ErrAlreadyHouseholdMember):
+		c.JSON(http.StatusConflict, gin.H{"error": "すでにこの世帯のメンバーです"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": internalMessage})
+	}
+}
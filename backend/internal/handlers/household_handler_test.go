@@ -0,0 +1,281 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"money-buddy-backend/internal/middleware"
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/services"
+)
+
+// householdServiceMock は HouseholdService のモック実装です
+type householdServiceMock struct {
+	CreateHouseholdFunc  func(ctx context.Context, userID string, name string) (models.Household, error)
+	SwitchHouseholdFunc  func(ctx context.Context, userID string, id *int) error
+	CreateInvitationFunc func(ctx context.Context, userID string, id int, role string) (models.HouseholdInvitation, error)
+	JoinHouseholdFunc    func(ctx context.Context, userID string, code string) (models.Household, error)
+	RemoveMemberFunc     func(ctx context.Context, userID string, id int, memberID string) error
+}
+
+func (m *householdServiceMock) CurrentMembership(ctx context.Context, userID string) (*models.HouseholdMembership, error) {
+	return nil, nil
+}
+
+func (m *householdServiceMock) ListHouseholds(ctx context.Context, userID string) ([]models.Household, error) {
+	return nil, nil
+}
+
+func (m *householdServiceMock) GetHousehold(ctx context.Context, userID string, id int) (models.HouseholdDetail, error) {
+	return models.HouseholdDetail{}, nil
+}
+
+func (m *householdServiceMock) CreateHousehold(ctx context.Context, userID string, name string) (models.Household, error) {
+	if m.CreateHouseholdFunc != nil {
+		return m.CreateHouseholdFunc(ctx, userID, name)
+	}
+	return models.Household{}, nil
+}
+
+func (m *householdServiceMock) SwitchHousehold(ctx context.Context, userID string, id *int) error {
+	if m.SwitchHouseholdFunc != nil {
+		return m.SwitchHouseholdFunc(ctx, userID, id)
+	}
+	return nil
+}
+
+func (m *householdServiceMock) CreateInvitation(ctx context.Context, userID string, id int, role string) (models.HouseholdInvitation, error) {
+	if m.CreateInvitationFunc != nil {
+		return m.CreateInvitationFunc(ctx, userID, id, role)
+	}
+	return models.HouseholdInvitation{}, nil
+}
+
+func (m *householdServiceMock) JoinHousehold(ctx context.Context, userID string, code string) (models.Household, error) {
+	if m.JoinHouseholdFunc != nil {
+		return m.JoinHouseholdFunc(ctx, userID, code)
+	}
+	return models.Household{}, nil
+}
+
+func (m *householdServiceMock) UpdateMemberRole(ctx context.Context, userID string, id int, memberID string, role string) error {
+	return nil
+}
+
+func (m *householdServiceMock) RemoveMember(ctx context.Context, userID string, id int, memberID string) error {
+	if m.RemoveMemberFunc != nil {
+		return m.RemoveMemberFunc(ctx, userID, id, memberID)
+	}
+	return nil
+}
+
+func (m *householdServiceMock) DeleteHousehold(ctx context.Context, userID string, id int) error {
+	return nil
+}
+
+// newHouseholdMemberRouter は owner-user の世帯を role の役割で選択中の状態を再現するルーターを返します
+func newHouseholdMemberRouter(role string) *gin.Engine {
+	router := newAuthedRouter()
+	router.Use(func(c *gin.Context) {
+		c.Set(string(middleware.HouseholdKey), &models.HouseholdMembership{HouseholdID: 1, OwnerID: "owner-user", Role: role})
+		c.Next()
+	})
+	return router
+}
+
+// TestCreateHouseholdHandler は世帯作成の正常系のテストです
+func TestCreateHouseholdHandler(t *testing.T) {
+	router := newAuthedRouter()
+	svc := &householdServiceMock{
+		CreateHouseholdFunc: func(ctx context.Context, userID string, name string) (models.Household, error) {
+			assert.Equal(t, DummyUserID, userID)
+			return models.Household{ID: 1, Name: name, OwnerID: userID, Role: models.HouseholdRoleOwner, Current: true}, nil
+		},
+	}
+	NewHouseholdHandler(router, svc)
+
+	req := httptest.NewRequest(http.MethodPost, "/households", strings.NewReader(`{"name":"わが家"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	var resp models.Household
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	assert.Equal(t, "わが家", resp.Name)
+	assert.True(t, resp.Current)
+}
+
+// TestCreateHouseholdHandler_AlreadyOwned は作成済みの場合に 409 を返すテストです
+func TestCreateHouseholdHandler_AlreadyOwned(t *testing.T) {
+	router := newAuthedRouter()
+	svc := &householdServiceMock{
+		CreateHouseholdFunc: func(ctx context.Context, userID string, name string) (models.Household, error) {
+			return models.Household{}, services.ErrHouseholdAlreadyOwned
+		},
+	}
+	NewHouseholdHandler(router, svc)
+
+	req := httptest.NewRequest(http.MethodPost, "/households", strings.NewReader(`{"name":"わが家"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+// TestSwitchHouseholdHandler は選択中の世帯の切り替えのテストです
+func TestSwitchHouseholdHandler(t *testing.T) {
+	cases := []struct {
+		name string
+		body string
+		want *int
+	}{
+		{name: "世帯を選択する", body: `{"household_id":1}`, want: func() *int { v := 1; return &v }()},
+		{name: "自分の家計簿に戻す", body: `{"household_id":null}`, want: nil},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := newAuthedRouter()
+			var got *int
+			svc := &householdServiceMock{
+				SwitchHouseholdFunc: func(ctx context.Context, userID string, id *int) error {
+					got = id
+					return nil
+				},
+			}
+			NewHouseholdHandler(router, svc)
+
+			req := httptest.NewRequest(http.MethodPut, "/households/current", strings.NewReader(tc.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, http.StatusNoContent, w.Code)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+// TestCreateInvitationHandler_OwnerOnly はオーナー以外の招待で 403 を返すテストです
+func TestCreateInvitationHandler_OwnerOnly(t *testing.T) {
+	router := newAuthedRouter()
+	svc := &householdServiceMock{
+		CreateInvitationFunc: func(ctx context.Context, userID string, id int, role string) (models.HouseholdInvitation, error) {
+			assert.Equal(t, 1, id)
+			assert.Equal(t, models.HouseholdRoleViewer, role)
+			return models.HouseholdInvitation{}, services.ErrHouseholdOwnerOnly
+		},
+	}
+	NewHouseholdHandler(router, svc)
+
+	req := httptest.NewRequest(http.MethodPost, "/households/1/invitations", strings.NewReader(`{"role":"viewer"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+// TestJoinHouseholdHandler_InvalidCode は無効な招待コードで 404 を返すテストです
+func TestJoinHouseholdHandler_InvalidCode(t *testing.T) {
+	router := newAuthedRouter()
+	svc := &householdServiceMock{
+		JoinHouseholdFunc: func(ctx context.Context, userID string, code string) (models.Household, error) {
+			return models.Household{}, &services.NotFoundError{Message: "招待コードが無効か、有効期限が切れています"}
+		},
+	}
+	NewHouseholdHandler(router, svc)
+
+	req := httptest.NewRequest(http.MethodPost, "/households/join", strings.NewReader(`{"code":"EXPIRED000"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), "招待コードが無効か")
+}
+
+// TestRemoveMemberHandler はメンバー削除のパラメータのテストです
+func TestRemoveMemberHandler(t *testing.T) {
+	router := newAuthedRouter()
+	var gotID int
+	var gotMember string
+	svc := &householdServiceMock{
+		RemoveMemberFunc: func(ctx context.Context, userID string, id int, memberID string) error {
+			gotID, gotMember = id, memberID
+			return nil
+		},
+	}
+	NewHouseholdHandler(router, svc)
+
+	req := httptest.NewRequest(http.MethodDelete, "/households/3/members/partner-user", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, 3, gotID)
+	assert.Equal(t, "partner-user", gotMember)
+}
+
+// TestHouseholdHandler_InvalidID は世帯IDが数値でない場合に 400 を返すテストです
+func TestHouseholdHandler_InvalidID(t *testing.T) {
+	router := newAuthedRouter()
+	NewHouseholdHandler(router, &householdServiceMock{})
+
+	req := httptest.NewRequest(http.MethodDelete, "/households/abc", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+// TestCreateExpenseHandler_HouseholdMember は世帯のメンバーがオーナーの家計簿に登録するテストです
+func TestCreateExpenseHandler_HouseholdMember(t *testing.T) {
+	router := newHouseholdMemberRouter(models.HouseholdRoleMember)
+	var gotUserID string
+	var gotInput models.CreateExpenseInput
+	svc := &expenseServiceMock{
+		CreateExpenseFunc: func(userID string, input models.CreateExpenseInput) (models.Expense, error) {
+			gotUserID, gotInput = userID, input
+			return models.Expense{ID: 1}, nil
+		},
+	}
+	NewExpenseHandler(router, svc)
+
+	req := httptest.NewRequest(http.MethodPost, "/expenses", strings.NewReader(`{"amount":1000,"category_id":1,"spent_at":"2025-06-01"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "owner-user", gotUserID)
+	assert.Equal(t, DummyUserID, gotInput.CreatedBy)
+}
+
+// TestCreateExpenseHandler_HouseholdViewer は閲覧者の登録に 403 を返すテストです
+func TestCreateExpenseHandler_HouseholdViewer(t *testing.T) {
+	router := newHouseholdMemberRouter(models.HouseholdRoleViewer)
+	svc := &expenseServiceMock{
+		CreateExpenseFunc: func(userID string, input models.CreateExpenseInput) (models.Expense, error) {
+			t.Fatal("閲覧者の登録はサービスを呼び出さない")
+			return models.Expense{}, nil
+		},
+	}
+	NewExpenseHandler(router, svc)
+
+	req := httptest.NewRequest(http.MethodPost, "/expenses", strings.NewReader(`{"amount":1000,"category_id":1,"spent_at":"2025-06-01"}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
@@ -23,7 +23,8 @@ type initialSetupRequest struct {
 
 func NewInitialSetupHandler(r gin.IRouter, service services.InitialSetupService) {
 	h := &InitialSetupHandler{service: service}
-	r.POST("/setup", h.CompleteInitialSetup)
+	// 初期設定はログイン中のユーザー本人のみ実行できる
+	r.POST("/setup", middleware.RequireSession(), h.CompleteInitialSetup)
 }
 
 func (h *InitialSetupHandler) CompleteInitialSetup(c *gin.Context) {
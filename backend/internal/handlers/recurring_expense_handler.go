@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"money-buddy-backend/internal/middleware"
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/services"
+)
+
+const (
+	// recurringScopeOccurrence は「この回のみ」を対象とする scope の値です
+	recurringScopeOccurrence = "occurrence"
+	// recurringScopeFuture は「以降すべて」を対象とする scope の値です（既定）
+	recurringScopeFuture = "future"
+)
+
+type RecurringExpenseHandler struct {
+	service services.RecurringExpenseService
+}
+
+func NewRecurringExpenseHandler(r gin.IRouter, service services.RecurringExpenseService) {
+	h := &RecurringExpenseHandler{service: service}
+	// パーソナルアクセストークンでは read で参照のみ許可し、変更はログイン中のユーザー（世帯の閲覧者を除く）に限る
+	read := middleware.RequireScope(models.APITokenScopeRead)
+	session := middleware.RequireSession()
+	editor := middleware.RequireEditor()
+	r.GET("/recurring-expenses", read, h.ListRules)
+	r.POST("/recurring-expenses", session, editor, h.CreateRule)
+	r.POST("/recurring-expenses/materialize", session, editor, h.Materialize)
+	r.PUT("/recurring-expenses/:id", session, editor, h.UpdateRule)
+	r.DELETE("/recurring-expenses/:id", session, editor, h.DeleteRule)
+}
+
+// ListRules は繰り返しルール一覧を取得します
+func (h *RecurringExpenseHandler) ListRules(c *gin.Context) {
+	userID, ok := middleware.GetDataOwnerID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	rules, err := h.service.ListRules(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "繰り返しルールの取得に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"recurring_expenses": rules})
+}
+
+// CreateRule は繰り返しルールを作成し、予定支出を生成します
+func (h *RecurringExpenseHandler) CreateRule(c *gin.Context) {
+	userID, ok := middleware.GetDataOwnerID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	var req models.RecurringRuleInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule, err := h.service.CreateRule(c.Request.Context(), userID, req)
+	if err != nil {
+		h.handleError(c, err, "繰り返しルールの作成に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"recurring_expense": rule})
+}
+
+// UpdateRule は繰り返しルールを変更します。
+// scope=occurrence の場合は date の回のみ、scope=future（既定）の場合は date（省略時は今日）以降のすべての回を変更します。
+func (h *RecurringExpenseHandler) UpdateRule(c *gin.Context) {
+	userID, ok := middleware.GetDataOwnerID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "IDが正しくありません"})
+		return
+	}
+
+	scope, ok := parseRecurringScope(c)
+	if !ok {
+		return
+	}
+
+	if scope == recurringScopeOccurrence {
+		var req models.RecurringOccurrenceInput
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		expense, err := h.service.UpdateOccurrence(c.Request.Context(), userID, id, c.Query("date"), req)
+		if err != nil {
+			h.handleError(c, err, "繰り返しルールの更新に失敗しました")
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{"expense": expense})
+		return
+	}
+
+	var req models.RecurringRuleInput
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule, err := h.service.UpdateFutureOccurrences(c.Request.Context(), userID, id, c.Query("date"), req)
+	if err != nil {
+		h.handleError(c, err, "繰り返しルールの更新に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"recurring_expense": rule})
+}
+
+// DeleteRule は繰り返しルールを削除します。
+// scope=occurrence の場合は date の回のみ、scope=future（既定）の場合は date（省略時は今日）以降のすべての回を削除します。
+func (h *RecurringExpenseHandler) DeleteRule(c *gin.Context) {
+	userID, ok := middleware.GetDataOwnerID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "IDが正しくありません"})
+		return
+	}
+
+	scope, ok := parseRecurringScope(c)
+	if !ok {
+		return
+	}
+
+	if scope == recurringScopeOccurrence {
+		err = h.service.DeleteOccurrence(c.Request.Context(), userID, id, c.Query("date"))
+	} else {
+		err = h.service.DeleteFutureOccurrences(c.Request.Context(), userID, id, c.Query("date"))
+	}
+	if err != nil {
+		h.handleError(c, err, "繰り返しルールの削除に失敗しました")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// Materialize は生成期間内で未生成の予定支出を生成します
+func (h *RecurringExpenseHandler) Materialize(c *gin.Context) {
+	userID, ok := middleware.GetDataOwnerID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	created, err := h.service.Materialize(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "予定支出の生成に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"created": created})
+}
+
+// parseRecurringScope は scope クエリを解析します。不正な値の場合は 400 を返し false を返します。
+func parseRecurringScope(c *gin.Context) (string, bool) {
+	switch scope := c.DefaultQuery("scope", recurringScopeFuture); scope {
+	case recurringScopeOccurrence, recurringScopeFuture:
+		return scope, true
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "scope は occurrence または future を指定してください"})
+		return "", false
+	}
+}
+
+// handleError はサービス層のエラーをHTTPレスポンスに変換します
+func (h *RecurringExpenseHandler) handleError(c *gin.Context, err error, internalMessage string) {
+	var ve *services.ValidationError
+	if errors.As(err, &ve) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": ve.Message})
+		return
+	}
+	var ne *services.NotFoundError
+	if errors.As(err, &ne) {
+		c.JSON(http.StatusNotFound, gin.H{"error": ne.Message})
+		return
+	}
+	// 「この回のみ」の変更中に支出が他の端末で更新された場合
+	if errors.Is(err, services.ErrVersionConflict) {
+		c.JSON(http.StatusConflict, gin.H{"error": "他の端末で更新されています。最新の内容を確認してください"})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": internalMessage})
+}
@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/services"
+)
+
+// recurringExpenseServiceMock is a mock implementing services.RecurringExpenseService
+type recurringExpenseServiceMock struct {
+	UpdateOccurrenceFunc        func(ctx context.Context, userID string, id int, date string, input models.RecurringOccurrenceInput) (models.Expense, error)
+	UpdateFutureOccurrencesFunc func(ctx context.Context, userID string, id int, from string, input models.RecurringRuleInput) (models.RecurringRule, error)
+	DeleteOccurrenceFunc        func(ctx context.Context, userID string, id int, date string) error
+	DeleteFutureOccurrencesFunc func(ctx context.Context, userID string, id int, from string) error
+}
+
+func (m *recurringExpenseServiceMock) ListRules(ctx context.Context, userID string) ([]models.RecurringRule, error) {
+	return nil, nil
+}
+
+func (m *recurringExpenseServiceMock) CreateRule(ctx context.Context, userID string, input models.RecurringRuleInput) (models.RecurringRule, error) {
+	return models.RecurringRule{}, nil
+}
+
+func (m *recurringExpenseServiceMock) UpdateOccurrence(ctx context.Context, userID string, id int, date string, input models.RecurringOccurrenceInput) (models.Expense, error) {
+	if m.UpdateOccurrenceFunc != nil {
+		return m.UpdateOccurrenceFunc(ctx, userID, id, date, input)
+	}
+	return models.Expense{}, nil
+}
+
+func (m *recurringExpenseServiceMock) UpdateFutureOccurrences(ctx context.Context, userID string, id int, from string, input models.RecurringRuleInput) (models.RecurringRule, error) {
+	if m.UpdateFutureOccurrencesFunc != nil {
+		return m.UpdateFutureOccurrencesFunc(ctx, userID, id, from, input)
+	}
+	return models.RecurringRule{}, nil
+}
+
+func (m *recurringExpenseServiceMock) DeleteOccurrence(ctx context.Context, userID string, id int, date string) error {
+	if m.DeleteOccurrenceFunc != nil {
+		return m.DeleteOccurrenceFunc(ctx, userID, id, date)
+	}
+	return nil
+}
+
+func (m *recurringExpenseServiceMock) DeleteFutureOccurrences(ctx context.Context, userID string, id int, from string) error {
+	if m.DeleteFutureOccurrencesFunc != nil {
+		return m.DeleteFutureOccurrencesFunc(ctx, userID, id, from)
+	}
+	return nil
+}
+
+func (m *recurringExpenseServiceMock) Materialize(ctx context.Context, userID string) (int, error) {
+	return 0, nil
+}
+
+func (m *recurringExpenseServiceMock) MaterializeAll(ctx context.Context) (int, error) {
+	return 0, nil
+}
+
+// TestRecurringExpenseHandler_Scope は scope クエリで「この回のみ」「以降すべて」が振り分けられることをテストします
+func TestRecurringExpenseHandler_Scope(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		url        string
+		wantCalled string
+		wantDate   string
+		wantStatus int
+	}{
+		{name: "更新: この回のみ", method: http.MethodPut, url: "/recurring-expenses/3?scope=occurrence&date=2025-01-08", wantCalled: "UpdateOccurrence", wantDate: "2025-01-08", wantStatus: http.StatusOK},
+		{name: "更新: 既定は以降すべて", method: http.MethodPut, url: "/recurring-expenses/3", wantCalled: "UpdateFutureOccurrences", wantStatus: http.StatusOK},
+		{name: "削除: この回のみ", method: http.MethodDelete, url: "/recurring-expenses/3?scope=occurrence&date=2025-01-08", wantCalled: "DeleteOccurrence", wantDate: "2025-01-08", wantStatus: http.StatusNoContent},
+		{name: "削除: 以降すべて", method: http.MethodDelete, url: "/recurring-expenses/3?scope=future&date=2025-02-01", wantCalled: "DeleteFutureOccurrences", wantDate: "2025-02-01", wantStatus: http.StatusNoContent},
+		{name: "不正な scope", method: http.MethodDelete, url: "/recurring-expenses/3?scope=all", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var called, gotDate string
+			svc := &recurringExpenseServiceMock{
+				UpdateOccurrenceFunc: func(ctx context.Context, userID string, id int, date string, input models.RecurringOccurrenceInput) (models.Expense, error) {
+					called, gotDate = "UpdateOccurrence", date
+					require.Equal(t, 3, id)
+					return models.Expense{}, nil
+				},
+				UpdateFutureOccurrencesFunc: func(ctx context.Context, userID string, id int, from string, input models.RecurringRuleInput) (models.RecurringRule, error) {
+					called, gotDate = "UpdateFutureOccurrences", from
+					return models.RecurringRule{}, nil
+				},
+				DeleteOccurrenceFunc: func(ctx context.Context, userID string, id int, date string) error {
+					called, gotDate = "DeleteOccurrence", date
+					return nil
+				},
+				DeleteFutureOccurrencesFunc: func(ctx context.Context, userID string, id int, from string) error {
+					called, gotDate = "DeleteFutureOccurrences", from
+					return nil
+				},
+			}
+			router := newAuthedRouter()
+			NewRecurringExpenseHandler(router, svc)
+
+			req := httptest.NewRequest(tt.method, tt.url, strings.NewReader(`{"amount":1000,"category_id":1}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.wantStatus, w.Code)
+			require.Equal(t, tt.wantCalled, called)
+			require.Equal(t, tt.wantDate, gotDate)
+		})
+	}
+}
+
+// TestRecurringExpenseHandler_NotFound はルールが存在しない場合に 404 を返すことをテストします
+func TestRecurringExpenseHandler_NotFound(t *testing.T) {
+	svc := &recurringExpenseServiceMock{
+		DeleteFutureOccurrencesFunc: func(ctx context.Context, userID string, id int, from string) error {
+			return &services.NotFoundError{Message: "繰り返しルールが見つかりません"}
+		},
+	}
+	router := newAuthedRouter()
+	NewRecurringExpenseHandler(router, svc)
+
+	req := httptest.NewRequest(http.MethodDelete, "/recurring-expenses/99", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,304 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"money-buddy-backend/internal/middleware"
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/services"
+)
+
+// TrendsResponse は推移レポートAPIのレスポンス構造です。
+type TrendsResponse struct {
+	From          string `json:"from"`
+	To            string `json:"to"`
+	FixedCostMode string `json:"fixed_cost_mode"`
+	// Months は月ごとの集計です（古い月から順）
+	Months []TrendMonthResponse `json:"months"`
+	// Categories はカテゴリ別の支出の推移です
+	Categories []CategoryTrendResponse `json:"categories"`
+}
+
+// TrendMonthResponse は推移レポートの1か月分の集計です。
+type TrendMonthResponse struct {
+	Month             string `json:"month"`
+	Income            int64  `json:"income"`
+	SavingGoal        int64  `json:"saving_goal"`
+	FixedCosts        int64  `json:"fixed_costs"`
+	VariableBudget    int64  `json:"variable_budget"`
+	ConfirmedExpenses int64  `json:"confirmed_expenses"`
+	PlannedExpenses   int64  `json:"planned_expenses"`
+	Remaining         int64  `json:"remaining"`
+}
+
+// CategoryTrendResponse はカテゴリ別の支出の推移です。
+// series は months と同じ月の並びで、支出がない月は 0 になります。
+type CategoryTrendResponse struct {
+	CategoryID   int                          `json:"category_id"`
+	CategoryName string                       `json:"category_name"`
+	Series       []CategoryTrendPointResponse `json:"series"`
+}
+
+// CategoryTrendPointResponse はカテゴリの1か月分の支出です。
+type CategoryTrendPointResponse struct {
+	Month             string `json:"month"`
+	ConfirmedExpenses int64  `json:"confirmed_expenses"`
+	PlannedExpenses   int64  `json:"planned_expenses"`
+}
+
+// EstimateAccuracyResponse は見積もり精度レポートAPIのレスポンス構造です。
+type EstimateAccuracyResponse struct {
+	From  string                        `json:"from"`
+	To    string                        `json:"to"`
+	Total EstimateAccuracyStatsResponse `json:"total"`
+	// Categories は期間全体のカテゴリ別の集計です
+	Categories []CategoryEstimateAccuracyResponse `json:"categories"`
+	// Months は予定から確定にした支出がある月ごとの集計です（古い月から順）
+	Months []MonthlyEstimateAccuracyResponse `json:"months"`
+}
+
+// EstimateAccuracyStatsResponse は予定金額と確定した金額の比較です。
+// variance は actual_total - planned_total で、正の値は見積もりより多く使ったことを表します。
+type EstimateAccuracyStatsResponse struct {
+	ExpenseCount        int64    `json:"expense_count"`
+	PlannedTotal        int64    `json:"planned_total"`
+	ActualTotal         int64    `json:"actual_total"`
+	Variance            int64    `json:"variance"`
+	VarianceRate        *float64 `json:"variance_rate"`
+	Accuracy            *float64 `json:"accuracy"`
+	UnderestimatedCount int64    `json:"underestimated_count"`
+	OverestimatedCount  int64    `json:"overestimated_count"`
+}
+
+// CategoryEstimateAccuracyResponse はカテゴリ別の見積もり精度です。
+type CategoryEstimateAccuracyResponse struct {
+	CategoryID   int    `json:"category_id"`
+	CategoryName string `json:"category_name"`
+	EstimateAccuracyStatsResponse
+}
+
+// MonthlyEstimateAccuracyResponse は1か月分の見積もり精度です。
+type MonthlyEstimateAccuracyResponse struct {
+	Month string `json:"month"`
+	EstimateAccuracyStatsResponse
+	Categories []CategoryEstimateAccuracyResponse `json:"categories"`
+}
+
+// TagTotalsResponse はタグ別レポートAPIのレスポンス構造です。
+type TagTotalsResponse struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	// Tags は期間内に支出があるタグごとの集計です（確定支出の多い順）
+	Tags []TagTotalResponse `json:"tags"`
+}
+
+// TagTotalResponse はタグ別の支出の集計です。
+type TagTotalResponse struct {
+	TagID             int                           `json:"tag_id"`
+	TagName           string                        `json:"tag_name"`
+	ExpenseCount      int64                         `json:"expense_count"`
+	ConfirmedExpenses int64                         `json:"confirmed_expenses"`
+	PlannedExpenses   int64                         `json:"planned_expenses"`
+	Categories        []TagCategoryExpensesResponse `json:"categories"`
+}
+
+// TagCategoryExpensesResponse はタグの支出のカテゴリ別の内訳です。
+type TagCategoryExpensesResponse struct {
+	CategoryID        int    `json:"category_id"`
+	CategoryName      string `json:"category_name"`
+	ConfirmedExpenses int64  `json:"confirmed_expenses"`
+	PlannedExpenses   int64  `json:"planned_expenses"`
+}
+
+type ReportHandler struct {
+	service services.ReportService
+}
+
+func NewReportHandler(r gin.IRouter, service services.ReportService) {
+	h := &ReportHandler{service: service}
+	read := middleware.RequireScope(models.APITokenScopeRead)
+	r.GET("/reports/trends", read, h.GetTrends)
+	r.GET("/reports/estimate-accuracy", read, h.GetEstimateAccuracy)
+	r.GET("/reports/tags", read, h.GetTagTotals)
+}
+
+// GetTrends handles GET /reports/trends.
+// from から to（YYYY-MM）までの月ごとの集計とカテゴリ別の支出の推移を返します。
+func (h *ReportHandler) GetTrends(c *gin.Context) {
+	userID, ok := middleware.GetDataOwnerID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	trends, err := h.service.GetTrends(c.Request.Context(), userID, c.Query("from"), c.Query("to"), c.Query("fixed_cost_mode"))
+	if err != nil {
+		// 期間・固定費の計上方法が不正な場合
+		var ve *services.ValidationError
+		if errors.As(err, &ve) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ve.Message})
+			return
+		}
+		// ユーザーが存在しない場合
+		if errors.Is(err, sql.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "ユーザーが見つかりません"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "推移の取得に失敗しました"})
+		return
+	}
+
+	response := TrendsResponse{
+		From:          trends.From,
+		To:            trends.To,
+		FixedCostMode: string(trends.FixedCostMode),
+		Months:        make([]TrendMonthResponse, 0, len(trends.Months)),
+		Categories:    make([]CategoryTrendResponse, 0, len(trends.Categories)),
+	}
+	for _, m := range trends.Months {
+		response.Months = append(response.Months, TrendMonthResponse{
+			Month:             m.Month,
+			Income:            m.Income,
+			SavingGoal:        m.SavingGoal,
+			FixedCosts:        m.FixedCosts,
+			VariableBudget:    m.VariableBudget,
+			ConfirmedExpenses: m.ConfirmedExpenses,
+			PlannedExpenses:   m.PlannedExpenses,
+			Remaining:         m.Remaining,
+		})
+	}
+	for _, ct := range trends.Categories {
+		item := CategoryTrendResponse{
+			CategoryID:   ct.CategoryID,
+			CategoryName: ct.CategoryName,
+			Series:       make([]CategoryTrendPointResponse, 0, len(ct.Series)),
+		}
+		for _, p := range ct.Series {
+			item.Series = append(item.Series, CategoryTrendPointResponse{
+				Month:             p.Month,
+				ConfirmedExpenses: p.ConfirmedExpenses,
+				PlannedExpenses:   p.PlannedExpenses,
+			})
+		}
+		response.Categories = append(response.Categories, item)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetEstimateAccuracy handles GET /reports/estimate-accuracy.
+// from から to（YYYY-MM）までに予定から確定にした支出の見積もり精度を月別・カテゴリ別に返します。
+func (h *ReportHandler) GetEstimateAccuracy(c *gin.Context) {
+	userID, ok := middleware.GetDataOwnerID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	report, err := h.service.GetEstimateAccuracy(c.Request.Context(), userID, c.Query("from"), c.Query("to"))
+	if err != nil {
+		// 期間が不正な場合
+		var ve *services.ValidationError
+		if errors.As(err, &ve) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ve.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "見積もり精度の取得に失敗しました"})
+		return
+	}
+
+	response := EstimateAccuracyResponse{
+		From:       report.From,
+		To:         report.To,
+		Total:      toEstimateAccuracyStatsResponse(report.Total),
+		Categories: toCategoryEstimateAccuracyResponses(report.Categories),
+		Months:     make([]MonthlyEstimateAccuracyResponse, 0, len(report.Months)),
+	}
+	for _, m := range report.Months {
+		response.Months = append(response.Months, MonthlyEstimateAccuracyResponse{
+			Month:                         m.Month,
+			EstimateAccuracyStatsResponse: toEstimateAccuracyStatsResponse(m.EstimateAccuracyStats),
+			Categories:                    toCategoryEstimateAccuracyResponses(m.Categories),
+		})
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetTagTotals handles GET /reports/tags.
+// from から to（YYYY-MM-DD）までの支出をタグ別・カテゴリ別に返します。
+func (h *ReportHandler) GetTagTotals(c *gin.Context) {
+	userID, ok := middleware.GetDataOwnerID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	report, err := h.service.GetTagTotals(c.Request.Context(), userID, c.Query("from"), c.Query("to"))
+	if err != nil {
+		// 期間が不正な場合
+		var ve *services.ValidationError
+		if errors.As(err, &ve) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ve.Message})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "タグ別の集計の取得に失敗しました"})
+		return
+	}
+
+	response := TagTotalsResponse{
+		From: report.From,
+		To:   report.To,
+		Tags: make([]TagTotalResponse, 0, len(report.Tags)),
+	}
+	for _, tt := range report.Tags {
+		item := TagTotalResponse{
+			TagID:             tt.TagID,
+			TagName:           tt.TagName,
+			ExpenseCount:      tt.ExpenseCount,
+			ConfirmedExpenses: tt.ConfirmedExpenses,
+			PlannedExpenses:   tt.PlannedExpenses,
+			Categories:        make([]TagCategoryExpensesResponse, 0, len(tt.Categories)),
+		}
+		for _, ca := range tt.Categories {
+			item.Categories = append(item.Categories, TagCategoryExpensesResponse{
+				CategoryID:        ca.CategoryID,
+				CategoryName:      ca.CategoryName,
+				ConfirmedExpenses: ca.ConfirmedExpenses,
+				PlannedExpenses:   ca.PlannedExpenses,
+			})
+		}
+		response.Tags = append(response.Tags, item)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func toEstimateAccuracyStatsResponse(st services.EstimateAccuracyStats) EstimateAccuracyStatsResponse {
+	return EstimateAccuracyStatsResponse{
+		ExpenseCount:        st.ExpenseCount,
+		PlannedTotal:        st.PlannedTotal,
+		ActualTotal:         st.ActualTotal,
+		Variance:            st.Variance,
+		VarianceRate:        st.VarianceRate,
+		Accuracy:            st.Accuracy,
+		UnderestimatedCount: st.UnderestimatedCount,
+		OverestimatedCount:  st.OverestimatedCount,
+	}
+}
+
+func toCategoryEstimateAccuracyResponses(categories []services.CategoryEstimateAccuracy) []CategoryEstimateAccuracyResponse {
+	out := make([]CategoryEstimateAccuracyResponse, 0, len(categories))
+	for _, ca := range categories {
+		out = append(out, CategoryEstimateAccuracyResponse{
+			CategoryID:                    ca.CategoryID,
+			CategoryName:                  ca.CategoryName,
+			EstimateAccuracyStatsResponse: toEstimateAccuracyStatsResponse(ca.EstimateAccuracyStats),
+		})
+	}
+	return out
+}
@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/services"
+)
+
+// reportServiceMock は ReportService のモック実装です
+type reportServiceMock struct {
+	GetTrendsFunc           func(ctx context.Context, userID string, from, to string, fixedCostMode string) (*services.Trends, error)
+	GetEstimateAccuracyFunc func(ctx context.Context, userID string, from, to string) (*services.EstimateAccuracy, error)
+	GetTagTotalsFunc        func(ctx context.Context, userID string, from, to string) (*services.TagTotals, error)
+}
+
+func (m *reportServiceMock) GetTrends(ctx context.Context, userID string, from, to string, fixedCostMode string) (*services.Trends, error) {
+	if m.GetTrendsFunc != nil {
+		return m.GetTrendsFunc(ctx, userID, from, to, fixedCostMode)
+	}
+	return nil, nil
+}
+
+func (m *reportServiceMock) GetEstimateAccuracy(ctx context.Context, userID string, from, to string) (*services.EstimateAccuracy, error) {
+	if m.GetEstimateAccuracyFunc != nil {
+		return m.GetEstimateAccuracyFunc(ctx, userID, from, to)
+	}
+	return nil, nil
+}
+
+func (m *reportServiceMock) GetTagTotals(ctx context.Context, userID string, from, to string) (*services.TagTotals, error) {
+	if m.GetTagTotalsFunc != nil {
+		return m.GetTagTotalsFunc(ctx, userID, from, to)
+	}
+	return nil, nil
+}
+
+// TestReportHandler_GetTrends は推移レポートの正常系のテストです
+func TestReportHandler_GetTrends(t *testing.T) {
+	router := newAuthedRouter()
+	var gotFrom, gotTo, gotMode string
+	svc := &reportServiceMock{
+		GetTrendsFunc: func(ctx context.Context, userID string, from, to string, fixedCostMode string) (*services.Trends, error) {
+			gotFrom, gotTo, gotMode = from, to, fixedCostMode
+			return &services.Trends{
+				From:          "2025-01",
+				To:            "2025-02",
+				FixedCostMode: models.FixedCostModeAmortize,
+				Months: []*services.Dashboard{
+					{Month: "2025-01", Income: 300000, SavingGoal: 50000, FixedCosts: 100000, VariableBudget: 150000, ConfirmedExpenses: 80000, PlannedExpenses: 20000, Remaining: 50000},
+					{Month: "2025-02", Income: 300000, SavingGoal: 50000, FixedCosts: 100000, VariableBudget: 150000, Remaining: 150000},
+				},
+				Categories: []services.CategoryTrend{
+					{CategoryID: 1, CategoryName: "食費", Series: []services.CategoryMonthlyExpenses{
+						{Month: "2025-01", ConfirmedExpenses: 30000},
+						{Month: "2025-02"},
+					}},
+				},
+			}, nil
+		},
+	}
+	NewReportHandler(router, svc)
+
+	req := httptest.NewRequest(http.MethodGet, "/reports/trends?from=2025-01&to=2025-02&fixed_cost_mode=amortize", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "2025-01", gotFrom)
+	assert.Equal(t, "2025-02", gotTo)
+	assert.Equal(t, "amortize", gotMode)
+	assert.JSONEq(t, `{
+		"from": "2025-01", "to": "2025-02", "fixed_cost_mode": "amortize",
+		"months": [
+			{"month": "2025-01", "income": 300000, "saving_goal": 50000, "fixed_costs": 100000, "variable_budget": 150000, "confirmed_expenses": 80000, "planned_expenses": 20000, "remaining": 50000},
+			{"month": "2025-02", "income": 300000, "saving_goal": 50000, "fixed_costs": 100000, "variable_budget": 150000, "confirmed_expenses": 0, "planned_expenses": 0, "remaining": 150000}
+		],
+		"categories": [
+			{"category_id": 1, "category_name": "食費", "series": [
+				{"month": "2025-01", "confirmed_expenses": 30000, "planned_expenses": 0},
+				{"month": "2025-02", "confirmed_expenses": 0, "planned_expenses": 0}
+			]}
+		]
+	}`, w.Body.String())
+}
+
+// TestReportHandler_GetTrends_Errors は推移レポートのエラー時のテストです
+func TestReportHandler_GetTrends_Errors(t *testing.T) {
+	cases := []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "期間が不正", svcErr: &services.ValidationError{Message: "開始月は終了月以前を指定してください"}, wantStatus: http.StatusBadRequest},
+		{name: "ユーザーが存在しない", svcErr: sql.ErrNoRows, wantStatus: http.StatusNotFound},
+		{name: "内部エラー", svcErr: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := newAuthedRouter()
+			svc := &reportServiceMock{
+				GetTrendsFunc: func(ctx context.Context, userID string, from, to string, fixedCostMode string) (*services.Trends, error) {
+					return nil, tc.svcErr
+				},
+			}
+			NewReportHandler(router, svc)
+
+			req := httptest.NewRequest(http.MethodGet, "/reports/trends?from=2025-03&to=2025-02", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.wantStatus, w.Code)
+		})
+	}
+}
+
+// TestReportHandler_GetEstimateAccuracy は見積もり精度レポートのテストです
+func TestReportHandler_GetEstimateAccuracy(t *testing.T) {
+	router := newAuthedRouter()
+	var gotFrom, gotTo string
+	rate, accuracy := 10.0, 83.3
+	svc := &reportServiceMock{
+		GetEstimateAccuracyFunc: func(ctx context.Context, userID string, from, to string) (*services.EstimateAccuracy, error) {
+			gotFrom, gotTo = from, to
+			stats := services.EstimateAccuracyStats{ExpenseCount: 2, PlannedTotal: 30000, ActualTotal: 33000, Variance: 3000, VarianceRate: &rate, Accuracy: &accuracy, UnderestimatedCount: 1, OverestimatedCount: 1}
+			category := services.CategoryEstimateAccuracy{CategoryID: 1, CategoryName: "食費", EstimateAccuracyStats: stats}
+			return &services.EstimateAccuracy{
+				From:       "2025-01",
+				To:         "2025-02",
+				Total:      stats,
+				Categories: []services.CategoryEstimateAccuracy{category},
+				Months: []services.MonthlyEstimateAccuracy{
+					{Month: "2025-02", EstimateAccuracyStats: stats, Categories: []services.CategoryEstimateAccuracy{category}},
+				},
+			}, nil
+		},
+	}
+	NewReportHandler(router, svc)
+
+	req := httptest.NewRequest(http.MethodGet, "/reports/estimate-accuracy?from=2025-01&to=2025-02", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "2025-01", gotFrom)
+	assert.Equal(t, "2025-02", gotTo)
+	stats := `"expense_count": 2, "planned_total": 30000, "actual_total": 33000, "variance": 3000, "variance_rate": 10, "accuracy": 83.3, "underestimated_count": 1, "overestimated_count": 1`
+	assert.JSONEq(t, `{
+		"from": "2025-01", "to": "2025-02",
+		"total": {`+stats+`},
+		"categories": [{"category_id": 1, "category_name": "食費", `+stats+`}],
+		"months": [
+			{"month": "2025-02", `+stats+`, "categories": [{"category_id": 1, "category_name": "食費", `+stats+`}]}
+		]
+	}`, w.Body.String())
+}
+
+// TestReportHandler_GetEstimateAccuracy_Errors は見積もり精度レポートのエラー時のテストです
+func TestReportHandler_GetEstimateAccuracy_Errors(t *testing.T) {
+	cases := []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "期間が不正", svcErr: &services.ValidationError{Message: "開始月は終了月以前を指定してください"}, wantStatus: http.StatusBadRequest},
+		{name: "内部エラー", svcErr: errors.New("db down"), wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := newAuthedRouter()
+			svc := &reportServiceMock{
+				GetEstimateAccuracyFunc: func(ctx context.Context, userID string, from, to string) (*services.EstimateAccuracy, error) {
+					return nil, tc.svcErr
+				},
+			}
+			NewReportHandler(router, svc)
+
+			req := httptest.NewRequest(http.MethodGet, "/reports/estimate-accuracy?from=2025-03&to=2025-02", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.wantStatus, w.Code)
+		})
+	}
+}
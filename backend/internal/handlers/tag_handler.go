@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"money-buddy-backend/internal/middleware"
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/services"
+)
+
+type TagHandler struct {
+	service services.TagService
+}
+
+func NewTagHandler(r gin.IRouter, service services.TagService) {
+	h := &TagHandler{service: service}
+	// パーソナルアクセストークンでは read で参照のみ許可し、変更はログイン中のユーザー（世帯の閲覧者を除く）に限る
+	read := middleware.RequireScope(models.APITokenScopeRead)
+	session := middleware.RequireSession()
+	editor := middleware.RequireEditor()
+	r.GET("/tags", read, h.ListTags)
+	r.PUT("/tags/:id", session, editor, h.RenameTag)
+	r.DELETE("/tags/:id", session, editor, h.DeleteTag)
+}
+
+// ListTags はタグの一覧を、付けられている支出の件数とあわせて取得します
+func (h *TagHandler) ListTags(c *gin.Context) {
+	userID, ok := middleware.GetDataOwnerID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	tags, err := h.service.ListTags(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "タグの取得に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tags": tags})
+}
+
+// TagRequest はタグ名の変更のリクエストボディです
+type TagRequest struct {
+	Name string `json:"name"`
+}
+
+// RenameTag はタグの名前を変更します。タグを付けた支出にも反映されます
+func (h *TagHandler) RenameTag(c *gin.Context) {
+	userID, ok := middleware.GetDataOwnerID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "IDが正しくありません"})
+		return
+	}
+
+	var req TagRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tag, err := h.service.RenameTag(c.Request.Context(), userID, id, req.Name)
+	if err != nil {
+		h.handleError(c, err, "タグの更新に失敗しました")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tag": tag})
+}
+
+// DeleteTag はタグを削除します。タグを付けていた支出からは外れます（支出は削除しません）
+func (h *TagHandler) DeleteTag(c *gin.Context) {
+	userID, ok := middleware.GetDataOwnerID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "IDが正しくありません"})
+		return
+	}
+
+	if err := h.service.DeleteTag(c.Request.Context(), userID, id); err != nil {
+		h.handleError(c, err, "タグの削除に失敗しました")
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// handleError はサービス層のエラーをHTTPレスポンスに変換します
+func (h *TagHandler) handleError(c *gin.Context, err error, internalMessage string) {
+	var ve *services.ValidationError
+	if errors.As(err, &ve) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": ve.Message})
+		return
+	}
+	var ne *services.NotFoundError
+	if errors.As(err, &ne) {
+		c.JSON(http.StatusNotFound, gin.H{"error": ne.Message})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": internalMessage})
+}
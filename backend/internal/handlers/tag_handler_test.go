@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/services"
+)
+
+// tagServiceMock is a mock implementing services.TagService
+type tagServiceMock struct {
+	ListTagsFunc  func(ctx context.Context, userID string) ([]models.TagSummary, error)
+	RenameTagFunc func(ctx context.Context, userID string, id int, name string) (models.Tag, error)
+	DeleteTagFunc func(ctx context.Context, userID string, id int) error
+}
+
+func (m *tagServiceMock) ListTags(ctx context.Context, userID string) ([]models.TagSummary, error) {
+	if m.ListTagsFunc != nil {
+		return m.ListTagsFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *tagServiceMock) RenameTag(ctx context.Context, userID string, id int, name string) (models.Tag, error) {
+	if m.RenameTagFunc != nil {
+		return m.RenameTagFunc(ctx, userID, id, name)
+	}
+	return models.Tag{}, nil
+}
+
+func (m *tagServiceMock) DeleteTag(ctx context.Context, userID string, id int) error {
+	if m.DeleteTagFunc != nil {
+		return m.DeleteTagFunc(ctx, userID, id)
+	}
+	return nil
+}
+
+// TestListTags はタグ一覧が件数つきで返ることをテストします
+func TestListTags(t *testing.T) {
+	router := newAuthedRouter()
+	svc := &tagServiceMock{
+		ListTagsFunc: func(ctx context.Context, userID string) ([]models.TagSummary, error) {
+			require.Equal(t, DummyUserID, userID)
+			return []models.TagSummary{{Tag: models.Tag{ID: 1, Name: "結婚式"}, ExpenseCount: 4}}, nil
+		},
+	}
+	NewTagHandler(router, svc)
+
+	req := httptest.NewRequest(http.MethodGet, "/tags", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"tags":[{"id":1,"name":"結婚式","expense_count":4}]}`, w.Body.String())
+}
+
+// TestRenameTag はタグ名の変更とエラーの変換をテストします
+func TestRenameTag(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "変更できる", url: "/tags/3", wantStatus: http.StatusOK},
+		{name: "重複する名前は400", url: "/tags/3", svcErr: &services.ValidationError{Message: "同じ名前のタグが既に存在します"}, wantStatus: http.StatusBadRequest},
+		{name: "存在しないタグは404", url: "/tags/3", svcErr: &services.NotFoundError{Message: "タグが見つかりません"}, wantStatus: http.StatusNotFound},
+		{name: "IDが数値でない場合は400", url: "/tags/abc", wantStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newAuthedRouter()
+			svc := &tagServiceMock{
+				RenameTagFunc: func(ctx context.Context, userID string, id int, name string) (models.Tag, error) {
+					require.Equal(t, 3, id)
+					require.Equal(t, "仕事関連", name)
+					return models.Tag{ID: id, Name: name}, tt.svcErr
+				},
+			}
+			NewTagHandler(router, svc)
+
+			req := httptest.NewRequest(http.MethodPut, tt.url, strings.NewReader(`{"name":"仕事関連"}`))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus == http.StatusOK {
+				var resp struct {
+					Tag models.Tag `json:"tag"`
+				}
+				require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+				require.Equal(t, models.Tag{ID: 3, Name: "仕事関連"}, resp.Tag)
+			}
+		})
+	}
+}
+
+// TestDeleteTag は削除の成功と存在しないタグの404をテストします
+func TestDeleteTag(t *testing.T) {
+	for _, tt := range []struct {
+		name       string
+		svcErr     error
+		wantStatus int
+	}{
+		{name: "削除できる", wantStatus: http.StatusNoContent},
+		{name: "存在しないタグは404", svcErr: &services.NotFoundError{Message: "タグが見つかりません"}, wantStatus: http.StatusNotFound},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newAuthedRouter()
+			NewTagHandler(router, &tagServiceMock{
+				DeleteTagFunc: func(ctx context.Context, userID string, id int) error { return tt.svcErr },
+			})
+
+			req := httptest.NewRequest(http.MethodDelete, "/tags/3", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.wantStatus, w.Code)
+		})
+	}
+}
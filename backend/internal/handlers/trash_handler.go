@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"money-buddy-backend/internal/middleware"
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/services"
+)
+
+type TrashHandler struct {
+	service services.TrashService
+}
+
+func NewTrashHandler(r gin.IRouter, service services.TrashService) {
+	h := &TrashHandler{service: service}
+	read := middleware.RequireScope(models.APITokenScopeRead)
+	r.GET("/trash", read, h.ListTrash)
+}
+
+// ListTrash はゴミ箱の支出・固定費を取得します（削除から30日以内のもの）
+func (h *TrashHandler) ListTrash(c *gin.Context) {
+	userID, ok := middleware.GetDataOwnerID(c)
+	if !ok {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+		return
+	}
+
+	trash, err := h.service.ListTrash(c.Request.Context(), userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "ゴミ箱の取得に失敗しました"})
+		return
+	}
+
+	c.JSON(http.StatusOK, trash)
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"money-buddy-backend/internal/models"
+)
+
+// trashServiceMock is a mock implementing services.TrashService
+type trashServiceMock struct {
+	ListTrashFunc func(ctx context.Context, userID string) (models.Trash, error)
+}
+
+func (m *trashServiceMock) ListTrash(ctx context.Context, userID string) (models.Trash, error) {
+	if m.ListTrashFunc != nil {
+		return m.ListTrashFunc(ctx, userID)
+	}
+	return models.Trash{}, nil
+}
+
+func (m *trashServiceMock) PurgeExpired(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+// TestListTrash はゴミ箱の一覧が返ることをテストします
+func TestListTrash(t *testing.T) {
+	router := newAuthedRouter()
+	NewTrashHandler(router, &trashServiceMock{
+		ListTrashFunc: func(ctx context.Context, userID string) (models.Trash, error) {
+			require.Equal(t, DummyUserID, userID)
+			return models.Trash{
+				Expenses: []models.TrashedExpense{{
+					Expense:         models.Expense{ID: 1, Amount: 1200, Category: models.Category{ID: 2, Name: "食費"}, Version: 2},
+					DeletedAt:       "2025-06-01T12:30:00Z",
+					RestorableUntil: "2025-07-01T12:30:00Z",
+				}},
+				FixedCosts: []models.TrashedFixedCost{},
+			}, nil
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/trash", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{
+		"expenses": [{
+			"id": 1, "amount": 1200, "memo": "", "spent_at": "", "status": "", "created_by": "", "version": 2,
+			"category": {"id": 2, "name": "食費"}, "planned_amount": null, "variance": null,
+			"deleted_at": "2025-06-01T12:30:00Z", "restorable_until": "2025-07-01T12:30:00Z"
+		}],
+		"fixed_costs": []
+	}`, w.Body.String())
+}
+
+// TestListTrash_Error はゴミ箱の取得に失敗した場合に500を返すことをテストします
+func TestListTrash_Error(t *testing.T) {
+	router := newAuthedRouter()
+	NewTrashHandler(router, &trashServiceMock{
+		ListTrashFunc: func(ctx context.Context, userID string) (models.Trash, error) {
+			return models.Trash{}, errors.New("db error")
+		},
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/trash", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"money-buddy-backend/internal/middleware"
+	"money-buddy-backend/internal/models"
 	"money-buddy-backend/internal/services"
 )
 
@@ -16,8 +17,9 @@ type UserHandler struct {
 
 func NewUserHandler(r gin.IRouter, service services.UserService) {
 	h := &UserHandler{service: service}
-	r.GET("/user/me", h.GetCurrentUser)
-	r.PUT("/user/me", h.UpdateUserSettings)
+	// パーソナルアクセストークンでは read で参照のみ許可し、設定の変更はログイン中のユーザーに限る
+	r.GET("/user/me", middleware.RequireScope(models.APITokenScopeRead), h.GetCurrentUser)
+	r.PUT("/user/me", middleware.RequireSession(), h.UpdateUserSettings)
 }
 
 func (h *UserHandler) GetCurrentUser(c *gin.Context) {
@@ -36,9 +38,12 @@ func (h *UserHandler) GetCurrentUser(c *gin.Context) {
 	c.JSON(http.StatusOK, user)
 }
 
+// UpdateUserSettingsRequest はユーザー設定更新のリクエストボディです
+// effective_from（YYYY-MM）は新しい収入・貯金目標を適用する最初の月で、省略時は当月です
 type UpdateUserSettingsRequest struct {
-	Income     *int `json:"income"`
-	SavingGoal *int `json:"saving_goal"`
+	Income        *int   `json:"income"`
+	SavingGoal    *int   `json:"saving_goal"`
+	EffectiveFrom string `json:"effective_from"`
 }
 
 func (h *UserHandler) UpdateUserSettings(c *gin.Context) {
@@ -64,7 +69,7 @@ func (h *UserHandler) UpdateUserSettings(c *gin.Context) {
 		return
 	}
 
-	err := h.service.UpdateUserSettings(c.Request.Context(), userID, *req.Income, *req.SavingGoal)
+	err := h.service.UpdateUserSettings(c.Request.Context(), userID, *req.Income, *req.SavingGoal, req.EffectiveFrom)
 	if err != nil {
 		var validationErr *services.ValidationError
 		if errors.As(err, &validationErr) {
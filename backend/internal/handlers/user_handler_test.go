@@ -18,7 +18,7 @@ import (
 
 type userServiceMock struct {
 	GetUserByIDFunc        func(ctx context.Context, userID string) (*models.User, error)
-	UpdateUserSettingsFunc func(ctx context.Context, userID string, income int, savingGoal int) error
+	UpdateUserSettingsFunc func(ctx context.Context, userID string, income int, savingGoal int, effectiveFrom string) error
 }
 
 func (m *userServiceMock) GetUserByID(ctx context.Context, userID string) (*models.User, error) {
@@ -28,9 +28,9 @@ func (m *userServiceMock) GetUserByID(ctx context.Context, userID string) (*mode
 	return nil, nil
 }
 
-func (m *userServiceMock) UpdateUserSettings(ctx context.Context, userID string, income int, savingGoal int) error {
+func (m *userServiceMock) UpdateUserSettings(ctx context.Context, userID string, income int, savingGoal int, effectiveFrom string) error {
 	if m.UpdateUserSettingsFunc != nil {
-		return m.UpdateUserSettingsFunc(ctx, userID, income, savingGoal)
+		return m.UpdateUserSettingsFunc(ctx, userID, income, savingGoal, effectiveFrom)
 	}
 	return nil
 }
@@ -119,7 +119,7 @@ func TestUpdateUserSettingsHandler_Success(t *testing.T) {
 
 	called := false
 	svc := &userServiceMock{
-		UpdateUserSettingsFunc: func(ctx context.Context, userID string, income int, savingGoal int) error {
+		UpdateUserSettingsFunc: func(ctx context.Context, userID string, income int, savingGoal int, effectiveFrom string) error {
 			called = true
 			require.Equal(t, DummyUserID, userID)
 			require.Equal(t, 300000, income)
@@ -144,13 +144,35 @@ func TestUpdateUserSettingsHandler_Success(t *testing.T) {
 	require.Equal(t, "user settings updated successfully", resp["message"])
 }
 
+func TestUpdateUserSettingsHandler_EffectiveFrom(t *testing.T) {
+	router := newAuthedRouter()
+
+	var got string
+	svc := &userServiceMock{
+		UpdateUserSettingsFunc: func(ctx context.Context, userID string, income int, savingGoal int, effectiveFrom string) error {
+			got = effectiveFrom
+			return nil
+		},
+	}
+	NewUserHandler(router, svc)
+
+	body := `{"income": 320000, "saving_goal": 50000, "effective_from": "2025-04"}`
+	req := httptest.NewRequest(http.MethodPut, "/user/me", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "2025-04", got)
+}
+
 func TestUpdateUserSettingsHandler_InvalidJSON(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
 
 	called := false
 	svc := &userServiceMock{
-		UpdateUserSettingsFunc: func(ctx context.Context, userID string, income int, savingGoal int) error {
+		UpdateUserSettingsFunc: func(ctx context.Context, userID string, income int, savingGoal int, effectiveFrom string) error {
 			called = true
 			return nil
 		},
@@ -192,7 +214,7 @@ func TestUpdateUserSettingsHandler_MissingFields(t *testing.T) {
 
 			called := false
 			svc := &userServiceMock{
-				UpdateUserSettingsFunc: func(ctx context.Context, userID string, income int, savingGoal int) error {
+				UpdateUserSettingsFunc: func(ctx context.Context, userID string, income int, savingGoal int, effectiveFrom string) error {
 					called = true
 					return nil
 				},
@@ -245,7 +267,7 @@ func TestUpdateUserSettingsHandler_ValidationError(t *testing.T) {
 
 			called := false
 			svc := &userServiceMock{
-				UpdateUserSettingsFunc: func(ctx context.Context, userID string, income int, savingGoal int) error {
+				UpdateUserSettingsFunc: func(ctx context.Context, userID string, income int, savingGoal int, effectiveFrom string) error {
 					called = true
 					return &services.ValidationError{Message: tc.errorMessage}
 				},
@@ -274,7 +296,7 @@ func TestUpdateUserSettingsHandler_ServiceError(t *testing.T) {
 
 	called := false
 	svc := &userServiceMock{
-		UpdateUserSettingsFunc: func(ctx context.Context, userID string, income int, savingGoal int) error {
+		UpdateUserSettingsFunc: func(ctx context.Context, userID string, income int, savingGoal int, effectiveFrom string) error {
 			called = true
 			return errors.New("database connection error")
 		},
@@ -3,8 +3,10 @@ package middleware
 import (
 	"log"
 	"net/http"
+	"slices"
 	"strings"
 
+	"money-buddy-backend/internal/audit"
 	"money-buddy-backend/internal/auth"
 
 	"github.com/gin-gonic/gin"
@@ -14,7 +16,13 @@ type contextKey string
 
 const UserIDKey contextKey = "userID"
 
-func AuthMiddleware() gin.HandlerFunc {
+// ScopesKey はパーソナルアクセストークンで認証した場合の権限を保存するキーです。
+// ID トークンで認証した場合は保存しません（権限の制限なし）。
+const ScopesKey contextKey = "scopes"
+
+// AuthMiddleware は Authorization ヘッダーの Bearer トークンを verifier で検証し、
+// トークンが示すユーザーIDをコンテキストに保存します。
+func AuthMiddleware(verifier auth.TokenVerifier) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
@@ -40,7 +48,7 @@ func AuthMiddleware() gin.HandlerFunc {
 		}
 
 		// ID Token検証
-		token, err := auth.FirebaseAuth.VerifyIDToken(c.Request.Context(), idToken)
+		token, err := verifier.VerifyIDToken(c.Request.Context(), idToken)
 		if err != nil {
 			// エラーログから実際のトークンを除外（セキュリティ対策）
 			log.Printf("Failed to verify ID token: %v (token omitted for security)", err)
@@ -57,10 +65,52 @@ func AuthMiddleware() gin.HandlerFunc {
 			return
 		}
 		c.Set(string(UserIDKey), token.UID)
+		// 変更履歴に操作者として記録するため、リクエストのコンテキストにも設定する
+		c.Request = c.Request.WithContext(audit.WithActor(c.Request.Context(), token.UID))
+		if token.Scopes != nil {
+			c.Set(string(ScopesKey), token.Scopes)
+		}
+		c.Next()
+	}
+}
+
+// RequireScope はパーソナルアクセストークンで認証したリクエストに scope の権限がない場合に 403 を返します。
+// ID トークンで認証したリクエストはそのまま通します。
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scopes, restricted := GetScopes(c)
+		if restricted && !slices.Contains(scopes, scope) {
+			c.JSON(http.StatusForbidden, gin.H{"error": "このトークンには " + scope + " の権限がありません"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireSession はパーソナルアクセストークンで認証したリクエストに 403 を返します。
+// トークンの管理など、ログイン中のユーザー本人にのみ許可する操作に使います。
+func RequireSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, restricted := GetScopes(c); restricted {
+			c.JSON(http.StatusForbidden, gin.H{"error": "この操作はパーソナルアクセストークンでは実行できません"})
+			c.Abort()
+			return
+		}
 		c.Next()
 	}
 }
 
+// GetScopes はパーソナルアクセストークンの権限を取得します。
+// 第2戻り値は権限が制限されている（パーソナルアクセストークンで認証した）かを返します。
+func GetScopes(c *gin.Context) ([]string, bool) {
+	scopes, exists := c.Get(string(ScopesKey))
+	if !exists {
+		return nil, false
+	}
+	return scopes.([]string), true
+}
+
 // コンテキストからユーザーIDを取得するヘルパー関数
 // ミドルウェアを通過している場合は必ずユーザーIDが存在する
 // 第2戻り値でユーザーIDが存在するかを返す
@@ -0,0 +1,28 @@
+package middleware
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DBDeadline はリクエストのコンテキストに timeout 後の期限を設定します。
+// リポジトリはリクエストのコンテキストでクエリを実行するため、Neon のコールドスタートなどで期限を過ぎたクエリは中断されます。
+// クライアントが切断した場合もコンテキストがキャンセルされ、実行中のクエリは中断されます。
+// timeout が0以下の場合と、ルートが skipPaths のいずれかに一致する場合は期限を設定しません。
+// エクスポートのように 200 を返した後も行を読みながら書き出すルートは、期限で途中まで書き出されることがないよう skipPaths に指定します。
+func DBDeadline(timeout time.Duration, skipPaths ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if timeout <= 0 || slices.Contains(skipPaths, c.FullPath()) {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDBDeadline(t *testing.T) {
+	tests := []struct {
+		name         string
+		timeout      time.Duration
+		wantDeadline bool
+	}{
+		{name: "期限を設定する", timeout: 5 * time.Second, wantDeadline: true},
+		{name: "0の場合は期限を設定しない", timeout: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupTestRouter()
+			router.Use(DBDeadline(tt.timeout))
+			var deadline time.Time
+			var hasDeadline bool
+			router.GET("/test", func(c *gin.Context) {
+				deadline, hasDeadline = c.Request.Context().Deadline()
+				c.Status(http.StatusNoContent)
+			})
+
+			start := time.Now()
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/test", nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusNoContent, w.Code)
+			assert.Equal(t, tt.wantDeadline, hasDeadline)
+			if tt.wantDeadline {
+				assert.WithinDuration(t, start.Add(tt.timeout), deadline, time.Second)
+			}
+		})
+	}
+
+	t.Run("skipPaths に一致するルートには期限を設定しない", func(t *testing.T) {
+		router := setupTestRouter()
+		router.Use(DBDeadline(5*time.Second, "/items/export"))
+		deadlines := map[string]bool{}
+		handler := func(c *gin.Context) {
+			_, deadlines[c.FullPath()] = c.Request.Context().Deadline()
+			c.Status(http.StatusNoContent)
+		}
+		router.GET("/items/export", handler)
+		router.GET("/items/:id", handler)
+
+		for _, path := range []string{"/items/export", "/items/1"} {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", path, nil)
+			router.ServeHTTP(w, req)
+			assert.Equal(t, http.StatusNoContent, w.Code)
+		}
+
+		assert.False(t, deadlines["/items/export"])
+		assert.True(t, deadlines["/items/:id"])
+	})
+
+	t.Run("期限を過ぎるとコンテキストが終了する", func(t *testing.T) {
+		router := setupTestRouter()
+		router.Use(DBDeadline(10 * time.Millisecond))
+		var ctxErr error
+		router.GET("/test", func(c *gin.Context) {
+			<-c.Request.Context().Done()
+			ctxErr = c.Request.Context().Err()
+			c.Status(http.StatusServiceUnavailable)
+		})
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/test", nil)
+		router.ServeHTTP(w, req)
+
+		assert.ErrorIs(t, ctxErr, context.DeadlineExceeded)
+	})
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"context"
+	"log"
+	"net/http"
+
+	"money-buddy-backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HouseholdKey は選択中の世帯での所属情報（*models.HouseholdMembership）を保存するキーです。
+// 世帯を選択していない場合は保存しません。
+const HouseholdKey contextKey = "household"
+
+// HouseholdResolver はユーザーが選択中の世帯を解決します。
+type HouseholdResolver interface {
+	// CurrentMembership は選択中の世帯での所属情報を返します。世帯を選択していない場合は nil を返します。
+	CurrentMembership(ctx context.Context, userID string) (*models.HouseholdMembership, error)
+}
+
+// HouseholdMiddleware は認証済みユーザーが選択中の世帯を解決し、コンテキストに保存します。
+// AuthMiddleware の後に適用します。
+func HouseholdMiddleware(resolver HouseholdResolver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID, ok := GetUserID(c)
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+			c.Abort()
+			return
+		}
+
+		membership, err := resolver.CurrentMembership(c.Request.Context(), userID)
+		if err != nil {
+			log.Printf("Failed to resolve current household: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "世帯情報の取得に失敗しました"})
+			c.Abort()
+			return
+		}
+		if membership != nil {
+			c.Set(string(HouseholdKey), membership)
+		}
+		c.Next()
+	}
+}
+
+// RequireEditor は選択中の世帯で閲覧者（viewer）のリクエストに 403 を返します。
+// 家計簿のデータを変更するエンドポイントに使います。
+func RequireEditor() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if m, ok := GetHousehold(c); ok && m.Role == models.HouseholdRoleViewer {
+			c.JSON(http.StatusForbidden, gin.H{"error": "閲覧者は家計簿を変更できません"})
+			c.Abort()
+			return
+		}
+		c.Next()
+	}
+}
+
+// GetHousehold は選択中の世帯での所属情報を取得します。
+// 第2戻り値は世帯を選択しているかを返します。
+func GetHousehold(c *gin.Context) (*models.HouseholdMembership, bool) {
+	v, exists := c.Get(string(HouseholdKey))
+	if !exists {
+		return nil, false
+	}
+	return v.(*models.HouseholdMembership), true
+}
+
+// GetDataOwnerID は参照・変更する家計簿の所有者のユーザーIDを取得します。
+// 世帯を選択している場合は世帯のオーナー、それ以外はリクエストしたユーザー本人です。
+func GetDataOwnerID(c *gin.Context) (string, bool) {
+	if m, ok := GetHousehold(c); ok {
+		return m.OwnerID, true
+	}
+	return GetUserID(c)
+}
@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"money-buddy-backend/internal/models"
+)
+
+// stubResolver は HouseholdResolver のスタブ実装です
+type stubResolver struct {
+	membership *models.HouseholdMembership
+	err        error
+}
+
+func (r *stubResolver) CurrentMembership(ctx context.Context, userID string) (*models.HouseholdMembership, error) {
+	return r.membership, r.err
+}
+
+// householdRouter は userID で認証済みとして HouseholdMiddleware を適用したルーターを返します
+func householdRouter(userID string, resolver HouseholdResolver, handlers ...gin.HandlerFunc) *gin.Engine {
+	router := setupTestRouter()
+	router.Use(func(c *gin.Context) {
+		c.Set(string(UserIDKey), userID)
+		c.Next()
+	})
+	router.Use(HouseholdMiddleware(resolver))
+	handlers = append(handlers, func(c *gin.Context) {
+		ownerID, _ := GetDataOwnerID(c)
+		c.JSON(http.StatusOK, gin.H{"owner_id": ownerID})
+	})
+	router.POST("/test", handlers...)
+	return router
+}
+
+func TestHouseholdMiddleware_NoHousehold(t *testing.T) {
+	router := householdRouter("user-123", &stubResolver{})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/test", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"owner_id":"user-123"}`, w.Body.String())
+}
+
+func TestHouseholdMiddleware_UsesOwnerLedger(t *testing.T) {
+	router := householdRouter("partner-user", &stubResolver{
+		membership: &models.HouseholdMembership{HouseholdID: 1, OwnerID: "owner-user", Role: models.HouseholdRoleMember},
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/test", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"owner_id":"owner-user"}`, w.Body.String())
+}
+
+func TestHouseholdMiddleware_ResolverError(t *testing.T) {
+	router := householdRouter("user-123", &stubResolver{err: errors.New("db error")})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/test", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), "世帯情報の取得に失敗しました")
+}
+
+func TestRequireEditor(t *testing.T) {
+	cases := []struct {
+		name       string
+		membership *models.HouseholdMembership
+		want       int
+	}{
+		{name: "世帯を選択していない", membership: nil, want: http.StatusOK},
+		{name: "メンバー", membership: &models.HouseholdMembership{HouseholdID: 1, OwnerID: "owner-user", Role: models.HouseholdRoleMember}, want: http.StatusOK},
+		{name: "閲覧者", membership: &models.HouseholdMembership{HouseholdID: 1, OwnerID: "owner-user", Role: models.HouseholdRoleViewer}, want: http.StatusForbidden},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := householdRouter("user-123", &stubResolver{membership: tc.membership}, RequireEditor())
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/test", nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.want, w.Code)
+		})
+	}
+}
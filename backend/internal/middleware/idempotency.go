@@ -0,0 +1,160 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+	"slices"
+
+	"money-buddy-backend/internal/models"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IdempotencyKeyHeader はクライアントが再送を識別するために付けるヘッダーです。
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotentReplayedHeader は保存済みのレスポンスを返したことを示すヘッダーです。
+const IdempotentReplayedHeader = "Idempotent-Replayed"
+
+// idempotencyKeyMaxLen は Idempotency-Key の最大文字数
+const idempotencyKeyMaxLen = 255
+
+// IdempotencyStore は Idempotency-Key ごとにリクエストのハッシュとレスポンスを保存します。
+type IdempotencyStore interface {
+	// Reserve はキーを処理中として予約します。予約できた場合は nil を返し、
+	// 有効なキーがすでにある場合は保存済みの記録（処理中を含む）を返します。
+	Reserve(ctx context.Context, userID string, key string, requestHash string) (*models.IdempotencyRecord, error)
+	Save(ctx context.Context, userID string, key string, record models.IdempotencyRecord) error
+	// Release は予約を取り消し、同じキーで再試行できるようにします。
+	Release(ctx context.Context, userID string, key string) error
+}
+
+// Idempotency は paths のいずれかに一致する POST リクエストの Idempotency-Key ヘッダーを扱います。
+// 同じキーでの再送には最初のレスポンスをそのまま返し、別のリクエストでキーを再利用した場合は 422 を返します。
+// 5xx のレスポンスは保存せず、同じキーで再試行できるようにします。
+// ヘッダーがないリクエストはそのまま処理します。AuthMiddleware の後に適用します。
+func Idempotency(store IdempotencyStore, paths ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" || c.Request.Method != http.MethodPost || !slices.Contains(paths, c.FullPath()) {
+			c.Next()
+			return
+		}
+		if len(key) > idempotencyKeyMaxLen {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Idempotency-Key は255文字以内で指定してください"})
+			c.Abort()
+			return
+		}
+
+		userID, ok := GetUserID(c)
+		if !ok {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "ユーザーIDの取得に失敗しました"})
+			c.Abort()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "リクエストの読み込みに失敗しました"})
+			c.Abort()
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+		requestHash := hashIdempotentRequest(c.Request, body)
+
+		// クライアントが切断してもレスポンスの保存・予約の取り消しは行う
+		ctx := context.WithoutCancel(c.Request.Context())
+
+		record, err := store.Reserve(ctx, userID, key, requestHash)
+		if err != nil {
+			log.Printf("Failed to reserve idempotency key: %v", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "サーバーエラーが発生しました"})
+			c.Abort()
+			return
+		}
+		if record != nil {
+			replayIdempotentResponse(c, *record, requestHash)
+			return
+		}
+
+		recorder := &idempotencyRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+
+		completed := false
+		defer func() {
+			// ハンドラーが panic した場合は予約を取り消してから上位の Recovery に任せる
+			if !completed {
+				if err := store.Release(ctx, userID, key); err != nil {
+					log.Printf("Failed to release idempotency key: %v", err)
+				}
+			}
+		}()
+
+		c.Next()
+
+		status := recorder.Status()
+		if status >= http.StatusInternalServerError {
+			if err := store.Release(ctx, userID, key); err != nil {
+				log.Printf("Failed to release idempotency key: %v", err)
+			}
+		} else {
+			err := store.Save(ctx, userID, key, models.IdempotencyRecord{
+				RequestHash: requestHash,
+				StatusCode:  status,
+				ContentType: recorder.Header().Get("Content-Type"),
+				ETag:        recorder.Header().Get("ETag"),
+				Body:        recorder.body.Bytes(),
+			})
+			if err != nil {
+				log.Printf("Failed to save idempotent response: %v", err)
+			}
+		}
+		completed = true
+	}
+}
+
+// replayIdempotentResponse は保存済みの記録をもとにレスポンスを返します。
+func replayIdempotentResponse(c *gin.Context, record models.IdempotencyRecord, requestHash string) {
+	switch {
+	case record.RequestHash != requestHash:
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "この Idempotency-Key は別のリクエストで使用されています"})
+	case record.InProgress():
+		c.JSON(http.StatusConflict, gin.H{"error": "同じ Idempotency-Key のリクエストを処理中です。しばらくしてから再試行してください"})
+	default:
+		if record.ETag != "" {
+			c.Header("ETag", record.ETag)
+		}
+		c.Header(IdempotentReplayedHeader, "true")
+		c.Data(record.StatusCode, record.ContentType, record.Body)
+	}
+	c.Abort()
+}
+
+// hashIdempotentRequest はメソッド・パス・ボディから同じリクエストかを判定するハッシュを作ります。
+func hashIdempotentRequest(r *http.Request, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(r.Method + " " + r.URL.RequestURI() + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// idempotencyRecorder はクライアントに返したレスポンスボディを保存用に記録します。
+type idempotencyRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *idempotencyRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *idempotencyRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,190 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"money-buddy-backend/internal/models"
+)
+
+// memoryIdempotencyStore は IdempotencyStore のメモリ上の実装です
+type memoryIdempotencyStore struct {
+	records  map[string]models.IdempotencyRecord
+	released []string
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{records: map[string]models.IdempotencyRecord{}}
+}
+
+func (s *memoryIdempotencyStore) Reserve(ctx context.Context, userID string, key string, requestHash string) (*models.IdempotencyRecord, error) {
+	if r, ok := s.records[userID+"/"+key]; ok {
+		return &r, nil
+	}
+	s.records[userID+"/"+key] = models.IdempotencyRecord{RequestHash: requestHash}
+	return nil, nil
+}
+
+func (s *memoryIdempotencyStore) Save(ctx context.Context, userID string, key string, record models.IdempotencyRecord) error {
+	s.records[userID+"/"+key] = record
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(ctx context.Context, userID string, key string) error {
+	delete(s.records, userID+"/"+key)
+	s.released = append(s.released, key)
+	return nil
+}
+
+// idempotencyRouter は user-123 で認証済みとして Idempotency を POST /expenses に適用したルーターを返します
+func idempotencyRouter(store IdempotencyStore, handler gin.HandlerFunc) *gin.Engine {
+	router := setupTestRouter()
+	router.Use(func(c *gin.Context) {
+		c.Set(string(UserIDKey), "user-123")
+		c.Next()
+	})
+	router.Use(Idempotency(store, "/expenses"))
+	router.POST("/expenses", handler)
+	router.POST("/other", handler)
+	return router
+}
+
+func postWithKey(router *gin.Engine, path, key, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotency_ReplaysResponse(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	calls := 0
+	router := idempotencyRouter(store, func(c *gin.Context) {
+		calls++
+		c.Header("ETag", `"1"`)
+		c.JSON(http.StatusCreated, gin.H{"id": calls})
+	})
+
+	first := postWithKey(router, "/expenses", "key-1", `{"amount":100}`)
+	second := postWithKey(router, "/expenses", "key-1", `{"amount":100}`)
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Equal(t, http.StatusCreated, second.Code)
+	assert.JSONEq(t, first.Body.String(), second.Body.String())
+	assert.Equal(t, `"1"`, second.Header().Get("ETag"))
+	assert.Equal(t, "application/json; charset=utf-8", second.Header().Get("Content-Type"))
+	assert.Equal(t, "true", second.Header().Get(IdempotentReplayedHeader))
+	assert.Empty(t, first.Header().Get(IdempotentReplayedHeader))
+}
+
+func TestIdempotency_DifferentBody(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	calls := 0
+	router := idempotencyRouter(store, func(c *gin.Context) {
+		calls++
+		c.JSON(http.StatusCreated, gin.H{})
+	})
+
+	postWithKey(router, "/expenses", "key-1", `{"amount":100}`)
+	w := postWithKey(router, "/expenses", "key-1", `{"amount":200}`)
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestIdempotency_InProgress(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	calls := 0
+	router := idempotencyRouter(store, func(c *gin.Context) {
+		calls++
+		c.JSON(http.StatusCreated, gin.H{})
+	})
+
+	// 最初のリクエストを処理中に、同じキー・同じボディで再送された
+	req, _ := http.NewRequest("POST", "/expenses", nil)
+	store.records["user-123/key-1"] = models.IdempotencyRecord{RequestHash: hashIdempotentRequest(req, []byte(`{"amount":100}`))}
+
+	w := postWithKey(router, "/expenses", "key-1", `{"amount":100}`)
+
+	assert.Equal(t, 0, calls)
+	assert.Equal(t, http.StatusConflict, w.Code)
+}
+
+func TestIdempotency_ServerErrorReleasesKey(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	calls := 0
+	router := idempotencyRouter(store, func(c *gin.Context) {
+		calls++
+		if calls == 1 {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "db down"})
+			return
+		}
+		c.JSON(http.StatusCreated, gin.H{})
+	})
+
+	first := postWithKey(router, "/expenses", "key-1", `{"amount":100}`)
+	second := postWithKey(router, "/expenses", "key-1", `{"amount":100}`)
+
+	assert.Equal(t, http.StatusInternalServerError, first.Code)
+	assert.Equal(t, http.StatusCreated, second.Code)
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, []string{"key-1"}, store.released)
+}
+
+func TestIdempotency_PanicReleasesKey(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	router := setupTestRouter()
+	router.Use(gin.Recovery())
+	router.Use(func(c *gin.Context) {
+		c.Set(string(UserIDKey), "user-123")
+		c.Next()
+	})
+	router.Use(Idempotency(store, "/expenses"))
+	router.POST("/expenses", func(c *gin.Context) {
+		panic("boom")
+	})
+
+	w := postWithKey(router, "/expenses", "key-1", `{}`)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, []string{"key-1"}, store.released)
+}
+
+func TestIdempotency_Passthrough(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	calls := 0
+	router := idempotencyRouter(store, func(c *gin.Context) {
+		calls++
+		c.JSON(http.StatusCreated, gin.H{})
+	})
+
+	// ヘッダーなし・対象外のパスは毎回処理する
+	postWithKey(router, "/expenses", "", `{}`)
+	postWithKey(router, "/expenses", "", `{}`)
+	postWithKey(router, "/other", "key-1", `{}`)
+	postWithKey(router, "/other", "key-1", `{}`)
+
+	assert.Equal(t, 4, calls)
+	assert.Empty(t, store.records)
+}
+
+func TestIdempotency_KeyTooLong(t *testing.T) {
+	router := idempotencyRouter(newMemoryIdempotencyStore(), func(c *gin.Context) {
+		c.JSON(http.StatusCreated, gin.H{})
+	})
+
+	w := postWithKey(router, "/expenses", strings.Repeat("k", 256), `{}`)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,66 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"money-buddy-backend/internal/audit"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestIDHeader はリクエストIDを受け渡すヘッダーです。
+const RequestIDHeader = "X-Request-ID"
+
+// RequestIDKey はリクエストIDを保存するキーです。
+const RequestIDKey contextKey = "requestID"
+
+// requestIDMaxLen はクライアントが指定できるリクエストIDの最大文字数
+const requestIDMaxLen = 64
+
+// RequestID はリクエストごとにIDを割り当て、レスポンスの X-Request-ID ヘッダーで返します。
+// クライアントが X-Request-ID を指定した場合は、英数字と - _ . のみからなる64文字以内であればそれを使います。
+// リクエストIDは変更履歴に記録するため、リクエストのコンテキストにも設定します。
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(RequestIDHeader)
+		if !isValidRequestID(requestID) {
+			requestID = newRequestID()
+		}
+
+		c.Set(string(RequestIDKey), requestID)
+		c.Header(RequestIDHeader, requestID)
+		c.Request = c.Request.WithContext(audit.WithRequestID(c.Request.Context(), requestID))
+		c.Next()
+	}
+}
+
+// GetRequestID はリクエストIDを取得します。
+func GetRequestID(c *gin.Context) (string, bool) {
+	requestID, exists := c.Get(string(RequestIDKey))
+	if !exists {
+		return "", false
+	}
+	return requestID.(string), true
+}
+
+func isValidRequestID(s string) bool {
+	if s == "" || len(s) > requestIDMaxLen {
+		return false
+	}
+	for _, r := range s {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// newRequestID はランダムな32文字の16進数のリクエストIDを生成します。
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"money-buddy-backend/internal/audit"
+)
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name      string
+		header    string
+		wantSame  bool
+		wantGenLn int
+	}{
+		{name: "指定がない場合は生成する", header: "", wantGenLn: 32},
+		{name: "クライアントが指定したIDを使う", header: "req-2025.06_18", wantSame: true},
+		{name: "使えない文字を含む場合は生成し直す", header: "abc def", wantGenLn: 32},
+		{name: "長すぎる場合は生成し直す", header: strings.Repeat("a", 65), wantGenLn: 32},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupTestRouter()
+			router.Use(RequestID())
+			var fromGin, fromCtx string
+			router.GET("/test", func(c *gin.Context) {
+				fromGin, _ = GetRequestID(c)
+				fromCtx = audit.RequestIDFromContext(c.Request.Context())
+				c.Status(http.StatusNoContent)
+			})
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/test", nil)
+			if tt.header != "" {
+				req.Header.Set(RequestIDHeader, tt.header)
+			}
+			router.ServeHTTP(w, req)
+
+			got := w.Header().Get(RequestIDHeader)
+			if tt.wantSame {
+				assert.Equal(t, tt.header, got)
+			} else {
+				assert.Len(t, got, tt.wantGenLn)
+			}
+			assert.Equal(t, got, fromGin)
+			assert.Equal(t, got, fromCtx)
+		})
+	}
+}
+
+func TestAuthMiddleware_SetsAuditActor(t *testing.T) {
+	router := setupTestRouter()
+	router.Use(AuthMiddleware(&stubVerifier{uid: "firebase-user-123"}))
+	var actor string
+	router.GET("/test", func(c *gin.Context) {
+		actor = audit.ActorFromContext(c.Request.Context())
+		c.Status(http.StatusNoContent)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/test", nil)
+	req.Header.Set("Authorization", "Bearer VALID_TOKEN")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "firebase-user-123", actor)
+}
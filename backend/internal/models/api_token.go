@@ -0,0 +1,45 @@
+package models
+
+// APITokenPrefix はパーソナルアクセストークンの接頭辞です。
+// Firebase の ID トークンと区別するために使います。
+const APITokenPrefix = "mb_pat_"
+
+// パーソナルアクセストークンの権限
+const (
+	APITokenScopeRead          = "read"           // 家計簿の参照（世帯・トークンを除く）
+	APITokenScopeWriteExpenses = "write:expenses" // 支出の登録・更新・削除
+)
+
+// IsValidAPITokenScope は有効な権限かを判定します。
+func IsValidAPITokenScope(s string) bool {
+	switch s {
+	case APITokenScopeRead, APITokenScopeWriteExpenses:
+		return true
+	default:
+		return false
+	}
+}
+
+// APIToken はパーソナルアクセストークンです。トークン本体は作成時のみ返します。
+type APIToken struct {
+	ID         int      `json:"id"`
+	Name       string   `json:"name"`
+	Scopes     []string `json:"scopes"`
+	ExpiresAt  string   `json:"expires_at"`
+	LastUsedAt *string  `json:"last_used_at"`
+	CreatedAt  string   `json:"created_at"`
+}
+
+// CreatedAPIToken は作成したパーソナルアクセストークンです。
+// Token は平文のトークンで、サーバーにはハッシュのみを保存するため作成時の一度だけ返します。
+type CreatedAPIToken struct {
+	APIToken
+	Token string `json:"token"`
+}
+
+// APITokenInput はパーソナルアクセストークン作成の入力です。
+type APITokenInput struct {
+	Name          string   `json:"name"`
+	Scopes        []string `json:"scopes"`
+	ExpiresInDays *int     `json:"expires_in_days"`
+}
@@ -0,0 +1,62 @@
+package models
+
+import "encoding/json"
+
+// AuditEntityType は変更履歴の対象の種類です。
+type AuditEntityType string
+
+const (
+	AuditEntityExpense      AuditEntityType = "expense"
+	AuditEntityFixedCost    AuditEntityType = "fixed_cost"
+	AuditEntityUserSettings AuditEntityType = "user_settings" // 収入・貯金目標
+	AuditEntityInitialSetup AuditEntityType = "initial_setup" // 初期設定（収入・貯金目標・固定費の一括登録）
+)
+
+// IsValidAuditEntityType は有効な変更履歴の対象の種類かを判定します。
+func IsValidAuditEntityType(s string) bool {
+	switch AuditEntityType(s) {
+	case AuditEntityExpense, AuditEntityFixedCost, AuditEntityUserSettings, AuditEntityInitialSetup:
+		return true
+	default:
+		return false
+	}
+}
+
+// AuditAction は変更履歴の操作の種類です。
+type AuditAction string
+
+const (
+	AuditActionCreate  AuditAction = "create"
+	AuditActionUpdate  AuditAction = "update"
+	AuditActionDelete  AuditAction = "delete"  // ゴミ箱への移動
+	AuditActionRestore AuditAction = "restore" // ゴミ箱からの復元
+	AuditActionImport  AuditAction = "import"  // CSV 取り込み
+)
+
+// AuditEvent は変更履歴の1件です。Before・After は変更前後のスナップショットで、
+// 作成時の Before と削除時の After は null になります。
+type AuditEvent struct {
+	ID         int64           `json:"id"`
+	EntityType string          `json:"entity_type"`
+	EntityID   *int            `json:"entity_id"` // user_settings・initial_setup では null
+	Action     string          `json:"action"`
+	ActorID    string          `json:"actor_id"`   // 変更を行ったユーザー
+	RequestID  string          `json:"request_id"` // 変更したリクエストの X-Request-ID
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	CreatedAt  string          `json:"created_at"`
+}
+
+// AuditFilter は変更履歴一覧の絞り込み条件とページング指定です。
+type AuditFilter struct {
+	EntityType string // 空の場合はすべての種類
+	Cursor     string // 前ページの next_cursor
+	Limit      int    // 0 の場合は既定の件数
+}
+
+// AuditPage はカーソルページングされた変更履歴一覧です。新しいものから順に並びます。
+// NextCursor は次のページが存在しない場合 nil になります。
+type AuditPage struct {
+	Events     []AuditEvent `json:"events"`
+	NextCursor *string      `json:"next_cursor"`
+}
@@ -0,0 +1,8 @@
+package models
+
+// Budget はカテゴリ別の月次予算です
+type Budget struct {
+	CategoryID   int    `json:"category_id"`
+	CategoryName string `json:"category_name"`
+	MonthlyLimit int    `json:"monthly_limit"`
+}
@@ -1,6 +1,7 @@
 package models
 
 type Category struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	IsCustom bool   `json:"is_custom,omitempty"` // ユーザーが作成したカテゴリの場合 true
 }
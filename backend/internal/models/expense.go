@@ -1,11 +1,30 @@
 package models
 
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
 type CreateExpenseInput struct {
 	Amount     *int   `json:"amount"`
 	CategoryID *int   `json:"category_id"`
 	Memo       string `json:"memo"`
 	SpentAt    string `json:"spent_at"`
 	Status     string `json:"status"`
+	// CreatedBy は登録したユーザーIDです（リクエストからは受け取らない）。空の場合は家計簿の所有者とします。
+	CreatedBy string `json:"-"`
+	// Items は支出を複数のカテゴリに分ける明細です。省略した場合は分けずに登録します。
+	Items []ExpenseItemInput `json:"items"`
+	// Tags は支出に付けるタグの名前です。まだないタグは作成します。
+	Tags []string `json:"tags"`
+}
+
+// ExpenseItemInput は支出の明細の入力です。明細の金額の合計は支出の金額と一致させます。
+type ExpenseItemInput struct {
+	Amount     *int   `json:"amount"`
+	CategoryID *int   `json:"category_id"`
+	Memo       string `json:"memo"`
 }
 
 type UpdateExpenseInput struct {
@@ -15,13 +34,131 @@ type UpdateExpenseInput struct {
 	Memo       string `json:"memo"`
 	SpentAt    string `json:"spent_at"`
 	Status     string `json:"status"`
+	// Version は If-Match で指定された更新前のバージョンです（リクエストボディからは受け取らない）
+	Version int `json:"-"`
+	// PlannedAmount は予定から確定にしたときに記録する予定金額です（リクエストボディからは受け取らない）。nil の場合は変更しません。
+	PlannedAmount *int `json:"-"`
+	// Items は明細です。nil の場合は現在の明細を変更せず、空の場合は明細を削除します。
+	Items *[]ExpenseItemInput `json:"items"`
+	// Tags はタグの名前です。nil の場合は現在のタグを変更せず、空の場合はタグをすべて外します。
+	Tags *[]string `json:"tags"`
+}
+
+// PatchExpenseInput は JSON Merge Patch（RFC 7396）形式の支出の部分更新です。
+// nil のフィールドは変更しません。memo に null を指定した場合はメモを、items・tags に null を指定した場合は明細・タグを削除します。
+type PatchExpenseInput struct {
+	ID         int                 `json:"-"`
+	Amount     *int                `json:"amount"`
+	CategoryID *int                `json:"category_id"`
+	Memo       *string             `json:"memo"`
+	SpentAt    *string             `json:"spent_at"`
+	Status     *string             `json:"status"`
+	Items      *[]ExpenseItemInput `json:"items"`
+	Tags       *[]string           `json:"tags"`
+	// Version は If-Match で指定された更新前のバージョンです（リクエストボディからは受け取らない）
+	Version int `json:"-"`
+}
+
+// patchRequiredFields は null で削除できない項目とその表示名です。
+var patchRequiredFields = []struct{ key, label string }{
+	{"amount", "金額"},
+	{"category_id", "カテゴリ"},
+	{"spent_at", "日付"},
+	{"status", "ステータス"},
+}
+
+// UnmarshalJSON は省略された項目と null を指定された項目を区別して読み込みます。
+func (p *PatchExpenseInput) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if fields == nil {
+		return errors.New("変更内容は JSON オブジェクトで指定してください")
+	}
+
+	type plain PatchExpenseInput
+	if err := json.Unmarshal(data, (*plain)(p)); err != nil {
+		return err
+	}
+
+	for _, f := range patchRequiredFields {
+		if raw, ok := fields[f.key]; ok && isJSONNull(raw) {
+			return errors.New(f.label + "は削除できません")
+		}
+	}
+	if raw, ok := fields["memo"]; ok && isJSONNull(raw) {
+		empty := ""
+		p.Memo = &empty
+	}
+	if raw, ok := fields["items"]; ok && isJSONNull(raw) {
+		p.Items = &[]ExpenseItemInput{}
+	}
+	if raw, ok := fields["tags"]; ok && isJSONNull(raw) {
+		p.Tags = &[]string{}
+	}
+	return nil
+}
+
+// IsEmpty は変更する項目がないかを返します。
+func (p PatchExpenseInput) IsEmpty() bool {
+	return p.Amount == nil && p.CategoryID == nil && p.Memo == nil && p.SpentAt == nil && p.Status == nil && p.Items == nil && p.Tags == nil
+}
+
+// IsStatusOnly はステータスのみを変更するかを返します。
+func (p PatchExpenseInput) IsStatusOnly() bool {
+	return p.Status != nil && p.Amount == nil && p.CategoryID == nil && p.Memo == nil && p.SpentAt == nil && p.Items == nil && p.Tags == nil
+}
+
+func isJSONNull(raw json.RawMessage) bool {
+	return bytes.Equal(bytes.TrimSpace(raw), []byte("null"))
 }
 
 type Expense struct {
-	ID       int      `json:"id"`
+	ID        int      `json:"id"`
+	Amount    int      `json:"amount"`
+	Memo      string   `json:"memo"`
+	SpentAt   string   `json:"spent_at"`
+	Status    string   `json:"status"`
+	CreatedBy string   `json:"created_by"` // 登録したユーザーID
+	Version   int      `json:"version"`    // 更新ごとに加算されるバージョン（ETag）
+	Category  Category `json:"category"`
+	// PlannedAmount は予定から確定にしたときの予定金額（見積もり）です。予定を経ずに登録した支出は nil です。
+	PlannedAmount *int `json:"planned_amount"`
+	// Variance は確定した金額と予定金額の差（amount - planned_amount）です。正の値は見積もりより多く使ったことを表します。
+	Variance *int `json:"variance"`
+	// Items は複数のカテゴリに分けた明細です（登録順）。分けていない支出では省略します。
+	// 明細がある場合、Category は先頭の明細のカテゴリです。
+	Items []ExpenseItem `json:"items,omitempty"`
+	// Tags は支出に付けたタグです（タグ名順）。タグがない支出では省略します。
+	Tags []Tag `json:"tags,omitempty"`
+}
+
+// ExpenseItem は支出の明細です。
+type ExpenseItem struct {
 	Amount   int      `json:"amount"`
 	Memo     string   `json:"memo"`
-	SpentAt  string   `json:"spent_at"`
-	Status   string   `json:"status"`
 	Category Category `json:"category"`
 }
+
+// ExpenseFilter は支出一覧の絞り込み条件とページング指定です。
+// 値はクエリパラメータから受け取ったままの形で保持し、検証はサービス層で行います。
+type ExpenseFilter struct {
+	From        string // 期間の開始日（YYYY-MM-DD、含む）
+	To          string // 期間の終了日（YYYY-MM-DD、含む）
+	CategoryIDs []int
+	TagIDs      []int // いずれかのタグが付いた支出
+	Status      string
+	AmountMin   *int
+	AmountMax   *int
+	Memo        string // メモの部分一致
+	Cursor      string // 前ページの next_cursor
+	Limit       int    // 0 の場合は既定の件数
+}
+
+// ExpensePage はカーソルページングされた支出一覧です。
+// NextCursor は次のページが存在しない場合 nil になります。
+type ExpensePage struct {
+	Expenses   []Expense `json:"expenses"`
+	NextCursor *string   `json:"next_cursor"`
+}
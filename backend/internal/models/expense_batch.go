@@ -0,0 +1,97 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+)
+
+// ExpenseBatchOp は一括操作の1件の種類です。
+type ExpenseBatchOp string
+
+const (
+	ExpenseBatchCreate  ExpenseBatchOp = "create"
+	ExpenseBatchUpdate  ExpenseBatchOp = "update"
+	ExpenseBatchDelete  ExpenseBatchOp = "delete"
+	ExpenseBatchConfirm ExpenseBatchOp = "confirm"
+)
+
+// 一括操作のモード
+const (
+	// ExpenseBatchAtomic は1件でも失敗した場合にすべての操作を取り消します（既定）
+	ExpenseBatchAtomic = "atomic"
+	// ExpenseBatchBestEffort は失敗した操作のみを取り消し、成功した操作は登録します
+	ExpenseBatchBestEffort = "best_effort"
+)
+
+// ExpenseBatchInput は POST /expenses/batch のリクエストです。
+type ExpenseBatchInput struct {
+	Mode       string                  `json:"mode"`
+	Operations []ExpenseBatchOperation `json:"operations"`
+}
+
+// ExpenseBatchOperation は一括操作の1件です。
+// create は Create に登録内容を、update は Patch に変更内容（JSON Merge Patch）を持ちます。
+// update・confirm の Version は更新前のバージョン（GET /expenses/:id の ETag）です。
+type ExpenseBatchOperation struct {
+	Op      ExpenseBatchOp `json:"op"`
+	ID      int            `json:"id"`
+	Version int            `json:"version"`
+	// Amount は confirm で確定した金額です。省略した場合は予定の金額のまま確定します。
+	Amount *int                `json:"amount"`
+	Create *CreateExpenseInput `json:"-"`
+	Patch  *PatchExpenseInput  `json:"-"`
+}
+
+// UnmarshalJSON は op に応じて expense を登録内容または変更内容として読み込みます。
+func (o *ExpenseBatchOperation) UnmarshalJSON(data []byte) error {
+	type plain ExpenseBatchOperation
+	var raw struct {
+		plain
+		Expense json.RawMessage `json:"expense"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*o = ExpenseBatchOperation(raw.plain)
+
+	if len(raw.Expense) == 0 || isJSONNull(raw.Expense) {
+		return nil
+	}
+	switch o.Op {
+	case ExpenseBatchCreate:
+		o.Create = &CreateExpenseInput{}
+		return json.Unmarshal(raw.Expense, o.Create)
+	case ExpenseBatchUpdate:
+		o.Patch = &PatchExpenseInput{}
+		return json.Unmarshal(raw.Expense, o.Patch)
+	default:
+		return errors.New(string(o.Op) + " では expense を指定できません")
+	}
+}
+
+// ExpenseBatchItemResult は一括操作の1件の結果です。
+type ExpenseBatchItemResult struct {
+	Index  int            `json:"index"` // operations 内の位置（0から）
+	Op     ExpenseBatchOp `json:"op"`
+	Status string         `json:"status"` // succeeded / failed / rolled_back
+	// Expense は操作後の支出です（delete では省略）。バージョンの不一致で失敗した場合は現在の支出です。
+	Expense *Expense `json:"expense,omitempty"`
+	Code    string   `json:"code,omitempty"` // 失敗の種類（validation / not_found / invalid_transition / version_conflict / internal）
+	Error   string   `json:"error,omitempty"`
+}
+
+const (
+	ExpenseBatchSucceeded  = "succeeded"
+	ExpenseBatchFailed     = "failed"
+	ExpenseBatchRolledBack = "rolled_back" // atomic で他の操作が失敗したため取り消された
+)
+
+// ExpenseBatchResult は一括操作の結果です。Committed が false の場合はどの操作も登録されていません。
+type ExpenseBatchResult struct {
+	Mode      string                   `json:"mode"`
+	Committed bool                     `json:"committed"`
+	Total     int                      `json:"total"`
+	Succeeded int                      `json:"succeeded"`
+	Failed    int                      `json:"failed"`
+	Results   []ExpenseBatchItemResult `json:"results"`
+}
@@ -0,0 +1,36 @@
+package models
+
+// ExpenseImportMapping は CSV の列と支出項目の対応です。値は CSV のヘッダー名です。
+// Category は省略可能で、省略した場合や値が空の行には既定のカテゴリを使用します。
+type ExpenseImportMapping struct {
+	Date     string `json:"date"`
+	Amount   string `json:"amount"`
+	Memo     string `json:"memo"`
+	Category string `json:"category"`
+}
+
+// ExpenseImportRow は CSV 1 行分の取り込み結果です。
+type ExpenseImportRow struct {
+	Line       int    `json:"line"`   // CSV 上の行番号（ヘッダーを 1 行目とする）
+	Status     string `json:"status"` // accepted / rejected
+	Amount     *int   `json:"amount,omitempty"`
+	CategoryID *int   `json:"category_id,omitempty"`
+	Memo       string `json:"memo"`
+	SpentAt    string `json:"spent_at,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+const (
+	ImportRowAccepted = "accepted"
+	ImportRowRejected = "rejected"
+)
+
+// ExpenseImportResult は CSV 取り込みの結果です。
+// Committed が false の場合（プレビュー）はデータベースに登録されていません。
+type ExpenseImportResult struct {
+	Committed bool               `json:"committed"`
+	Total     int                `json:"total"`
+	Accepted  int                `json:"accepted"`
+	Rejected  int                `json:"rejected"`
+	Rows      []ExpenseImportRow `json:"rows"`
+}
@@ -1,15 +1,76 @@
 package models
 
+// FixedCostFrequency は固定費の支払い周期を表す列挙型です。
+type FixedCostFrequency string
+
+const (
+	FixedCostMonthly   FixedCostFrequency = "monthly"   // 毎月
+	FixedCostBimonthly FixedCostFrequency = "bimonthly" // 2か月ごと
+	FixedCostQuarterly FixedCostFrequency = "quarterly" // 3か月ごと
+	FixedCostYearly    FixedCostFrequency = "yearly"    // 毎年
+)
+
+// Months は支払い周期の月数を返します。無効な値の場合は 0 を返します。
+func (f FixedCostFrequency) Months() int {
+	switch f {
+	case FixedCostMonthly:
+		return 1
+	case FixedCostBimonthly:
+		return 2
+	case FixedCostQuarterly:
+		return 3
+	case FixedCostYearly:
+		return 12
+	default:
+		return 0
+	}
+}
+
+// IsValidFixedCostFrequency は有効な支払い周期かを判定します。
+func IsValidFixedCostFrequency(s string) bool {
+	return FixedCostFrequency(s).Months() > 0
+}
+
+// FixedCostMode はダッシュボードで毎月以外の固定費をどう計上するかを表します。
+type FixedCostMode string
+
+const (
+	FixedCostModeAmortize     FixedCostMode = "amortize"      // 周期の月数で割って毎月計上する
+	FixedCostModeBillingMonth FixedCostMode = "billing_month" // 請求月にのみ全額を計上する
+)
+
+// IsValidFixedCostMode は有効な計上方法かを判定します。
+func IsValidFixedCostMode(s string) bool {
+	switch FixedCostMode(s) {
+	case FixedCostModeAmortize, FixedCostModeBillingMonth:
+		return true
+	default:
+		return false
+	}
+}
+
+// FixedCostSchedule は固定費の支払いスケジュールです。
+// 毎月以外の場合、請求月は支払い周期の起点となり、そこから周期ごとの月に請求されます
+// （例: quarterly で billing_month = 2 の場合は 2・5・8・11 月）。
+type FixedCostSchedule struct {
+	Frequency    string `json:"frequency"`     // monthly / bimonthly / quarterly / yearly（省略時は monthly）
+	BillingMonth *int   `json:"billing_month"` // 請求月（1〜12）。毎月以外の場合は必須
+	BillingDay   *int   `json:"billing_day"`   // 請求日（1〜31、省略可）
+}
+
 type FixedCost struct {
-	ID        int    `json:"id"`
-	UserID    string `json:"user_id"`
-	Name      string `json:"name"`
-	Amount    int    `json:"amount"`
+	ID     int    `json:"id"`
+	UserID string `json:"user_id"`
+	Name   string `json:"name"`
+	Amount int    `json:"amount"`
+	FixedCostSchedule
 	CreatedAt string `json:"created_at"`
 	UpdatedAt string `json:"updated_at"`
+	Version   int    `json:"version"` // 更新ごとに加算されるバージョン（ETag）
 }
 
 type FixedCostInput struct {
 	Name   string `json:"name"`
 	Amount int    `json:"amount"`
+	FixedCostSchedule
 }
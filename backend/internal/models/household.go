@@ -0,0 +1,57 @@
+package models
+
+// 世帯での役割
+const (
+	HouseholdRoleOwner  = "owner"  // 世帯の作成者。メンバーの招待・管理、世帯の削除ができる
+	HouseholdRoleMember = "member" // 家計簿の参照・変更ができる
+	HouseholdRoleViewer = "viewer" // 家計簿の参照のみできる
+)
+
+// IsValidHouseholdRole は招待・変更で指定できる役割（member / viewer）かを判定します。
+// owner は世帯の作成者のみで、招待や役割の変更では指定できません。
+func IsValidHouseholdRole(role string) bool {
+	switch role {
+	case HouseholdRoleMember, HouseholdRoleViewer:
+		return true
+	default:
+		return false
+	}
+}
+
+// Household は世帯です。Role・Current はリクエストしたユーザーから見た値です。
+type Household struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	OwnerID   string `json:"owner_id"`
+	Role      string `json:"role"`    // 自分の役割
+	Current   bool   `json:"current"` // 現在選択している世帯の場合 true
+	CreatedAt string `json:"created_at"`
+}
+
+// HouseholdMember は世帯のメンバーです。
+type HouseholdMember struct {
+	UserID   string `json:"user_id"`
+	Role     string `json:"role"`
+	JoinedAt string `json:"joined_at"`
+}
+
+// HouseholdDetail はメンバー一覧を含む世帯です。
+type HouseholdDetail struct {
+	Household
+	Members []HouseholdMember `json:"members"`
+}
+
+// HouseholdInvitation は世帯への招待コードです。
+type HouseholdInvitation struct {
+	Code      string `json:"code"`
+	Role      string `json:"role"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+// HouseholdMembership は選択中の世帯でのリクエストしたユーザーの所属情報です。
+// 世帯の家計簿はオーナーのデータを共有するため、OwnerID が参照・変更するデータの所有者になります。
+type HouseholdMembership struct {
+	HouseholdID int
+	OwnerID     string
+	Role        string
+}
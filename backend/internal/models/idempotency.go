@@ -0,0 +1,16 @@
+package models
+
+// IdempotencyRecord は Idempotency-Key ごとに保存したリクエストのハッシュとレスポンスです。
+type IdempotencyRecord struct {
+	RequestHash string
+	// StatusCode が 0 の場合は最初のリクエストを処理中です。
+	StatusCode  int
+	ContentType string
+	ETag        string
+	Body        []byte
+}
+
+// InProgress は最初のリクエストの処理が終わっていないかを返します。
+func (r IdempotencyRecord) InProgress() bool {
+	return r.StatusCode == 0
+}
@@ -0,0 +1,53 @@
+package models
+
+// Frequency は繰り返しの頻度を表す列挙型です。
+type Frequency string
+
+const (
+	FrequencyWeekly   Frequency = "weekly"   // 毎週（開始日と同じ曜日）
+	FrequencyBiweekly Frequency = "biweekly" // 隔週（開始日と同じ曜日）
+	FrequencyMonthly  Frequency = "monthly"  // 毎月 day_of_month 日
+	FrequencyYearly   Frequency = "yearly"   // 毎年（開始日と同じ月日）
+)
+
+// IsValidFrequency は有効な頻度かを判定します。
+func IsValidFrequency(s string) bool {
+	switch Frequency(s) {
+	case FrequencyWeekly, FrequencyBiweekly, FrequencyMonthly, FrequencyYearly:
+		return true
+	default:
+		return false
+	}
+}
+
+// RecurringRule は繰り返しの予定支出ルールです。
+// ルールから生成される支出は status = planned で登録されます。
+type RecurringRule struct {
+	ID             int      `json:"id"`
+	Amount         int      `json:"amount"`
+	Memo           string   `json:"memo"`
+	Category       Category `json:"category"`
+	Frequency      string   `json:"frequency"`
+	DayOfMonth     *int     `json:"day_of_month"`
+	StartDate      string   `json:"start_date"`
+	EndDate        *string  `json:"end_date"`
+	GeneratedUntil *string  `json:"generated_until"`
+}
+
+// RecurringRuleInput は繰り返しルールの作成・更新の入力です。
+type RecurringRuleInput struct {
+	Amount     *int   `json:"amount"`
+	CategoryID *int   `json:"category_id"`
+	Memo       string `json:"memo"`
+	Frequency  string `json:"frequency"`
+	DayOfMonth *int   `json:"day_of_month"`
+	StartDate  string `json:"start_date"`
+	EndDate    string `json:"end_date"`
+}
+
+// RecurringOccurrenceInput は繰り返しの「この回のみ」を変更する場合の入力です。
+type RecurringOccurrenceInput struct {
+	Amount     *int   `json:"amount"`
+	CategoryID *int   `json:"category_id"`
+	Memo       string `json:"memo"`
+}
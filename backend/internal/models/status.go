@@ -3,21 +3,37 @@ package models
 import "strings"
 
 // Status は支出の状態を表す列挙型です。
+//
+// ダッシュボード・レポートでの扱い:
+//   - planned: 予定支出として残額から差し引く
+//   - confirmed: 確定支出として残額・カテゴリ予算から差し引く
+//   - reimbursable: 立替中。精算されるまでは確定支出と同じく差し引く
+//   - reimbursed: 精算済みの立替。支出として数えない
+//   - cancelled: 取りやめた予定。記録のみ残し、支出として数えない
 type Status string
 
 const (
-	StatusPlanned   Status = "planned"
-	StatusConfirmed Status = "confirmed"
+	StatusPlanned      Status = "planned"
+	StatusConfirmed    Status = "confirmed"
+	StatusCancelled    Status = "cancelled"
+	StatusReimbursable Status = "reimbursable"
+	StatusReimbursed   Status = "reimbursed"
 )
 
+// statusTransitions はステータスごとに変更できるステータスの一覧です。
+// 実際に支払った支出（confirmed / reimbursable / reimbursed）を予定・取りやめに戻すことはできません。
+var statusTransitions = map[Status][]Status{
+	StatusPlanned:      {StatusConfirmed, StatusCancelled, StatusReimbursable},
+	StatusConfirmed:    {StatusReimbursable},
+	StatusCancelled:    {StatusPlanned},
+	StatusReimbursable: {StatusConfirmed, StatusReimbursed},
+	StatusReimbursed:   {},
+}
+
 // IsValidStatus は有効なステータスかを判定します。
 func IsValidStatus(s string) bool {
-	switch strings.ToLower(s) {
-	case string(StatusPlanned), string(StatusConfirmed):
-		return true
-	default:
-		return false
-	}
+	_, ok := statusTransitions[Status(strings.ToLower(s))]
+	return ok
 }
 
 // NormalizeStatus はステータス文字列を正規化（小文字化）し、妥当性も判定します。
@@ -32,3 +48,29 @@ func NormalizeStatus(s string) (string, bool) {
 	}
 	return "", false
 }
+
+// CanTransitionStatus は from から to へステータスを変更できるかを判定します。
+// 同じステータスのままの更新は常に許可します。
+func CanTransitionStatus(from, to string) bool {
+	from, to = strings.ToLower(from), strings.ToLower(to)
+	if from == to {
+		return IsValidStatus(from)
+	}
+	for _, next := range statusTransitions[Status(from)] {
+		if string(next) == to {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSettledStatus は予定の支出を実際に支払った状態（confirmed / reimbursable）かを判定します。
+// 予定から settled なステータスに変更したときに、予定金額を記録します。
+func IsSettledStatus(s string) bool {
+	switch Status(strings.ToLower(s)) {
+	case StatusConfirmed, StatusReimbursable:
+		return true
+	default:
+		return false
+	}
+}
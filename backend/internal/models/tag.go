@@ -0,0 +1,13 @@
+package models
+
+// Tag は支出に付けるタグです。カテゴリをまたいで「旅行」「イベント」などの単位で支出をまとめるために使います。
+type Tag struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// TagSummary はタグ一覧の1件です。
+type TagSummary struct {
+	Tag
+	ExpenseCount int `json:"expense_count"` // タグを付けた支出の件数
+}
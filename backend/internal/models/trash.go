@@ -0,0 +1,22 @@
+package models
+
+// TrashedExpense はゴミ箱の支出です。明細・タグは省略します。
+type TrashedExpense struct {
+	Expense
+	DeletedAt       string `json:"deleted_at"`       // ゴミ箱に移した日時
+	RestorableUntil string `json:"restorable_until"` // 復元できる期限。過ぎると完全に削除されます
+}
+
+// TrashedFixedCost はゴミ箱の固定費です。
+type TrashedFixedCost struct {
+	FixedCost
+	EndedFrom       string `json:"ended_from"`       // 削除時に記録した解約月（YYYY-MM）。この月以降は計上しません
+	DeletedAt       string `json:"deleted_at"`       // ゴミ箱に移した日時
+	RestorableUntil string `json:"restorable_until"` // 復元できる期限。過ぎると復元できなくなります
+}
+
+// Trash はゴミ箱の内容です。いずれも新しく削除したものから順に並びます。
+type Trash struct {
+	Expenses   []TrashedExpense   `json:"expenses"`
+	FixedCosts []TrashedFixedCost `json:"fixed_costs"`
+}
@@ -1,9 +1,10 @@
 package models
 
 type User struct {
-	ID         string `json:"id"`
-	Income     int    `json:"income"`
-	SavingGoal int    `json:"saving_goal"`
-	CreatedAt  string `json:"created_at"`
-	UpdatedAt  string `json:"updated_at"`
+	ID                 string `json:"id"`
+	Income             int    `json:"income"`
+	SavingGoal         int    `json:"saving_goal"`
+	CurrentHouseholdID *int   `json:"current_household_id"` // 選択中の世帯（自分の家計簿の場合は nil）
+	CreatedAt          string `json:"created_at"`
+	UpdatedAt          string `json:"updated_at"`
 }
@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+
+	"money-buddy-backend/internal/models"
+)
+
+// APITokenRepository はパーソナルアクセストークンリポジトリの振る舞いを表します。
+type APITokenRepository interface {
+	// CreateAPIToken はトークンのハッシュを保存します。有効期限は作成時点から expiresInDays 日後です。
+	CreateAPIToken(ctx context.Context, userID string, name string, tokenHash string, scopes []string, expiresInDays int) (models.APIToken, error)
+	ListAPITokens(ctx context.Context, userID string) ([]models.APIToken, error)
+	// DeleteAPIToken はトークンを削除します。削除対象が存在しない場合は false を返します。
+	DeleteAPIToken(ctx context.Context, userID string, id int32) (bool, error)
+	// AuthenticateAPIToken は有効期限内のトークンの所有ユーザーと権限を返し、最終利用日時を更新します。
+	// 該当するトークンがない場合は sql.ErrNoRows を返します。
+	AuthenticateAPIToken(ctx context.Context, tokenHash string) (userID string, scopes []string, err error)
+}
@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"context"
+
+	"money-buddy-backend/internal/models"
+)
+
+// AuditEventRecord は追記する変更履歴です。Before・After は JSON 文字列で、記録しない側は "null" にします。
+type AuditEventRecord struct {
+	UserID     string // 家計簿の所有者
+	ActorID    string // 変更を行ったユーザー
+	EntityType models.AuditEntityType
+	EntityID   *int32
+	Action     models.AuditAction
+	Before     string
+	After      string
+	RequestID  string
+}
+
+// AuditListQuery は変更履歴一覧の検索条件です。
+type AuditListQuery struct {
+	EntityType *string // nil の場合はすべての種類
+	BeforeID   *int64  // この ID より前の履歴を返す（カーソル）
+	Limit      int32
+}
+
+// AuditRepository は変更履歴（追記のみ）を扱います。
+// CreateAuditEvent は変更と同じトランザクションで呼び出してください。
+type AuditRepository interface {
+	CreateAuditEvent(ctx context.Context, event AuditEventRecord) error
+	// ListAuditEvents は変更履歴を新しいものから順に返します。
+	ListAuditEvents(ctx context.Context, userID string, query AuditListQuery) ([]models.AuditEvent, error)
+	// ListEntityAuditEvents は1件のデータの変更履歴を古いものから順に返します。
+	ListEntityAuditEvents(ctx context.Context, userID string, entityType models.AuditEntityType, entityID int32) ([]models.AuditEvent, error)
+}
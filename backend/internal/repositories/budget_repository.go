@@ -0,0 +1,16 @@
+package repositories
+
+import (
+	"context"
+
+	"money-buddy-backend/internal/models"
+)
+
+// BudgetRepository はカテゴリ別月次予算リポジトリの振る舞いを表します。
+type BudgetRepository interface {
+	ListBudgets(ctx context.Context, userID string) ([]models.Budget, error)
+	// UpsertBudget はカテゴリの月次予算を設定します（既に設定済みの場合は上書きします）。
+	UpsertBudget(ctx context.Context, userID string, categoryID int32, monthlyLimit int) error
+	// DeleteBudget はカテゴリの月次予算を削除します。削除対象が存在しない場合は false を返します。
+	DeleteBudget(ctx context.Context, userID string, categoryID int32) (bool, error)
+}
@@ -6,7 +6,25 @@ import (
 	"money-buddy-backend/internal/models"
 )
 
+// CategoryRepository はカテゴリリポジトリの振る舞いを表します。
+// ユーザーから見えるカテゴリは、共通のデフォルトカテゴリ（非表示にしたものを除く）と
+// そのユーザーが作成したカテゴリです。
 type CategoryRepository interface {
-	ListCategories(ctx context.Context) ([]models.Category, error)
-	CategoryExists(ctx context.Context, id int32) (bool, error)
+	ListCategories(ctx context.Context, userID string) ([]models.Category, error)
+	CategoryExists(ctx context.Context, userID string, id int32) (bool, error)
+	// GetCategory はデフォルトまたは userID が作成したカテゴリを返します（非表示かどうかは問いません）。
+	GetCategory(ctx context.Context, userID string, id int32) (models.Category, error)
+	CreateCategory(ctx context.Context, userID string, name string) (models.Category, error)
+	UpdateCategory(ctx context.Context, userID string, id int32, name string) error
+	DeleteCategory(ctx context.Context, userID string, id int32) error
+	// LockCategory は userID が作成したカテゴリの行をトランザクションの終了までロックします。
+	LockCategory(ctx context.Context, userID string, id int32) error
+	HideCategory(ctx context.Context, userID string, id int32) error
+	UnhideCategory(ctx context.Context, userID string, id int32) error
+	// CountExpenses はカテゴリを参照している支出（明細を含む）と繰り返しルールの件数を返します。
+	CountExpenses(ctx context.Context, userID string, id int32) (int64, error)
+	// ListExpenseIDs は支出または明細のカテゴリが id の支出（ゴミ箱の支出を除く）の ID を返します。
+	ListExpenseIDs(ctx context.Context, userID string, id int32) ([]int32, error)
+	// MoveExpenses は支出・明細・繰り返しルールのカテゴリを fromID から toID に移動し、移動した支出のバージョンを進めます。
+	MoveExpenses(ctx context.Context, userID string, fromID int32, toID int32) error
 }
@@ -1,6 +1,11 @@
 package repositories
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"money-buddy-backend/internal/models"
+)
 
 // MonthlySummary は月次サマリー（収入・貯金目標・固定費）を表します。
 type MonthlySummary struct {
@@ -10,13 +15,101 @@ type MonthlySummary struct {
 }
 
 // MonthlyExpensesSummary は月次支出サマリー（確定支出・予定支出）を表します。
+// 確定支出には精算前の立替（reimbursable）を含み、取りやめ・精算済みの支出は含みません。
 type MonthlyExpensesSummary struct {
 	ConfirmedExpenses int64
 	PlannedExpenses   int64
 }
 
+// CategoryExpensesSummary はカテゴリ別の月次支出サマリーを表します。
+type CategoryExpensesSummary struct {
+	CategoryID        int32
+	CategoryName      string
+	MonthlyLimit      *int64 // 予算未設定の場合は nil
+	ConfirmedExpenses int64
+	PlannedExpenses   int64
+}
+
+// MemberExpensesSummary は登録したユーザー（世帯のメンバー）別の月次支出サマリーを表します。
+type MemberExpensesSummary struct {
+	UserID            string
+	ConfirmedExpenses int64
+	PlannedExpenses   int64
+}
+
+// MonthlyTrendSummary は月ごとの収入・貯金目標・固定費と支出の集計を表します。
+type MonthlyTrendSummary struct {
+	Month    time.Time // 月初日
+	Summary  MonthlySummary
+	Expenses MonthlyExpensesSummary
+}
+
+// MonthlyCategoryExpenses はカテゴリ別・月別の支出集計を表します。
+type MonthlyCategoryExpenses struct {
+	Month             time.Time // 月初日
+	CategoryID        int32
+	CategoryName      string
+	ConfirmedExpenses int64
+	PlannedExpenses   int64
+}
+
+// MonthlyCategoryEstimateAccuracy はカテゴリ別・月別の、予定から確定にした支出の予定金額と確定金額の集計を表します。
+type MonthlyCategoryEstimateAccuracy struct {
+	Month               time.Time // 月初日
+	CategoryID          int32
+	CategoryName        string
+	ExpenseCount        int64 // 予定から確定にした支出の件数
+	PlannedTotal        int64 // 予定金額の合計
+	ActualTotal         int64 // 確定した金額の合計
+	AbsoluteVariance    int64 // 支出ごとの差額の絶対値の合計
+	UnderestimatedCount int64 // 確定した金額が予定金額を上回った件数
+	OverestimatedCount  int64 // 確定した金額が予定金額を下回った件数
+}
+
+// TagTotal はタグ別の支出の集計を表します。
+type TagTotal struct {
+	TagID             int32
+	TagName           string
+	ExpenseCount      int64
+	ConfirmedExpenses int64
+	PlannedExpenses   int64
+}
+
+// TagCategoryExpenses はタグ別・カテゴリ別の支出の集計を表します。
+type TagCategoryExpenses struct {
+	TagID             int32
+	CategoryID        int32
+	CategoryName      string
+	ConfirmedExpenses int64
+	PlannedExpenses   int64
+}
+
 // DashboardRepository はダッシュボードリポジトリの振る舞いを表します。
 type DashboardRepository interface {
-	GetMonthlySummary(ctx context.Context, userID string) (*MonthlySummary, error)
-	GetMonthlyExpensesSummary(ctx context.Context, userID string) (*MonthlyExpensesSummary, error)
+	// GetMonthlySummary は month（月初日）を含む月の収入・貯金目標・固定費を返します。
+	// いずれも month の時点で有効だった値を使います。
+	// 毎月以外の固定費は mode に従って月割りまたは請求月のみに計上します。
+	GetMonthlySummary(ctx context.Context, userID string, month time.Time, mode models.FixedCostMode) (*MonthlySummary, error)
+	// GetMonthlyExpensesSummary は month（月初日）を含む月の支出を集計します。
+	GetMonthlyExpensesSummary(ctx context.Context, userID string, month time.Time) (*MonthlyExpensesSummary, error)
+	// GetMonthlyCategoryExpensesSummary は予算が設定されているカテゴリ、または month の月に支出があるカテゴリごとに支出を集計します。
+	GetMonthlyCategoryExpensesSummary(ctx context.Context, userID string, month time.Time) ([]CategoryExpensesSummary, error)
+	// GetMonthlyMemberExpensesSummary は userID の家計簿を共有する世帯のメンバーと、month の月に支出を登録したユーザーごとに支出を集計します。
+	// 家計簿の所有者（userID）を先頭に返します。
+	GetMonthlyMemberExpensesSummary(ctx context.Context, userID string, month time.Time) ([]MemberExpensesSummary, error)
+	// ListMonthlySummaries は from から to（いずれも月初日、両端を含む）までの各月の集計を古い月から順に返します。
+	// 各月の値は GetMonthlySummary・GetMonthlyExpensesSummary と同じ規則で集計します。
+	// ユーザーが存在しない場合は sql.ErrNoRows を返します。
+	ListMonthlySummaries(ctx context.Context, userID string, from, to time.Time, mode models.FixedCostMode) ([]MonthlyTrendSummary, error)
+	// ListMonthlyCategoryExpenses は from から to（いずれも月初日、両端を含む）までの支出を月・カテゴリごとに集計します。
+	// 支出がない月・カテゴリの組み合わせは含みません。
+	ListMonthlyCategoryExpenses(ctx context.Context, userID string, from, to time.Time) ([]MonthlyCategoryExpenses, error)
+	// ListMonthlyCategoryEstimateAccuracy は from から to（いずれも月初日、両端を含む）までに予定から確定にした支出を
+	// 月・カテゴリごとに集計します。古い月から順、同じ月の中はカテゴリID順に返します。
+	ListMonthlyCategoryEstimateAccuracy(ctx context.Context, userID string, from, to time.Time) ([]MonthlyCategoryEstimateAccuracy, error)
+	// ListTagTotals は from から to（両端を含む日付）までの支出をタグごとに集計します。確定支出の多いタグから順に返します。
+	// 1件の支出に複数のタグが付いている場合は、それぞれのタグに計上します。
+	ListTagTotals(ctx context.Context, userID string, from, to time.Time) ([]TagTotal, error)
+	// ListTagCategoryExpenses は ListTagTotals と同じ支出をタグ・カテゴリごとに集計します。タグID順、同じタグの中はカテゴリID順に返します。
+	ListTagCategoryExpenses(ctx context.Context, userID string, from, to time.Time) ([]TagCategoryExpenses, error)
 }
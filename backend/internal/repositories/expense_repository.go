@@ -1,12 +1,48 @@
 package repositories
 
-import "money-buddy-backend/internal/models"
+import (
+	"context"
+	"time"
+
+	"money-buddy-backend/internal/models"
+)
+
+// ExpenseListQuery は支出一覧取得の検証済み条件です。
+// nil / 空のフィールドは条件として扱いません。
+// CursorSpentAt が指定された場合は (spent_at, id) がカーソルより前の行のみを返します。
+type ExpenseListQuery struct {
+	From          *time.Time
+	To            *time.Time
+	CategoryIDs   []int32
+	TagIDs        []int32
+	Status        string
+	AmountMin     *int32
+	AmountMax     *int32
+	Memo          string
+	CursorSpentAt *time.Time
+	CursorID      int32
+	Limit         int32
+}
 
 // ExpenseRepository は経費リポジトリの振る舞いを表します。
+// すべてのメソッドは ctx にトランザクションが設定されている場合はそのトランザクション内で実行し、
+// ctx がキャンセルされた・期限を過ぎた場合はクエリを中断します。
 type ExpenseRepository interface {
-	CreateExpense(userID string, input models.CreateExpenseInput) (models.Expense, error)
-	FindAll(userID string) ([]models.Expense, error)
-	GetExpenseByID(userID string, id int32) (models.Expense, error)
-	DeleteExpense(userID string, id int32) error
-	UpdateExpense(userID string, input models.UpdateExpenseInput) (models.Expense, error)
+	// CreateExpense は input.Items があれば明細も同時に登録し、input.Tags のタグを付けます（まだないタグは作成します）。
+	CreateExpense(ctx context.Context, userID string, input models.CreateExpenseInput) (models.Expense, error)
+	// FindAll・GetExpenseByID は明細（Items）とタグ（Tags）も含めて返します。
+	FindAll(ctx context.Context, userID string, query ExpenseListQuery) ([]models.Expense, error)
+	GetExpenseByID(ctx context.Context, userID string, id int32) (models.Expense, error)
+	// DeleteExpense は支出をゴミ箱に移します。ゴミ箱の支出は FindAll・GetExpenseByID・更新の対象になりません。
+	DeleteExpense(ctx context.Context, userID string, id int32) error
+	// RestoreExpense は deletedAfter より後にゴミ箱に移した支出を元に戻し、復元した支出を返します。対象が存在しない場合は sql.ErrNoRows を返します。
+	RestoreExpense(ctx context.Context, userID string, id int32, deletedAfter time.Time) (models.Expense, error)
+	// UpdateExpense は input.Version が現在のバージョンと一致する場合のみ更新します。
+	// 支出が存在しない・バージョンが一致しない場合は sql.ErrNoRows を返します。
+	// input.PlannedAmount が nil の場合は予定金額を、input.Items・input.Tags が nil の場合は明細・タグを変更しません。
+	UpdateExpense(ctx context.Context, userID string, input models.UpdateExpenseInput) (models.Expense, error)
+	// UpdateExpenseStatus はステータスのみを変更します。バージョンと予定金額の扱いは UpdateExpense と同じです。
+	UpdateExpenseStatus(ctx context.Context, userID string, id int32, status string, version int, plannedAmount *int) (models.Expense, error)
+	// BulkCreateExpenses は検証済みの支出をまとめて登録し、登録した支出を inputs と同じ順で返します。
+	BulkCreateExpenses(ctx context.Context, userID string, inputs []models.CreateExpenseInput) ([]models.Expense, error)
 }
@@ -2,15 +2,24 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"money-buddy-backend/internal/models"
 )
 
+// FixedCostRepository は固定費を扱います。
+// 金額・支払いスケジュールは適用開始月ごとの版として保持し、解約した固定費は削除せずに終了月を記録します。
 type FixedCostRepository interface {
-	CreateFixedCost(ctx context.Context, userID string, name string, amount int) (models.FixedCost, error)
+	CreateFixedCost(ctx context.Context, userID string, name string, amount int, schedule models.FixedCostSchedule, effectiveFrom time.Time) (models.FixedCost, error)
+	// ListFixedCostsByUser は解約されていない固定費を現在有効な値で返します
 	ListFixedCostsByUser(ctx context.Context, userID string) ([]models.FixedCost, error)
-	DeleteFixedCostsByUser(ctx context.Context, userID string) error
-	BulkCreateFixedCosts(ctx context.Context, userID string, fixedCosts []models.FixedCostInput) error
-	UpdateFixedCost(ctx context.Context, id int32, userID string, name string, amount int) error
-	DeleteFixedCost(ctx context.Context, id int32, userID string) error
+	EndFixedCostsByUser(ctx context.Context, userID string, endedFrom time.Time) error
+	BulkCreateFixedCosts(ctx context.Context, userID string, fixedCosts []models.FixedCostInput, effectiveFrom time.Time) error
+	// UpdateFixedCost は version が現在のバージョンと一致する場合のみ更新します。
+	// 固定費が存在しない・バージョンが一致しない場合は false を返します。
+	UpdateFixedCost(ctx context.Context, id int32, userID string, name string, amount int, schedule models.FixedCostSchedule, effectiveFrom time.Time, version int) (bool, error)
+	// EndFixedCost は固定費を endedFrom の月で解約し、ゴミ箱に移します。
+	EndFixedCost(ctx context.Context, id int32, userID string, endedFrom time.Time) error
+	// RestoreFixedCost は deletedAfter より後にゴミ箱に移した固定費の解約を取り消します。対象が存在しない場合は false を返します。
+	RestoreFixedCost(ctx context.Context, id int32, userID string, deletedAfter time.Time) (bool, error)
 }
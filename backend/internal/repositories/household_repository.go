@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"context"
+
+	"money-buddy-backend/internal/models"
+)
+
+// HouseholdRepository は世帯リポジトリの振る舞いを表します。
+type HouseholdRepository interface {
+	// GetCurrentMembership は userID が選択中の世帯での所属情報を返します。
+	// 世帯を選択していない、または選択中の世帯のメンバーでない場合は sql.ErrNoRows を返します。
+	GetCurrentMembership(ctx context.Context, userID string) (models.HouseholdMembership, error)
+	ListHouseholds(ctx context.Context, userID string) ([]models.Household, error)
+	// GetHousehold は userID が所属する世帯を返します。所属していない場合は sql.ErrNoRows を返します。
+	GetHousehold(ctx context.Context, userID string, id int32) (models.Household, error)
+	ListMembers(ctx context.Context, id int32) ([]models.HouseholdMember, error)
+	OwnsHousehold(ctx context.Context, userID string) (bool, error)
+	CreateHousehold(ctx context.Context, ownerID string, name string) (int32, error)
+	// AddMember はメンバーを追加します。すでにメンバーの場合は false を返します。
+	AddMember(ctx context.Context, id int32, userID string, role string) (bool, error)
+	// SetCurrentHousehold は選択中の世帯を設定します。id が nil の場合は選択を解除します。
+	// ユーザーが存在しない場合は sql.ErrNoRows を返します。
+	SetCurrentHousehold(ctx context.Context, userID string, id *int32) error
+	// UpdateMemberRole はオーナー以外のメンバーの役割を変更します。対象がない場合は false を返します。
+	UpdateMemberRole(ctx context.Context, id int32, userID string, role string) (bool, error)
+	// DeleteMember はオーナー以外のメンバーを世帯から外します。対象がない場合は false を返します。
+	DeleteMember(ctx context.Context, id int32, userID string) (bool, error)
+	// DeleteHousehold は ownerID が所有する世帯を削除します。対象がない場合は false を返します。
+	DeleteHousehold(ctx context.Context, ownerID string, id int32) (bool, error)
+	// CreateInvitation は招待コードを保存します。有効期限は作成時点から expiresInDays 日後です。
+	CreateInvitation(ctx context.Context, id int32, createdBy string, code string, role string, expiresInDays int) (models.HouseholdInvitation, error)
+	// ConsumeInvitation は有効期限内の招待コードを削除し、招待先の世帯と役割を返します。
+	// 該当する招待コードがない場合は sql.ErrNoRows を返します。
+	ConsumeInvitation(ctx context.Context, code string) (householdID int32, role string, err error)
+}
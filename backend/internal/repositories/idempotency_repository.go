@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+
+	"money-buddy-backend/internal/models"
+)
+
+// IdempotencyRepository は Idempotency-Key リポジトリの振る舞いを表します。
+type IdempotencyRepository interface {
+	// ReserveIdempotencyKey はキーを処理中として保存します。有効なキーがすでにある場合は false を返します。
+	ReserveIdempotencyKey(ctx context.Context, userID string, key string, requestHash string) (bool, error)
+	// GetIdempotencyKey は有効期限内のキーを返します。存在しない場合は sql.ErrNoRows を返します。
+	GetIdempotencyKey(ctx context.Context, userID string, key string) (models.IdempotencyRecord, error)
+	SaveIdempotencyResponse(ctx context.Context, userID string, key string, record models.IdempotencyRecord) error
+	DeleteIdempotencyKey(ctx context.Context, userID string, key string) error
+	// DeleteExpiredIdempotencyKeys は有効期限を過ぎたキーを削除し、削除件数を返します。
+	DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error)
+}
@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"context"
+	"time"
+)
+
+// RecurringRule は繰り返しルールの保存形式です。日付は UTC の 00:00 で表します。
+type RecurringRule struct {
+	ID             int32
+	Amount         int32
+	CategoryID     int32
+	CategoryName   string
+	Memo           string
+	Frequency      string
+	DayOfMonth     *int32
+	StartDate      time.Time
+	EndDate        *time.Time
+	GeneratedUntil *time.Time
+}
+
+// RecurringOccurrence はルールから生成済み（またはスキップ済み）の回です。
+// ExpenseID が nil の場合はスキップされた回を表します。
+type RecurringOccurrence struct {
+	OccursOn  time.Time
+	ExpenseID *int32
+	Status    string // 生成した支出のステータス（スキップの場合は空）
+}
+
+// RecurringRepository は繰り返しルールリポジトリの振る舞いを表します。
+type RecurringRepository interface {
+	ListRules(ctx context.Context, userID string) ([]RecurringRule, error)
+	// ListRuleOwners は繰り返しルールを持つユーザーの ID を返します。
+	ListRuleOwners(ctx context.Context) ([]string, error)
+	GetRule(ctx context.Context, userID string, id int32) (RecurringRule, error)
+	CreateRule(ctx context.Context, userID string, rule RecurringRule) (int32, error)
+	UpdateRule(ctx context.Context, userID string, rule RecurringRule) error
+	DeleteRule(ctx context.Context, userID string, id int32) error
+	SetGeneratedUntil(ctx context.Context, userID string, id int32, until time.Time) error
+
+	// ListOccurrencesFrom は from 以降の生成済みの回を日付順に返します。
+	ListOccurrencesFrom(ctx context.Context, ruleID int32, from time.Time) ([]RecurringOccurrence, error)
+	GetOccurrence(ctx context.Context, ruleID int32, on time.Time) (RecurringOccurrence, error)
+	// ClaimOccurrence は on の回を生成済みとして記録します。既に記録済みの場合は false を返します。
+	ClaimOccurrence(ctx context.Context, ruleID int32, on time.Time) (bool, error)
+	// SetOccurrenceExpense は on の回に支出を紐付けます。expenseID が nil の場合はスキップとして記録します。
+	SetOccurrenceExpense(ctx context.Context, ruleID int32, on time.Time, expenseID *int32) error
+	DeleteOccurrence(ctx context.Context, ruleID int32, on time.Time) error
+}
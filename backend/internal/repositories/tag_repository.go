@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+
+	"money-buddy-backend/internal/models"
+)
+
+// TagRepository はタグリポジトリの振る舞いを表します。
+// タグの作成と支出への付け外しは ExpenseRepository の登録・更新で行います。
+type TagRepository interface {
+	// ListTags はタグを名前順に、付けられている支出の件数とあわせて返します。
+	ListTags(ctx context.Context, userID string) ([]models.TagSummary, error)
+	// RenameTag はタグの名前を変更します。対象が存在しない場合は false を返します。
+	RenameTag(ctx context.Context, userID string, id int32, name string) (bool, error)
+	// DeleteTag はタグを削除し、支出からも外します。対象が存在しない場合は false を返します。
+	DeleteTag(ctx context.Context, userID string, id int32) (bool, error)
+}
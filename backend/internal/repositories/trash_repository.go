@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"money-buddy-backend/internal/models"
+)
+
+// TrashedExpense はゴミ箱に移した支出です。
+type TrashedExpense struct {
+	Expense   models.Expense // 明細・タグは含みません
+	DeletedAt time.Time
+}
+
+// TrashedFixedCost はゴミ箱に移した固定費です。
+type TrashedFixedCost struct {
+	FixedCost models.FixedCost
+	EndedFrom time.Time // 削除時に記録した解約月
+	DeletedAt time.Time
+}
+
+// TrashRepository はゴミ箱（削除した支出・固定費）を扱います。
+// ゴミ箱への移動と復元は ExpenseRepository・FixedCostRepository で行います。
+type TrashRepository interface {
+	// ListTrashedExpenses・ListTrashedFixedCosts は deletedAfter より後に削除したものを、新しく削除したものから順に返します。
+	ListTrashedExpenses(ctx context.Context, userID string, deletedAfter time.Time) ([]TrashedExpense, error)
+	ListTrashedFixedCosts(ctx context.Context, userID string, deletedAfter time.Time) ([]TrashedFixedCost, error)
+	// PurgeTrashedExpenses は deletedBefore 以前に削除した支出を全ユーザー分完全に削除し、削除件数を返します。
+	PurgeTrashedExpenses(ctx context.Context, deletedBefore time.Time) (int64, error)
+	// PurgeTrashedFixedCosts は deletedBefore 以前に削除した固定費のうち、どの月にも計上されていないものを完全に削除し、削除件数を返します。
+	// 計上された月がある固定費は過去月の集計のために解約済みとして残します。
+	PurgeTrashedFixedCosts(ctx context.Context, deletedBefore time.Time) (int64, error)
+}
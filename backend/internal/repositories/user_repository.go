@@ -2,12 +2,14 @@ package repositories
 
 import (
 	"context"
+	"time"
 
 	"money-buddy-backend/internal/models"
 )
 
 type UserRepository interface {
-	CreateUser(ctx context.Context, id string, income int, savingGoal int) error
+	CreateUser(ctx context.Context, id string, income int, savingGoal int, effectiveFrom time.Time) error
 	GetUserByID(ctx context.Context, id string) (models.User, error)
-	UpdateUserSettings(ctx context.Context, id string, income int, savingGoal int) error
+	// UpdateUserSettings は effectiveFrom の月から適用する収入・貯金目標を登録します
+	UpdateUserSettings(ctx context.Context, id string, income int, savingGoal int, effectiveFrom time.Time) error
 }
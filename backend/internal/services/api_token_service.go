@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/repositories"
+)
+
+const (
+	// APITokenNameMaxLen はトークン名の最大文字数
+	APITokenNameMaxLen = 50
+	// APITokenDefaultExpiresInDays は有効期限を省略した場合の日数
+	APITokenDefaultExpiresInDays = 90
+	// APITokenMaxExpiresInDays は有効期限に指定できる最大日数
+	APITokenMaxExpiresInDays = 365
+)
+
+// ErrInvalidAPIToken はパーソナルアクセストークンが存在しない・期限切れであることを表すエラーです。
+var ErrInvalidAPIToken = errors.New("invalid api token")
+
+// APITokenService はパーソナルアクセストークンサービスのインターフェースです。
+type APITokenService interface {
+	ListTokens(ctx context.Context, userID string) ([]models.APIToken, error)
+	// CreateToken はトークンを作成し、平文のトークンを含めて返します。平文のトークンは保存しません。
+	CreateToken(ctx context.Context, userID string, input models.APITokenInput) (models.CreatedAPIToken, error)
+	RevokeToken(ctx context.Context, userID string, id int) error
+	// AuthenticateAPIToken はトークンを検証し、所有ユーザーと権限を返します。
+	// 存在しない・期限切れのトークンの場合は ErrInvalidAPIToken を返します。
+	AuthenticateAPIToken(ctx context.Context, token string) (userID string, scopes []string, err error)
+}
+
+type apiTokenService struct {
+	repo repositories.APITokenRepository
+}
+
+func NewAPITokenService(repo repositories.APITokenRepository) APITokenService {
+	return &apiTokenService{repo: repo}
+}
+
+func (s *apiTokenService) ListTokens(ctx context.Context, userID string) ([]models.APIToken, error) {
+	return s.repo.ListAPITokens(ctx, userID)
+}
+
+func (s *apiTokenService) CreateToken(ctx context.Context, userID string, input models.APITokenInput) (models.CreatedAPIToken, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return models.CreatedAPIToken{}, &ValidationError{Message: "トークン名を入力してください"}
+	}
+	if utf8.RuneCountInString(name) > APITokenNameMaxLen {
+		return models.CreatedAPIToken{}, &ValidationError{Message: "トークン名は50文字以内で入力してください"}
+	}
+
+	scopes, err := normalizeAPITokenScopes(input.Scopes)
+	if err != nil {
+		return models.CreatedAPIToken{}, err
+	}
+
+	expiresInDays := APITokenDefaultExpiresInDays
+	if input.ExpiresInDays != nil {
+		expiresInDays = *input.ExpiresInDays
+	}
+	if expiresInDays < 1 || expiresInDays > APITokenMaxExpiresInDays {
+		return models.CreatedAPIToken{}, &ValidationError{Message: "有効期限は1〜365日で指定してください"}
+	}
+
+	token, err := generateAPIToken()
+	if err != nil {
+		return models.CreatedAPIToken{}, err
+	}
+
+	created, err := s.repo.CreateAPIToken(ctx, userID, name, hashAPIToken(token), scopes, expiresInDays)
+	if err != nil {
+		return models.CreatedAPIToken{}, err
+	}
+	return models.CreatedAPIToken{APIToken: created, Token: token}, nil
+}
+
+func (s *apiTokenService) RevokeToken(ctx context.Context, userID string, id int) error {
+	deleted, err := s.repo.DeleteAPIToken(ctx, userID, int32(id))
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return &NotFoundError{Message: "トークンが見つかりません"}
+	}
+	return nil
+}
+
+func (s *apiTokenService) AuthenticateAPIToken(ctx context.Context, token string) (string, []string, error) {
+	if !strings.HasPrefix(token, models.APITokenPrefix) {
+		return "", nil, ErrInvalidAPIToken
+	}
+	userID, scopes, err := s.repo.AuthenticateAPIToken(ctx, hashAPIToken(token))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil, ErrInvalidAPIToken
+		}
+		return "", nil, err
+	}
+	return userID, scopes, nil
+}
+
+// normalizeAPITokenScopes は権限を検証し、重複を除いて返します。
+func normalizeAPITokenScopes(scopes []string) ([]string, error) {
+	if len(scopes) == 0 {
+		return nil, &ValidationError{Message: "権限を1つ以上指定してください"}
+	}
+	out := make([]string, 0, len(scopes))
+	seen := make(map[string]bool, len(scopes))
+	for _, scope := range scopes {
+		if !models.IsValidAPITokenScope(scope) {
+			return nil, &ValidationError{Message: "権限は read または write:expenses を指定してください"}
+		}
+		if seen[scope] {
+			continue
+		}
+		seen[scope] = true
+		out = append(out, scope)
+	}
+	return out, nil
+}
+
+// generateAPIToken は接頭辞付きのランダムなトークンを生成します。
+func generateAPIToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return models.APITokenPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashAPIToken は保存・照合に使うトークンのハッシュ（SHA-256 の16進表記）を返します。
+// トークンは十分な長さのランダム値のため、ソルトなしのハッシュで照合します。
+func hashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
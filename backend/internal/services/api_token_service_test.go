@@ -0,0 +1,160 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"money-buddy-backend/internal/models"
+)
+
+// mockAPITokenRepo は APITokenRepository のモック実装です
+type mockAPITokenRepo struct {
+	createFunc       func(ctx context.Context, userID, name, tokenHash string, scopes []string, expiresInDays int) (models.APIToken, error)
+	listFunc         func(ctx context.Context, userID string) ([]models.APIToken, error)
+	deleteFunc       func(ctx context.Context, userID string, id int32) (bool, error)
+	authenticateFunc func(ctx context.Context, tokenHash string) (string, []string, error)
+}
+
+func (m *mockAPITokenRepo) CreateAPIToken(ctx context.Context, userID string, name string, tokenHash string, scopes []string, expiresInDays int) (models.APIToken, error) {
+	if m.createFunc != nil {
+		return m.createFunc(ctx, userID, name, tokenHash, scopes, expiresInDays)
+	}
+	return models.APIToken{}, errors.New("not implemented")
+}
+
+func (m *mockAPITokenRepo) ListAPITokens(ctx context.Context, userID string) ([]models.APIToken, error) {
+	if m.listFunc != nil {
+		return m.listFunc(ctx, userID)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockAPITokenRepo) DeleteAPIToken(ctx context.Context, userID string, id int32) (bool, error) {
+	if m.deleteFunc != nil {
+		return m.deleteFunc(ctx, userID, id)
+	}
+	return false, errors.New("not implemented")
+}
+
+func (m *mockAPITokenRepo) AuthenticateAPIToken(ctx context.Context, tokenHash string) (string, []string, error) {
+	if m.authenticateFunc != nil {
+		return m.authenticateFunc(ctx, tokenHash)
+	}
+	return "", nil, errors.New("not implemented")
+}
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// TestCreateToken はトークン作成時にハッシュのみを保存し、平文を返すことのテストです
+func TestCreateToken(t *testing.T) {
+	var gotName, gotHash string
+	var gotScopes []string
+	var gotDays int
+	repo := &mockAPITokenRepo{
+		createFunc: func(ctx context.Context, userID, name, tokenHash string, scopes []string, expiresInDays int) (models.APIToken, error) {
+			assert.Equal(t, "test-user", userID)
+			gotName, gotHash, gotScopes, gotDays = name, tokenHash, scopes, expiresInDays
+			return models.APIToken{ID: 1, Name: name, Scopes: scopes}, nil
+		},
+	}
+	service := NewAPITokenService(repo)
+
+	created, err := service.CreateToken(context.Background(), "test-user", models.APITokenInput{
+		Name:   "  iOS ショートカット ",
+		Scopes: []string{"write:expenses", "read", "write:expenses"},
+	})
+
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(created.Token, models.APITokenPrefix))
+	assert.Equal(t, sha256Hex(created.Token), gotHash)
+	assert.NotContains(t, gotHash, created.Token)
+	assert.Equal(t, "iOS ショートカット", gotName)
+	assert.Equal(t, []string{"write:expenses", "read"}, gotScopes)
+	assert.Equal(t, APITokenDefaultExpiresInDays, gotDays)
+	assert.Equal(t, 1, created.ID)
+
+	// 毎回異なるトークンを生成する
+	again, err := service.CreateToken(context.Background(), "test-user", models.APITokenInput{Name: "cron", Scopes: []string{"read"}})
+	require.NoError(t, err)
+	assert.NotEqual(t, created.Token, again.Token)
+}
+
+// TestCreateToken_Validation はトークン作成の入力チェックのテストです
+func TestCreateToken_Validation(t *testing.T) {
+	days := func(n int) *int { return &n }
+	cases := []struct {
+		name  string
+		input models.APITokenInput
+	}{
+		{name: "名前が空", input: models.APITokenInput{Name: " ", Scopes: []string{"read"}}},
+		{name: "名前が長すぎる", input: models.APITokenInput{Name: strings.Repeat("あ", 51), Scopes: []string{"read"}}},
+		{name: "権限が未指定", input: models.APITokenInput{Name: "cron"}},
+		{name: "権限が不正", input: models.APITokenInput{Name: "cron", Scopes: []string{"admin"}}},
+		{name: "有効期限が0日", input: models.APITokenInput{Name: "cron", Scopes: []string{"read"}, ExpiresInDays: days(0)}},
+		{name: "有効期限が長すぎる", input: models.APITokenInput{Name: "cron", Scopes: []string{"read"}, ExpiresInDays: days(366)}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := NewAPITokenService(&mockAPITokenRepo{})
+
+			_, err := service.CreateToken(context.Background(), "test-user", tc.input)
+
+			var ve *ValidationError
+			assert.ErrorAs(t, err, &ve)
+		})
+	}
+}
+
+// TestRevokeToken_NotFound は存在しないトークンの削除のテストです
+func TestRevokeToken_NotFound(t *testing.T) {
+	repo := &mockAPITokenRepo{
+		deleteFunc: func(ctx context.Context, userID string, id int32) (bool, error) {
+			assert.Equal(t, int32(5), id)
+			return false, nil
+		},
+	}
+	service := NewAPITokenService(repo)
+
+	err := service.RevokeToken(context.Background(), "test-user", 5)
+
+	var ne *NotFoundError
+	assert.ErrorAs(t, err, &ne)
+}
+
+// TestAuthenticateAPIToken はハッシュでトークンを照合することのテストです
+func TestAuthenticateAPIToken(t *testing.T) {
+	token := models.APITokenPrefix + "abc"
+	repo := &mockAPITokenRepo{
+		authenticateFunc: func(ctx context.Context, tokenHash string) (string, []string, error) {
+			if tokenHash == sha256Hex(token) {
+				return "test-user", []string{"read"}, nil
+			}
+			return "", nil, sql.ErrNoRows
+		},
+	}
+	service := NewAPITokenService(repo)
+
+	userID, scopes, err := service.AuthenticateAPIToken(context.Background(), token)
+	require.NoError(t, err)
+	assert.Equal(t, "test-user", userID)
+	assert.Equal(t, []string{"read"}, scopes)
+
+	// 存在しない・期限切れ
+	_, _, err = service.AuthenticateAPIToken(context.Background(), models.APITokenPrefix+"unknown")
+	assert.ErrorIs(t, err, ErrInvalidAPIToken)
+
+	// 接頭辞がない
+	_, _, err = service.AuthenticateAPIToken(context.Background(), "abc")
+	assert.ErrorIs(t, err, ErrInvalidAPIToken)
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+
+	"money-buddy-backend/internal/audit"
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/repositories"
+)
+
+const (
+	// DefaultAuditPageSize は変更履歴一覧で limit 未指定時の件数
+	DefaultAuditPageSize = 50
+	// MaxAuditPageSize は変更履歴一覧で指定できる limit の上限
+	MaxAuditPageSize = 200
+)
+
+// AuditService は変更履歴（監査ログ）の参照を扱います。
+// 変更履歴の記録は各サービスが変更と同じトランザクションで行います（recordAudit）。
+type AuditService interface {
+	// ListAuditEvents は家計簿の変更履歴を新しいものから順に返します。
+	ListAuditEvents(ctx context.Context, userID string, filter models.AuditFilter) (models.AuditPage, error)
+	// GetExpenseHistory は支出の変更履歴を古いものから順に返します。ゴミ箱の支出の履歴も返します。
+	GetExpenseHistory(ctx context.Context, userID string, id int) ([]models.AuditEvent, error)
+}
+
+type auditService struct {
+	repo repositories.AuditRepository
+}
+
+func NewAuditService(repo repositories.AuditRepository) AuditService {
+	return &auditService{repo: repo}
+}
+
+func (s *auditService) ListAuditEvents(ctx context.Context, userID string, filter models.AuditFilter) (models.AuditPage, error) {
+	var query repositories.AuditListQuery
+
+	if filter.EntityType != "" {
+		if !models.IsValidAuditEntityType(filter.EntityType) {
+			return models.AuditPage{}, &ValidationError{Message: "種類は expense・fixed_cost・user_settings・initial_setup から指定してください"}
+		}
+		query.EntityType = &filter.EntityType
+	}
+	if filter.Cursor != "" {
+		id, err := decodeAuditCursor(filter.Cursor)
+		if err != nil {
+			return models.AuditPage{}, &ValidationError{Message: "カーソルが正しくありません"}
+		}
+		query.BeforeID = &id
+	}
+
+	limit := DefaultAuditPageSize
+	if filter.Limit != 0 {
+		if filter.Limit < 0 || filter.Limit > MaxAuditPageSize {
+			return models.AuditPage{}, &ValidationError{Message: "取得件数は1〜200の範囲で指定してください"}
+		}
+		limit = filter.Limit
+	}
+
+	// 次ページの有無を判定するため 1 件多く取得する
+	query.Limit = int32(limit + 1)
+	events, err := s.repo.ListAuditEvents(ctx, userID, query)
+	if err != nil {
+		return models.AuditPage{}, &InternalError{Message: "internal error"}
+	}
+
+	page := models.AuditPage{Events: events}
+	if len(events) > limit {
+		page.Events = events[:limit]
+		cursor := encodeAuditCursor(page.Events[limit-1].ID)
+		page.NextCursor = &cursor
+	}
+	return page, nil
+}
+
+func (s *auditService) GetExpenseHistory(ctx context.Context, userID string, id int) ([]models.AuditEvent, error) {
+	events, err := s.repo.ListEntityAuditEvents(ctx, userID, models.AuditEntityExpense, int32(id))
+	if err != nil {
+		return nil, &InternalError{Message: "internal error"}
+	}
+	if len(events) == 0 {
+		return nil, &NotFoundError{Message: "支出の変更履歴が見つかりません"}
+	}
+	return events, nil
+}
+
+// encodeAuditCursor は変更履歴の ID を不透明なカーソル文字列に変換します。
+func encodeAuditCursor(id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(id, 10)))
+}
+
+// decodeAuditCursor は encodeAuditCursor で生成したカーソルを ID に戻します。
+func decodeAuditCursor(cursor string) (int64, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, err
+	}
+	id, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil || id <= 0 {
+		return 0, strconv.ErrSyntax
+	}
+	return id, nil
+}
+
+// recordAudit は変更前後のスナップショットを JSON にして変更履歴を1件記録します。
+// 変更と同じトランザクションの ctx で呼び出してください。before・after が nil の場合は null を記録し、
+// entityID が 0 の場合は対象の ID を記録しません。操作者は ctx の audit.ActorFromContext（未設定の場合は userID）です。
+func recordAudit(ctx context.Context, repo repositories.AuditRepository, userID string, entityType models.AuditEntityType, entityID int, action models.AuditAction, before, after any) error {
+	beforeJSON, err := json.Marshal(before)
+	if err != nil {
+		return &InternalError{Message: "internal error"}
+	}
+	afterJSON, err := json.Marshal(after)
+	if err != nil {
+		return &InternalError{Message: "internal error"}
+	}
+
+	actor := audit.ActorFromContext(ctx)
+	if actor == "" {
+		actor = userID
+	}
+	event := repositories.AuditEventRecord{
+		UserID:     userID,
+		ActorID:    actor,
+		EntityType: entityType,
+		Action:     action,
+		Before:     string(beforeJSON),
+		After:      string(afterJSON),
+		RequestID:  audit.RequestIDFromContext(ctx),
+	}
+	if entityID != 0 {
+		id := int32(entityID)
+		event.EntityID = &id
+	}
+
+	if err := repo.CreateAuditEvent(ctx, event); err != nil {
+		return &InternalError{Message: "internal error"}
+	}
+	return nil
+}
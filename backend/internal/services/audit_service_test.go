@@ -0,0 +1,234 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"money-buddy-backend/internal/audit"
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/repositories"
+)
+
+// mockAuditRepo は AuditRepository のモック実装です。記録した変更履歴を保持します
+type mockAuditRepo struct {
+	created   []repositories.AuditEventRecord
+	createErr error
+	events    []models.AuditEvent
+	listErr   error
+	query     repositories.AuditListQuery
+}
+
+func (m *mockAuditRepo) CreateAuditEvent(ctx context.Context, event repositories.AuditEventRecord) error {
+	if m.createErr != nil {
+		return m.createErr
+	}
+	m.created = append(m.created, event)
+	return nil
+}
+
+func (m *mockAuditRepo) ListAuditEvents(ctx context.Context, userID string, query repositories.AuditListQuery) ([]models.AuditEvent, error) {
+	m.query = query
+	return m.events, m.listErr
+}
+
+func (m *mockAuditRepo) ListEntityAuditEvents(ctx context.Context, userID string, entityType models.AuditEntityType, entityID int32) ([]models.AuditEvent, error) {
+	return m.events, m.listErr
+}
+
+// fakeTxManager はコミット・ロールバック・セーブポイントの回数だけを記録する TxManager です
+type fakeTxManager struct {
+	commits              int
+	rollbacks            int
+	savepoints           int
+	rollbacksToSavepoint int
+}
+
+func (m *fakeTxManager) Begin(ctx context.Context) (Tx, error) {
+	return &fakeTx{m: m}, nil
+}
+
+type fakeTx struct{ m *fakeTxManager }
+
+func (t *fakeTx) Commit() error {
+	t.m.commits++
+	return nil
+}
+
+func (t *fakeTx) Rollback() error {
+	t.m.rollbacks++
+	return nil
+}
+
+func (t *fakeTx) Context(ctx context.Context) context.Context { return ctx }
+
+func (t *fakeTx) Savepoint(ctx context.Context, name string) error {
+	t.m.savepoints++
+	return nil
+}
+
+func (t *fakeTx) RollbackToSavepoint(ctx context.Context, name string) error {
+	t.m.rollbacksToSavepoint++
+	return nil
+}
+
+func auditEvents(ids ...int64) []models.AuditEvent {
+	events := make([]models.AuditEvent, 0, len(ids))
+	for _, id := range ids {
+		events = append(events, models.AuditEvent{ID: id})
+	}
+	return events
+}
+
+// TestListAuditEvents は変更履歴一覧の取得のテストです
+func TestListAuditEvents(t *testing.T) {
+	t.Run("次のページがある場合はカーソルを返す", func(t *testing.T) {
+		repo := &mockAuditRepo{events: auditEvents(30, 29, 28)}
+		s := NewAuditService(repo)
+
+		page, err := s.ListAuditEvents(context.Background(), "user-1", models.AuditFilter{Limit: 2})
+		require.NoError(t, err)
+		assert.Len(t, page.Events, 2)
+		// 次ページの有無を判定するため 1 件多く取得する
+		assert.Equal(t, int32(3), repo.query.Limit)
+		require.NotNil(t, page.NextCursor)
+
+		// カーソルを渡すと最後の履歴より前から取得する
+		_, err = s.ListAuditEvents(context.Background(), "user-1", models.AuditFilter{Cursor: *page.NextCursor})
+		require.NoError(t, err)
+		require.NotNil(t, repo.query.BeforeID)
+		assert.Equal(t, int64(29), *repo.query.BeforeID)
+		assert.Equal(t, int32(DefaultAuditPageSize+1), repo.query.Limit)
+	})
+
+	t.Run("最後のページではカーソルを返さない", func(t *testing.T) {
+		repo := &mockAuditRepo{events: auditEvents(2, 1)}
+		s := NewAuditService(repo)
+
+		page, err := s.ListAuditEvents(context.Background(), "user-1", models.AuditFilter{EntityType: "fixed_cost"})
+		require.NoError(t, err)
+		assert.Len(t, page.Events, 2)
+		assert.Nil(t, page.NextCursor)
+		require.NotNil(t, repo.query.EntityType)
+		assert.Equal(t, "fixed_cost", *repo.query.EntityType)
+	})
+
+	cases := []struct {
+		name    string
+		filter  models.AuditFilter
+		wantMsg string
+	}{
+		{name: "不明な種類", filter: models.AuditFilter{EntityType: "budget"}, wantMsg: "種類は expense・fixed_cost・user_settings・initial_setup から指定してください"},
+		{name: "不正なカーソル", filter: models.AuditFilter{Cursor: "!!"}, wantMsg: "カーソルが正しくありません"},
+		{name: "上限を超える件数", filter: models.AuditFilter{Limit: MaxAuditPageSize + 1}, wantMsg: "取得件数は1〜200の範囲で指定してください"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewAuditService(&mockAuditRepo{})
+
+			_, err := s.ListAuditEvents(context.Background(), "user-1", tc.filter)
+			var ve *ValidationError
+			require.ErrorAs(t, err, &ve)
+			assert.Equal(t, tc.wantMsg, ve.Message)
+		})
+	}
+
+	t.Run("リポジトリのエラーは内部エラー", func(t *testing.T) {
+		s := NewAuditService(&mockAuditRepo{listErr: errors.New("db error")})
+
+		_, err := s.ListAuditEvents(context.Background(), "user-1", models.AuditFilter{})
+		var ie *InternalError
+		assert.ErrorAs(t, err, &ie)
+	})
+}
+
+// TestGetExpenseHistory は支出の変更履歴の取得のテストです
+func TestGetExpenseHistory(t *testing.T) {
+	t.Run("履歴を返す", func(t *testing.T) {
+		s := NewAuditService(&mockAuditRepo{events: auditEvents(1, 5)})
+
+		events, err := s.GetExpenseHistory(context.Background(), "user-1", 3)
+		require.NoError(t, err)
+		assert.Len(t, events, 2)
+	})
+
+	t.Run("履歴がない場合は NotFound", func(t *testing.T) {
+		s := NewAuditService(&mockAuditRepo{})
+
+		_, err := s.GetExpenseHistory(context.Background(), "user-1", 3)
+		var nfe *NotFoundError
+		require.ErrorAs(t, err, &nfe)
+		assert.Equal(t, "支出の変更履歴が見つかりません", nfe.Message)
+	})
+}
+
+// TestRecordAudit は変更履歴の記録内容のテストです
+func TestRecordAudit(t *testing.T) {
+	t.Run("操作者とリクエストIDをコンテキストから記録する", func(t *testing.T) {
+		repo := &mockAuditRepo{}
+		ctx := audit.WithRequestID(audit.WithActor(context.Background(), "member-1"), "req-1")
+
+		err := recordAudit(ctx, repo, "owner-1", models.AuditEntityExpense, 7, models.AuditActionUpdate,
+			models.Expense{ID: 7, Amount: 100}, models.Expense{ID: 7, Amount: 200})
+		require.NoError(t, err)
+
+		require.Len(t, repo.created, 1)
+		got := repo.created[0]
+		assert.Equal(t, "owner-1", got.UserID)
+		assert.Equal(t, "member-1", got.ActorID)
+		assert.Equal(t, "req-1", got.RequestID)
+		require.NotNil(t, got.EntityID)
+		assert.Equal(t, int32(7), *got.EntityID)
+
+		var before, after models.Expense
+		require.NoError(t, json.Unmarshal([]byte(got.Before), &before))
+		require.NoError(t, json.Unmarshal([]byte(got.After), &after))
+		assert.Equal(t, 100, before.Amount)
+		assert.Equal(t, 200, after.Amount)
+	})
+
+	t.Run("操作者が未設定の場合は所有者、対象がない場合は ID を記録しない", func(t *testing.T) {
+		repo := &mockAuditRepo{}
+
+		err := recordAudit(context.Background(), repo, "owner-1", models.AuditEntityUserSettings, 0, models.AuditActionUpdate, nil, map[string]any{"income": 1})
+		require.NoError(t, err)
+
+		require.Len(t, repo.created, 1)
+		assert.Equal(t, "owner-1", repo.created[0].ActorID)
+		assert.Nil(t, repo.created[0].EntityID)
+		assert.Equal(t, "null", repo.created[0].Before)
+	})
+}
+
+// TestExpenseMutations_RecordAudit は支出の変更が同じトランザクションで履歴に記録されることのテストです
+func TestExpenseMutations_RecordAudit(t *testing.T) {
+	t.Run("削除は変更前の支出を記録してコミットする", func(t *testing.T) {
+		auditRepo := &mockAuditRepo{}
+		tm := &fakeTxManager{}
+		s := NewExpenseService(&mockDeleteRepo{}, &mockCategoryRepo{}, auditRepo, tm)
+
+		require.NoError(t, s.DeleteExpense(context.Background(), "user-1", 10))
+
+		require.Len(t, auditRepo.created, 1)
+		assert.Equal(t, models.AuditActionDelete, auditRepo.created[0].Action)
+		assert.Equal(t, models.AuditEntityExpense, auditRepo.created[0].EntityType)
+		assert.JSONEq(t, "null", auditRepo.created[0].After)
+		assert.Equal(t, 1, tm.commits)
+	})
+
+	t.Run("履歴の記録に失敗した場合はロールバックする", func(t *testing.T) {
+		auditRepo := &mockAuditRepo{createErr: errors.New("db error")}
+		tm := &fakeTxManager{}
+		s := NewExpenseService(&mockDeleteRepo{restored: true}, &mockCategoryRepo{}, auditRepo, tm)
+
+		_, err := s.RestoreExpense(context.Background(), "user-1", 11)
+		var ie *InternalError
+		require.ErrorAs(t, err, &ie)
+		assert.Equal(t, 0, tm.commits)
+		assert.Equal(t, 1, tm.rollbacks)
+	})
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/repositories"
+)
+
+type BudgetService interface {
+	ListBudgets(ctx context.Context, userID string) ([]models.Budget, error)
+	// SetBudget はカテゴリの月次予算を設定します。既に設定済みの場合は上書きします。
+	SetBudget(ctx context.Context, userID string, categoryID int, monthlyLimit int) (models.Budget, error)
+	DeleteBudget(ctx context.Context, userID string, categoryID int) error
+}
+
+type budgetService struct {
+	repo         repositories.BudgetRepository
+	categoryRepo repositories.CategoryRepository
+}
+
+func NewBudgetService(repo repositories.BudgetRepository, categoryRepo repositories.CategoryRepository) BudgetService {
+	return &budgetService{repo: repo, categoryRepo: categoryRepo}
+}
+
+func (s *budgetService) ListBudgets(ctx context.Context, userID string) ([]models.Budget, error) {
+	return s.repo.ListBudgets(ctx, userID)
+}
+
+func (s *budgetService) SetBudget(ctx context.Context, userID string, categoryID int, monthlyLimit int) (models.Budget, error) {
+	if monthlyLimit <= 0 {
+		return models.Budget{}, &ValidationError{Message: "予算額は1円以上で入力してください"}
+	}
+	if monthlyLimit > BusinessMaxAmount {
+		return models.Budget{}, &ValidationError{Message: "予算額は10億円以下で入力してください"}
+	}
+
+	// 予算を設定できるのはユーザーから参照可能なカテゴリのみ
+	category, err := s.categoryRepo.GetCategory(ctx, userID, int32(categoryID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Budget{}, &NotFoundError{Message: "カテゴリが見つかりません"}
+		}
+		return models.Budget{}, err
+	}
+
+	if err := s.repo.UpsertBudget(ctx, userID, int32(categoryID), monthlyLimit); err != nil {
+		return models.Budget{}, err
+	}
+
+	return models.Budget{
+		CategoryID:   category.ID,
+		CategoryName: category.Name,
+		MonthlyLimit: monthlyLimit,
+	}, nil
+}
+
+func (s *budgetService) DeleteBudget(ctx context.Context, userID string, categoryID int) error {
+	deleted, err := s.repo.DeleteBudget(ctx, userID, int32(categoryID))
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return &NotFoundError{Message: "予算が見つかりません"}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"money-buddy-backend/internal/models"
+)
+
+// budgetRepoMock は予算リポジトリのモックです
+type budgetRepoMock struct{ mock.Mock }
+
+func (m *budgetRepoMock) ListBudgets(ctx context.Context, userID string) ([]models.Budget, error) {
+	args := m.Called(ctx, userID)
+	if list, ok := args.Get(0).([]models.Budget); ok {
+		return list, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *budgetRepoMock) UpsertBudget(ctx context.Context, userID string, categoryID int32, monthlyLimit int) error {
+	args := m.Called(ctx, userID, categoryID, monthlyLimit)
+	return args.Error(0)
+}
+
+func (m *budgetRepoMock) DeleteBudget(ctx context.Context, userID string, categoryID int32) (bool, error) {
+	args := m.Called(ctx, userID, categoryID)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestSetBudget(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("参照可能なカテゴリに予算を設定できる", func(t *testing.T) {
+		repo := new(budgetRepoMock)
+		catRepo := new(categoryRepoMock)
+		catRepo.On("GetCategory", ctx, "user1", int32(1)).Return(models.Category{ID: 1, Name: "食費"}, nil)
+		repo.On("UpsertBudget", ctx, "user1", int32(1), 40000).Return(nil)
+
+		s := NewBudgetService(repo, catRepo)
+		got, err := s.SetBudget(ctx, "user1", 1, 40000)
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.Budget{CategoryID: 1, CategoryName: "食費", MonthlyLimit: 40000}, got)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("予算額が範囲外の場合はエラー", func(t *testing.T) {
+		for _, limit := range []int{0, -1, BusinessMaxAmount + 1} {
+			repo := new(budgetRepoMock)
+			s := NewBudgetService(repo, new(categoryRepoMock))
+			_, err := s.SetBudget(ctx, "user1", 1, limit)
+
+			var ve *ValidationError
+			assert.ErrorAs(t, err, &ve)
+			repo.AssertNotCalled(t, "UpsertBudget", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		}
+	})
+
+	t.Run("参照できないカテゴリは見つからない", func(t *testing.T) {
+		repo := new(budgetRepoMock)
+		catRepo := new(categoryRepoMock)
+		catRepo.On("GetCategory", ctx, "user1", int32(99)).Return(models.Category{}, sql.ErrNoRows)
+
+		s := NewBudgetService(repo, catRepo)
+		_, err := s.SetBudget(ctx, "user1", 99, 1000)
+
+		var ne *NotFoundError
+		assert.ErrorAs(t, err, &ne)
+		repo.AssertNotCalled(t, "UpsertBudget", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestDeleteBudget(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("設定済みの予算を削除できる", func(t *testing.T) {
+		repo := new(budgetRepoMock)
+		repo.On("DeleteBudget", ctx, "user1", int32(1)).Return(true, nil)
+
+		s := NewBudgetService(repo, new(categoryRepoMock))
+		assert.NoError(t, s.DeleteBudget(ctx, "user1", 1))
+	})
+
+	t.Run("未設定の場合は見つからない", func(t *testing.T) {
+		repo := new(budgetRepoMock)
+		repo.On("DeleteBudget", ctx, "user1", int32(2)).Return(false, nil)
+
+		s := NewBudgetService(repo, new(categoryRepoMock))
+		err := s.DeleteBudget(ctx, "user1", 2)
+
+		var ne *NotFoundError
+		assert.ErrorAs(t, err, &ne)
+	})
+}
@@ -2,23 +2,212 @@ package services
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"unicode/utf8"
 
 	"money-buddy-backend/internal/models"
 	"money-buddy-backend/internal/repositories"
 )
 
+const (
+	// CategoryNameMaxLen はカテゴリ名の最大文字数
+	CategoryNameMaxLen = 50
+)
+
 type CategoryService interface {
-	ListCategories(ctx context.Context) ([]models.Category, error)
+	ListCategories(ctx context.Context, userID string) ([]models.Category, error)
+	CreateCategory(ctx context.Context, userID string, name string) (models.Category, error)
+	UpdateCategory(ctx context.Context, userID string, id int, name string) (models.Category, error)
+	// DeleteCategory はユーザーが作成したカテゴリを削除します。
+	// 支出・繰り返しルールで使用中の場合は moveTo に移動先カテゴリを指定する必要があります。
+	DeleteCategory(ctx context.Context, userID string, id int, moveTo *int) error
+	HideCategory(ctx context.Context, userID string, id int) error
+	UnhideCategory(ctx context.Context, userID string, id int) error
 }
 
 type categoryService struct {
-	repo repositories.CategoryRepository
+	repo        repositories.CategoryRepository
+	expenseRepo repositories.ExpenseRepository
+	auditRepo   repositories.AuditRepository
+	txManager   TxManager
+}
+
+func NewCategoryService(repo repositories.CategoryRepository, expenseRepo repositories.ExpenseRepository, auditRepo repositories.AuditRepository, txManager TxManager) CategoryService {
+	return &categoryService{repo: repo, expenseRepo: expenseRepo, auditRepo: auditRepo, txManager: txManager}
+}
+
+func (s *categoryService) ListCategories(ctx context.Context, userID string) ([]models.Category, error) {
+	return s.repo.ListCategories(ctx, userID)
+}
+
+func (s *categoryService) CreateCategory(ctx context.Context, userID string, name string) (models.Category, error) {
+	name = strings.TrimSpace(name)
+	if err := s.validateCategoryName(ctx, userID, 0, name); err != nil {
+		return models.Category{}, err
+	}
+
+	return s.repo.CreateCategory(ctx, userID, name)
 }
 
-func NewCategoryService(repo repositories.CategoryRepository) CategoryService {
-	return &categoryService{repo: repo}
+func (s *categoryService) UpdateCategory(ctx context.Context, userID string, id int, name string) (models.Category, error) {
+	if _, err := s.getCustomCategory(ctx, userID, id); err != nil {
+		return models.Category{}, err
+	}
+
+	name = strings.TrimSpace(name)
+	if err := s.validateCategoryName(ctx, userID, id, name); err != nil {
+		return models.Category{}, err
+	}
+
+	if err := s.repo.UpdateCategory(ctx, userID, int32(id), name); err != nil {
+		return models.Category{}, err
+	}
+
+	return models.Category{ID: id, Name: name, IsCustom: true}, nil
+}
+
+func (s *categoryService) DeleteCategory(ctx context.Context, userID string, id int, moveTo *int) error {
+	if moveTo != nil && *moveTo == id {
+		return &ValidationError{Message: "移動先には削除するカテゴリ以外を選択してください"}
+	}
+
+	// 確認から削除までの間に支出が登録されないよう、カテゴリをロックしてから同じトランザクションで確認する
+	// （並行して登録・更新する支出は外部キーの確認でロックを待ち、削除後は外部キー制約違反になる）
+	return withTx(ctx, s.txManager, func(txCtx context.Context) error {
+		if _, err := s.getCustomCategory(txCtx, userID, id); err != nil {
+			return err
+		}
+		if err := s.repo.LockCategory(txCtx, userID, int32(id)); err != nil {
+			return err
+		}
+
+		count, err := s.repo.CountExpenses(txCtx, userID, int32(id))
+		if err != nil {
+			return err
+		}
+
+		// 使用中のカテゴリは移動先の指定を必須とする
+		if count > 0 && moveTo == nil {
+			return ErrCategoryInUse
+		}
+		if moveTo != nil {
+			exists, err := s.repo.CategoryExists(txCtx, userID, int32(*moveTo))
+			if err != nil {
+				return err
+			}
+			if !exists {
+				return &ValidationError{Message: "移動先のカテゴリが存在しません"}
+			}
+			if err := s.moveExpenses(txCtx, userID, int32(id), int32(*moveTo)); err != nil {
+				return err
+			}
+		}
+		return s.repo.DeleteCategory(txCtx, userID, int32(id))
+	})
 }
 
-func (s *categoryService) ListCategories(ctx context.Context) ([]models.Category, error) {
-	return s.repo.ListCategories(ctx)
+// moveExpenses は支出のカテゴリを移動し、移動した支出ごとに変更履歴を記録します。
+// ゴミ箱の支出も移動しますが、変更履歴は記録しません（復元時の記録に移動後の内容が残ります）。
+func (s *categoryService) moveExpenses(ctx context.Context, userID string, fromID int32, toID int32) error {
+	ids, err := s.repo.ListExpenseIDs(ctx, userID, fromID)
+	if err != nil {
+		return err
+	}
+	before := make([]models.Expense, 0, len(ids))
+	for _, expenseID := range ids {
+		exp, err := s.expenseRepo.GetExpenseByID(ctx, userID, expenseID)
+		if err != nil {
+			return err
+		}
+		before = append(before, exp)
+	}
+
+	if err := s.repo.MoveExpenses(ctx, userID, fromID, toID); err != nil {
+		return err
+	}
+
+	for _, prev := range before {
+		after, err := s.expenseRepo.GetExpenseByID(ctx, userID, int32(prev.ID))
+		if err != nil {
+			return err
+		}
+		if err := recordAudit(ctx, s.auditRepo, userID, models.AuditEntityExpense, prev.ID, models.AuditActionUpdate, prev, after); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *categoryService) HideCategory(ctx context.Context, userID string, id int) error {
+	category, err := s.getCategory(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if category.IsCustom {
+		return &ValidationError{Message: "非表示にできるのはデフォルトカテゴリのみです"}
+	}
+
+	return s.repo.HideCategory(ctx, userID, int32(id))
+}
+
+func (s *categoryService) UnhideCategory(ctx context.Context, userID string, id int) error {
+	category, err := s.getCategory(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if category.IsCustom {
+		return &ValidationError{Message: "非表示にできるのはデフォルトカテゴリのみです"}
+	}
+
+	return s.repo.UnhideCategory(ctx, userID, int32(id))
+}
+
+// getCategory はユーザーから参照可能なカテゴリを取得します。
+func (s *categoryService) getCategory(ctx context.Context, userID string, id int) (models.Category, error) {
+	category, err := s.repo.GetCategory(ctx, userID, int32(id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Category{}, &NotFoundError{Message: "カテゴリが見つかりません"}
+		}
+		return models.Category{}, err
+	}
+	return category, nil
+}
+
+// getCustomCategory はユーザーが作成したカテゴリを取得します。デフォルトカテゴリの場合はエラーを返します。
+func (s *categoryService) getCustomCategory(ctx context.Context, userID string, id int) (models.Category, error) {
+	category, err := s.getCategory(ctx, userID, id)
+	if err != nil {
+		return models.Category{}, err
+	}
+	if !category.IsCustom {
+		return models.Category{}, &ValidationError{Message: "デフォルトカテゴリは変更・削除できません"}
+	}
+	return category, nil
+}
+
+// validateCategoryName はカテゴリ名の入力チェックと、表示中のカテゴリとの重複チェックを行います。
+// selfID には更新対象のカテゴリID（新規作成時は 0）を指定します。
+func (s *categoryService) validateCategoryName(ctx context.Context, userID string, selfID int, name string) error {
+	// 名前チェック（呼び出し側で既にTrimSpaceされていることを前提）
+	if name == "" {
+		return &ValidationError{Message: "カテゴリ名を入力してください"}
+	}
+	if utf8.RuneCountInString(name) > CategoryNameMaxLen {
+		return &ValidationError{Message: "カテゴリ名は50文字以内で入力してください"}
+	}
+
+	categories, err := s.repo.ListCategories(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, c := range categories {
+		if c.ID != selfID && c.Name == name {
+			return &ValidationError{Message: "同じ名前のカテゴリが既に存在します"}
+		}
+	}
+
+	return nil
 }
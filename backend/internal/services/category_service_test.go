@@ -0,0 +1,363 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"money-buddy-backend/internal/models"
+)
+
+// categoryRepoMock はカテゴリリポジトリのモックです
+type categoryRepoMock struct{ mock.Mock }
+
+func (m *categoryRepoMock) ListCategories(ctx context.Context, userID string) ([]models.Category, error) {
+	args := m.Called(ctx, userID)
+	if list, ok := args.Get(0).([]models.Category); ok {
+		return list, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *categoryRepoMock) CategoryExists(ctx context.Context, userID string, id int32) (bool, error) {
+	args := m.Called(ctx, userID, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *categoryRepoMock) GetCategory(ctx context.Context, userID string, id int32) (models.Category, error) {
+	args := m.Called(ctx, userID, id)
+	if c, ok := args.Get(0).(models.Category); ok {
+		return c, args.Error(1)
+	}
+	return models.Category{}, args.Error(1)
+}
+
+func (m *categoryRepoMock) CreateCategory(ctx context.Context, userID string, name string) (models.Category, error) {
+	args := m.Called(ctx, userID, name)
+	if c, ok := args.Get(0).(models.Category); ok {
+		return c, args.Error(1)
+	}
+	return models.Category{}, args.Error(1)
+}
+
+func (m *categoryRepoMock) UpdateCategory(ctx context.Context, userID string, id int32, name string) error {
+	args := m.Called(ctx, userID, id, name)
+	return args.Error(0)
+}
+
+func (m *categoryRepoMock) DeleteCategory(ctx context.Context, userID string, id int32) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *categoryRepoMock) LockCategory(ctx context.Context, userID string, id int32) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *categoryRepoMock) HideCategory(ctx context.Context, userID string, id int32) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *categoryRepoMock) UnhideCategory(ctx context.Context, userID string, id int32) error {
+	args := m.Called(ctx, userID, id)
+	return args.Error(0)
+}
+
+func (m *categoryRepoMock) CountExpenses(ctx context.Context, userID string, id int32) (int64, error) {
+	args := m.Called(ctx, userID, id)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *categoryRepoMock) ListExpenseIDs(ctx context.Context, userID string, id int32) ([]int32, error) {
+	args := m.Called(ctx, userID, id)
+	if ids, ok := args.Get(0).([]int32); ok {
+		return ids, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *categoryRepoMock) MoveExpenses(ctx context.Context, userID string, fromID int32, toID int32) error {
+	args := m.Called(ctx, userID, fromID, toID)
+	return args.Error(0)
+}
+
+var visibleCategories = []models.Category{
+	{ID: 1, Name: "食費"},
+	{ID: 2, Name: "日用品"},
+	{ID: 10, Name: "ペット", IsCustom: true},
+}
+
+func TestCreateCategory(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("前後の空白を除去して作成できる", func(t *testing.T) {
+		repo := new(categoryRepoMock)
+		repo.On("ListCategories", ctx, "user1").Return(visibleCategories, nil)
+		repo.On("CreateCategory", ctx, "user1", "推し活").Return(models.Category{ID: 11, Name: "推し活", IsCustom: true}, nil)
+
+		s := NewCategoryService(repo, newFakeExpenseRepo(), &mockAuditRepo{}, new(txManagerMock))
+		got, err := s.CreateCategory(ctx, "user1", "  推し活  ")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 11, got.ID)
+		assert.True(t, got.IsCustom)
+		repo.AssertExpectations(t)
+	})
+
+	cases := []struct {
+		name string
+		in   string
+	}{
+		{name: "空文字はエラー", in: "   "},
+		{name: "50文字を超える場合はエラー", in: strings.Repeat("あ", 51)},
+		{name: "表示中のカテゴリと同名はエラー", in: "食費"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := new(categoryRepoMock)
+			repo.On("ListCategories", ctx, "user1").Return(visibleCategories, nil).Maybe()
+
+			s := NewCategoryService(repo, newFakeExpenseRepo(), &mockAuditRepo{}, new(txManagerMock))
+			_, err := s.CreateCategory(ctx, "user1", tc.in)
+
+			var ve *ValidationError
+			assert.ErrorAs(t, err, &ve)
+			repo.AssertNotCalled(t, "CreateCategory", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestUpdateCategory(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("自分のカテゴリは更新できる（同名の自分自身は重複扱いしない）", func(t *testing.T) {
+		repo := new(categoryRepoMock)
+		repo.On("GetCategory", ctx, "user1", int32(10)).Return(models.Category{ID: 10, Name: "ペット", IsCustom: true}, nil)
+		repo.On("ListCategories", ctx, "user1").Return(visibleCategories, nil)
+		repo.On("UpdateCategory", ctx, "user1", int32(10), "ペット").Return(nil)
+
+		s := NewCategoryService(repo, newFakeExpenseRepo(), &mockAuditRepo{}, new(txManagerMock))
+		got, err := s.UpdateCategory(ctx, "user1", 10, "ペット")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "ペット", got.Name)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("デフォルトカテゴリは更新できない", func(t *testing.T) {
+		repo := new(categoryRepoMock)
+		repo.On("GetCategory", ctx, "user1", int32(1)).Return(models.Category{ID: 1, Name: "食費"}, nil)
+
+		s := NewCategoryService(repo, newFakeExpenseRepo(), &mockAuditRepo{}, new(txManagerMock))
+		_, err := s.UpdateCategory(ctx, "user1", 1, "ごはん")
+
+		var ve *ValidationError
+		assert.ErrorAs(t, err, &ve)
+		repo.AssertNotCalled(t, "UpdateCategory", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("他ユーザーのカテゴリは見つからない", func(t *testing.T) {
+		repo := new(categoryRepoMock)
+		repo.On("GetCategory", ctx, "user1", int32(99)).Return(models.Category{}, sql.ErrNoRows)
+
+		s := NewCategoryService(repo, newFakeExpenseRepo(), &mockAuditRepo{}, new(txManagerMock))
+		_, err := s.UpdateCategory(ctx, "user1", 99, "x")
+
+		var ne *NotFoundError
+		assert.ErrorAs(t, err, &ne)
+	})
+}
+
+func TestDeleteCategory(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("未使用のカテゴリは移動先なしで削除できる", func(t *testing.T) {
+		repo := new(categoryRepoMock)
+		tx := new(txMock)
+		tm := new(txManagerMock)
+		tm.On("Begin", ctx).Return(tx, nil)
+		repo.On("GetCategory", ctx, "user1", int32(10)).Return(models.Category{ID: 10, IsCustom: true}, nil)
+		repo.On("LockCategory", ctx, "user1", int32(10)).Return(nil)
+		repo.On("CountExpenses", ctx, "user1", int32(10)).Return(int64(0), nil)
+		repo.On("DeleteCategory", ctx, "user1", int32(10)).Return(nil)
+		tx.On("Commit").Return(nil)
+
+		s := NewCategoryService(repo, newFakeExpenseRepo(), &mockAuditRepo{}, tm)
+		err := s.DeleteCategory(ctx, "user1", 10, nil)
+
+		assert.NoError(t, err)
+		repo.AssertNotCalled(t, "MoveExpenses", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		repo.AssertExpectations(t)
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("使用中のカテゴリは移動先なしでは削除できない", func(t *testing.T) {
+		repo := new(categoryRepoMock)
+		tx := new(txMock)
+		tm := new(txManagerMock)
+		tm.On("Begin", ctx).Return(tx, nil)
+		repo.On("GetCategory", ctx, "user1", int32(10)).Return(models.Category{ID: 10, IsCustom: true}, nil)
+		repo.On("LockCategory", ctx, "user1", int32(10)).Return(nil)
+		repo.On("CountExpenses", ctx, "user1", int32(10)).Return(int64(3), nil)
+		tx.On("Rollback").Return(nil)
+
+		s := NewCategoryService(repo, newFakeExpenseRepo(), &mockAuditRepo{}, tm)
+		err := s.DeleteCategory(ctx, "user1", 10, nil)
+
+		assert.ErrorIs(t, err, ErrCategoryInUse)
+		repo.AssertNotCalled(t, "DeleteCategory", mock.Anything, mock.Anything, mock.Anything)
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("使用中かどうかはカテゴリをロックしてから同じトランザクションで確認する", func(t *testing.T) {
+		repo := new(categoryRepoMock)
+		tx := new(txMock)
+		tm := new(txManagerMock)
+		var calls []string
+		tm.On("Begin", ctx).Run(func(args mock.Arguments) { calls = append(calls, "begin") }).Return(tx, nil)
+		repo.On("GetCategory", ctx, "user1", int32(10)).Return(models.Category{ID: 10, IsCustom: true}, nil)
+		repo.On("LockCategory", ctx, "user1", int32(10)).Run(func(args mock.Arguments) { calls = append(calls, "lock") }).Return(nil)
+		repo.On("CountExpenses", ctx, "user1", int32(10)).Run(func(args mock.Arguments) { calls = append(calls, "count") }).Return(int64(0), nil)
+		repo.On("DeleteCategory", ctx, "user1", int32(10)).Return(nil)
+		tx.On("Commit").Run(func(args mock.Arguments) { calls = append(calls, "commit") }).Return(nil)
+
+		s := NewCategoryService(repo, newFakeExpenseRepo(), &mockAuditRepo{}, tm)
+		err := s.DeleteCategory(ctx, "user1", 10, nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"begin", "lock", "count", "commit"}, calls)
+	})
+
+	t.Run("使用中のカテゴリは支出を移動してから削除する", func(t *testing.T) {
+		repo := new(categoryRepoMock)
+		tx := new(txMock)
+		tm := new(txManagerMock)
+		moveTo := 2
+		var calls []string
+		tm.On("Begin", ctx).Return(tx, nil)
+		repo.On("GetCategory", ctx, "user1", int32(10)).Return(models.Category{ID: 10, IsCustom: true}, nil)
+		repo.On("LockCategory", ctx, "user1", int32(10)).Return(nil)
+		repo.On("CountExpenses", ctx, "user1", int32(10)).Return(int64(3), nil)
+		repo.On("CategoryExists", ctx, "user1", int32(2)).Return(true, nil)
+		repo.On("ListExpenseIDs", ctx, "user1", int32(10)).Return([]int32(nil), nil)
+		repo.On("MoveExpenses", ctx, "user1", int32(10), int32(2)).Run(func(args mock.Arguments) { calls = append(calls, "move") }).Return(nil)
+		repo.On("DeleteCategory", ctx, "user1", int32(10)).Run(func(args mock.Arguments) { calls = append(calls, "delete") }).Return(nil)
+		tx.On("Commit").Run(func(args mock.Arguments) { calls = append(calls, "commit") }).Return(nil)
+
+		s := NewCategoryService(repo, newFakeExpenseRepo(), &mockAuditRepo{}, tm)
+		err := s.DeleteCategory(ctx, "user1", 10, &moveTo)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"move", "delete", "commit"}, calls)
+	})
+
+	t.Run("移動した支出ごとに変更前後の内容を変更履歴に記録する", func(t *testing.T) {
+		repo := new(categoryRepoMock)
+		tx := new(txMock)
+		tm := new(txManagerMock)
+		expenses := newFakeExpenseRepo()
+		expenses.items[5] = models.Expense{ID: 5, Amount: 1000, Category: models.Category{ID: 10}, Version: 1}
+		expenses.items[7] = models.Expense{ID: 7, Amount: 2000, Category: models.Category{ID: 10}, Version: 3}
+		audits := &mockAuditRepo{}
+		moveTo := 2
+		tm.On("Begin", ctx).Return(tx, nil)
+		repo.On("GetCategory", ctx, "user1", int32(10)).Return(models.Category{ID: 10, IsCustom: true}, nil)
+		repo.On("LockCategory", ctx, "user1", int32(10)).Return(nil)
+		repo.On("CountExpenses", ctx, "user1", int32(10)).Return(int64(2), nil)
+		repo.On("CategoryExists", ctx, "user1", int32(2)).Return(true, nil)
+		repo.On("ListExpenseIDs", ctx, "user1", int32(10)).Return([]int32{5, 7}, nil)
+		repo.On("MoveExpenses", ctx, "user1", int32(10), int32(2)).Run(func(args mock.Arguments) {
+			for id, exp := range expenses.items {
+				exp.Category = models.Category{ID: 2}
+				exp.Version++
+				expenses.items[id] = exp
+			}
+		}).Return(nil)
+		repo.On("DeleteCategory", ctx, "user1", int32(10)).Return(nil)
+		tx.On("Commit").Return(nil)
+
+		s := NewCategoryService(repo, expenses, audits, tm)
+		err := s.DeleteCategory(ctx, "user1", 10, &moveTo)
+
+		assert.NoError(t, err)
+		if assert.Len(t, audits.created, 2) {
+			event := audits.created[0]
+			assert.Equal(t, models.AuditEntityExpense, event.EntityType)
+			assert.Equal(t, models.AuditActionUpdate, event.Action)
+			assert.Equal(t, int32(5), *event.EntityID)
+			assert.Contains(t, event.Before, `"category":{"id":10`)
+			assert.Contains(t, event.After, `"category":{"id":2`)
+			assert.Equal(t, int32(7), *audits.created[1].EntityID)
+		}
+	})
+
+	t.Run("移動先が参照できないカテゴリの場合はエラー", func(t *testing.T) {
+		repo := new(categoryRepoMock)
+		tx := new(txMock)
+		tm := new(txManagerMock)
+		moveTo := 99
+		tm.On("Begin", ctx).Return(tx, nil)
+		repo.On("GetCategory", ctx, "user1", int32(10)).Return(models.Category{ID: 10, IsCustom: true}, nil)
+		repo.On("LockCategory", ctx, "user1", int32(10)).Return(nil)
+		repo.On("CountExpenses", ctx, "user1", int32(10)).Return(int64(3), nil)
+		repo.On("CategoryExists", ctx, "user1", int32(99)).Return(false, nil)
+		tx.On("Rollback").Return(nil)
+
+		s := NewCategoryService(repo, newFakeExpenseRepo(), &mockAuditRepo{}, tm)
+		err := s.DeleteCategory(ctx, "user1", 10, &moveTo)
+
+		var ve *ValidationError
+		assert.ErrorAs(t, err, &ve)
+		repo.AssertNotCalled(t, "MoveExpenses", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("デフォルトカテゴリは削除できない", func(t *testing.T) {
+		repo := new(categoryRepoMock)
+		tx := new(txMock)
+		tm := new(txManagerMock)
+		tm.On("Begin", ctx).Return(tx, nil)
+		repo.On("GetCategory", ctx, "user1", int32(1)).Return(models.Category{ID: 1}, nil)
+		tx.On("Rollback").Return(nil)
+
+		s := NewCategoryService(repo, newFakeExpenseRepo(), &mockAuditRepo{}, tm)
+		err := s.DeleteCategory(ctx, "user1", 1, nil)
+
+		var ve *ValidationError
+		assert.ErrorAs(t, err, &ve)
+		repo.AssertNotCalled(t, "DeleteCategory", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestHideCategory(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("デフォルトカテゴリは非表示にできる", func(t *testing.T) {
+		repo := new(categoryRepoMock)
+		repo.On("GetCategory", ctx, "user1", int32(1)).Return(models.Category{ID: 1}, nil)
+		repo.On("HideCategory", ctx, "user1", int32(1)).Return(nil)
+
+		s := NewCategoryService(repo, newFakeExpenseRepo(), &mockAuditRepo{}, new(txManagerMock))
+		err := s.HideCategory(ctx, "user1", 1)
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("自分のカテゴリは非表示にできない", func(t *testing.T) {
+		repo := new(categoryRepoMock)
+		repo.On("GetCategory", ctx, "user1", int32(10)).Return(models.Category{ID: 10, IsCustom: true}, nil)
+
+		s := NewCategoryService(repo, newFakeExpenseRepo(), &mockAuditRepo{}, new(txManagerMock))
+		err := s.HideCategory(ctx, "user1", 10)
+
+		var ve *ValidationError
+		assert.ErrorAs(t, err, &ve)
+		repo.AssertNotCalled(t, "HideCategory", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -2,49 +2,172 @@ package services
 
 import (
 	"context"
+	"time"
 
+	"money-buddy-backend/internal/models"
 	"money-buddy-backend/internal/repositories"
 )
 
 // Dashboard はダッシュボード表示用のデータ構造です。
 type Dashboard struct {
-	Income            int64 // 月収
-	SavingGoal        int64 // 貯金目標
-	FixedCosts        int64 // 固定費合計
-	VariableBudget    int64 // 変動費（自由に使える額）= 収入 - 固定費 - 貯金目標
+	Month             string                 // 対象月（YYYY-MM）
+	PeriodStart       string                 // 集計期間の開始日（YYYY-MM-DD）
+	PeriodEnd         string                 // 集計期間の終了日（YYYY-MM-DD）
+	FixedCostMode     models.FixedCostMode   // 毎月以外の固定費の計上方法
+	Income            int64                  // 月収
+	SavingGoal        int64                  // 貯金目標
+	FixedCosts        int64                  // 固定費合計
+	VariableBudget    int64                  // 変動費（自由に使える額）= 収入 - 固定費 - 貯金目標
+	ConfirmedExpenses int64                  // 確定支出（精算前の立替を含む）
+	PlannedExpenses   int64                  // 予定支出
+	Remaining         int64                  // 残額 = 変動費 - (確定支出 + 予定支出)
+	Categories        []CategoryBudgetStatus // カテゴリ別の予算消化状況
+	Members           []MemberExpenses       // 登録したメンバー別の支出
+	Pace              DashboardPace          // 今日までの利用ペースと月末の見込み
+}
+
+// DashboardPace は対象月の利用ペースと月末の残額見込みです。
+// 経過日数・残り日数はどちらも今日を含みます。過去の月は全日経過、未来の月は全日残りとして扱います。
+type DashboardPace struct {
+	DaysInMonth        int   // 対象月の日数
+	DaysElapsed        int   // 経過日数（今日を含む）
+	DaysRemaining      int   // 残り日数（今日を含む）
+	IdealSpendToDate   int64 // 今日までの理想の支出 = 変動費 × 経過日数 / 月の日数
+	ActualSpendToDate  int64 // 今日までの実際の支出（確定支出）
+	SafeToSpendPerDay  int64 // 1日あたり使える額 = 残額 / 残り日数（残額がマイナスの場合は 0）
+	ProjectedRemaining int64 // 月末の残額見込み = 変動費 - (確定支出を今のペースで月末まで延ばした額 + 予定支出)
+}
+
+// MemberExpenses は登録したユーザー（世帯のメンバー）別の支出です。
+// 世帯を作成していない場合は本人のみになります。
+type MemberExpenses struct {
+	UserID            string
 	ConfirmedExpenses int64 // 確定支出
 	PlannedExpenses   int64 // 予定支出
-	Remaining         int64 // 残額 = 変動費 - (確定支出 + 予定支出)
+}
+
+// BudgetLevel はカテゴリ予算の消化状況を表す信号色です。
+type BudgetLevel string
+
+const (
+	BudgetLevelGreen  BudgetLevel = "green"  // 予算の80%未満
+	BudgetLevelYellow BudgetLevel = "yellow" // 予算の80%以上100%以下
+	BudgetLevelRed    BudgetLevel = "red"    // 予算超過
+)
+
+// BudgetWarningPercent は予算消化率がこの値（%）以上になると yellow とする閾値です。
+const BudgetWarningPercent = 80
+
+// CategoryBudgetStatus はカテゴリ別の予算消化状況です。
+// 予算が未設定のカテゴリでは Limit・Remaining が nil、Level が空になります。
+type CategoryBudgetStatus struct {
+	CategoryID        int
+	CategoryName      string
+	Limit             *int64 // 月次予算
+	ConfirmedExpenses int64  // 確定支出
+	PlannedExpenses   int64  // 予定支出
+	Remaining         *int64 // 残額 = 予算 - (確定支出 + 予定支出)
+	Level             BudgetLevel
 }
 
 // DashboardService はダッシュボードサービスのインターフェースです。
 type DashboardService interface {
-	GetDashboard(ctx context.Context, userID string) (*Dashboard, error)
+	// GetDashboard は month（YYYY-MM）のダッシュボードを返します。month が空の場合は当月を対象とします。
+	// fixedCostMode は毎月以外の固定費の計上方法（amortize / billing_month）で、空の場合は amortize とします。
+	GetDashboard(ctx context.Context, userID string, month string, fixedCostMode string) (*Dashboard, error)
+	// ExportMonthlySummaries は from から to（YYYY-MM、両端を含む）までの各月の集計を古い月から順に fn に渡します。
+	// to が空の場合は当月、from が空の場合は to を含む直近12か月を対象とします。カテゴリ別の内訳は含みません。
+	ExportMonthlySummaries(ctx context.Context, userID string, from, to string, fixedCostMode string, fn func(*Dashboard) error) error
 }
 
+// MonthRangeMaxMonths は月次集計の書き出し・推移で指定できる期間の上限（月数）です。
+const MonthRangeMaxMonths = 120
+
 type dashboardService struct {
 	repo repositories.DashboardRepository
+	now  func() time.Time
 }
 
 // NewDashboardService は DashboardService の新しいインスタンスを作成します。
 func NewDashboardService(repo repositories.DashboardRepository) DashboardService {
-	return &dashboardService{repo: repo}
+	return &dashboardService{repo: repo, now: time.Now}
 }
 
 // GetDashboard はダッシュボード表示用のデータを取得します。
-func (s *dashboardService) GetDashboard(ctx context.Context, userID string) (*Dashboard, error) {
+func (s *dashboardService) GetDashboard(ctx context.Context, userID string, month string, fixedCostMode string) (*Dashboard, error) {
+	monthStart, err := s.resolveMonth(month)
+	if err != nil {
+		return nil, err
+	}
+	mode, err := resolveFixedCostMode(fixedCostMode)
+	if err != nil {
+		return nil, err
+	}
+
 	// 月次サマリー（収入・貯金目標・固定費）を取得
-	summary, err := s.repo.GetMonthlySummary(ctx, userID)
+	summary, err := s.repo.GetMonthlySummary(ctx, userID, monthStart, mode)
 	if err != nil {
 		return nil, err
 	}
 
 	// 月次支出サマリー（確定支出・予定支出）を取得
-	expenses, err := s.repo.GetMonthlyExpensesSummary(ctx, userID)
+	expenses, err := s.repo.GetMonthlyExpensesSummary(ctx, userID, monthStart)
 	if err != nil {
 		return nil, err
 	}
 
+	// カテゴリ別の支出サマリーを取得
+	categorySummaries, err := s.repo.GetMonthlyCategoryExpensesSummary(ctx, userID, monthStart)
+	if err != nil {
+		return nil, err
+	}
+
+	// メンバー別の支出サマリーを取得
+	memberSummaries, err := s.repo.GetMonthlyMemberExpensesSummary(ctx, userID, monthStart)
+	if err != nil {
+		return nil, err
+	}
+
+	dashboard := buildDashboard(monthStart, mode, summary, expenses)
+	dashboard.Categories = buildCategoryBudgetStatuses(categorySummaries)
+	dashboard.Members = make([]MemberExpenses, 0, len(memberSummaries))
+	for _, ms := range memberSummaries {
+		dashboard.Members = append(dashboard.Members, MemberExpenses(ms))
+	}
+	dashboard.Pace = buildPace(monthStart, s.now(), dashboard)
+	return dashboard, nil
+}
+
+// ExportMonthlySummaries は月ごとのダッシュボード集計を書き出し用に順に返します。
+func (s *dashboardService) ExportMonthlySummaries(ctx context.Context, userID string, from, to string, fixedCostMode string, fn func(*Dashboard) error) error {
+	fromMonth, toMonth, err := resolveMonthRange(from, to, s.now())
+	if err != nil {
+		return err
+	}
+	mode, err := resolveFixedCostMode(fixedCostMode)
+	if err != nil {
+		return err
+	}
+
+	for month := fromMonth; !month.After(toMonth); month = month.AddDate(0, 1, 0) {
+		// 請求月に計上する場合は固定費が月ごとに異なるため、月ごとに取得する
+		summary, err := s.repo.GetMonthlySummary(ctx, userID, month, mode)
+		if err != nil {
+			return err
+		}
+		expenses, err := s.repo.GetMonthlyExpensesSummary(ctx, userID, month)
+		if err != nil {
+			return err
+		}
+		if err := fn(buildDashboard(month, mode, summary, expenses)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// buildDashboard は月次サマリーと支出サマリーから monthStart の月の集計を組み立てます。
+func buildDashboard(monthStart time.Time, mode models.FixedCostMode, summary *repositories.MonthlySummary, expenses *repositories.MonthlyExpensesSummary) *Dashboard {
 	// 変動費を計算: 収入 - 固定費 - 貯金目標
 	variableBudget := summary.Income - summary.FixedCosts - summary.SavingGoal
 
@@ -52,6 +175,10 @@ func (s *dashboardService) GetDashboard(ctx context.Context, userID string) (*Da
 	remaining := variableBudget - (expenses.ConfirmedExpenses + expenses.PlannedExpenses)
 
 	return &Dashboard{
+		Month:             monthStart.Format("2006-01"),
+		PeriodStart:       monthStart.Format("2006-01-02"),
+		PeriodEnd:         monthStart.AddDate(0, 1, -1).Format("2006-01-02"),
+		FixedCostMode:     mode,
 		Income:            summary.Income,
 		SavingGoal:        summary.SavingGoal,
 		FixedCosts:        summary.FixedCosts,
@@ -59,5 +186,149 @@ func (s *dashboardService) GetDashboard(ctx context.Context, userID string) (*Da
 		ConfirmedExpenses: expenses.ConfirmedExpenses,
 		PlannedExpenses:   expenses.PlannedExpenses,
 		Remaining:         remaining,
-	}, nil
+	}
+}
+
+// buildPace は now 時点での start の月の利用ペースと月末の見込みを計算します。
+func buildPace(start, now time.Time, d *Dashboard) DashboardPace {
+	daysInMonth := start.AddDate(0, 1, -1).Day()
+
+	// 経過日数・残り日数はどちらも今日を含む
+	var elapsed, remainingDays int
+	switch current := monthStart(now); {
+	case start.Before(current): // 過去の月
+		elapsed = daysInMonth
+	case start.After(current): // 未来の月
+		remainingDays = daysInMonth
+	default:
+		today := calendarDate(now).Day()
+		elapsed = today
+		remainingDays = daysInMonth - today + 1
+	}
+
+	pace := DashboardPace{
+		DaysInMonth:       daysInMonth,
+		DaysElapsed:       elapsed,
+		DaysRemaining:     remainingDays,
+		ActualSpendToDate: d.ConfirmedExpenses,
+	}
+	if d.VariableBudget > 0 {
+		pace.IdealSpendToDate = d.VariableBudget * int64(elapsed) / int64(daysInMonth)
+	}
+	if remainingDays > 0 && d.Remaining > 0 {
+		pace.SafeToSpendPerDay = d.Remaining / int64(remainingDays)
+	}
+
+	// 確定支出を経過日数のペースで月末まで延ばす（まだ1日も経過していない月は現在の確定支出のまま）
+	projectedConfirmed := d.ConfirmedExpenses
+	if elapsed > 0 {
+		projectedConfirmed = d.ConfirmedExpenses * int64(daysInMonth) / int64(elapsed)
+	}
+	pace.ProjectedRemaining = d.VariableBudget - (projectedConfirmed + d.PlannedExpenses)
+
+	return pace
+}
+
+// buildCategoryBudgetStatuses はカテゴリ別の支出サマリーから予算消化状況を組み立てます。
+func buildCategoryBudgetStatuses(summaries []repositories.CategoryExpensesSummary) []CategoryBudgetStatus {
+	statuses := make([]CategoryBudgetStatus, 0, len(summaries))
+	for _, cs := range summaries {
+		status := CategoryBudgetStatus{
+			CategoryID:        int(cs.CategoryID),
+			CategoryName:      cs.CategoryName,
+			Limit:             cs.MonthlyLimit,
+			ConfirmedExpenses: cs.ConfirmedExpenses,
+			PlannedExpenses:   cs.PlannedExpenses,
+		}
+		if cs.MonthlyLimit != nil {
+			spent := cs.ConfirmedExpenses + cs.PlannedExpenses
+			remaining := *cs.MonthlyLimit - spent
+			status.Remaining = &remaining
+			status.Level = budgetLevel(*cs.MonthlyLimit, spent)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// budgetLevel は予算額と支出額（確定 + 予定）から信号色を判定します。
+func budgetLevel(limit, spent int64) BudgetLevel {
+	switch {
+	case spent > limit:
+		return BudgetLevelRed
+	case spent*100 >= limit*BudgetWarningPercent:
+		return BudgetLevelYellow
+	default:
+		return BudgetLevelGreen
+	}
+}
+
+// resolveMonth は対象月の月初日を返します。month が空の場合は当月を使用します。
+func (s *dashboardService) resolveMonth(month string) (time.Time, error) {
+	if month == "" {
+		return monthStart(s.now()), nil
+	}
+	return parseMonth(month)
+}
+
+// resolveMonthRange は from から to（YYYY-MM、両端を含む）までの期間を検証し、開始月と終了月の月初日を返します。
+// to が空の場合は now を含む月、from が空の場合は to を含む直近12か月とします。
+func resolveMonthRange(from, to string, now time.Time) (time.Time, time.Time, error) {
+	toMonth := monthStart(now)
+	if to != "" {
+		var err error
+		if toMonth, err = parseMonth(to); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+	fromMonth := toMonth.AddDate(0, -11, 0)
+	if from != "" {
+		var err error
+		if fromMonth, err = parseMonth(from); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+	}
+	if fromMonth.After(toMonth) {
+		return time.Time{}, time.Time{}, &ValidationError{Message: "開始月は終了月以前を指定してください"}
+	}
+	if !fromMonth.AddDate(0, MonthRangeMaxMonths, 0).After(toMonth) {
+		return time.Time{}, time.Time{}, &ValidationError{Message: "期間は120か月以内で指定してください"}
+	}
+	return fromMonth, toMonth, nil
+}
+
+// AppLocation は「今日」「当月」を判定するタイムゾーン（日本時間）です。
+// 変更前の集計が使っていたデータベースの CURRENT_DATE と同じく、月初の 0〜9 時も当月として扱います。
+var AppLocation = time.FixedZone("Asia/Tokyo", 9*60*60)
+
+// calendarDate は t の AppLocation での日付を返します。日付は支出の spent_at と同じく UTC の 00:00 で表します。
+func calendarDate(t time.Time) time.Time {
+	t = t.In(AppLocation)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// monthStart は t を含む月（AppLocation）の月初日を UTC の 00:00 で返します。
+func monthStart(t time.Time) time.Time {
+	d := calendarDate(t)
+	return time.Date(d.Year(), d.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// resolveFixedCostMode は固定費の計上方法を検証します。空の場合は amortize を使用します。
+func resolveFixedCostMode(mode string) (models.FixedCostMode, error) {
+	if mode == "" {
+		return models.FixedCostModeAmortize, nil
+	}
+	if !models.IsValidFixedCostMode(mode) {
+		return "", &ValidationError{Message: "固定費の計上方法は amortize または billing_month を指定してください"}
+	}
+	return models.FixedCostMode(mode), nil
+}
+
+// parseMonth は YYYY-MM 形式の文字列を検証し、その月の月初日（UTC）を返します。
+func parseMonth(month string) (time.Time, error) {
+	t, err := time.Parse("2006-01", month)
+	if err != nil {
+		return time.Time{}, &ValidationError{Message: "対象月は YYYY-MM 形式で指定してください"}
+	}
+	return t, nil
 }
@@ -5,37 +5,101 @@ import (
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"money-buddy-backend/internal/models"
 	"money-buddy-backend/internal/repositories"
 )
 
 // mockDashboardRepo は DashboardRepository のモック実装です
 type mockDashboardRepo struct {
-	getMonthlySummaryFunc         func(ctx context.Context, userID string) (*repositories.MonthlySummary, error)
-	getMonthlyExpensesSummaryFunc func(ctx context.Context, userID string) (*repositories.MonthlyExpensesSummary, error)
+	getMonthlySummaryFunc         func(ctx context.Context, userID string, month time.Time, mode models.FixedCostMode) (*repositories.MonthlySummary, error)
+	getMonthlyExpensesSummaryFunc func(ctx context.Context, userID string, month time.Time) (*repositories.MonthlyExpensesSummary, error)
+	getCategorySummaryFunc        func(ctx context.Context, userID string, month time.Time) ([]repositories.CategoryExpensesSummary, error)
+	listMonthlySummariesFunc      func(ctx context.Context, userID string, from, to time.Time, mode models.FixedCostMode) ([]repositories.MonthlyTrendSummary, error)
+	listCategoryExpensesFunc      func(ctx context.Context, userID string, from, to time.Time) ([]repositories.MonthlyCategoryExpenses, error)
+	getMemberSummaryFunc          func(ctx context.Context, userID string, month time.Time) ([]repositories.MemberExpensesSummary, error)
+	listEstimateAccuracyFunc      func(ctx context.Context, userID string, from, to time.Time) ([]repositories.MonthlyCategoryEstimateAccuracy, error)
+	listTagTotalsFunc             func(ctx context.Context, userID string, from, to time.Time) ([]repositories.TagTotal, error)
+	listTagCategoryExpensesFunc   func(ctx context.Context, userID string, from, to time.Time) ([]repositories.TagCategoryExpenses, error)
 }
 
-func (m *mockDashboardRepo) GetMonthlySummary(ctx context.Context, userID string) (*repositories.MonthlySummary, error) {
+func (m *mockDashboardRepo) GetMonthlySummary(ctx context.Context, userID string, month time.Time, mode models.FixedCostMode) (*repositories.MonthlySummary, error) {
 	if m.getMonthlySummaryFunc != nil {
-		return m.getMonthlySummaryFunc(ctx, userID)
+		return m.getMonthlySummaryFunc(ctx, userID, month, mode)
 	}
 	return nil, errors.New("not implemented")
 }
 
-func (m *mockDashboardRepo) GetMonthlyExpensesSummary(ctx context.Context, userID string) (*repositories.MonthlyExpensesSummary, error) {
+func (m *mockDashboardRepo) GetMonthlyExpensesSummary(ctx context.Context, userID string, month time.Time) (*repositories.MonthlyExpensesSummary, error) {
 	if m.getMonthlyExpensesSummaryFunc != nil {
-		return m.getMonthlyExpensesSummaryFunc(ctx, userID)
+		return m.getMonthlyExpensesSummaryFunc(ctx, userID, month)
 	}
 	return nil, errors.New("not implemented")
 }
 
+// GetMonthlyCategoryExpensesSummary は未設定の場合、カテゴリ別の集計なしとして扱います
+func (m *mockDashboardRepo) GetMonthlyCategoryExpensesSummary(ctx context.Context, userID string, month time.Time) ([]repositories.CategoryExpensesSummary, error) {
+	if m.getCategorySummaryFunc != nil {
+		return m.getCategorySummaryFunc(ctx, userID, month)
+	}
+	return nil, nil
+}
+
+// GetMonthlyMemberExpensesSummary は未設定の場合、メンバー別の集計なしとして扱います
+func (m *mockDashboardRepo) GetMonthlyMemberExpensesSummary(ctx context.Context, userID string, month time.Time) ([]repositories.MemberExpensesSummary, error) {
+	if m.getMemberSummaryFunc != nil {
+		return m.getMemberSummaryFunc(ctx, userID, month)
+	}
+	return nil, nil
+}
+
+func (m *mockDashboardRepo) ListMonthlySummaries(ctx context.Context, userID string, from, to time.Time, mode models.FixedCostMode) ([]repositories.MonthlyTrendSummary, error) {
+	if m.listMonthlySummariesFunc != nil {
+		return m.listMonthlySummariesFunc(ctx, userID, from, to, mode)
+	}
+	return nil, errors.New("not implemented")
+}
+
+// ListMonthlyCategoryExpenses は未設定の場合、カテゴリ別の集計なしとして扱います
+func (m *mockDashboardRepo) ListMonthlyCategoryExpenses(ctx context.Context, userID string, from, to time.Time) ([]repositories.MonthlyCategoryExpenses, error) {
+	if m.listCategoryExpensesFunc != nil {
+		return m.listCategoryExpensesFunc(ctx, userID, from, to)
+	}
+	return nil, nil
+}
+
+// ListMonthlyCategoryEstimateAccuracy は未設定の場合、集計対象の支出なしとして扱います
+func (m *mockDashboardRepo) ListMonthlyCategoryEstimateAccuracy(ctx context.Context, userID string, from, to time.Time) ([]repositories.MonthlyCategoryEstimateAccuracy, error) {
+	if m.listEstimateAccuracyFunc != nil {
+		return m.listEstimateAccuracyFunc(ctx, userID, from, to)
+	}
+	return nil, nil
+}
+
+// ListTagTotals は未設定の場合、タグの付いた支出なしとして扱います
+func (m *mockDashboardRepo) ListTagTotals(ctx context.Context, userID string, from, to time.Time) ([]repositories.TagTotal, error) {
+	if m.listTagTotalsFunc != nil {
+		return m.listTagTotalsFunc(ctx, userID, from, to)
+	}
+	return nil, nil
+}
+
+// ListTagCategoryExpenses は未設定の場合、タグの付いた支出なしとして扱います
+func (m *mockDashboardRepo) ListTagCategoryExpenses(ctx context.Context, userID string, from, to time.Time) ([]repositories.TagCategoryExpenses, error) {
+	if m.listTagCategoryExpensesFunc != nil {
+		return m.listTagCategoryExpensesFunc(ctx, userID, from, to)
+	}
+	return nil, nil
+}
+
 // TestGetDashboard_Success は正常系のテストです
 func TestGetDashboard_Success(t *testing.T) {
 	repo := &mockDashboardRepo{
-		getMonthlySummaryFunc: func(ctx context.Context, userID string) (*repositories.MonthlySummary, error) {
+		getMonthlySummaryFunc: func(ctx context.Context, userID string, month time.Time, mode models.FixedCostMode) (*repositories.MonthlySummary, error) {
 			assert.Equal(t, "test-user", userID)
 			return &repositories.MonthlySummary{
 				Income:     300000,
@@ -43,7 +107,7 @@ func TestGetDashboard_Success(t *testing.T) {
 				FixedCosts: 100000,
 			}, nil
 		},
-		getMonthlyExpensesSummaryFunc: func(ctx context.Context, userID string) (*repositories.MonthlyExpensesSummary, error) {
+		getMonthlyExpensesSummaryFunc: func(ctx context.Context, userID string, month time.Time) (*repositories.MonthlyExpensesSummary, error) {
 			assert.Equal(t, "test-user", userID)
 			return &repositories.MonthlyExpensesSummary{
 				ConfirmedExpenses: 80000,
@@ -53,7 +117,7 @@ func TestGetDashboard_Success(t *testing.T) {
 	}
 
 	service := NewDashboardService(repo)
-	dashboard, err := service.GetDashboard(context.Background(), "test-user")
+	dashboard, err := service.GetDashboard(context.Background(), "test-user", "", "")
 
 	require.NoError(t, err)
 	require.NotNil(t, dashboard)
@@ -75,14 +139,14 @@ func TestGetDashboard_Success(t *testing.T) {
 // TestGetDashboard_ZeroExpenses は支出がゼロの場合のテストです
 func TestGetDashboard_ZeroExpenses(t *testing.T) {
 	repo := &mockDashboardRepo{
-		getMonthlySummaryFunc: func(ctx context.Context, userID string) (*repositories.MonthlySummary, error) {
+		getMonthlySummaryFunc: func(ctx context.Context, userID string, month time.Time, mode models.FixedCostMode) (*repositories.MonthlySummary, error) {
 			return &repositories.MonthlySummary{
 				Income:     300000,
 				SavingGoal: 50000,
 				FixedCosts: 100000,
 			}, nil
 		},
-		getMonthlyExpensesSummaryFunc: func(ctx context.Context, userID string) (*repositories.MonthlyExpensesSummary, error) {
+		getMonthlyExpensesSummaryFunc: func(ctx context.Context, userID string, month time.Time) (*repositories.MonthlyExpensesSummary, error) {
 			return &repositories.MonthlyExpensesSummary{
 				ConfirmedExpenses: 0,
 				PlannedExpenses:   0,
@@ -91,7 +155,7 @@ func TestGetDashboard_ZeroExpenses(t *testing.T) {
 	}
 
 	service := NewDashboardService(repo)
-	dashboard, err := service.GetDashboard(context.Background(), "test-user")
+	dashboard, err := service.GetDashboard(context.Background(), "test-user", "", "")
 
 	require.NoError(t, err)
 	require.NotNil(t, dashboard)
@@ -105,14 +169,14 @@ func TestGetDashboard_ZeroExpenses(t *testing.T) {
 // TestGetDashboard_ZeroFixedCosts は固定費がゼロの場合のテストです
 func TestGetDashboard_ZeroFixedCosts(t *testing.T) {
 	repo := &mockDashboardRepo{
-		getMonthlySummaryFunc: func(ctx context.Context, userID string) (*repositories.MonthlySummary, error) {
+		getMonthlySummaryFunc: func(ctx context.Context, userID string, month time.Time, mode models.FixedCostMode) (*repositories.MonthlySummary, error) {
 			return &repositories.MonthlySummary{
 				Income:     300000,
 				SavingGoal: 50000,
 				FixedCosts: 0,
 			}, nil
 		},
-		getMonthlyExpensesSummaryFunc: func(ctx context.Context, userID string) (*repositories.MonthlyExpensesSummary, error) {
+		getMonthlyExpensesSummaryFunc: func(ctx context.Context, userID string, month time.Time) (*repositories.MonthlyExpensesSummary, error) {
 			return &repositories.MonthlyExpensesSummary{
 				ConfirmedExpenses: 100000,
 				PlannedExpenses:   50000,
@@ -121,7 +185,7 @@ func TestGetDashboard_ZeroFixedCosts(t *testing.T) {
 	}
 
 	service := NewDashboardService(repo)
-	dashboard, err := service.GetDashboard(context.Background(), "test-user")
+	dashboard, err := service.GetDashboard(context.Background(), "test-user", "", "")
 
 	require.NoError(t, err)
 	require.NotNil(t, dashboard)
@@ -135,13 +199,13 @@ func TestGetDashboard_ZeroFixedCosts(t *testing.T) {
 // TestGetDashboard_UserNotFound はユーザーが存在しない場合のテストです
 func TestGetDashboard_UserNotFound(t *testing.T) {
 	repo := &mockDashboardRepo{
-		getMonthlySummaryFunc: func(ctx context.Context, userID string) (*repositories.MonthlySummary, error) {
+		getMonthlySummaryFunc: func(ctx context.Context, userID string, month time.Time, mode models.FixedCostMode) (*repositories.MonthlySummary, error) {
 			return nil, sql.ErrNoRows
 		},
 	}
 
 	service := NewDashboardService(repo)
-	dashboard, err := service.GetDashboard(context.Background(), "non-existent-user")
+	dashboard, err := service.GetDashboard(context.Background(), "non-existent-user", "", "")
 
 	require.Error(t, err)
 	require.Nil(t, dashboard)
@@ -151,20 +215,20 @@ func TestGetDashboard_UserNotFound(t *testing.T) {
 // TestGetDashboard_ExpensesSummaryError は支出サマリー取得時のエラーをテストします
 func TestGetDashboard_ExpensesSummaryError(t *testing.T) {
 	repo := &mockDashboardRepo{
-		getMonthlySummaryFunc: func(ctx context.Context, userID string) (*repositories.MonthlySummary, error) {
+		getMonthlySummaryFunc: func(ctx context.Context, userID string, month time.Time, mode models.FixedCostMode) (*repositories.MonthlySummary, error) {
 			return &repositories.MonthlySummary{
 				Income:     300000,
 				SavingGoal: 50000,
 				FixedCosts: 100000,
 			}, nil
 		},
-		getMonthlyExpensesSummaryFunc: func(ctx context.Context, userID string) (*repositories.MonthlyExpensesSummary, error) {
+		getMonthlyExpensesSummaryFunc: func(ctx context.Context, userID string, month time.Time) (*repositories.MonthlyExpensesSummary, error) {
 			return nil, errors.New("database connection error")
 		},
 	}
 
 	service := NewDashboardService(repo)
-	dashboard, err := service.GetDashboard(context.Background(), "test-user")
+	dashboard, err := service.GetDashboard(context.Background(), "test-user", "", "")
 
 	require.Error(t, err)
 	require.Nil(t, dashboard)
@@ -174,14 +238,14 @@ func TestGetDashboard_ExpensesSummaryError(t *testing.T) {
 // TestGetDashboard_NegativeRemaining は残額がマイナスになる場合のテストです
 func TestGetDashboard_NegativeRemaining(t *testing.T) {
 	repo := &mockDashboardRepo{
-		getMonthlySummaryFunc: func(ctx context.Context, userID string) (*repositories.MonthlySummary, error) {
+		getMonthlySummaryFunc: func(ctx context.Context, userID string, month time.Time, mode models.FixedCostMode) (*repositories.MonthlySummary, error) {
 			return &repositories.MonthlySummary{
 				Income:     300000,
 				SavingGoal: 50000,
 				FixedCosts: 100000,
 			}, nil
 		},
-		getMonthlyExpensesSummaryFunc: func(ctx context.Context, userID string) (*repositories.MonthlyExpensesSummary, error) {
+		getMonthlyExpensesSummaryFunc: func(ctx context.Context, userID string, month time.Time) (*repositories.MonthlyExpensesSummary, error) {
 			return &repositories.MonthlyExpensesSummary{
 				ConfirmedExpenses: 120000,
 				PlannedExpenses:   80000,
@@ -190,7 +254,7 @@ func TestGetDashboard_NegativeRemaining(t *testing.T) {
 	}
 
 	service := NewDashboardService(repo)
-	dashboard, err := service.GetDashboard(context.Background(), "test-user")
+	dashboard, err := service.GetDashboard(context.Background(), "test-user", "", "")
 
 	require.NoError(t, err)
 	require.NotNil(t, dashboard)
@@ -200,3 +264,430 @@ func TestGetDashboard_NegativeRemaining(t *testing.T) {
 	// 残額 = 150000 - (120000 + 80000) = -50000 (マイナス)
 	assert.Equal(t, int64(-50000), dashboard.Remaining)
 }
+
+// TestGetDashboard_SpecifiedMonth は対象月を指定した場合のテストです
+func TestGetDashboard_SpecifiedMonth(t *testing.T) {
+	var gotMonth time.Time
+	repo := &mockDashboardRepo{
+		getMonthlySummaryFunc: func(ctx context.Context, userID string, month time.Time, mode models.FixedCostMode) (*repositories.MonthlySummary, error) {
+			return &repositories.MonthlySummary{Income: 300000, SavingGoal: 50000, FixedCosts: 100000}, nil
+		},
+		getMonthlyExpensesSummaryFunc: func(ctx context.Context, userID string, month time.Time) (*repositories.MonthlyExpensesSummary, error) {
+			gotMonth = month
+			return &repositories.MonthlyExpensesSummary{}, nil
+		},
+	}
+
+	service := NewDashboardService(repo)
+	dashboard, err := service.GetDashboard(context.Background(), "test-user", "2024-02", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), gotMonth)
+	assert.Equal(t, "2024-02", dashboard.Month)
+	assert.Equal(t, "2024-02-01", dashboard.PeriodStart)
+	// うるう年の2月末
+	assert.Equal(t, "2024-02-29", dashboard.PeriodEnd)
+}
+
+// TestGetDashboard_DefaultsToCurrentMonth は対象月未指定の場合に当月を使うことのテストです
+func TestGetDashboard_DefaultsToCurrentMonth(t *testing.T) {
+	var gotMonth time.Time
+	repo := &mockDashboardRepo{
+		getMonthlySummaryFunc: func(ctx context.Context, userID string, month time.Time, mode models.FixedCostMode) (*repositories.MonthlySummary, error) {
+			return &repositories.MonthlySummary{}, nil
+		},
+		getMonthlyExpensesSummaryFunc: func(ctx context.Context, userID string, month time.Time) (*repositories.MonthlyExpensesSummary, error) {
+			gotMonth = month
+			return &repositories.MonthlyExpensesSummary{}, nil
+		},
+	}
+
+	service := &dashboardService{
+		repo: repo,
+		now:  func() time.Time { return time.Date(2025, 11, 18, 9, 30, 0, 0, time.UTC) },
+	}
+	dashboard, err := service.GetDashboard(context.Background(), "test-user", "", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC), gotMonth)
+	assert.Equal(t, "2025-11", dashboard.Month)
+	assert.Equal(t, "2025-11-30", dashboard.PeriodEnd)
+}
+
+// TestGetDashboard_CurrentMonthInJapanTime は月初の 0〜9 時（日本時間）が当月として扱われることのテストです
+func TestGetDashboard_CurrentMonthInJapanTime(t *testing.T) {
+	var gotMonth time.Time
+	repo := &mockDashboardRepo{
+		getMonthlySummaryFunc: func(ctx context.Context, userID string, month time.Time, mode models.FixedCostMode) (*repositories.MonthlySummary, error) {
+			return &repositories.MonthlySummary{}, nil
+		},
+		getMonthlyExpensesSummaryFunc: func(ctx context.Context, userID string, month time.Time) (*repositories.MonthlyExpensesSummary, error) {
+			gotMonth = month
+			return &repositories.MonthlyExpensesSummary{}, nil
+		},
+	}
+
+	// 2026-01-01 01:00（日本時間）
+	service := &dashboardService{
+		repo: repo,
+		now:  func() time.Time { return time.Date(2025, 12, 31, 16, 0, 0, 0, time.UTC) },
+	}
+	dashboard, err := service.GetDashboard(context.Background(), "test-user", "", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), gotMonth)
+	assert.Equal(t, "2026-01", dashboard.Month)
+}
+
+// TestGetDashboard_InvalidMonth は対象月の形式が不正な場合のテストです
+func TestGetDashboard_InvalidMonth(t *testing.T) {
+	for _, month := range []string{"2025-13", "2025/01", "202501", "2025-1-01"} {
+		t.Run(month, func(t *testing.T) {
+			repo := &mockDashboardRepo{}
+			service := NewDashboardService(repo)
+
+			dashboard, err := service.GetDashboard(context.Background(), "test-user", month, "")
+
+			require.Nil(t, dashboard)
+			var ve *ValidationError
+			assert.ErrorAs(t, err, &ve)
+		})
+	}
+}
+
+// TestGetDashboard_FixedCostMode は固定費の計上方法の指定と既定値のテストです
+func TestGetDashboard_FixedCostMode(t *testing.T) {
+	cases := []struct {
+		input string
+		want  models.FixedCostMode
+	}{
+		{input: "", want: models.FixedCostModeAmortize},
+		{input: "amortize", want: models.FixedCostModeAmortize},
+		{input: "billing_month", want: models.FixedCostModeBillingMonth},
+	}
+	for _, tc := range cases {
+		t.Run(tc.input, func(t *testing.T) {
+			var gotMode models.FixedCostMode
+			repo := &mockDashboardRepo{
+				getMonthlySummaryFunc: func(ctx context.Context, userID string, month time.Time, mode models.FixedCostMode) (*repositories.MonthlySummary, error) {
+					gotMode = mode
+					return &repositories.MonthlySummary{}, nil
+				},
+				getMonthlyExpensesSummaryFunc: func(ctx context.Context, userID string, month time.Time) (*repositories.MonthlyExpensesSummary, error) {
+					return &repositories.MonthlyExpensesSummary{}, nil
+				},
+			}
+
+			service := NewDashboardService(repo)
+			dashboard, err := service.GetDashboard(context.Background(), "test-user", "2025-04", tc.input)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, gotMode)
+			assert.Equal(t, tc.want, dashboard.FixedCostMode)
+		})
+	}
+
+	t.Run("不正な計上方法", func(t *testing.T) {
+		service := NewDashboardService(&mockDashboardRepo{})
+
+		dashboard, err := service.GetDashboard(context.Background(), "test-user", "2025-04", "daily")
+
+		require.Nil(t, dashboard)
+		var ve *ValidationError
+		assert.ErrorAs(t, err, &ve)
+	})
+}
+
+// TestGetDashboard_Pace は利用ペースと月末の見込みのテストです
+func TestGetDashboard_Pace(t *testing.T) {
+	// 2025-06 の18日目（30日の月）
+	now := func() time.Time { return time.Date(2025, 6, 18, 9, 0, 0, 0, time.UTC) }
+	repo := &mockDashboardRepo{
+		getMonthlySummaryFunc: func(ctx context.Context, userID string, month time.Time, mode models.FixedCostMode) (*repositories.MonthlySummary, error) {
+			// 変動費 = 300000 - 90000 - 60000 = 150000
+			return &repositories.MonthlySummary{Income: 300000, SavingGoal: 60000, FixedCosts: 90000}, nil
+		},
+		getMonthlyExpensesSummaryFunc: func(ctx context.Context, userID string, month time.Time) (*repositories.MonthlyExpensesSummary, error) {
+			return &repositories.MonthlyExpensesSummary{ConfirmedExpenses: 99000, PlannedExpenses: 13000}, nil
+		},
+	}
+
+	cases := []struct {
+		name  string
+		month string
+		want  DashboardPace
+	}{
+		{
+			name:  "当月",
+			month: "2025-06",
+			want: DashboardPace{
+				DaysInMonth:       30,
+				DaysElapsed:       18,
+				DaysRemaining:     13,
+				IdealSpendToDate:  90000, // 150000 × 18 / 30
+				ActualSpendToDate: 99000,
+				// 残額 = 150000 - (99000 + 13000) = 38000
+				SafeToSpendPerDay: 2923, // 38000 / 13
+				// 99000 × 30 / 18 = 165000
+				ProjectedRemaining: -28000, // 150000 - (165000 + 13000)
+			},
+		},
+		{
+			name:  "過去の月は全日経過",
+			month: "2025-05",
+			want: DashboardPace{
+				DaysInMonth:        31,
+				DaysElapsed:        31,
+				DaysRemaining:      0,
+				IdealSpendToDate:   150000,
+				ActualSpendToDate:  99000,
+				SafeToSpendPerDay:  0,
+				ProjectedRemaining: 38000,
+			},
+		},
+		{
+			name:  "未来の月は全日残り",
+			month: "2025-07",
+			want: DashboardPace{
+				DaysInMonth:        31,
+				DaysElapsed:        0,
+				DaysRemaining:      31,
+				IdealSpendToDate:   0,
+				ActualSpendToDate:  99000,
+				SafeToSpendPerDay:  1225, // 38000 / 31
+				ProjectedRemaining: 38000,
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := &dashboardService{repo: repo, now: now}
+
+			dashboard, err := service.GetDashboard(context.Background(), "test-user", tc.month, "")
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, dashboard.Pace)
+		})
+	}
+}
+
+// TestGetDashboard_PaceOverBudget は残額がマイナスの場合に1日あたり使える額が 0 になることのテストです
+func TestGetDashboard_PaceOverBudget(t *testing.T) {
+	repo := &mockDashboardRepo{
+		getMonthlySummaryFunc: func(ctx context.Context, userID string, month time.Time, mode models.FixedCostMode) (*repositories.MonthlySummary, error) {
+			return &repositories.MonthlySummary{Income: 200000, SavingGoal: 50000, FixedCosts: 100000}, nil
+		},
+		getMonthlyExpensesSummaryFunc: func(ctx context.Context, userID string, month time.Time) (*repositories.MonthlyExpensesSummary, error) {
+			return &repositories.MonthlyExpensesSummary{ConfirmedExpenses: 60000}, nil
+		},
+	}
+	service := &dashboardService{
+		repo: repo,
+		now:  func() time.Time { return time.Date(2024, 2, 10, 0, 0, 0, 0, time.UTC) },
+	}
+
+	dashboard, err := service.GetDashboard(context.Background(), "test-user", "", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(-10000), dashboard.Remaining)
+	assert.Equal(t, 29, dashboard.Pace.DaysInMonth)
+	assert.Equal(t, 20, dashboard.Pace.DaysRemaining)
+	assert.Equal(t, int64(0), dashboard.Pace.SafeToSpendPerDay)
+	// 60000 × 29 / 10 = 174000
+	assert.Equal(t, int64(50000-174000), dashboard.Pace.ProjectedRemaining)
+}
+
+// TestGetDashboard_CategoryBudgets はカテゴリ別予算の消化状況のテストです
+func TestGetDashboard_CategoryBudgets(t *testing.T) {
+	limit := func(v int64) *int64 { return &v }
+	repo := &mockDashboardRepo{
+		getMonthlySummaryFunc: func(ctx context.Context, userID string, month time.Time, mode models.FixedCostMode) (*repositories.MonthlySummary, error) {
+			return &repositories.MonthlySummary{Income: 300000}, nil
+		},
+		getMonthlyExpensesSummaryFunc: func(ctx context.Context, userID string, month time.Time) (*repositories.MonthlyExpensesSummary, error) {
+			return &repositories.MonthlyExpensesSummary{}, nil
+		},
+		getCategorySummaryFunc: func(ctx context.Context, userID string, month time.Time) ([]repositories.CategoryExpensesSummary, error) {
+			assert.Equal(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), month)
+			return []repositories.CategoryExpensesSummary{
+				{CategoryID: 1, CategoryName: "食費", MonthlyLimit: limit(50000), ConfirmedExpenses: 30000, PlannedExpenses: 9999},
+				{CategoryID: 2, CategoryName: "日用品", MonthlyLimit: limit(10000), ConfirmedExpenses: 6000, PlannedExpenses: 2000},
+				{CategoryID: 3, CategoryName: "交際費", MonthlyLimit: limit(20000), ConfirmedExpenses: 20000},
+				{CategoryID: 4, CategoryName: "趣味", MonthlyLimit: limit(5000), ConfirmedExpenses: 4000, PlannedExpenses: 1001},
+				{CategoryID: 5, CategoryName: "交通費", ConfirmedExpenses: 3000},
+			}, nil
+		},
+	}
+
+	service := NewDashboardService(repo)
+	dashboard, err := service.GetDashboard(context.Background(), "test-user", "2025-03", "")
+
+	require.NoError(t, err)
+	require.Len(t, dashboard.Categories, 5)
+
+	// 80%未満は green
+	assert.Equal(t, BudgetLevelGreen, dashboard.Categories[0].Level)
+	assert.Equal(t, int64(10001), *dashboard.Categories[0].Remaining)
+	// 80%ちょうどは yellow
+	assert.Equal(t, BudgetLevelYellow, dashboard.Categories[1].Level)
+	// 100%ちょうどは yellow
+	assert.Equal(t, BudgetLevelYellow, dashboard.Categories[2].Level)
+	assert.Equal(t, int64(0), *dashboard.Categories[2].Remaining)
+	// 予定支出を含めて超過した場合は red
+	assert.Equal(t, BudgetLevelRed, dashboard.Categories[3].Level)
+	assert.Equal(t, int64(-1), *dashboard.Categories[3].Remaining)
+	// 予算未設定のカテゴリは支出のみ
+	assert.Nil(t, dashboard.Categories[4].Limit)
+	assert.Nil(t, dashboard.Categories[4].Remaining)
+	assert.Equal(t, BudgetLevel(""), dashboard.Categories[4].Level)
+	assert.Equal(t, int64(3000), dashboard.Categories[4].ConfirmedExpenses)
+}
+
+// TestGetDashboard_CategorySummaryError はカテゴリ別集計でエラーが発生した場合のテストです
+func TestGetDashboard_CategorySummaryError(t *testing.T) {
+	repo := &mockDashboardRepo{
+		getMonthlySummaryFunc: func(ctx context.Context, userID string, month time.Time, mode models.FixedCostMode) (*repositories.MonthlySummary, error) {
+			return &repositories.MonthlySummary{}, nil
+		},
+		getMonthlyExpensesSummaryFunc: func(ctx context.Context, userID string, month time.Time) (*repositories.MonthlyExpensesSummary, error) {
+			return &repositories.MonthlyExpensesSummary{}, nil
+		},
+		getCategorySummaryFunc: func(ctx context.Context, userID string, month time.Time) ([]repositories.CategoryExpensesSummary, error) {
+			return nil, errors.New("db error")
+		},
+	}
+
+	service := NewDashboardService(repo)
+	dashboard, err := service.GetDashboard(context.Background(), "test-user", "", "")
+
+	require.Error(t, err)
+	assert.Nil(t, dashboard)
+}
+
+// TestGetDashboard_Members は世帯のメンバー別の支出のテストです
+func TestGetDashboard_Members(t *testing.T) {
+	repo := &mockDashboardRepo{
+		getMonthlySummaryFunc: func(ctx context.Context, userID string, month time.Time, mode models.FixedCostMode) (*repositories.MonthlySummary, error) {
+			return &repositories.MonthlySummary{Income: 300000}, nil
+		},
+		getMonthlyExpensesSummaryFunc: func(ctx context.Context, userID string, month time.Time) (*repositories.MonthlyExpensesSummary, error) {
+			return &repositories.MonthlyExpensesSummary{ConfirmedExpenses: 50000, PlannedExpenses: 10000}, nil
+		},
+		getMemberSummaryFunc: func(ctx context.Context, userID string, month time.Time) ([]repositories.MemberExpensesSummary, error) {
+			assert.Equal(t, "owner-user", userID)
+			assert.Equal(t, time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC), month)
+			return []repositories.MemberExpensesSummary{
+				{UserID: "owner-user", ConfirmedExpenses: 30000, PlannedExpenses: 10000},
+				{UserID: "partner-user", ConfirmedExpenses: 20000},
+				{UserID: "viewer-user"},
+			}, nil
+		},
+	}
+
+	service := NewDashboardService(repo)
+	dashboard, err := service.GetDashboard(context.Background(), "owner-user", "2025-03", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, []MemberExpenses{
+		{UserID: "owner-user", ConfirmedExpenses: 30000, PlannedExpenses: 10000},
+		{UserID: "partner-user", ConfirmedExpenses: 20000},
+		{UserID: "viewer-user"},
+	}, dashboard.Members)
+}
+
+// TestGetDashboard_MemberSummaryError はメンバー別集計でエラーが発生した場合のテストです
+func TestGetDashboard_MemberSummaryError(t *testing.T) {
+	repo := &mockDashboardRepo{
+		getMonthlySummaryFunc: func(ctx context.Context, userID string, month time.Time, mode models.FixedCostMode) (*repositories.MonthlySummary, error) {
+			return &repositories.MonthlySummary{}, nil
+		},
+		getMonthlyExpensesSummaryFunc: func(ctx context.Context, userID string, month time.Time) (*repositories.MonthlyExpensesSummary, error) {
+			return &repositories.MonthlyExpensesSummary{}, nil
+		},
+		getMemberSummaryFunc: func(ctx context.Context, userID string, month time.Time) ([]repositories.MemberExpensesSummary, error) {
+			return nil, errors.New("db error")
+		},
+	}
+
+	service := NewDashboardService(repo)
+	dashboard, err := service.GetDashboard(context.Background(), "test-user", "", "")
+
+	require.Error(t, err)
+	assert.Nil(t, dashboard)
+}
+
+// TestExportMonthlySummaries は月次集計の書き出しのテストです
+func TestExportMonthlySummaries(t *testing.T) {
+	summaryCalls := 0
+	repo := &mockDashboardRepo{
+		getMonthlySummaryFunc: func(ctx context.Context, userID string, month time.Time, mode models.FixedCostMode) (*repositories.MonthlySummary, error) {
+			summaryCalls++
+			return &repositories.MonthlySummary{Income: 300000, SavingGoal: 50000, FixedCosts: 100000}, nil
+		},
+		getMonthlyExpensesSummaryFunc: func(ctx context.Context, userID string, month time.Time) (*repositories.MonthlyExpensesSummary, error) {
+			return &repositories.MonthlyExpensesSummary{ConfirmedExpenses: int64(month.Month()) * 1000, PlannedExpenses: 500}, nil
+		},
+	}
+	service := NewDashboardService(repo)
+
+	var got []*Dashboard
+	err := service.ExportMonthlySummaries(context.Background(), "test-user", "2024-11", "2025-02", "", func(d *Dashboard) error {
+		got = append(got, d)
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, summaryCalls)
+	require.Len(t, got, 4)
+	assert.Equal(t, "2024-11", got[0].Month)
+	assert.Equal(t, "2025-02", got[3].Month)
+	assert.Equal(t, "2025-02-28", got[3].PeriodEnd)
+	assert.Equal(t, int64(2000), got[3].ConfirmedExpenses)
+	// 残額 = 150000 - (2000 + 500)
+	assert.Equal(t, int64(147500), got[3].Remaining)
+}
+
+// TestExportMonthlySummaries_DefaultPeriod は期間未指定時に当月までの12か月を対象とすることのテストです
+func TestExportMonthlySummaries_DefaultPeriod(t *testing.T) {
+	var months []string
+	repo := &mockDashboardRepo{
+		getMonthlySummaryFunc: func(ctx context.Context, userID string, month time.Time, mode models.FixedCostMode) (*repositories.MonthlySummary, error) {
+			return &repositories.MonthlySummary{}, nil
+		},
+		getMonthlyExpensesSummaryFunc: func(ctx context.Context, userID string, month time.Time) (*repositories.MonthlyExpensesSummary, error) {
+			months = append(months, month.Format("2006-01"))
+			return &repositories.MonthlyExpensesSummary{}, nil
+		},
+	}
+	service := &dashboardService{
+		repo: repo,
+		now:  func() time.Time { return time.Date(2025, 11, 18, 9, 30, 0, 0, time.UTC) },
+	}
+
+	err := service.ExportMonthlySummaries(context.Background(), "test-user", "", "", "", func(*Dashboard) error { return nil })
+
+	require.NoError(t, err)
+	require.Len(t, months, 12)
+	assert.Equal(t, "2024-12", months[0])
+	assert.Equal(t, "2025-11", months[11])
+}
+
+// TestExportMonthlySummaries_InvalidPeriod は期間が不正な場合のテストです
+func TestExportMonthlySummaries_InvalidPeriod(t *testing.T) {
+	cases := []struct{ name, from, to string }{
+		{name: "開始月の形式が不正", from: "2025/01", to: "2025-02"},
+		{name: "終了月の形式が不正", from: "2025-01", to: "2025-13"},
+		{name: "開始月が終了月より後", from: "2025-03", to: "2025-02"},
+		{name: "期間が上限を超える", from: "2015-01", to: "2025-01"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := NewDashboardService(&mockDashboardRepo{})
+
+			err := service.ExportMonthlySummaries(context.Background(), "test-user", tc.from, tc.to, "", func(*Dashboard) error { return nil })
+
+			var ve *ValidationError
+			assert.ErrorAs(t, err, &ve)
+		})
+	}
+}
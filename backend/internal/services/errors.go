@@ -41,3 +41,10 @@ func (e *InternalError) Error() string {
 
 // ErrInvalidStatusTransition は不正なステータス遷移を表すエラーです。
 var ErrInvalidStatusTransition = errors.New("invalid status transition")
+
+// ErrVersionConflict は If-Match で指定したバージョンが現在のバージョンと一致しないことを表すエラーです。
+// 更新系のメソッドは現在の状態とともにこのエラーを返します。
+var ErrVersionConflict = errors.New("version conflict")
+
+// ErrCategoryInUse は支出・繰り返しルールで使用中のカテゴリを移動先なしで削除しようとしたことを表すエラーです。
+var ErrCategoryInUse = errors.New("category in use")
@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"money-buddy-backend/internal/models"
+)
+
+const (
+	// MaxExpenseBatchOperations は1回の一括操作で指定できる操作の上限
+	MaxExpenseBatchOperations = 100
+)
+
+// ErrBatchHasFailedOperations は atomic の一括操作で失敗した操作があり、すべてを取り消したことを表すエラーです。
+var ErrBatchHasFailedOperations = errors.New("batch has failed operations")
+
+func (s *expenseService) BatchExpenses(ctx context.Context, userID string, createdBy string, input models.ExpenseBatchInput) (models.ExpenseBatchResult, error) {
+	mode := input.Mode
+	if mode == "" {
+		mode = models.ExpenseBatchAtomic
+	}
+	if mode != models.ExpenseBatchAtomic && mode != models.ExpenseBatchBestEffort {
+		return models.ExpenseBatchResult{}, &ValidationError{Message: "mode は atomic または best_effort を指定してください"}
+	}
+	if len(input.Operations) == 0 {
+		return models.ExpenseBatchResult{}, &ValidationError{Message: "操作を1件以上指定してください"}
+	}
+	if len(input.Operations) > MaxExpenseBatchOperations {
+		return models.ExpenseBatchResult{}, &ValidationError{Message: "操作は100件以内で指定してください"}
+	}
+
+	result := models.ExpenseBatchResult{
+		Mode:    mode,
+		Total:   len(input.Operations),
+		Results: make([]models.ExpenseBatchItemResult, 0, len(input.Operations)),
+	}
+	touched := make(map[int]bool, len(input.Operations))
+
+	// 各操作は単一のトランザクション内のセーブポイントで実行し、失敗した操作の変更のみを取り消す。
+	// 各サービスメソッドの withTx はこのトランザクションに参加する。
+	err := withTxScope(ctx, s.txManager, func(txCtx context.Context, tx Tx) error {
+		for i, op := range input.Operations {
+			savepoint := fmt.Sprintf("expense_batch_%d", i)
+			if err := tx.Savepoint(txCtx, savepoint); err != nil {
+				return err
+			}
+
+			item := models.ExpenseBatchItemResult{Index: i, Op: op.Op}
+			exp, opErr := s.applyBatchOperation(txCtx, userID, createdBy, op, touched)
+			if opErr != nil {
+				if err := tx.RollbackToSavepoint(txCtx, savepoint); err != nil {
+					return err
+				}
+				item.Status = models.ExpenseBatchFailed
+				item.Code, item.Error = batchErrorDetail(opErr)
+				if errors.Is(opErr, ErrVersionConflict) {
+					item.Expense = &exp
+				}
+				result.Failed++
+			} else {
+				item.Status = models.ExpenseBatchSucceeded
+				if op.Op != models.ExpenseBatchDelete {
+					item.Expense = &exp
+				}
+				result.Succeeded++
+			}
+			result.Results = append(result.Results, item)
+		}
+
+		if mode == models.ExpenseBatchAtomic && result.Failed > 0 {
+			return ErrBatchHasFailedOperations
+		}
+		return nil
+	})
+	if errors.Is(err, ErrBatchHasFailedOperations) {
+		// 成功した操作もロールバックされたため、取り消されたことを返す
+		for i := range result.Results {
+			if result.Results[i].Status == models.ExpenseBatchSucceeded {
+				result.Results[i].Status = models.ExpenseBatchRolledBack
+				result.Results[i].Expense = nil
+			}
+		}
+		result.Succeeded = 0
+		return result, err
+	}
+	if err != nil {
+		return models.ExpenseBatchResult{}, &InternalError{Message: "internal error"}
+	}
+
+	result.Committed = true
+	return result, nil
+}
+
+// applyBatchOperation は一括操作の1件を、個別の API と同じ検証で実行します。
+// touched は変更済みの支出IDで、同じ支出を1回の一括操作で複数回変更することはできません。
+func (s *expenseService) applyBatchOperation(ctx context.Context, userID string, createdBy string, op models.ExpenseBatchOperation, touched map[int]bool) (models.Expense, error) {
+	if op.Op == models.ExpenseBatchCreate {
+		if op.Create == nil {
+			return models.Expense{}, &ValidationError{Message: "登録する支出を expense に指定してください"}
+		}
+		input := *op.Create
+		input.CreatedBy = createdBy
+		return s.CreateExpense(ctx, userID, input)
+	}
+
+	switch op.Op {
+	case models.ExpenseBatchUpdate, models.ExpenseBatchDelete, models.ExpenseBatchConfirm:
+	default:
+		return models.Expense{}, &ValidationError{Message: "op は create・update・delete・confirm から指定してください"}
+	}
+	if op.ID <= 0 {
+		return models.Expense{}, &ValidationError{Message: "支出IDを指定してください"}
+	}
+	if op.Op != models.ExpenseBatchDelete && op.Version <= 0 {
+		return models.Expense{}, &ValidationError{Message: "version を指定してください"}
+	}
+	if touched[op.ID] {
+		return models.Expense{}, &ValidationError{Message: "同じ支出を1回の一括操作で複数回変更することはできません"}
+	}
+
+	var exp models.Expense
+	var err error
+	switch op.Op {
+	case models.ExpenseBatchUpdate:
+		if op.Patch == nil {
+			return models.Expense{}, &ValidationError{Message: "変更内容を expense に指定してください"}
+		}
+		patch := *op.Patch
+		patch.ID = op.ID
+		patch.Version = op.Version
+		exp, err = s.PatchExpense(ctx, userID, patch)
+	case models.ExpenseBatchDelete:
+		err = s.DeleteExpense(ctx, userID, op.ID)
+	case models.ExpenseBatchConfirm:
+		confirmed := string(models.StatusConfirmed)
+		exp, err = s.PatchExpense(ctx, userID, models.PatchExpenseInput{ID: op.ID, Version: op.Version, Amount: op.Amount, Status: &confirmed})
+	}
+	if err != nil {
+		return exp, err
+	}
+	touched[op.ID] = true
+	return exp, nil
+}
+
+// batchErrorDetail は一括操作の1件のエラーを、結果に含める種類とメッセージに変換します。
+// メッセージは個別の API のエラーレスポンスと同じです。
+func batchErrorDetail(err error) (string, string) {
+	var ve *ValidationError
+	if errors.As(err, &ve) {
+		return "validation", ve.Message
+	}
+	var ne *NotFoundError
+	if errors.As(err, &ne) {
+		return "not_found", ne.Message
+	}
+	if errors.Is(err, ErrInvalidStatusTransition) {
+		return "invalid_transition", "このステータスには変更できません"
+	}
+	if errors.Is(err, ErrVersionConflict) {
+		return "version_conflict", "他の端末で更新されています。最新の内容を確認してください"
+	}
+	return "internal", "サーバーエラーが発生しました"
+}
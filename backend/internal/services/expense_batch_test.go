@@ -0,0 +1,241 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/repositories"
+)
+
+// mockBatchRepo は支出を ID ごとに保持する ExpenseRepository のモック実装です
+type mockBatchRepo struct {
+	mockRepo
+	expenses  map[int]models.Expense
+	nextID    int
+	createdBy string
+}
+
+func newMockBatchRepo(expenses ...models.Expense) *mockBatchRepo {
+	m := &mockBatchRepo{expenses: map[int]models.Expense{}, nextID: 100}
+	for _, e := range expenses {
+		m.expenses[e.ID] = e
+	}
+	return m
+}
+
+func (m *mockBatchRepo) CreateExpense(ctx context.Context, userID string, input models.CreateExpenseInput) (models.Expense, error) {
+	m.nextID++
+	m.createdBy = input.CreatedBy
+	e := models.Expense{ID: m.nextID, Amount: *input.Amount, SpentAt: input.SpentAt, Status: input.Status, Category: models.Category{ID: *input.CategoryID}, Version: 1}
+	m.expenses[e.ID] = e
+	return e, nil
+}
+
+func (m *mockBatchRepo) GetExpenseByID(ctx context.Context, userID string, id int32) (models.Expense, error) {
+	e, ok := m.expenses[int(id)]
+	if !ok {
+		return models.Expense{}, sql.ErrNoRows
+	}
+	return e, nil
+}
+
+func (m *mockBatchRepo) DeleteExpense(ctx context.Context, userID string, id int32) error {
+	delete(m.expenses, int(id))
+	return nil
+}
+
+func (m *mockBatchRepo) UpdateExpense(ctx context.Context, userID string, input models.UpdateExpenseInput) (models.Expense, error) {
+	e := m.expenses[input.ID]
+	e.Amount = *input.Amount
+	e.Memo = input.Memo
+	e.Status = input.Status
+	e.PlannedAmount = input.PlannedAmount
+	e.Version++
+	m.expenses[e.ID] = e
+	return e, nil
+}
+
+func (m *mockBatchRepo) UpdateExpenseStatus(ctx context.Context, userID string, id int32, status string, version int, plannedAmount *int) (models.Expense, error) {
+	e := m.expenses[int(id)]
+	e.Status = status
+	e.PlannedAmount = plannedAmount
+	e.Version++
+	m.expenses[e.ID] = e
+	return e, nil
+}
+
+func (m *mockBatchRepo) FindAll(ctx context.Context, userID string, query repositories.ExpenseListQuery) ([]models.Expense, error) {
+	return nil, nil
+}
+
+func (m *mockBatchRepo) RestoreExpense(ctx context.Context, userID string, id int32, deletedAfter time.Time) (models.Expense, error) {
+	return models.Expense{}, sql.ErrNoRows
+}
+
+func batchExpense(id int, status string, version int) models.Expense {
+	return models.Expense{ID: id, Amount: 5000, SpentAt: "2025-01-03T00:00:00Z", Status: status, Category: models.Category{ID: 1}, Version: version}
+}
+
+func batchCreate(amount int) models.ExpenseBatchOperation {
+	categoryID := 1
+	return models.ExpenseBatchOperation{Op: models.ExpenseBatchCreate, Create: &models.CreateExpenseInput{Amount: &amount, CategoryID: &categoryID, SpentAt: "2025-01-03"}}
+}
+
+func TestBatchExpenses_BestEffort(t *testing.T) {
+	repo := newMockBatchRepo(batchExpense(1, "planned", 1), batchExpense(2, "confirmed", 4))
+	audits := &mockAuditRepo{}
+	txm := &fakeTxManager{}
+	s := NewExpenseService(repo, &mockCategoryRepo{exists: map[int32]bool{1: true}}, audits, txm)
+
+	actual := 4800
+	res, err := s.BatchExpenses(context.Background(), "user1", "member1", models.ExpenseBatchInput{
+		Mode: models.ExpenseBatchBestEffort,
+		Operations: []models.ExpenseBatchOperation{
+			batchCreate(1200),
+			{Op: models.ExpenseBatchConfirm, ID: 1, Version: 1, Amount: &actual},
+			{Op: models.ExpenseBatchDelete, ID: 9},
+			{Op: models.ExpenseBatchUpdate, ID: 2, Version: 3, Patch: &models.PatchExpenseInput{Amount: &actual}},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.True(t, res.Committed)
+	assert.Equal(t, 4, res.Total)
+	assert.Equal(t, 2, res.Succeeded)
+	assert.Equal(t, 2, res.Failed)
+	require.Len(t, res.Results, 4)
+
+	assert.Equal(t, models.ExpenseBatchSucceeded, res.Results[0].Status)
+	assert.Equal(t, 1200, res.Results[0].Expense.Amount)
+
+	// 予定の金額を記録して確定する
+	assert.Equal(t, models.ExpenseBatchSucceeded, res.Results[1].Status)
+	assert.Equal(t, "confirmed", res.Results[1].Expense.Status)
+	assert.Equal(t, 4800, res.Results[1].Expense.Amount)
+	require.NotNil(t, res.Results[1].Expense.PlannedAmount)
+	assert.Equal(t, 5000, *res.Results[1].Expense.PlannedAmount)
+
+	assert.Equal(t, models.ExpenseBatchFailed, res.Results[2].Status)
+	assert.Equal(t, "not_found", res.Results[2].Code)
+	assert.Nil(t, res.Results[2].Expense)
+
+	// バージョンの不一致では現在の支出を返す
+	assert.Equal(t, models.ExpenseBatchFailed, res.Results[3].Status)
+	assert.Equal(t, "version_conflict", res.Results[3].Code)
+	require.NotNil(t, res.Results[3].Expense)
+	assert.Equal(t, 4, res.Results[3].Expense.Version)
+
+	// 1つのトランザクションで実行し、失敗した操作のみセーブポイントまで戻す
+	assert.Equal(t, 1, txm.commits)
+	assert.Equal(t, 0, txm.rollbacks)
+	assert.Equal(t, 4, txm.savepoints)
+	assert.Equal(t, 2, txm.rollbacksToSavepoint)
+	require.Len(t, audits.created, 2)
+	assert.Equal(t, "member1", repo.createdBy)
+}
+
+func TestBatchExpenses_Atomic(t *testing.T) {
+	t.Run("すべて成功した場合はコミットする", func(t *testing.T) {
+		repo := newMockBatchRepo(batchExpense(1, "planned", 1), batchExpense(2, "planned", 1))
+		txm := &fakeTxManager{}
+		s := NewExpenseService(repo, &mockCategoryRepo{exists: map[int32]bool{1: true}}, &mockAuditRepo{}, txm)
+
+		res, err := s.BatchExpenses(context.Background(), "user1", "user1", models.ExpenseBatchInput{
+			Operations: []models.ExpenseBatchOperation{
+				{Op: models.ExpenseBatchConfirm, ID: 1, Version: 1},
+				{Op: models.ExpenseBatchConfirm, ID: 2, Version: 1},
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, models.ExpenseBatchAtomic, res.Mode)
+		assert.True(t, res.Committed)
+		assert.Equal(t, 2, res.Succeeded)
+		assert.Equal(t, 1, txm.commits)
+	})
+
+	t.Run("失敗した操作がある場合はすべて取り消す", func(t *testing.T) {
+		repo := newMockBatchRepo(batchExpense(1, "planned", 1), batchExpense(2, "confirmed", 1))
+		txm := &fakeTxManager{}
+		s := NewExpenseService(repo, &mockCategoryRepo{exists: map[int32]bool{1: true}}, &mockAuditRepo{}, txm)
+
+		res, err := s.BatchExpenses(context.Background(), "user1", "user1", models.ExpenseBatchInput{
+			Mode: models.ExpenseBatchAtomic,
+			Operations: []models.ExpenseBatchOperation{
+				{Op: models.ExpenseBatchConfirm, ID: 1, Version: 1},
+				{Op: models.ExpenseBatchUpdate, ID: 2, Version: 1, Patch: &models.PatchExpenseInput{Status: strPtr("planned")}},
+			},
+		})
+		require.ErrorIs(t, err, ErrBatchHasFailedOperations)
+		assert.False(t, res.Committed)
+		assert.Equal(t, 0, res.Succeeded)
+		assert.Equal(t, 1, res.Failed)
+		assert.Equal(t, models.ExpenseBatchRolledBack, res.Results[0].Status)
+		assert.Nil(t, res.Results[0].Expense)
+		assert.Equal(t, models.ExpenseBatchFailed, res.Results[1].Status)
+		assert.Equal(t, "invalid_transition", res.Results[1].Code)
+		assert.Equal(t, 0, txm.commits)
+		assert.Equal(t, 1, txm.rollbacks)
+	})
+}
+
+func TestBatchExpenses_Validation(t *testing.T) {
+	s := NewExpenseService(newMockBatchRepo(), &mockCategoryRepo{exists: map[int32]bool{1: true}}, &mockAuditRepo{}, &fakeTxManager{})
+
+	t.Run("リクエスト全体の検証", func(t *testing.T) {
+		tooMany := make([]models.ExpenseBatchOperation, MaxExpenseBatchOperations+1)
+		cases := map[string]models.ExpenseBatchInput{
+			"mode が不正":  {Mode: "partial", Operations: []models.ExpenseBatchOperation{batchCreate(100)}},
+			"操作がない":     {},
+			"操作が上限を超える": {Operations: tooMany},
+		}
+		for name, input := range cases {
+			t.Run(name, func(t *testing.T) {
+				_, err := s.BatchExpenses(context.Background(), "user1", "user1", input)
+				var ve *ValidationError
+				assert.ErrorAs(t, err, &ve)
+			})
+		}
+	})
+
+	t.Run("操作ごとの検証", func(t *testing.T) {
+		repo := newMockBatchRepo(batchExpense(1, "planned", 1))
+		s := NewExpenseService(repo, &mockCategoryRepo{exists: map[int32]bool{1: true}}, &mockAuditRepo{}, &fakeTxManager{})
+
+		res, err := s.BatchExpenses(context.Background(), "user1", "user1", models.ExpenseBatchInput{
+			Mode: models.ExpenseBatchBestEffort,
+			Operations: []models.ExpenseBatchOperation{
+				{Op: "archive", ID: 1},
+				{Op: models.ExpenseBatchCreate},
+				{Op: models.ExpenseBatchDelete},
+				{Op: models.ExpenseBatchConfirm, ID: 1},
+				{Op: models.ExpenseBatchUpdate, ID: 1, Version: 1},
+				batchCreate(0),
+				{Op: models.ExpenseBatchConfirm, ID: 1, Version: 1},
+				{Op: models.ExpenseBatchDelete, ID: 1},
+			},
+		})
+		require.NoError(t, err)
+
+		wantErrors := []string{
+			"op は create・update・delete・confirm から指定してください",
+			"登録する支出を expense に指定してください",
+			"支出IDを指定してください",
+			"version を指定してください",
+			"変更内容を expense に指定してください",
+			"金額は1円以上で入力してください",
+			"",
+			"同じ支出を1回の一括操作で複数回変更することはできません",
+		}
+		require.Len(t, res.Results, len(wantErrors))
+		for i, want := range wantErrors {
+			assert.Equal(t, want, res.Results[i].Error, "operations[%d]", i)
+		}
+		assert.Equal(t, 1, res.Succeeded)
+	})
+}
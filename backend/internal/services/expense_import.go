@@ -0,0 +1,309 @@
+package services
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"money-buddy-backend/internal/models"
+)
+
+const (
+	// ExpenseImportMaxRows は CSV 取り込みで扱えるデータ行数の上限
+	ExpenseImportMaxRows = 5000
+)
+
+// ErrImportHasRejectedRows は取り込めない行を含む CSV を登録しようとしたことを表すエラーです。
+var ErrImportHasRejectedRows = errors.New("import has rejected rows")
+
+// importDateLayouts は CSV の日付として受け付ける形式です。
+var importDateLayouts = []string{"2006-01-02", "2006/01/02", "2006/1/2", "2006-1-2"}
+
+func (s *expenseService) ImportExpenses(ctx context.Context, userID string, createdBy string, r io.Reader, mapping models.ExpenseImportMapping, defaultCategoryID *int, commit bool) (models.ExpenseImportResult, error) {
+	rows, err := s.previewImport(ctx, userID, r, mapping, defaultCategoryID)
+	if err != nil {
+		return models.ExpenseImportResult{}, err
+	}
+
+	result := models.ExpenseImportResult{Total: len(rows), Rows: make([]models.ExpenseImportRow, 0, len(rows))}
+	inputs := make([]models.CreateExpenseInput, 0, len(rows))
+	for _, row := range rows {
+		result.Rows = append(result.Rows, row.result)
+		if row.result.Status == models.ImportRowAccepted {
+			result.Accepted++
+			input := row.input
+			input.CreatedBy = createdBy
+			inputs = append(inputs, input)
+		} else {
+			result.Rejected++
+		}
+	}
+
+	if !commit {
+		return result, nil
+	}
+	// 登録は全行が取り込み可能な場合のみ行う（一部だけ登録されることを避ける）
+	if result.Rejected > 0 {
+		return result, ErrImportHasRejectedRows
+	}
+
+	err = withTx(ctx, s.txManager, func(txCtx context.Context) error {
+		created, err := s.repo.BulkCreateExpenses(txCtx, userID, inputs)
+		if err != nil {
+			return err
+		}
+		// 支出の変更履歴から辿れるよう、取り込んだ支出ごとに登録後の支出を変更履歴として記録する
+		for _, exp := range created {
+			if err := recordAudit(txCtx, s.auditRepo, userID, models.AuditEntityExpense, exp.ID, models.AuditActionImport, nil, exp); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return models.ExpenseImportResult{}, &InternalError{Message: "internal error"}
+	}
+
+	result.Committed = true
+	return result, nil
+}
+
+// importRow は検証済みの 1 行と、その取り込み結果です。
+type importRow struct {
+	input  models.CreateExpenseInput
+	result models.ExpenseImportRow
+}
+
+// previewImport は CSV を読み込み、各行を CreateExpense と同じ検証にかけた結果を返します。
+// CSV 全体の形式やマッピングに問題がある場合は ValidationError を返します。
+func (s *expenseService) previewImport(ctx context.Context, userID string, r io.Reader, mapping models.ExpenseImportMapping, defaultCategoryID *int) ([]importRow, error) {
+	if mapping.Date == "" || mapping.Amount == "" {
+		return nil, &ValidationError{Message: "日付と金額の列を指定してください"}
+	}
+	if mapping.Category == "" && defaultCategoryID == nil {
+		return nil, &ValidationError{Message: "カテゴリの列または既定のカテゴリを指定してください"}
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, &ValidationError{Message: "CSVが空です"}
+	}
+	if err != nil {
+		return nil, &ValidationError{Message: "CSVの形式が正しくありません"}
+	}
+	if len(header) > 0 {
+		// Excel などが付与する UTF-8 の BOM を除去する
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, h := range header {
+		columns[strings.TrimSpace(h)] = i
+	}
+	column := func(name string) (int, error) {
+		if name == "" {
+			return -1, nil
+		}
+		i, ok := columns[name]
+		if !ok {
+			return 0, &ValidationError{Message: "CSVに列「" + name + "」がありません"}
+		}
+		return i, nil
+	}
+	dateCol, err := column(mapping.Date)
+	if err != nil {
+		return nil, err
+	}
+	amountCol, err := column(mapping.Amount)
+	if err != nil {
+		return nil, err
+	}
+	memoCol, err := column(mapping.Memo)
+	if err != nil {
+		return nil, err
+	}
+	categoryCol, err := column(mapping.Category)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver, err := s.newImportCategoryResolver(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []importRow
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, &ValidationError{Message: "CSVの" + strconv.Itoa(line) + "行目の形式が正しくありません"}
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+		if len(rows) >= ExpenseImportMaxRows {
+			return nil, &ValidationError{Message: "一度に取り込めるのは5000行までです"}
+		}
+
+		input := models.CreateExpenseInput{
+			Memo:   field(record, memoCol),
+			Status: string(models.StatusConfirmed),
+		}
+		row := importRow{result: models.ExpenseImportRow{Line: line, Memo: input.Memo}}
+
+		rowErr := func() error {
+			spentAt, err := parseImportDate(field(record, dateCol))
+			if err != nil {
+				return err
+			}
+			input.SpentAt = spentAt
+
+			amount, err := parseImportAmount(field(record, amountCol))
+			if err != nil {
+				return err
+			}
+			input.Amount = &amount
+
+			categoryID, err := resolver.resolve(ctx, field(record, categoryCol), defaultCategoryID)
+			if err != nil {
+				return err
+			}
+			input.CategoryID = &categoryID
+
+			// 個別登録（CreateExpense）と同じ検証を行う
+			return validateCreateExpenseInput(&input)
+		}()
+
+		row.input = input
+		row.result.Amount = input.Amount
+		row.result.CategoryID = input.CategoryID
+		row.result.SpentAt = input.SpentAt
+		if rowErr != nil {
+			var ve *ValidationError
+			if !errors.As(rowErr, &ve) {
+				return nil, rowErr
+			}
+			row.result.Status = models.ImportRowRejected
+			row.result.Error = ve.Message
+		} else {
+			row.result.Status = models.ImportRowAccepted
+		}
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 {
+		return nil, &ValidationError{Message: "取り込むデータがありません"}
+	}
+	return rows, nil
+}
+
+// importCategoryResolver は CSV のカテゴリ列の値（カテゴリ名または ID）をカテゴリ ID に変換します。
+type importCategoryResolver struct {
+	s      *expenseService
+	userID string
+	byName map[string]int
+	exists map[int]bool
+}
+
+func (s *expenseService) newImportCategoryResolver(ctx context.Context, userID string) (*importCategoryResolver, error) {
+	categories, err := s.categoryRepo.ListCategories(ctx, userID)
+	if err != nil {
+		return nil, &InternalError{Message: "internal error"}
+	}
+	byName := make(map[string]int, len(categories))
+	for _, c := range categories {
+		byName[c.Name] = c.ID
+	}
+	return &importCategoryResolver{s: s, userID: userID, byName: byName, exists: map[int]bool{}}, nil
+}
+
+func (cr *importCategoryResolver) resolve(ctx context.Context, value string, defaultCategoryID *int) (int, error) {
+	var id int
+	switch {
+	case value == "" && defaultCategoryID != nil:
+		id = *defaultCategoryID
+	case value == "":
+		return 0, &ValidationError{Message: "カテゴリを選択してください"}
+	default:
+		if byName, ok := cr.byName[value]; ok {
+			return byName, nil
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return 0, &ValidationError{Message: "カテゴリ「" + value + "」が存在しません"}
+		}
+		id = n
+	}
+	if id <= 0 {
+		return 0, &ValidationError{Message: "有効なカテゴリを選択してください"}
+	}
+
+	// CreateExpense と同じく CategoryExists で存在確認する（同じ ID は一度だけ問い合わせる）
+	exists, ok := cr.exists[id]
+	if !ok {
+		var err error
+		exists, err = cr.s.categoryRepo.CategoryExists(ctx, cr.userID, int32(id))
+		if err != nil {
+			return 0, &InternalError{Message: "internal error"}
+		}
+		cr.exists[id] = exists
+	}
+	if !exists {
+		return 0, &ValidationError{Message: "カテゴリが存在しません"}
+	}
+	return id, nil
+}
+
+// parseImportDate は CSV の日付を YYYY-MM-DD 形式に変換します。
+func parseImportDate(value string) (string, error) {
+	if value == "" {
+		return "", &ValidationError{Message: "日付を入力してください"}
+	}
+	for _, layout := range importDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.Format("2006-01-02"), nil
+		}
+	}
+	return "", &ValidationError{Message: "日付の形式が正しくありません"}
+}
+
+// parseImportAmount は CSV の金額を整数に変換します。桁区切りのカンマや通貨記号は取り除きます。
+func parseImportAmount(value string) (int, error) {
+	cleaned := strings.NewReplacer(",", "", "¥", "", "￥", "", "円", "", " ", "").Replace(value)
+	if cleaned == "" {
+		return 0, &ValidationError{Message: "金額を入力してください"}
+	}
+	amount, err := strconv.Atoi(cleaned)
+	if err != nil {
+		return 0, &ValidationError{Message: "金額の形式が正しくありません"}
+	}
+	return amount, nil
+}
+
+// field は record の i 列目を前後の空白を除いて返します。i が負または範囲外の場合は空文字を返します。
+func field(record []string, i int) string {
+	if i < 0 || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+func isBlankRecord(record []string) bool {
+	for _, v := range record {
+		if strings.TrimSpace(v) != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"money-buddy-backend/internal/models"
+)
+
+// mockImportRepo records BulkCreateExpenses calls for import tests.
+type mockImportRepo struct {
+	mockRepo
+	bulkCalled bool
+	bulkCtx    context.Context
+	bulkInputs []models.CreateExpenseInput
+	bulkErr    error
+}
+
+func (m *mockImportRepo) BulkCreateExpenses(ctx context.Context, userID string, inputs []models.CreateExpenseInput) ([]models.Expense, error) {
+	m.bulkCalled = true
+	m.bulkCtx = ctx
+	m.bulkInputs = inputs
+	if m.bulkErr != nil {
+		return nil, m.bulkErr
+	}
+	created := make([]models.Expense, 0, len(inputs))
+	for i, in := range inputs {
+		created = append(created, models.Expense{
+			ID:        101 + i,
+			Amount:    *in.Amount,
+			Memo:      in.Memo,
+			SpentAt:   in.SpentAt,
+			Status:    in.Status,
+			Category:  models.Category{ID: *in.CategoryID},
+			CreatedBy: in.CreatedBy,
+			Version:   1,
+		})
+	}
+	return created, nil
+}
+
+func newImportCategoryRepo() *mockCategoryRepo {
+	return &mockCategoryRepo{
+		categories: []models.Category{{ID: 1, Name: "食費"}, {ID: 2, Name: "日用品"}},
+		exists:     map[int32]bool{1: true, 2: true},
+	}
+}
+
+var importMapping = models.ExpenseImportMapping{Date: "日付", Amount: "金額", Memo: "内容", Category: "カテゴリ"}
+
+func TestImportExpenses_Preview(t *testing.T) {
+	csvData := "\ufeff日付,金額,内容,カテゴリ\n" +
+		"2024/05/01,\"1,200\",スーパー,食費\n" +
+		"2024-05-02,¥300,洗剤,2\n" +
+		"2024-13-01,100,不正な日付,食費\n" +
+		"2024-05-03,0,金額ゼロ,食費\n" +
+		"2024-05-04,500,不明,交際費\n" +
+		",,,\n" +
+		"2024-05-05,800,既定カテゴリ,\n"
+
+	repo := &mockImportRepo{}
+	s := NewExpenseService(repo, newImportCategoryRepo(), &mockAuditRepo{}, &fakeTxManager{})
+
+	defaultCategoryID := 2
+	res, err := s.ImportExpenses(context.Background(), "user1", "user1", strings.NewReader(csvData), importMapping, &defaultCategoryID, false)
+	require.NoError(t, err)
+
+	assert.False(t, res.Committed)
+	assert.Equal(t, 6, res.Total)
+	assert.Equal(t, 3, res.Accepted)
+	assert.Equal(t, 3, res.Rejected)
+	assert.False(t, repo.bulkCalled)
+
+	require.Len(t, res.Rows, 6)
+	assert.Equal(t, models.ImportRowAccepted, res.Rows[0].Status)
+	assert.Equal(t, 2, res.Rows[0].Line)
+	assert.Equal(t, 1200, *res.Rows[0].Amount)
+	assert.Equal(t, 1, *res.Rows[0].CategoryID)
+	assert.Equal(t, "2024-05-01", res.Rows[0].SpentAt)
+
+	assert.Equal(t, models.ImportRowAccepted, res.Rows[1].Status)
+	assert.Equal(t, 300, *res.Rows[1].Amount)
+	assert.Equal(t, 2, *res.Rows[1].CategoryID)
+
+	assert.Equal(t, models.ImportRowRejected, res.Rows[2].Status)
+	assert.Equal(t, "日付の形式が正しくありません", res.Rows[2].Error)
+	assert.Equal(t, models.ImportRowRejected, res.Rows[3].Status)
+	assert.Equal(t, "金額は1円以上で入力してください", res.Rows[3].Error)
+	assert.Equal(t, models.ImportRowRejected, res.Rows[4].Status)
+	assert.Equal(t, "カテゴリ「交際費」が存在しません", res.Rows[4].Error)
+
+	// 空行は飛ばし、行番号は CSV 上の位置を指す
+	assert.Equal(t, 8, res.Rows[5].Line)
+	assert.Equal(t, models.ImportRowAccepted, res.Rows[5].Status)
+	assert.Equal(t, 2, *res.Rows[5].CategoryID)
+}
+
+func TestImportExpenses_InvalidMapping(t *testing.T) {
+	cases := []struct {
+		name    string
+		csv     string
+		mapping models.ExpenseImportMapping
+	}{
+		{name: "日付の列が未指定", csv: "日付,金額\n2024-05-01,100\n", mapping: models.ExpenseImportMapping{Amount: "金額", Category: "カテゴリ"}},
+		{name: "カテゴリの列も既定のカテゴリもない", csv: "日付,金額\n2024-05-01,100\n", mapping: models.ExpenseImportMapping{Date: "日付", Amount: "金額"}},
+		{name: "ヘッダーにない列を指定", csv: "日付,金額\n2024-05-01,100\n", mapping: importMapping},
+		{name: "空のCSV", csv: "", mapping: importMapping},
+		{name: "データ行がない", csv: "日付,金額,内容,カテゴリ\n", mapping: importMapping},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewExpenseService(&mockImportRepo{}, newImportCategoryRepo(), &mockAuditRepo{}, &fakeTxManager{})
+			_, err := s.ImportExpenses(context.Background(), "user1", "user1", strings.NewReader(tc.csv), tc.mapping, nil, false)
+
+			var ve *ValidationError
+			assert.True(t, errors.As(err, &ve), "expected ValidationError, got %v", err)
+		})
+	}
+}
+
+func TestImportExpenses_CommitInsertsInTransaction(t *testing.T) {
+	ctx := context.Background()
+	csvData := "日付,金額,内容,カテゴリ\n2024-05-01,1200,スーパー,食費\n2024-05-02,300,洗剤,日用品\n"
+
+	tm := new(txManagerMock)
+	tx := new(txMock)
+	tm.On("Begin", ctx).Return(tx, nil)
+	tx.On("Commit").Return(nil)
+
+	repo := &mockImportRepo{}
+	audits := &mockAuditRepo{}
+	s := NewExpenseService(repo, newImportCategoryRepo(), audits, tm)
+
+	res, err := s.ImportExpenses(ctx, "user1", "partner", strings.NewReader(csvData), importMapping, nil, true)
+	require.NoError(t, err)
+
+	assert.True(t, res.Committed)
+	assert.Equal(t, 2, res.Accepted)
+	require.True(t, repo.bulkCalled)
+	require.Len(t, repo.bulkInputs, 2)
+	assert.Equal(t, 1200, *repo.bulkInputs[0].Amount)
+	assert.Equal(t, string(models.StatusConfirmed), repo.bulkInputs[0].Status)
+	assert.Equal(t, 2, *repo.bulkInputs[1].CategoryID)
+	// 登録したユーザーを記録する
+	assert.Equal(t, "partner", repo.bulkInputs[0].CreatedBy)
+	// 取り込んだ支出ごとに、登録された ID で変更履歴を記録する
+	require.Len(t, audits.created, 2)
+	for i, event := range audits.created {
+		require.NotNil(t, event.EntityID)
+		assert.Equal(t, int32(101+i), *event.EntityID)
+		assert.Equal(t, models.AuditEntityExpense, event.EntityType)
+		assert.Equal(t, models.AuditActionImport, event.Action)
+	}
+	// 変更履歴には CreateExpense と同じく登録後の支出を記録する
+	var after models.Expense
+	require.NoError(t, json.Unmarshal([]byte(audits.created[1].After), &after))
+	assert.Equal(t, 102, after.ID)
+	assert.Equal(t, "洗剤", after.Memo)
+	assert.Equal(t, "partner", after.CreatedBy)
+	assert.Equal(t, 1, after.Version)
+	tm.AssertExpectations(t)
+	tx.AssertExpectations(t)
+}
+
+func TestImportExpenses_CommitRejectedRows(t *testing.T) {
+	csvData := "日付,金額,内容,カテゴリ\n2024-05-01,1200,スーパー,食費\n2024-05-02,abc,洗剤,日用品\n"
+
+	tm := new(txManagerMock)
+	repo := &mockImportRepo{}
+	s := NewExpenseService(repo, newImportCategoryRepo(), &mockAuditRepo{}, tm)
+
+	res, err := s.ImportExpenses(context.Background(), "user1", "user1", strings.NewReader(csvData), importMapping, nil, true)
+
+	assert.ErrorIs(t, err, ErrImportHasRejectedRows)
+	assert.False(t, res.Committed)
+	assert.Equal(t, 1, res.Rejected)
+	assert.False(t, repo.bulkCalled)
+	tm.AssertNotCalled(t, "Begin", context.Background())
+}
+
+func TestImportExpenses_CommitRollbackOnInsertError(t *testing.T) {
+	ctx := context.Background()
+	csvData := "日付,金額,内容,カテゴリ\n2024-05-01,1200,スーパー,食費\n"
+
+	tm := new(txManagerMock)
+	tx := new(txMock)
+	tm.On("Begin", ctx).Return(tx, nil)
+	tx.On("Rollback").Return(nil)
+
+	repo := &mockImportRepo{bulkErr: errors.New("db error")}
+	s := NewExpenseService(repo, newImportCategoryRepo(), &mockAuditRepo{}, tm)
+
+	_, err := s.ImportExpenses(ctx, "user1", "user1", strings.NewReader(csvData), importMapping, nil, true)
+
+	var ie *InternalError
+	assert.True(t, errors.As(err, &ie))
+	tx.AssertExpectations(t)
+	tx.AssertNotCalled(t, "Commit")
+}
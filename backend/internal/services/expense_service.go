@@ -3,7 +3,11 @@ package services
 import (
 	"context"
 	"database/sql"
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"io"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,47 +20,135 @@ const (
 	BusinessMaxAmount = 1000000000
 	// MemoMaxLen はメモの最大長
 	MemoMaxLen = 5000
+	// DefaultExpensePageSize は一覧取得で limit 未指定時の件数
+	DefaultExpensePageSize = 50
+	// MaxExpensePageSize は一覧取得で指定できる limit の上限
+	MaxExpensePageSize = 200
+	// ExpenseExportBatchSize は書き出し時に 1 回のクエリで取得する件数
+	ExpenseExportBatchSize = 500
+	// MaxExpenseItems は1件の支出に登録できる明細の上限
+	MaxExpenseItems = 50
+	// MaxExpenseTags は1件の支出に付けられるタグの上限
+	MaxExpenseTags = 20
 )
 
+// ExpenseService は支出を扱います。各メソッドは ctx をリポジトリまで渡すため、
+// リクエストの中断や期限切れでクエリも中断します。
 type ExpenseService interface {
-	CreateExpense(userID string, input models.CreateExpenseInput) (models.Expense, error)
-	ListExpenses(userID string) ([]models.Expense, error)
-	DeleteExpense(userID string, id int) error
-	UpdateExpense(userID string, input models.UpdateExpenseInput) (models.Expense, error)
+	// CreateExpense・DeleteExpense・RestoreExpense・UpdateExpense・PatchExpense は変更と同じトランザクションで変更履歴を記録します。
+	CreateExpense(ctx context.Context, userID string, input models.CreateExpenseInput) (models.Expense, error)
+	ListExpenses(ctx context.Context, userID string, filter models.ExpenseFilter) (models.ExpensePage, error)
+	// ExportExpenses は filter に一致するすべての支出を一覧と同じ順序で fn に渡します。
+	// filter の Cursor・Limit は使用しません。条件が不正な場合は fn を呼ぶ前に ValidationError を返します。
+	ExportExpenses(ctx context.Context, userID string, filter models.ExpenseFilter, fn func(models.Expense) error) error
+	GetExpense(ctx context.Context, userID string, id int) (models.Expense, error)
+	// DeleteExpense は支出をゴミ箱に移します。TrashRetentionDays 日以内であれば RestoreExpense で元に戻せます。
+	DeleteExpense(ctx context.Context, userID string, id int) error
+	// RestoreExpense はゴミ箱の支出を元に戻し、バージョンを更新します。
+	RestoreExpense(ctx context.Context, userID string, id int) (models.Expense, error)
+	// UpdateExpense は input.Version が現在のバージョンと一致する場合のみ更新します。
+	// 一致しない場合は現在の支出とともに ErrVersionConflict を返します。
+	UpdateExpense(ctx context.Context, userID string, input models.UpdateExpenseInput) (models.Expense, error)
+	// PatchExpense は input で指定された項目のみを変更します。検証・ステータスの遷移ルール・バージョンの扱いは UpdateExpense と同じです。
+	PatchExpense(ctx context.Context, userID string, input models.PatchExpenseInput) (models.Expense, error)
+	// ImportExpenses は CSV の各行を CreateExpense と同じ検証にかけ、行ごとの結果を返します。
+	// commit が true の場合は全行を単一のトランザクションで登録します。取り込めない行がある場合は
+	// 何も登録せず、結果とともに ErrImportHasRejectedRows を返します。createdBy は登録したユーザーとして記録します。
+	ImportExpenses(ctx context.Context, userID string, createdBy string, r io.Reader, mapping models.ExpenseImportMapping, defaultCategoryID *int, commit bool) (models.ExpenseImportResult, error)
+	// BatchExpenses は登録・更新・削除・確定の操作を単一のトランザクションで順に実行し、操作ごとの結果を返します。
+	// 各操作は個別の API と同じ検証を行います。mode が atomic（既定）の場合は1件でも失敗すると何も登録せず、
+	// 結果とともに ErrBatchHasFailedOperations を返します。best_effort の場合は成功した操作のみを登録します。
+	BatchExpenses(ctx context.Context, userID string, createdBy string, input models.ExpenseBatchInput) (models.ExpenseBatchResult, error)
 }
 
 type expenseService struct {
 	repo         repositories.ExpenseRepository
 	categoryRepo repositories.CategoryRepository
+	auditRepo    repositories.AuditRepository
+	txManager    TxManager
 }
 
-func NewExpenseService(repo repositories.ExpenseRepository, categoryRepo repositories.CategoryRepository) ExpenseService {
-	return &expenseService{repo: repo, categoryRepo: categoryRepo}
+func NewExpenseService(repo repositories.ExpenseRepository, categoryRepo repositories.CategoryRepository, auditRepo repositories.AuditRepository, txManager TxManager) ExpenseService {
+	return &expenseService{repo: repo, categoryRepo: categoryRepo, auditRepo: auditRepo, txManager: txManager}
 }
 
-func (s *expenseService) CreateExpense(userID string, input models.CreateExpenseInput) (models.Expense, error) {
+func (s *expenseService) CreateExpense(ctx context.Context, userID string, input models.CreateExpenseInput) (models.Expense, error) {
+	if err := validateCreateExpenseInput(&input); err != nil {
+		return models.Expense{}, err
+	}
+
+	// カテゴリ存在チェック（CategoryExists を用いる）
+	exists, err := s.categoryRepo.CategoryExists(ctx, userID, int32(*input.CategoryID))
+	if err != nil {
+		// リポジトリ/DB からのエラーは内部エラーとして扱う
+		return models.Expense{}, &InternalError{Message: "internal error"}
+	}
+	if !exists {
+		return models.Expense{}, &ValidationError{Message: "カテゴリが存在しません"}
+	}
+	if err := s.checkExpenseItemCategories(ctx, userID, input.Items); err != nil {
+		return models.Expense{}, err
+	}
+
+	var exp models.Expense
+	err = withTx(ctx, s.txManager, func(txCtx context.Context) error {
+		var err error
+		exp, err = s.repo.CreateExpense(txCtx, userID, input)
+		if err != nil {
+			return err
+		}
+		return recordAudit(txCtx, s.auditRepo, userID, models.AuditEntityExpense, exp.ID, models.AuditActionCreate, nil, exp)
+	})
+	if err != nil {
+		// sql.ErrNoRows -> NotFoundError
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Expense{}, &NotFoundError{Message: "支出が見つかりません"}
+		}
+
+		if isCategoryForeignKeyError(err) {
+			return models.Expense{}, &ValidationError{Message: "カテゴリが存在しません"}
+		}
+
+		// その他は内部エラーとしてラップして返す
+		return models.Expense{}, &InternalError{Message: "internal error"}
+	}
+
+	return exp, nil
+}
+
+// validateCreateExpenseInput は支出作成の入力チェック（カテゴリの存在確認を除く）を行い、
+// ステータスを正規化します。CSV 取り込みでも同じ検証を行うために切り出しています。
+func validateCreateExpenseInput(input *models.CreateExpenseInput) error {
 	// 金額チェック: 入力が存在するかをまず確認し、その後業務上の制約を確認する
 	if input.Amount == nil {
-		return models.Expense{}, &ValidationError{Message: "金額を入力してください"}
+		return &ValidationError{Message: "金額を入力してください"}
 	}
 	if *input.Amount <= 0 {
-		return models.Expense{}, &ValidationError{Message: "金額は1円以上で入力してください"}
+		return &ValidationError{Message: "金額は1円以上で入力してください"}
 	}
 	if *input.Amount > BusinessMaxAmount {
-		return models.Expense{}, &ValidationError{Message: "金額は10億円以下で入力してください"}
+		return &ValidationError{Message: "金額は10億円以下で入力してください"}
+	}
+
+	// 明細がある場合、支出のカテゴリは先頭の明細のカテゴリとする
+	if len(input.Items) > 0 {
+		if err := validateExpenseItems(*input.Amount, input.Items); err != nil {
+			return err
+		}
+		input.CategoryID = input.Items[0].CategoryID
 	}
 
 	// カテゴリID チェック
 	if input.CategoryID == nil {
-		return models.Expense{}, &ValidationError{Message: "カテゴリを選択してください"}
+		return &ValidationError{Message: "カテゴリを選択してください"}
 	}
 	if *input.CategoryID <= 0 {
-		return models.Expense{}, &ValidationError{Message: "有効なカテゴリを選択してください"}
+		return &ValidationError{Message: "有効なカテゴリを選択してください"}
 	}
 
 	// SpentAt の非空チェック
 	if input.SpentAt == "" {
-		return models.Expense{}, &ValidationError{Message: "日付を入力してください"}
+		return &ValidationError{Message: "日付を入力してください"}
 	}
 
 	// 日付フォーマットの検証（RFC3339 をまず試し、失敗したら日付のみフォーマットを試す）
@@ -66,18 +158,27 @@ func (s *expenseService) CreateExpense(userID string, input models.CreateExpense
 	if err != nil {
 		spentAt, err = time.Parse("2006-01-02", input.SpentAt)
 		if err != nil {
-			return models.Expense{}, &ValidationError{Message: "日付の形式が正しくありません"}
+			return &ValidationError{Message: "日付の形式が正しくありません"}
 		}
 		// 日付のみの場合は UTC の 00:00 として扱う
 		spentAt = time.Date(spentAt.Year(), spentAt.Month(), spentAt.Day(), 0, 0, 0, 0, time.UTC)
 	}
 	if spentAt.IsZero() {
-		return models.Expense{}, &ValidationError{Message: "有効な日付を入力してください"}
+		return &ValidationError{Message: "有効な日付を入力してください"}
 	}
 
 	// Memo 長チェック
 	if len(input.Memo) > MemoMaxLen {
-		return models.Expense{}, &ValidationError{Message: "メモは5000文字以内で入力してください"}
+		return &ValidationError{Message: "メモは5000文字以内で入力してください"}
+	}
+
+	// タグ名の正規化（前後の空白・重複を取り除く）
+	if len(input.Tags) > 0 {
+		tags, err := normalizeTagNames(input.Tags)
+		if err != nil {
+			return err
+		}
+		input.Tags = tags
 	}
 
 	// Status の検証（任意入力、指定されている場合のみチェック）
@@ -86,62 +187,318 @@ func (s *expenseService) CreateExpense(userID string, input models.CreateExpense
 			// 正規化: DB は小文字で扱う前提
 			input.Status = normalized
 		} else {
-			return models.Expense{}, &ValidationError{Message: "ステータスは「予定」または「確定」を選択してください"}
+			return &ValidationError{Message: "ステータスは「予定」「確定」「取りやめ」「立替中」「精算済み」から選択してください"}
 		}
 	}
 
-	// カテゴリ存在チェック（CategoryExists を用いる）
-	exists, err := s.categoryRepo.CategoryExists(context.Background(), int32(*input.CategoryID))
+	return nil
+}
+
+// validateExpenseItems は明細の入力チェックを行います。明細の金額の合計は amount と一致する必要があります。
+func validateExpenseItems(amount int, items []models.ExpenseItemInput) error {
+	if len(items) < 2 {
+		return &ValidationError{Message: "明細は2件以上指定してください"}
+	}
+	if len(items) > MaxExpenseItems {
+		return &ValidationError{Message: "明細は50件以内で指定してください"}
+	}
+
+	sum := 0
+	for i, item := range items {
+		prefix := fmt.Sprintf("%d件目の明細: ", i+1)
+		if item.Amount == nil {
+			return &ValidationError{Message: prefix + "金額を入力してください"}
+		}
+		if *item.Amount <= 0 {
+			return &ValidationError{Message: prefix + "金額は1円以上で入力してください"}
+		}
+		if *item.Amount > BusinessMaxAmount {
+			return &ValidationError{Message: prefix + "金額は10億円以下で入力してください"}
+		}
+		if item.CategoryID == nil {
+			return &ValidationError{Message: prefix + "カテゴリを選択してください"}
+		}
+		if *item.CategoryID <= 0 {
+			return &ValidationError{Message: prefix + "有効なカテゴリを選択してください"}
+		}
+		if len(item.Memo) > MemoMaxLen {
+			return &ValidationError{Message: prefix + "メモは5000文字以内で入力してください"}
+		}
+		sum += *item.Amount
+	}
+	if sum != amount {
+		return &ValidationError{Message: fmt.Sprintf("明細の合計（%d円）が金額（%d円）と一致しません", sum, amount)}
+	}
+	return nil
+}
+
+// checkExpenseItemCategories は明細のカテゴリが存在するかを確認します。
+func (s *expenseService) checkExpenseItemCategories(ctx context.Context, userID string, items []models.ExpenseItemInput) error {
+	checked := make(map[int]bool, len(items))
+	for i, item := range items {
+		if checked[*item.CategoryID] {
+			continue
+		}
+		exists, err := s.categoryRepo.CategoryExists(ctx, userID, int32(*item.CategoryID))
+		if err != nil {
+			return &InternalError{Message: "internal error"}
+		}
+		if !exists {
+			return &ValidationError{Message: fmt.Sprintf("%d件目の明細: カテゴリが存在しません", i+1)}
+		}
+		checked[*item.CategoryID] = true
+	}
+	return nil
+}
+
+// expenseItemInputs は登録済みの明細を入力の形に変換します。
+func expenseItemInputs(items []models.ExpenseItem) []models.ExpenseItemInput {
+	inputs := make([]models.ExpenseItemInput, 0, len(items))
+	for _, item := range items {
+		amount, categoryID := item.Amount, item.Category.ID
+		inputs = append(inputs, models.ExpenseItemInput{Amount: &amount, CategoryID: &categoryID, Memo: item.Memo})
+	}
+	return inputs
+}
+
+func (s *expenseService) ListExpenses(ctx context.Context, userID string, filter models.ExpenseFilter) (models.ExpensePage, error) {
+	query, err := buildExpenseListQuery(filter)
 	if err != nil {
-		// リポジトリ/DB からのエラーは内部エラーとして扱う
-		return models.Expense{}, &InternalError{Message: "internal error"}
+		return models.ExpensePage{}, err
 	}
-	if !exists {
-		return models.Expense{}, &ValidationError{Message: "カテゴリが存在しません"}
+	limit := query.Limit
+
+	// 次ページの有無を判定するため 1 件多く取得する
+	query.Limit = limit + 1
+	expenses, err := s.repo.FindAll(ctx, userID, query)
+	if err != nil {
+		return models.ExpensePage{}, &InternalError{Message: "internal error"}
+	}
+
+	page := models.ExpensePage{Expenses: expenses}
+	if len(expenses) > int(limit) {
+		page.Expenses = expenses[:limit]
+		last := page.Expenses[limit-1]
+		spentAt, err := time.Parse(time.RFC3339, last.SpentAt)
+		if err != nil {
+			return models.ExpensePage{}, &InternalError{Message: "internal error"}
+		}
+		cursor := encodeExpenseCursor(spentAt, last.ID)
+		page.NextCursor = &cursor
 	}
 
-	exp, err := s.repo.CreateExpense(userID, input)
+	return page, nil
+}
+
+func (s *expenseService) ExportExpenses(ctx context.Context, userID string, filter models.ExpenseFilter, fn func(models.Expense) error) error {
+	filter.Cursor = ""
+	filter.Limit = 0
+	query, err := buildExpenseListQuery(filter)
 	if err != nil {
-		// sql.ErrNoRows -> NotFoundError
-		if errors.Is(err, sql.ErrNoRows) {
-			return models.Expense{}, &NotFoundError{Message: "支出が見つかりません"}
+		return err
+	}
+
+	// 全件をまとめて読み込まず、カーソルで一定件数ずつ取得して渡す
+	query.Limit = ExpenseExportBatchSize
+	for {
+		expenses, err := s.repo.FindAll(ctx, userID, query)
+		if err != nil {
+			return &InternalError{Message: "internal error"}
+		}
+		for _, e := range expenses {
+			if err := fn(e); err != nil {
+				return err
+			}
+		}
+		if len(expenses) < int(query.Limit) {
+			return nil
 		}
 
-		// 外部キー制約（category_id）の検出。
-		// ドライバ固有の型へアサートするよりも、エラーメッセージに含まれる
-		// 文言を確認して判定する（安全策）。
-		lerr := strings.ToLower(err.Error())
-		if strings.Contains(lerr, "foreign key") && (strings.Contains(lerr, "category") || strings.Contains(lerr, "category_id")) {
-			return models.Expense{}, &ValidationError{Message: "カテゴリが存在しません"}
+		last := expenses[len(expenses)-1]
+		spentAt, err := time.Parse(time.RFC3339, last.SpentAt)
+		if err != nil {
+			return &InternalError{Message: "internal error"}
 		}
+		query.CursorSpentAt = &spentAt
+		query.CursorID = int32(last.ID)
+	}
+}
 
-		// その他は内部エラーとしてラップして返す
-		return models.Expense{}, &InternalError{Message: "internal error"}
+// buildExpenseListQuery は一覧取得の絞り込み条件を検証し、リポジトリ向けのクエリに変換します。
+func buildExpenseListQuery(filter models.ExpenseFilter) (repositories.ExpenseListQuery, error) {
+	var query repositories.ExpenseListQuery
+
+	// 期間
+	if filter.From != "" {
+		from, err := time.Parse("2006-01-02", filter.From)
+		if err != nil {
+			return query, &ValidationError{Message: "開始日の形式が正しくありません"}
+		}
+		query.From = &from
+	}
+	if filter.To != "" {
+		to, err := time.Parse("2006-01-02", filter.To)
+		if err != nil {
+			return query, &ValidationError{Message: "終了日の形式が正しくありません"}
+		}
+		query.To = &to
+	}
+	if query.From != nil && query.To != nil && query.From.After(*query.To) {
+		return query, &ValidationError{Message: "開始日は終了日以前の日付を指定してください"}
 	}
 
-	return exp, nil
+	// カテゴリ
+	for _, id := range filter.CategoryIDs {
+		if id <= 0 {
+			return query, &ValidationError{Message: "有効なカテゴリを選択してください"}
+		}
+		query.CategoryIDs = append(query.CategoryIDs, int32(id))
+	}
+
+	// タグ
+	for _, id := range filter.TagIDs {
+		if id <= 0 {
+			return query, &ValidationError{Message: "有効なタグを選択してください"}
+		}
+		query.TagIDs = append(query.TagIDs, int32(id))
+	}
+
+	// ステータス
+	if filter.Status != "" {
+		normalized, ok := models.NormalizeStatus(filter.Status)
+		if !ok {
+			return query, &ValidationError{Message: "ステータスは「予定」「確定」「取りやめ」「立替中」「精算済み」から選択してください"}
+		}
+		query.Status = normalized
+	}
+
+	// 金額範囲
+	if filter.AmountMin != nil {
+		if *filter.AmountMin < 0 || *filter.AmountMin > BusinessMaxAmount {
+			return query, &ValidationError{Message: "最小金額は0円以上10億円以下で入力してください"}
+		}
+		v := int32(*filter.AmountMin)
+		query.AmountMin = &v
+	}
+	if filter.AmountMax != nil {
+		if *filter.AmountMax < 0 || *filter.AmountMax > BusinessMaxAmount {
+			return query, &ValidationError{Message: "最大金額は0円以上10億円以下で入力してください"}
+		}
+		v := int32(*filter.AmountMax)
+		query.AmountMax = &v
+	}
+	if query.AmountMin != nil && query.AmountMax != nil && *query.AmountMin > *query.AmountMax {
+		return query, &ValidationError{Message: "最小金額は最大金額以下で入力してください"}
+	}
+
+	// メモ
+	if len(filter.Memo) > MemoMaxLen {
+		return query, &ValidationError{Message: "メモは5000文字以内で入力してください"}
+	}
+	query.Memo = filter.Memo
+
+	// カーソル
+	if filter.Cursor != "" {
+		spentAt, id, err := decodeExpenseCursor(filter.Cursor)
+		if err != nil {
+			return query, &ValidationError{Message: "カーソルが正しくありません"}
+		}
+		query.CursorSpentAt = &spentAt
+		query.CursorID = int32(id)
+	}
+
+	// 件数
+	switch {
+	case filter.Limit == 0:
+		query.Limit = DefaultExpensePageSize
+	case filter.Limit < 0 || filter.Limit > MaxExpensePageSize:
+		return query, &ValidationError{Message: "取得件数は1〜200の範囲で指定してください"}
+	default:
+		query.Limit = int32(filter.Limit)
+	}
+
+	return query, nil
+}
+
+// encodeExpenseCursor は (spent_at, id) を不透明なカーソル文字列に変換します。
+func encodeExpenseCursor(spentAt time.Time, id int) string {
+	raw := spentAt.Format("2006-01-02") + ":" + strconv.Itoa(id)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodeExpenseCursor は encodeExpenseCursor で生成したカーソルを (spent_at, id) に戻します。
+func decodeExpenseCursor(cursor string) (time.Time, int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	datePart, idPart, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return time.Time{}, 0, errors.New("invalid cursor")
+	}
+	spentAt, err := time.Parse("2006-01-02", datePart)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	id, err := strconv.Atoi(idPart)
+	if err != nil || id <= 0 {
+		return time.Time{}, 0, errors.New("invalid cursor")
+	}
+	return spentAt, id, nil
 }
 
-func (s *expenseService) ListExpenses(userID string) ([]models.Expense, error) {
-	return s.repo.FindAll(userID)
+func (s *expenseService) GetExpense(ctx context.Context, userID string, id int) (models.Expense, error) {
+	expense, err := s.repo.GetExpenseByID(ctx, userID, int32(id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Expense{}, &NotFoundError{Message: "支出が見つかりません"}
+		}
+		return models.Expense{}, &InternalError{Message: "internal error"}
+	}
+	return expense, nil
 }
 
-func (s *expenseService) DeleteExpense(userID string, id int) error {
-	expense, err := s.repo.GetExpenseByID(userID, int32(id))
+func (s *expenseService) DeleteExpense(ctx context.Context, userID string, id int) error {
+	expense, err := s.repo.GetExpenseByID(ctx, userID, int32(id))
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return &NotFoundError{Message: "支出が見つかりません"}
 		}
 		return &InternalError{Message: "internal error"}
 	}
-	if expense == (models.Expense{}) {
+	if expense.ID == 0 {
 		return &NotFoundError{Message: "支出が見つかりません"}
 	}
 
-	return s.repo.DeleteExpense(userID, int32(id))
+	return withTx(ctx, s.txManager, func(txCtx context.Context) error {
+		if err := s.repo.DeleteExpense(txCtx, userID, int32(id)); err != nil {
+			return err
+		}
+		return recordAudit(txCtx, s.auditRepo, userID, models.AuditEntityExpense, id, models.AuditActionDelete, expense, nil)
+	})
 }
 
-func (s *expenseService) UpdateExpense(userID string, input models.UpdateExpenseInput) (models.Expense, error) {
+func (s *expenseService) RestoreExpense(ctx context.Context, userID string, id int) (models.Expense, error) {
+	var restored models.Expense
+	err := withTx(ctx, s.txManager, func(txCtx context.Context) error {
+		var err error
+		restored, err = s.repo.RestoreExpense(txCtx, userID, int32(id), trashCutoff(time.Now()))
+		if err != nil {
+			return err
+		}
+		return recordAudit(txCtx, s.auditRepo, userID, models.AuditEntityExpense, id, models.AuditActionRestore, nil, restored)
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return models.Expense{}, &NotFoundError{Message: "ゴミ箱に支出が見つかりません"}
+	}
+	if err != nil {
+		return models.Expense{}, &InternalError{Message: "internal error"}
+	}
+
+	return restored, nil
+}
+
+func (s *expenseService) UpdateExpense(ctx context.Context, userID string, input models.UpdateExpenseInput) (models.Expense, error) {
 	// 金額チェック
 	if input.Amount == nil {
 		return models.Expense{}, &ValidationError{Message: "金額を入力してください"}
@@ -153,6 +510,14 @@ func (s *expenseService) UpdateExpense(userID string, input models.UpdateExpense
 		return models.Expense{}, &ValidationError{Message: "金額は10億円以下で入力してください"}
 	}
 
+	// 明細を指定した場合、支出のカテゴリは先頭の明細のカテゴリとする
+	if input.Items != nil && len(*input.Items) > 0 {
+		if err := validateExpenseItems(*input.Amount, *input.Items); err != nil {
+			return models.Expense{}, err
+		}
+		input.CategoryID = (*input.Items)[0].CategoryID
+	}
+
 	// カテゴリID チェック
 	if input.CategoryID == nil {
 		return models.Expense{}, &ValidationError{Message: "カテゴリを選択してください"}
@@ -186,8 +551,17 @@ func (s *expenseService) UpdateExpense(userID string, input models.UpdateExpense
 		return models.Expense{}, &ValidationError{Message: "メモは5000文字以内で入力してください"}
 	}
 
+	// タグ名の正規化（nil の場合は現在のタグのまま）
+	if input.Tags != nil {
+		tags, err := normalizeTagNames(*input.Tags)
+		if err != nil {
+			return models.Expense{}, err
+		}
+		input.Tags = &tags
+	}
+
 	// 現在の状態を取得し、ステータス遷移のバリデーションを行う
-	current, err := s.repo.GetExpenseByID(userID, int32(input.ID))
+	current, err := s.repo.GetExpenseByID(ctx, userID, int32(input.ID))
 	if err != nil {
 		// テスト仕様に合わせ、見つからない場合も遷移エラーとして扱う
 		if errors.Is(err, sql.ErrNoRows) {
@@ -195,15 +569,32 @@ func (s *expenseService) UpdateExpense(userID string, input models.UpdateExpense
 		}
 		return models.Expense{}, &InternalError{Message: "internal error"}
 	}
+	if current.Version != input.Version {
+		return current, ErrVersionConflict
+	}
+
+	// 明細を変更しない場合も、金額は登録済みの明細の合計と一致させる
+	if input.Items == nil && len(current.Items) > 0 {
+		if err := validateExpenseItems(*input.Amount, expenseItemInputs(current.Items)); err != nil {
+			return models.Expense{}, err
+		}
+		categoryID := current.Items[0].Category.ID
+		input.CategoryID = &categoryID
+	}
 
 	// カテゴリ存在チェック（現在のExpense取得後に実施）
-	exists, err := s.categoryRepo.CategoryExists(context.Background(), int32(*input.CategoryID))
+	exists, err := s.categoryRepo.CategoryExists(ctx, userID, int32(*input.CategoryID))
 	if err != nil {
 		return models.Expense{}, &InternalError{Message: "internal error"}
 	}
 	if !exists {
 		return models.Expense{}, &ValidationError{Message: "カテゴリが存在しません"}
 	}
+	if input.Items != nil {
+		if err := s.checkExpenseItemCategories(ctx, userID, *input.Items); err != nil {
+			return models.Expense{}, err
+		}
+	}
 
 	// 変更後ステータスの決定（未指定なら現状維持）
 	desiredStatus := input.Status
@@ -213,16 +604,146 @@ func (s *expenseService) UpdateExpense(userID string, input models.UpdateExpense
 		if normalized, ok := models.NormalizeStatus(desiredStatus); ok {
 			desiredStatus = normalized
 		} else {
-			return models.Expense{}, &ValidationError{Message: "ステータスは「予定」または「確定」を選択してください"}
+			return models.Expense{}, &ValidationError{Message: "ステータスは「予定」「確定」「取りやめ」「立替中」「精算済み」から選択してください"}
 		}
 	}
 
-	// 遷移ルール: confirmed → planned は禁止
-	if strings.ToLower(current.Status) == "confirmed" && desiredStatus == "planned" {
+	// 遷移ルール（models.CanTransitionStatus の遷移表に従う）
+	if !models.CanTransitionStatus(current.Status, desiredStatus) {
 		return models.Expense{}, ErrInvalidStatusTransition
 	}
 
 	// リポジトリに渡す前に正規化済みステータスをセット
 	input.Status = desiredStatus
-	return s.repo.UpdateExpense(userID, input)
+	input.PlannedAmount = plannedAmountOnConfirm(current, desiredStatus)
+	var updated models.Expense
+	err = withTx(ctx, s.txManager, func(txCtx context.Context) error {
+		var err error
+		updated, err = s.repo.UpdateExpense(txCtx, userID, input)
+		if err != nil {
+			return err
+		}
+		return recordAudit(txCtx, s.auditRepo, userID, models.AuditEntityExpense, input.ID, models.AuditActionUpdate, current, updated)
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return s.latestAfterConflict(ctx, userID, input.ID)
+	}
+	if isCategoryForeignKeyError(err) {
+		// 確認の後にカテゴリが削除された場合
+		return models.Expense{}, &ValidationError{Message: "カテゴリが存在しません"}
+	}
+	if err != nil {
+		return models.Expense{}, err
+	}
+	return updated, nil
+}
+
+// isCategoryForeignKeyError は err が支出・明細の category_id の外部キー制約違反かを判定します。
+// ドライバ固有の型へアサートするよりも、エラーメッセージに含まれる
+// 文言を確認して判定する（安全策）。
+func isCategoryForeignKeyError(err error) bool {
+	if err == nil {
+		return false
+	}
+	lerr := strings.ToLower(err.Error())
+	return strings.Contains(lerr, "foreign key") && strings.Contains(lerr, "category")
+}
+
+func (s *expenseService) PatchExpense(ctx context.Context, userID string, input models.PatchExpenseInput) (models.Expense, error) {
+	current, err := s.GetExpense(ctx, userID, input.ID)
+	if err != nil {
+		return models.Expense{}, err
+	}
+	if current.Version != input.Version {
+		return current, ErrVersionConflict
+	}
+	if input.IsEmpty() {
+		return current, nil
+	}
+
+	if input.Status != nil {
+		normalized, ok := models.NormalizeStatus(*input.Status)
+		if !ok {
+			return models.Expense{}, &ValidationError{Message: "ステータスは「予定」「確定」「取りやめ」「立替中」「精算済み」から選択してください"}
+		}
+		input.Status = &normalized
+	}
+
+	// ステータスのみの変更は他の項目を検証し直さずに更新する
+	if input.IsStatusOnly() {
+		if !models.CanTransitionStatus(current.Status, *input.Status) {
+			return models.Expense{}, ErrInvalidStatusTransition
+		}
+		if *input.Status == current.Status {
+			return current, nil
+		}
+		var updated models.Expense
+		err := withTx(ctx, s.txManager, func(txCtx context.Context) error {
+			var err error
+			updated, err = s.repo.UpdateExpenseStatus(txCtx, userID, int32(input.ID), *input.Status, input.Version, plannedAmountOnConfirm(current, *input.Status))
+			if err != nil {
+				return err
+			}
+			return recordAudit(txCtx, s.auditRepo, userID, models.AuditEntityExpense, input.ID, models.AuditActionUpdate, current, updated)
+		})
+		if errors.Is(err, sql.ErrNoRows) {
+			return s.latestAfterConflict(ctx, userID, input.ID)
+		}
+		if err != nil {
+			return models.Expense{}, &InternalError{Message: "internal error"}
+		}
+		return updated, nil
+	}
+
+	// 指定されていない項目は現在の値のまま、全項目の更新と同じ検証を行う
+	merged := models.UpdateExpenseInput{
+		ID:         input.ID,
+		Amount:     &current.Amount,
+		CategoryID: &current.Category.ID,
+		Memo:       current.Memo,
+		SpentAt:    current.SpentAt,
+		Version:    input.Version,
+	}
+	if input.Amount != nil {
+		merged.Amount = input.Amount
+	}
+	if input.CategoryID != nil {
+		merged.CategoryID = input.CategoryID
+	}
+	if input.Memo != nil {
+		merged.Memo = *input.Memo
+	}
+	if input.SpentAt != nil {
+		merged.SpentAt = *input.SpentAt
+	}
+	if input.Status != nil {
+		merged.Status = *input.Status
+	}
+	merged.Items = input.Items
+	merged.Tags = input.Tags
+	return s.UpdateExpense(ctx, userID, merged)
+}
+
+// plannedAmountOnConfirm は予定の支出を確定（または立替中）にする場合に、記録する予定金額（変更前の金額）を返します。
+// それ以外の変更では予定金額を変更しないため nil を返します。
+func plannedAmountOnConfirm(current models.Expense, desiredStatus string) *int {
+	if strings.ToLower(current.Status) != string(models.StatusPlanned) || !models.IsSettledStatus(desiredStatus) {
+		return nil
+	}
+	planned := current.Amount
+	return &planned
+}
+
+// latestAfterConflict は条件付きの更新が0件だった場合に、最新の支出とともに ErrVersionConflict を返します。
+// 取得してから更新するまでの間に他のリクエストで更新・削除された場合に使います。
+func (s *expenseService) latestAfterConflict(ctx context.Context, userID string, id int) (models.Expense, error) {
+	latest, err := s.repo.GetExpenseByID(ctx, userID, int32(id))
+	if err != nil {
+		// テスト仕様に合わせ、見つからない場合も遷移エラーとして扱う
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Expense{}, ErrInvalidStatusTransition
+		}
+		return models.Expense{}, &InternalError{Message: "internal error"}
+	}
+	return latest, ErrVersionConflict
 }
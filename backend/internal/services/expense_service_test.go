@@ -3,13 +3,17 @@ package services
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/repositories"
 )
 
 type mockRepo struct {
@@ -17,37 +21,95 @@ type mockRepo struct {
 	in     models.CreateExpenseInput
 }
 
-func (m *mockRepo) CreateExpense(userID string, input models.CreateExpenseInput) (models.Expense, error) {
+func (m *mockRepo) CreateExpense(ctx context.Context, userID string, input models.CreateExpenseInput) (models.Expense, error) {
 	m.called = true
 	m.in = input
 	return models.Expense{ID: 1, Amount: *input.Amount, Memo: input.Memo, SpentAt: input.SpentAt, Category: models.Category{ID: *input.CategoryID, Name: ""}}, nil
 }
 
-func (m *mockRepo) FindAll(userID string) ([]models.Expense, error) {
+func (m *mockRepo) FindAll(ctx context.Context, userID string, query repositories.ExpenseListQuery) ([]models.Expense, error) {
 	return nil, errors.New("not implemented")
 }
 
-func (m *mockRepo) GetExpenseByID(userID string, id int32) (models.Expense, error) {
+func (m *mockRepo) GetExpenseByID(ctx context.Context, userID string, id int32) (models.Expense, error) {
 	return models.Expense{}, errors.New("not implemented")
 }
 
-func (m *mockRepo) DeleteExpense(userID string, id int32) error { return errors.New("not implemented") }
+func (m *mockRepo) DeleteExpense(ctx context.Context, userID string, id int32) error {
+	return errors.New("not implemented")
+}
+
+func (m *mockRepo) RestoreExpense(ctx context.Context, userID string, id int32, deletedAfter time.Time) (models.Expense, error) {
+	return models.Expense{}, errors.New("not implemented")
+}
+
+func (m *mockRepo) UpdateExpense(ctx context.Context, userID string, input models.UpdateExpenseInput) (models.Expense, error) {
+	return models.Expense{}, errors.New("not implemented")
+}
 
-func (m *mockRepo) UpdateExpense(userID string, input models.UpdateExpenseInput) (models.Expense, error) {
+func (m *mockRepo) UpdateExpenseStatus(ctx context.Context, userID string, id int32, status string, version int, plannedAmount *int) (models.Expense, error) {
 	return models.Expense{}, errors.New("not implemented")
 }
 
+func (m *mockRepo) BulkCreateExpenses(ctx context.Context, userID string, inputs []models.CreateExpenseInput) ([]models.Expense, error) {
+	return nil, errors.New("not implemented")
+}
+
 // mockCategoryRepo satisfies CategoryRepository for testing
 type mockCategoryRepo struct {
-	exists map[int32]bool
-	err    error
+	categories []models.Category
+	exists     map[int32]bool
+	err        error
+}
+
+func (m *mockCategoryRepo) ListCategories(ctx context.Context, userID string) ([]models.Category, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.categories, nil
+}
+
+func (m *mockCategoryRepo) GetCategory(ctx context.Context, userID string, id int32) (models.Category, error) {
+	return models.Category{}, errors.New("not implemented")
+}
+
+func (m *mockCategoryRepo) CreateCategory(ctx context.Context, userID string, name string) (models.Category, error) {
+	return models.Category{}, errors.New("not implemented")
+}
+
+func (m *mockCategoryRepo) UpdateCategory(ctx context.Context, userID string, id int32, name string) error {
+	return errors.New("not implemented")
+}
+
+func (m *mockCategoryRepo) DeleteCategory(ctx context.Context, userID string, id int32) error {
+	return errors.New("not implemented")
+}
+
+func (m *mockCategoryRepo) LockCategory(ctx context.Context, userID string, id int32) error {
+	return errors.New("not implemented")
+}
+
+func (m *mockCategoryRepo) HideCategory(ctx context.Context, userID string, id int32) error {
+	return errors.New("not implemented")
+}
+
+func (m *mockCategoryRepo) UnhideCategory(ctx context.Context, userID string, id int32) error {
+	return errors.New("not implemented")
+}
+
+func (m *mockCategoryRepo) CountExpenses(ctx context.Context, userID string, id int32) (int64, error) {
+	return 0, errors.New("not implemented")
 }
 
-func (m *mockCategoryRepo) ListCategories(ctx context.Context) ([]models.Category, error) {
+func (m *mockCategoryRepo) ListExpenseIDs(ctx context.Context, userID string, id int32) ([]int32, error) {
 	return nil, errors.New("not implemented")
 }
 
-func (m *mockCategoryRepo) CategoryExists(ctx context.Context, id int32) (bool, error) {
+func (m *mockCategoryRepo) MoveExpenses(ctx context.Context, userID string, fromID int32, toID int32) error {
+	return errors.New("not implemented")
+}
+
+func (m *mockCategoryRepo) CategoryExists(ctx context.Context, userID string, id int32) (bool, error) {
 	if m.err != nil {
 		return false, m.err
 	}
@@ -89,9 +151,9 @@ func TestCreateExpenseValidation(t *testing.T) {
 				exists[int32(*tc.input.CategoryID)] = true
 			}
 			cr := &mockCategoryRepo{exists: exists}
-			s := NewExpenseService(m, cr)
+			s := NewExpenseService(m, cr, &mockAuditRepo{}, &fakeTxManager{})
 
-			out, err := s.CreateExpense("test-user", tc.input)
+			out, err := s.CreateExpense(context.Background(), "test-user", tc.input)
 
 			if tc.wantErr {
 				if !assert.Error(t, err, "expected error for case %s", tc.name) {
@@ -135,9 +197,9 @@ func TestCreateExpense_DBErrorMapping(t *testing.T) {
 			t.Parallel()
 			m := &mockRepoErr{returnErr: tc.repoErr}
 			cr := &mockCategoryRepo{exists: map[int32]bool{1: true}}
-			s := NewExpenseService(m, cr)
+			s := NewExpenseService(m, cr, &mockAuditRepo{}, &fakeTxManager{})
 
-			_, err := s.CreateExpense("test-user", validInput)
+			_, err := s.CreateExpense(context.Background(), "test-user", validInput)
 			if !assert.Error(t, err) {
 				return
 			}
@@ -174,26 +236,38 @@ type mockRepoErr struct {
 	returnErr error
 }
 
-func (m *mockRepoErr) CreateExpense(userID string, input models.CreateExpenseInput) (models.Expense, error) {
+func (m *mockRepoErr) CreateExpense(ctx context.Context, userID string, input models.CreateExpenseInput) (models.Expense, error) {
 	return models.Expense{}, m.returnErr
 }
 
-func (m *mockRepoErr) FindAll(userID string) ([]models.Expense, error) {
+func (m *mockRepoErr) FindAll(ctx context.Context, userID string, query repositories.ExpenseListQuery) ([]models.Expense, error) {
 	return nil, errors.New("not implemented")
 }
 
-func (m *mockRepoErr) GetExpenseByID(userID string, id int32) (models.Expense, error) {
+func (m *mockRepoErr) GetExpenseByID(ctx context.Context, userID string, id int32) (models.Expense, error) {
 	return models.Expense{}, errors.New("not implemented")
 }
 
-func (m *mockRepoErr) DeleteExpense(userID string, id int32) error {
+func (m *mockRepoErr) DeleteExpense(ctx context.Context, userID string, id int32) error {
 	return errors.New("not implemented")
 }
 
-func (m *mockRepoErr) UpdateExpense(userID string, input models.UpdateExpenseInput) (models.Expense, error) {
+func (m *mockRepoErr) RestoreExpense(ctx context.Context, userID string, id int32, deletedAfter time.Time) (models.Expense, error) {
+	return models.Expense{}, errors.New("not implemented")
+}
+
+func (m *mockRepoErr) UpdateExpense(ctx context.Context, userID string, input models.UpdateExpenseInput) (models.Expense, error) {
 	return models.Expense{}, errors.New("not implemented")
 }
 
+func (m *mockRepoErr) UpdateExpenseStatus(ctx context.Context, userID string, id int32, status string, version int, plannedAmount *int) (models.Expense, error) {
+	return models.Expense{}, errors.New("not implemented")
+}
+
+func (m *mockRepoErr) BulkCreateExpenses(ctx context.Context, userID string, inputs []models.CreateExpenseInput) ([]models.Expense, error) {
+	return nil, errors.New("not implemented")
+}
+
 // sqlErrNoRows returns sql.ErrNoRows from database/sql
 func sqlErrNoRows() error { return sql.ErrNoRows }
 
@@ -204,9 +278,9 @@ func TestCreateExpense_CategoryExistsError(t *testing.T) {
 
 	m := &mockRepo{}
 	cr := &mockCategoryRepo{err: errors.New("db error")}
-	s := NewExpenseService(m, cr)
+	s := NewExpenseService(m, cr, &mockAuditRepo{}, &fakeTxManager{})
 
-	_, err := s.CreateExpense("test-user", input)
+	_, err := s.CreateExpense(context.Background(), "test-user", input)
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -260,9 +334,9 @@ func TestCreateExpense_StatusValidation(t *testing.T) {
 				exists[int32(*tc.input.CategoryID)] = true
 			}
 			cr := &mockCategoryRepo{exists: exists}
-			s := NewExpenseService(m, cr)
+			s := NewExpenseService(m, cr, &mockAuditRepo{}, &fakeTxManager{})
 
-			_, err := s.CreateExpense("test-user", tc.input)
+			_, err := s.CreateExpense(context.Background(), "test-user", tc.input)
 
 			if tc.wantErr {
 				if !assert.Error(t, err) {
@@ -281,32 +355,109 @@ func TestCreateExpense_StatusValidation(t *testing.T) {
 }
 
 // mockDeleteRepo satisfies repositories.ExpenseRepository and adds DeleteExpense for tests
+func TestCreateExpense_Items(t *testing.T) {
+	t.Parallel()
+
+	item := func(amount, categoryID int, memo string) models.ExpenseItemInput {
+		return models.ExpenseItemInput{Amount: intPtr(amount), CategoryID: intPtr(categoryID), Memo: memo}
+	}
+
+	t.Run("明細の合計が金額と一致すれば登録し、カテゴリは先頭の明細のカテゴリになる", func(t *testing.T) {
+		t.Parallel()
+		m := &mockRepo{}
+		cr := &mockCategoryRepo{exists: map[int32]bool{1: true, 2: true, 3: true}}
+		s := NewExpenseService(m, cr, &mockAuditRepo{}, &fakeTxManager{})
+
+		_, err := s.CreateExpense(context.Background(), "test-user", models.CreateExpenseInput{
+			Amount:  intPtr(3000),
+			SpentAt: "2025-01-10",
+			Items:   []models.ExpenseItemInput{item(2000, 2, "食材"), item(600, 3, ""), item(400, 1, "ビール")},
+		})
+
+		require.NoError(t, err)
+		require.True(t, m.called)
+		assert.Equal(t, 2, *m.in.CategoryID)
+		assert.Len(t, m.in.Items, 3)
+	})
+
+	cases := []struct {
+		name    string
+		items   []models.ExpenseItemInput
+		wantMsg string
+	}{
+		{name: "明細が1件のみ", items: []models.ExpenseItemInput{item(3000, 1, "")}, wantMsg: "明細は2件以上指定してください"},
+		{name: "合計が一致しない", items: []models.ExpenseItemInput{item(2000, 1, ""), item(500, 2, "")}, wantMsg: "明細の合計（2500円）が金額（3000円）と一致しません"},
+		{name: "明細の金額が0以下", items: []models.ExpenseItemInput{item(3000, 1, ""), item(0, 2, "")}, wantMsg: "2件目の明細: 金額は1円以上で入力してください"},
+		{name: "明細のカテゴリが未指定", items: []models.ExpenseItemInput{{Amount: intPtr(1000)}, item(2000, 2, "")}, wantMsg: "1件目の明細: カテゴリを選択してください"},
+		{name: "明細のカテゴリが存在しない", items: []models.ExpenseItemInput{item(1000, 1, ""), item(2000, 9999, "")}, wantMsg: "2件目の明細: カテゴリが存在しません"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			m := &mockRepo{}
+			cr := &mockCategoryRepo{exists: map[int32]bool{1: true, 2: true}}
+			s := NewExpenseService(m, cr, &mockAuditRepo{}, &fakeTxManager{})
+
+			_, err := s.CreateExpense(context.Background(), "test-user", models.CreateExpenseInput{Amount: intPtr(3000), SpentAt: "2025-01-10", Items: tc.items})
+
+			var ve *ValidationError
+			require.ErrorAs(t, err, &ve)
+			assert.Equal(t, tc.wantMsg, ve.Message)
+			assert.False(t, m.called)
+		})
+	}
+}
+
 type mockDeleteRepo struct {
 	called    bool
 	deletedID int32
 	returnErr error
+	// restored は RestoreExpense で元に戻せるかどうかです
+	restored     bool
+	restoredID   int32
+	deletedAfter time.Time
 }
 
-func (m *mockDeleteRepo) CreateExpense(userID string, input models.CreateExpenseInput) (models.Expense, error) {
+func (m *mockDeleteRepo) CreateExpense(ctx context.Context, userID string, input models.CreateExpenseInput) (models.Expense, error) {
 	return models.Expense{}, errors.New("not implemented")
 }
 
-func (m *mockDeleteRepo) FindAll(userID string) ([]models.Expense, error) {
+func (m *mockDeleteRepo) FindAll(ctx context.Context, userID string, query repositories.ExpenseListQuery) ([]models.Expense, error) {
 	return nil, errors.New("not implemented")
 }
 
 // DeleteExpense is the method under test expectation
-func (m *mockDeleteRepo) DeleteExpense(userID string, id int32) error {
+func (m *mockDeleteRepo) DeleteExpense(ctx context.Context, userID string, id int32) error {
 	m.called = true
 	m.deletedID = id
 	return m.returnErr
 }
 
-func (m *mockDeleteRepo) UpdateExpense(userID string, input models.UpdateExpenseInput) (models.Expense, error) {
+func (m *mockDeleteRepo) RestoreExpense(ctx context.Context, userID string, id int32, deletedAfter time.Time) (models.Expense, error) {
+	m.restoredID = id
+	m.deletedAfter = deletedAfter
+	if m.returnErr != nil {
+		return models.Expense{}, m.returnErr
+	}
+	if !m.restored {
+		return models.Expense{}, sqlErrNoRows()
+	}
+	return m.GetExpenseByID(ctx, userID, id)
+}
+
+func (m *mockDeleteRepo) UpdateExpense(ctx context.Context, userID string, input models.UpdateExpenseInput) (models.Expense, error) {
+	return models.Expense{}, errors.New("not implemented")
+}
+
+func (m *mockDeleteRepo) UpdateExpenseStatus(ctx context.Context, userID string, id int32, status string, version int, plannedAmount *int) (models.Expense, error) {
 	return models.Expense{}, errors.New("not implemented")
 }
 
-func (m *mockDeleteRepo) GetExpenseByID(userID string, id int32) (models.Expense, error) {
+func (m *mockDeleteRepo) BulkCreateExpenses(ctx context.Context, userID string, inputs []models.CreateExpenseInput) ([]models.Expense, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockDeleteRepo) GetExpenseByID(ctx context.Context, userID string, id int32) (models.Expense, error) {
 	// simulate existence: 9999 -> not found, others exist
 	if id == 9999 {
 		return models.Expense{}, sqlErrNoRows()
@@ -325,9 +476,9 @@ func TestDeleteExpense_Success(t *testing.T) {
 	// category repo is unused for delete
 	cr := &mockCategoryRepo{}
 	// Construct concrete service to allow calling DeleteExpense (to be implemented)
-	s := &expenseService{repo: repo, categoryRepo: cr}
+	s := &expenseService{repo: repo, categoryRepo: cr, auditRepo: &mockAuditRepo{}, txManager: &fakeTxManager{}}
 
-	err := s.DeleteExpense("test-user", 1)
+	err := s.DeleteExpense(context.Background(), "test-user", 1)
 	assert.NoError(t, err)
 	assert.True(t, repo.called, "repo should be called")
 	assert.Equal(t, int32(1), repo.deletedID)
@@ -338,9 +489,9 @@ func TestDeleteExpense_NotFound(t *testing.T) {
 
 	repo := &mockDeleteRepo{returnErr: sqlErrNoRows()}
 	cr := &mockCategoryRepo{}
-	s := &expenseService{repo: repo, categoryRepo: cr}
+	s := &expenseService{repo: repo, categoryRepo: cr, auditRepo: &mockAuditRepo{}, txManager: &fakeTxManager{}}
 
-	err := s.DeleteExpense("test-user", 9999)
+	err := s.DeleteExpense(context.Background(), "test-user", 9999)
 	var nfe *NotFoundError
 	if !assert.ErrorAs(t, err, &nfe) {
 		return
@@ -364,9 +515,9 @@ func TestDeleteExpense_StatusAgnostic(t *testing.T) {
 
 			repo := &mockDeleteRepo{returnErr: nil}
 			cr := &mockCategoryRepo{}
-			s := &expenseService{repo: repo, categoryRepo: cr}
+			s := &expenseService{repo: repo, categoryRepo: cr, auditRepo: &mockAuditRepo{}, txManager: &fakeTxManager{}}
 
-			err := s.DeleteExpense("test-user", tc.id)
+			err := s.DeleteExpense(context.Background(), "test-user", tc.id)
 			assert.NoError(t, err)
 			assert.True(t, repo.called)
 			assert.Equal(t, int32(tc.id), repo.deletedID)
@@ -374,36 +525,79 @@ func TestDeleteExpense_StatusAgnostic(t *testing.T) {
 	}
 }
 
+func TestRestoreExpense_Success(t *testing.T) {
+	t.Parallel()
+
+	repo := &mockDeleteRepo{restored: true}
+	s := &expenseService{repo: repo, categoryRepo: &mockCategoryRepo{}, auditRepo: &mockAuditRepo{}, txManager: &fakeTxManager{}}
+
+	before := time.Now()
+	expense, err := s.RestoreExpense(context.Background(), "test-user", 11)
+	require.NoError(t, err)
+	assert.Equal(t, 11, expense.ID)
+	assert.Equal(t, int32(11), repo.restoredID)
+	// 削除から30日以内のものだけを復元する
+	assert.WithinDuration(t, before.AddDate(0, 0, -TrashRetentionDays), repo.deletedAfter, time.Minute)
+}
+
+func TestRestoreExpense_NotInTrash(t *testing.T) {
+	t.Parallel()
+
+	repo := &mockDeleteRepo{restored: false}
+	s := &expenseService{repo: repo, categoryRepo: &mockCategoryRepo{}, auditRepo: &mockAuditRepo{}, txManager: &fakeTxManager{}}
+
+	_, err := s.RestoreExpense(context.Background(), "test-user", 11)
+	var nfe *NotFoundError
+	require.ErrorAs(t, err, &nfe)
+	assert.Equal(t, "ゴミ箱に支出が見つかりません", nfe.Message)
+}
+
+func TestRestoreExpense_RepoError(t *testing.T) {
+	t.Parallel()
+
+	repo := &mockDeleteRepo{returnErr: errors.New("db error")}
+	s := &expenseService{repo: repo, categoryRepo: &mockCategoryRepo{}, auditRepo: &mockAuditRepo{}, txManager: &fakeTxManager{}}
+
+	_, err := s.RestoreExpense(context.Background(), "test-user", 11)
+	var ie *InternalError
+	assert.ErrorAs(t, err, &ie)
+}
+
 // mockUpdateRepo satisfies repositories.ExpenseRepository and simulates update behavior
 type mockUpdateRepo struct {
-	current   models.Expense
-	called    bool
-	in        models.UpdateExpenseInput
-	returnErr error
-	getErr    error
+	current      models.Expense
+	called       bool
+	statusCalled bool
+	in           models.UpdateExpenseInput
+	returnErr    error
+	getErr       error
 }
 
-func (m *mockUpdateRepo) CreateExpense(userID string, input models.CreateExpenseInput) (models.Expense, error) {
+func (m *mockUpdateRepo) CreateExpense(ctx context.Context, userID string, input models.CreateExpenseInput) (models.Expense, error) {
 	return models.Expense{}, errors.New("not implemented")
 }
 
-func (m *mockUpdateRepo) FindAll(userID string) ([]models.Expense, error) {
+func (m *mockUpdateRepo) FindAll(ctx context.Context, userID string, query repositories.ExpenseListQuery) ([]models.Expense, error) {
 	return nil, errors.New("not implemented")
 }
 
-func (m *mockUpdateRepo) GetExpenseByID(userID string, id int32) (models.Expense, error) {
+func (m *mockUpdateRepo) GetExpenseByID(ctx context.Context, userID string, id int32) (models.Expense, error) {
 	if m.getErr != nil {
 		return models.Expense{}, m.getErr
 	}
 	return m.current, nil
 }
 
-func (m *mockUpdateRepo) DeleteExpense(userID string, id int32) error {
+func (m *mockUpdateRepo) DeleteExpense(ctx context.Context, userID string, id int32) error {
 	return errors.New("not implemented")
 }
 
+func (m *mockUpdateRepo) RestoreExpense(ctx context.Context, userID string, id int32, deletedAfter time.Time) (models.Expense, error) {
+	return models.Expense{}, errors.New("not implemented")
+}
+
 // UpdateExpense updates fields; if Status is empty, keep current status
-func (m *mockUpdateRepo) UpdateExpense(userID string, input models.UpdateExpenseInput) (models.Expense, error) {
+func (m *mockUpdateRepo) UpdateExpense(ctx context.Context, userID string, input models.UpdateExpenseInput) (models.Expense, error) {
 	m.called = true
 	m.in = input
 	if m.returnErr != nil {
@@ -421,10 +615,31 @@ func (m *mockUpdateRepo) UpdateExpense(userID string, input models.UpdateExpense
 	if input.Status != "" {
 		e.Status = strings.ToLower(input.Status)
 	}
+	if input.PlannedAmount != nil {
+		e.PlannedAmount = input.PlannedAmount
+	}
 	m.current = e
 	return e, nil
 }
 
+// UpdateExpenseStatus changes only the status and records the call
+func (m *mockUpdateRepo) UpdateExpenseStatus(ctx context.Context, userID string, id int32, status string, version int, plannedAmount *int) (models.Expense, error) {
+	m.statusCalled = true
+	if m.returnErr != nil {
+		return models.Expense{}, m.returnErr
+	}
+	m.current.Status = status
+	if plannedAmount != nil {
+		m.current.PlannedAmount = plannedAmount
+	}
+	m.current.Version++
+	return m.current, nil
+}
+
+func (m *mockUpdateRepo) BulkCreateExpenses(ctx context.Context, userID string, inputs []models.CreateExpenseInput) ([]models.Expense, error) {
+	return nil, errors.New("not implemented")
+}
+
 func TestUpdateExpense_NormalCases(t *testing.T) {
 	t.Parallel()
 
@@ -434,7 +649,7 @@ func TestUpdateExpense_NormalCases(t *testing.T) {
 
 		repo := &mockUpdateRepo{current: models.Expense{ID: 1, Amount: 100, Memo: "old", SpentAt: "2025-01-01", Status: "planned", Category: models.Category{ID: 1}}}
 		cr := &mockCategoryRepo{exists: map[int32]bool{2: true}}
-		s := &expenseService{repo: repo, categoryRepo: cr}
+		s := &expenseService{repo: repo, categoryRepo: cr, auditRepo: &mockAuditRepo{}, txManager: &fakeTxManager{}}
 
 		input := models.UpdateExpenseInput{
 			ID:         1,
@@ -444,7 +659,7 @@ func TestUpdateExpense_NormalCases(t *testing.T) {
 			SpentAt:    "2025-02-01",
 			Status:     "confirmed",
 		}
-		out, err := s.UpdateExpense("test-user", input)
+		out, err := s.UpdateExpense(context.Background(), "test-user", input)
 
 		assert.NoError(t, err)
 		assert.True(t, repo.called)
@@ -453,6 +668,10 @@ func TestUpdateExpense_NormalCases(t *testing.T) {
 		assert.Equal(t, 2, out.Category.ID)
 		assert.Equal(t, "updated memo", out.Memo)
 		assert.Equal(t, "2025-02-01", out.SpentAt)
+		// 確定前の金額を予定金額として記録する
+		require.NotNil(t, repo.in.PlannedAmount)
+		assert.Equal(t, 100, *repo.in.PlannedAmount)
+		assert.Equal(t, 100, *out.PlannedAmount)
 	})
 
 	// Pattern 2: current confirmed -> update content without changing status
@@ -461,7 +680,7 @@ func TestUpdateExpense_NormalCases(t *testing.T) {
 
 		repo := &mockUpdateRepo{current: models.Expense{ID: 2, Amount: 300, Memo: "c-old", SpentAt: "2025-03-01", Status: "confirmed", Category: models.Category{ID: 3}}}
 		cr := &mockCategoryRepo{exists: map[int32]bool{4: true}}
-		s := &expenseService{repo: repo, categoryRepo: cr}
+		s := &expenseService{repo: repo, categoryRepo: cr, auditRepo: &mockAuditRepo{}, txManager: &fakeTxManager{}}
 
 		input := models.UpdateExpenseInput{
 			ID:         2,
@@ -471,7 +690,7 @@ func TestUpdateExpense_NormalCases(t *testing.T) {
 			SpentAt:    "2025-03-15",
 			Status:     "", // no change
 		}
-		out, err := s.UpdateExpense("test-user", input)
+		out, err := s.UpdateExpense(context.Background(), "test-user", input)
 
 		assert.NoError(t, err)
 		assert.True(t, repo.called)
@@ -480,6 +699,7 @@ func TestUpdateExpense_NormalCases(t *testing.T) {
 		assert.Equal(t, 4, out.Category.ID)
 		assert.Equal(t, "c-updated", out.Memo)
 		assert.Equal(t, "2025-03-15", out.SpentAt)
+		assert.Nil(t, repo.in.PlannedAmount)
 	})
 
 	// Pattern 3: current planned -> update content without changing status
@@ -488,7 +708,7 @@ func TestUpdateExpense_NormalCases(t *testing.T) {
 
 		repo := &mockUpdateRepo{current: models.Expense{ID: 3, Amount: 500, Memo: "p-old", SpentAt: "2025-04-01", Status: "planned", Category: models.Category{ID: 5}}}
 		cr := &mockCategoryRepo{exists: map[int32]bool{6: true}}
-		s := &expenseService{repo: repo, categoryRepo: cr}
+		s := &expenseService{repo: repo, categoryRepo: cr, auditRepo: &mockAuditRepo{}, txManager: &fakeTxManager{}}
 
 		input := models.UpdateExpenseInput{
 			ID:         3,
@@ -498,7 +718,7 @@ func TestUpdateExpense_NormalCases(t *testing.T) {
 			SpentAt:    "2025-04-10",
 			Status:     "", // no change
 		}
-		out, err := s.UpdateExpense("test-user", input)
+		out, err := s.UpdateExpense(context.Background(), "test-user", input)
 
 		assert.NoError(t, err)
 		assert.True(t, repo.called)
@@ -507,7 +727,66 @@ func TestUpdateExpense_NormalCases(t *testing.T) {
 		assert.Equal(t, 6, out.Category.ID)
 		assert.Equal(t, "p-updated", out.Memo)
 		assert.Equal(t, "2025-04-10", out.SpentAt)
+		assert.Nil(t, repo.in.PlannedAmount)
+	})
+}
+
+func TestUpdateExpense_StatusTransitions(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		from, to string
+		allowed  bool
+	}{
+		{"planned", "confirmed", true},
+		{"planned", "cancelled", true},
+		{"planned", "reimbursable", true},
+		{"planned", "reimbursed", false},
+		{"confirmed", "reimbursable", true},
+		{"confirmed", "cancelled", false},
+		{"cancelled", "planned", true},
+		{"cancelled", "confirmed", false},
+		{"reimbursable", "reimbursed", true},
+		{"reimbursable", "confirmed", true},
+		{"reimbursable", "planned", false},
+		{"reimbursed", "reimbursable", false},
+		{"reimbursed", "confirmed", false},
+		{"reimbursed", "reimbursed", true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.from+" to "+tc.to, func(t *testing.T) {
+			t.Parallel()
+			repo := &mockUpdateRepo{current: models.Expense{ID: 1, Amount: 1000, SpentAt: "2025-05-01", Status: tc.from, Category: models.Category{ID: 1}}}
+			s := &expenseService{repo: repo, categoryRepo: &mockCategoryRepo{exists: map[int32]bool{1: true}}, auditRepo: &mockAuditRepo{}, txManager: &fakeTxManager{}}
+
+			out, err := s.UpdateExpense(context.Background(), "test-user", models.UpdateExpenseInput{
+				ID: 1, Amount: intPtr(1000), CategoryID: intPtr(1), SpentAt: "2025-05-01", Status: tc.to,
+			})
+
+			if !tc.allowed {
+				assert.ErrorIs(t, err, ErrInvalidStatusTransition)
+				assert.False(t, repo.called)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.to, out.Status)
+		})
+	}
+}
+
+func TestUpdateExpense_PlannedToReimbursableRecordsPlannedAmount(t *testing.T) {
+	t.Parallel()
+
+	repo := &mockUpdateRepo{current: models.Expense{ID: 1, Amount: 5000, SpentAt: "2025-05-01", Status: "planned", Category: models.Category{ID: 1}}}
+	s := &expenseService{repo: repo, categoryRepo: &mockCategoryRepo{exists: map[int32]bool{1: true}}, auditRepo: &mockAuditRepo{}, txManager: &fakeTxManager{}}
+
+	_, err := s.UpdateExpense(context.Background(), "test-user", models.UpdateExpenseInput{
+		ID: 1, Amount: intPtr(5400), CategoryID: intPtr(1), SpentAt: "2025-05-01", Status: "reimbursable",
 	})
+
+	require.NoError(t, err)
+	require.NotNil(t, repo.in.PlannedAmount)
+	assert.Equal(t, 5000, *repo.in.PlannedAmount)
 }
 
 func TestUpdateExpense_InvalidTransition_ConfirmedToPlanned(t *testing.T) {
@@ -515,7 +794,7 @@ func TestUpdateExpense_InvalidTransition_ConfirmedToPlanned(t *testing.T) {
 
 	repo := &mockUpdateRepo{current: models.Expense{ID: 100, Amount: 1000, Memo: "confirmed item", SpentAt: "2025-05-01", Status: "confirmed", Category: models.Category{ID: 10}}}
 	cr := &mockCategoryRepo{exists: map[int32]bool{11: true}}
-	s := &expenseService{repo: repo, categoryRepo: cr}
+	s := &expenseService{repo: repo, categoryRepo: cr, auditRepo: &mockAuditRepo{}, txManager: &fakeTxManager{}}
 
 	input := models.UpdateExpenseInput{
 		ID:         100,
@@ -526,7 +805,7 @@ func TestUpdateExpense_InvalidTransition_ConfirmedToPlanned(t *testing.T) {
 		Status:     "planned",
 	}
 
-	_, err := s.UpdateExpense("test-user", input)
+	_, err := s.UpdateExpense(context.Background(), "test-user", input)
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -543,7 +822,7 @@ func TestUpdateExpense_NotFound(t *testing.T) {
 
 	repo := &mockUpdateRepo{getErr: sqlErrNoRows()}
 	cr := &mockCategoryRepo{}
-	s := &expenseService{repo: repo, categoryRepo: cr}
+	s := &expenseService{repo: repo, categoryRepo: cr, auditRepo: &mockAuditRepo{}, txManager: &fakeTxManager{}}
 
 	input := models.UpdateExpenseInput{
 		ID:         9999,
@@ -554,7 +833,7 @@ func TestUpdateExpense_NotFound(t *testing.T) {
 		Status:     "planned",
 	}
 
-	_, err := s.UpdateExpense("test-user", input)
+	_, err := s.UpdateExpense(context.Background(), "test-user", input)
 	if err == nil {
 		t.Fatalf("expected error")
 	}
@@ -565,3 +844,621 @@ func TestUpdateExpense_NotFound(t *testing.T) {
 	// Update が呼ばれないこと
 	assert.False(t, repo.called)
 }
+
+func TestUpdateExpense_VersionConflict(t *testing.T) {
+	t.Parallel()
+
+	repo := &mockUpdateRepo{current: models.Expense{ID: 1, Amount: 300, SpentAt: "2025-01-01", Status: "planned", Version: 3, Category: models.Category{ID: 1}}}
+	cr := &mockCategoryRepo{exists: map[int32]bool{1: true}}
+	s := &expenseService{repo: repo, categoryRepo: cr, auditRepo: &mockAuditRepo{}, txManager: &fakeTxManager{}}
+
+	input := models.UpdateExpenseInput{
+		ID:         1,
+		Amount:     intPtr(200),
+		CategoryID: intPtr(1),
+		SpentAt:    "2025-01-01",
+		Version:    2,
+	}
+	out, err := s.UpdateExpense(context.Background(), "test-user", input)
+
+	// 現在の支出とともに ErrVersionConflict を返し、更新しないこと
+	assert.ErrorIs(t, err, ErrVersionConflict)
+	assert.Equal(t, 300, out.Amount)
+	assert.Equal(t, 3, out.Version)
+	assert.False(t, repo.called)
+}
+
+func TestUpdateExpense_ConcurrentUpdate(t *testing.T) {
+	t.Parallel()
+
+	// 取得時はバージョンが一致したが、条件付き UPDATE で一致しなかった場合
+	repo := &mockUpdateRepo{
+		current:   models.Expense{ID: 1, Amount: 300, SpentAt: "2025-01-01", Status: "planned", Version: 2, Category: models.Category{ID: 1}},
+		returnErr: sqlErrNoRows(),
+	}
+	cr := &mockCategoryRepo{exists: map[int32]bool{1: true}}
+	s := &expenseService{repo: repo, categoryRepo: cr, auditRepo: &mockAuditRepo{}, txManager: &fakeTxManager{}}
+
+	input := models.UpdateExpenseInput{
+		ID:         1,
+		Amount:     intPtr(200),
+		CategoryID: intPtr(1),
+		SpentAt:    "2025-01-01",
+		Version:    2,
+	}
+	out, err := s.UpdateExpense(context.Background(), "test-user", input)
+
+	assert.ErrorIs(t, err, ErrVersionConflict)
+	assert.True(t, repo.called)
+	assert.Equal(t, 2, repo.in.Version)
+	assert.Equal(t, 300, out.Amount)
+}
+
+// TestUpdateExpense_CategoryDeletedConcurrently は確認の後にカテゴリが削除され、外部キー制約違反になった場合のテストです
+func TestUpdateExpense_CategoryDeletedConcurrently(t *testing.T) {
+	t.Parallel()
+
+	repo := &mockUpdateRepo{
+		current:   models.Expense{ID: 1, Amount: 300, SpentAt: "2025-01-01", Status: "planned", Version: 2, Category: models.Category{ID: 1}},
+		returnErr: errors.New("pq: insert or update on table \"expenses\" violates foreign key constraint \"expenses_category_id_fkey\""),
+	}
+	cr := &mockCategoryRepo{exists: map[int32]bool{2: true}}
+	s := &expenseService{repo: repo, categoryRepo: cr, auditRepo: &mockAuditRepo{}, txManager: &fakeTxManager{}}
+
+	_, err := s.UpdateExpense(context.Background(), "test-user", models.UpdateExpenseInput{
+		ID:         1,
+		Amount:     intPtr(200),
+		CategoryID: intPtr(2),
+		SpentAt:    "2025-01-01",
+		Version:    2,
+	})
+
+	var ve *ValidationError
+	require.ErrorAs(t, err, &ve)
+	assert.Equal(t, "カテゴリが存在しません", ve.Message)
+}
+
+func TestUpdateExpense_Items(t *testing.T) {
+	t.Parallel()
+
+	splitExpense := func() models.Expense {
+		return models.Expense{ID: 1, Amount: 3000, SpentAt: "2025-01-10", Status: "confirmed", Version: 1, Category: models.Category{ID: 2},
+			Items: []models.ExpenseItem{
+				{Amount: 2000, Category: models.Category{ID: 2}},
+				{Amount: 1000, Category: models.Category{ID: 3}},
+			}}
+	}
+	cr := &mockCategoryRepo{exists: map[int32]bool{1: true, 2: true, 3: true}}
+
+	t.Run("明細を指定しない場合は登録済みの明細の合計と金額を照合する", func(t *testing.T) {
+		t.Parallel()
+		repo := &mockUpdateRepo{current: splitExpense()}
+		s := NewExpenseService(repo, cr, &mockAuditRepo{}, &fakeTxManager{})
+
+		_, err := s.UpdateExpense(context.Background(), "test-user", models.UpdateExpenseInput{ID: 1, Amount: intPtr(3500), CategoryID: intPtr(1), SpentAt: "2025-01-10", Version: 1})
+
+		var ve *ValidationError
+		require.ErrorAs(t, err, &ve)
+		assert.Equal(t, "明細の合計（3000円）が金額（3500円）と一致しません", ve.Message)
+		assert.False(t, repo.called)
+	})
+
+	t.Run("明細を指定しない場合はカテゴリを先頭の明細のカテゴリのままにする", func(t *testing.T) {
+		t.Parallel()
+		repo := &mockUpdateRepo{current: splitExpense()}
+		s := NewExpenseService(repo, cr, &mockAuditRepo{}, &fakeTxManager{})
+
+		_, err := s.UpdateExpense(context.Background(), "test-user", models.UpdateExpenseInput{ID: 1, Amount: intPtr(3000), CategoryID: intPtr(1), SpentAt: "2025-01-10", Memo: "スーパー", Version: 1})
+
+		require.NoError(t, err)
+		assert.Nil(t, repo.in.Items)
+		assert.Equal(t, 2, *repo.in.CategoryID)
+	})
+
+	t.Run("PATCH で金額と明細をまとめて変更できる", func(t *testing.T) {
+		t.Parallel()
+		repo := &mockUpdateRepo{current: splitExpense()}
+		s := NewExpenseService(repo, cr, &mockAuditRepo{}, &fakeTxManager{})
+		items := []models.ExpenseItemInput{
+			{Amount: intPtr(2500), CategoryID: intPtr(3)},
+			{Amount: intPtr(1000), CategoryID: intPtr(2)},
+		}
+
+		_, err := s.PatchExpense(context.Background(), "test-user", models.PatchExpenseInput{ID: 1, Amount: intPtr(3500), Items: &items, Version: 1})
+
+		require.NoError(t, err)
+		require.NotNil(t, repo.in.Items)
+		assert.Len(t, *repo.in.Items, 2)
+		assert.Equal(t, 3, *repo.in.CategoryID)
+	})
+
+	t.Run("PATCH で items に null を指定すると明細を削除する", func(t *testing.T) {
+		t.Parallel()
+		repo := &mockUpdateRepo{current: splitExpense()}
+		s := NewExpenseService(repo, cr, &mockAuditRepo{}, &fakeTxManager{})
+		var patch models.PatchExpenseInput
+		require.NoError(t, json.Unmarshal([]byte(`{"items": null, "amount": 3200}`), &patch))
+		patch.ID, patch.Version = 1, 1
+
+		_, err := s.PatchExpense(context.Background(), "test-user", patch)
+
+		require.NoError(t, err)
+		require.NotNil(t, repo.in.Items)
+		assert.Empty(t, *repo.in.Items)
+		assert.Equal(t, 3200, *repo.in.Amount)
+		assert.Equal(t, 2, *repo.in.CategoryID)
+	})
+}
+
+func TestExpenseTags(t *testing.T) {
+	t.Parallel()
+
+	cr := &mockCategoryRepo{exists: map[int32]bool{1: true}}
+	current := func() models.Expense {
+		return models.Expense{ID: 1, Amount: 300, SpentAt: "2025-01-01", Status: "planned", Version: 2, Category: models.Category{ID: 1},
+			Tags: []models.Tag{{ID: 5, Name: "仕事関連"}}}
+	}
+
+	t.Run("登録時にタグ名の前後の空白と重複を取り除く", func(t *testing.T) {
+		t.Parallel()
+		m := &mockRepo{}
+		s := NewExpenseService(m, cr, &mockAuditRepo{}, &fakeTxManager{})
+
+		_, err := s.CreateExpense(context.Background(), "test-user", models.CreateExpenseInput{
+			Amount: intPtr(3000), CategoryID: intPtr(1), SpentAt: "2025-01-10",
+			Tags: []string{"沖縄旅行2026 ", "仕事関連", " 沖縄旅行2026"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"沖縄旅行2026", "仕事関連"}, m.in.Tags)
+	})
+
+	t.Run("空のタグ名は登録できない", func(t *testing.T) {
+		t.Parallel()
+		m := &mockRepo{}
+		s := NewExpenseService(m, cr, &mockAuditRepo{}, &fakeTxManager{})
+
+		_, err := s.CreateExpense(context.Background(), "test-user", models.CreateExpenseInput{Amount: intPtr(3000), CategoryID: intPtr(1), SpentAt: "2025-01-10", Tags: []string{""}})
+
+		var ve *ValidationError
+		require.ErrorAs(t, err, &ve)
+		assert.Equal(t, "タグ名を入力してください", ve.Message)
+		assert.False(t, m.called)
+	})
+
+	t.Run("タグを指定しない更新は付いているタグをそのままにする", func(t *testing.T) {
+		t.Parallel()
+		repo := &mockUpdateRepo{current: current()}
+		s := NewExpenseService(repo, cr, &mockAuditRepo{}, &fakeTxManager{})
+
+		_, err := s.UpdateExpense(context.Background(), "test-user", models.UpdateExpenseInput{ID: 1, Amount: intPtr(300), CategoryID: intPtr(1), SpentAt: "2025-01-01", Version: 2})
+
+		require.NoError(t, err)
+		assert.Nil(t, repo.in.Tags)
+	})
+
+	t.Run("PATCH で tags に null を指定するとタグをすべて外す", func(t *testing.T) {
+		t.Parallel()
+		repo := &mockUpdateRepo{current: current()}
+		s := NewExpenseService(repo, cr, &mockAuditRepo{}, &fakeTxManager{})
+		var patch models.PatchExpenseInput
+		require.NoError(t, json.Unmarshal([]byte(`{"tags": null, "status": "confirmed"}`), &patch))
+		patch.ID, patch.Version = 1, 2
+
+		_, err := s.PatchExpense(context.Background(), "test-user", patch)
+
+		require.NoError(t, err)
+		// タグも変更するためステータスのみの更新にはしない
+		assert.False(t, repo.statusCalled)
+		require.NotNil(t, repo.in.Tags)
+		assert.Empty(t, *repo.in.Tags)
+	})
+
+	t.Run("一覧のタグの指定が不正", func(t *testing.T) {
+		t.Parallel()
+		s := NewExpenseService(&mockRepo{}, cr, &mockAuditRepo{}, &fakeTxManager{})
+
+		_, err := s.ListExpenses(context.Background(), "test-user", models.ExpenseFilter{TagIDs: []int{0}})
+
+		var ve *ValidationError
+		require.ErrorAs(t, err, &ve)
+		assert.Equal(t, "有効なタグを選択してください", ve.Message)
+	})
+}
+
+func strPtr(v string) *string { return &v }
+
+func TestPatchExpense(t *testing.T) {
+	t.Parallel()
+
+	newRepo := func(status string) *mockUpdateRepo {
+		return &mockUpdateRepo{current: models.Expense{ID: 1, Amount: 300, Memo: "lunch", SpentAt: "2025-01-01", Status: status, Version: 2, Category: models.Category{ID: 1}}}
+	}
+
+	t.Run("ステータスのみの変更は UpdateExpenseStatus を使う", func(t *testing.T) {
+		t.Parallel()
+		repo := newRepo("planned")
+		s := &expenseService{repo: repo, categoryRepo: &mockCategoryRepo{}, auditRepo: &mockAuditRepo{}, txManager: &fakeTxManager{}}
+
+		out, err := s.PatchExpense(context.Background(), "test-user", models.PatchExpenseInput{ID: 1, Status: strPtr("Confirmed"), Version: 2})
+
+		require.NoError(t, err)
+		assert.True(t, repo.statusCalled)
+		assert.False(t, repo.called)
+		assert.Equal(t, "confirmed", out.Status)
+		assert.Equal(t, 300, out.Amount)
+		assert.Equal(t, 3, out.Version)
+		require.NotNil(t, out.PlannedAmount)
+		assert.Equal(t, 300, *out.PlannedAmount)
+	})
+
+	t.Run("金額とステータスを同時に変更した場合は変更前の金額を予定金額とする", func(t *testing.T) {
+		t.Parallel()
+		repo := newRepo("planned")
+		s := &expenseService{repo: repo, categoryRepo: &mockCategoryRepo{exists: map[int32]bool{1: true}}, auditRepo: &mockAuditRepo{}, txManager: &fakeTxManager{}}
+
+		out, err := s.PatchExpense(context.Background(), "test-user", models.PatchExpenseInput{ID: 1, Amount: intPtr(420), Status: strPtr("confirmed"), Version: 2})
+
+		require.NoError(t, err)
+		assert.True(t, repo.called)
+		assert.Equal(t, 420, out.Amount)
+		require.NotNil(t, repo.in.PlannedAmount)
+		assert.Equal(t, 300, *repo.in.PlannedAmount)
+	})
+
+	t.Run("確定済みを予定に戻すことはできない", func(t *testing.T) {
+		t.Parallel()
+		repo := newRepo("confirmed")
+		s := &expenseService{repo: repo, categoryRepo: &mockCategoryRepo{}, auditRepo: &mockAuditRepo{}, txManager: &fakeTxManager{}}
+
+		_, err := s.PatchExpense(context.Background(), "test-user", models.PatchExpenseInput{ID: 1, Status: strPtr("planned"), Version: 2})
+
+		assert.ErrorIs(t, err, ErrInvalidStatusTransition)
+		assert.False(t, repo.statusCalled)
+	})
+
+	t.Run("無効なステータスはバリデーションエラー", func(t *testing.T) {
+		t.Parallel()
+		repo := newRepo("planned")
+		s := &expenseService{repo: repo, categoryRepo: &mockCategoryRepo{}, auditRepo: &mockAuditRepo{}, txManager: &fakeTxManager{}}
+
+		_, err := s.PatchExpense(context.Background(), "test-user", models.PatchExpenseInput{ID: 1, Status: strPtr("done"), Version: 2})
+
+		var ve *ValidationError
+		assert.ErrorAs(t, err, &ve)
+	})
+
+	t.Run("指定しない項目は現在の値のまま更新する", func(t *testing.T) {
+		t.Parallel()
+		repo := newRepo("planned")
+		s := &expenseService{repo: repo, categoryRepo: &mockCategoryRepo{exists: map[int32]bool{1: true}}, auditRepo: &mockAuditRepo{}, txManager: &fakeTxManager{}}
+
+		out, err := s.PatchExpense(context.Background(), "test-user", models.PatchExpenseInput{ID: 1, Memo: strPtr(""), Version: 2})
+
+		require.NoError(t, err)
+		assert.True(t, repo.called)
+		assert.Equal(t, 300, *repo.in.Amount)
+		assert.Equal(t, 1, *repo.in.CategoryID)
+		assert.Equal(t, "2025-01-01", repo.in.SpentAt)
+		assert.Equal(t, "planned", repo.in.Status)
+		assert.Equal(t, 2, repo.in.Version)
+		assert.Equal(t, "", out.Memo)
+	})
+
+	t.Run("変更した項目は全項目の更新と同じ検証を行う", func(t *testing.T) {
+		t.Parallel()
+		repo := newRepo("planned")
+		s := &expenseService{repo: repo, categoryRepo: &mockCategoryRepo{exists: map[int32]bool{1: true}}, auditRepo: &mockAuditRepo{}, txManager: &fakeTxManager{}}
+
+		_, err := s.PatchExpense(context.Background(), "test-user", models.PatchExpenseInput{ID: 1, Amount: intPtr(0), Version: 2})
+
+		var ve *ValidationError
+		assert.ErrorAs(t, err, &ve)
+		assert.False(t, repo.called)
+	})
+
+	t.Run("バージョンが一致しない場合は ErrVersionConflict", func(t *testing.T) {
+		t.Parallel()
+		repo := newRepo("planned")
+		s := &expenseService{repo: repo, categoryRepo: &mockCategoryRepo{}, auditRepo: &mockAuditRepo{}, txManager: &fakeTxManager{}}
+
+		out, err := s.PatchExpense(context.Background(), "test-user", models.PatchExpenseInput{ID: 1, Status: strPtr("confirmed"), Version: 1})
+
+		assert.ErrorIs(t, err, ErrVersionConflict)
+		assert.Equal(t, 2, out.Version)
+		assert.False(t, repo.statusCalled)
+	})
+
+	t.Run("ステータスの変更中に他の端末で更新された", func(t *testing.T) {
+		t.Parallel()
+		repo := newRepo("planned")
+		repo.returnErr = sqlErrNoRows()
+		s := &expenseService{repo: repo, categoryRepo: &mockCategoryRepo{}, auditRepo: &mockAuditRepo{}, txManager: &fakeTxManager{}}
+
+		_, err := s.PatchExpense(context.Background(), "test-user", models.PatchExpenseInput{ID: 1, Status: strPtr("confirmed"), Version: 2})
+
+		assert.ErrorIs(t, err, ErrVersionConflict)
+		assert.True(t, repo.statusCalled)
+	})
+
+	t.Run("存在しない支出は NotFoundError", func(t *testing.T) {
+		t.Parallel()
+		repo := newRepo("planned")
+		repo.getErr = sqlErrNoRows()
+		s := &expenseService{repo: repo, categoryRepo: &mockCategoryRepo{}, auditRepo: &mockAuditRepo{}, txManager: &fakeTxManager{}}
+
+		_, err := s.PatchExpense(context.Background(), "test-user", models.PatchExpenseInput{ID: 1, Status: strPtr("confirmed"), Version: 2})
+
+		var nf *NotFoundError
+		assert.ErrorAs(t, err, &nf)
+	})
+}
+
+// mockListRepo は一覧取得のテスト用モックです
+type mockListRepo struct {
+	items  []models.Expense
+	called bool
+	query  repositories.ExpenseListQuery
+}
+
+func (m *mockListRepo) CreateExpense(ctx context.Context, userID string, input models.CreateExpenseInput) (models.Expense, error) {
+	return models.Expense{}, errors.New("not implemented")
+}
+
+func (m *mockListRepo) FindAll(ctx context.Context, userID string, query repositories.ExpenseListQuery) ([]models.Expense, error) {
+	m.called = true
+	m.query = query
+	if int(query.Limit) < len(m.items) {
+		return m.items[:query.Limit], nil
+	}
+	return m.items, nil
+}
+
+func (m *mockListRepo) GetExpenseByID(ctx context.Context, userID string, id int32) (models.Expense, error) {
+	return models.Expense{}, errors.New("not implemented")
+}
+
+func (m *mockListRepo) DeleteExpense(ctx context.Context, userID string, id int32) error {
+	return errors.New("not implemented")
+}
+
+func (m *mockListRepo) RestoreExpense(ctx context.Context, userID string, id int32, deletedAfter time.Time) (models.Expense, error) {
+	return models.Expense{}, errors.New("not implemented")
+}
+
+func (m *mockListRepo) UpdateExpense(ctx context.Context, userID string, input models.UpdateExpenseInput) (models.Expense, error) {
+	return models.Expense{}, errors.New("not implemented")
+}
+
+func (m *mockListRepo) UpdateExpenseStatus(ctx context.Context, userID string, id int32, status string, version int, plannedAmount *int) (models.Expense, error) {
+	return models.Expense{}, errors.New("not implemented")
+}
+
+func (m *mockListRepo) BulkCreateExpenses(ctx context.Context, userID string, inputs []models.CreateExpenseInput) ([]models.Expense, error) {
+	return nil, errors.New("not implemented")
+}
+
+func TestListExpenses_FilterValidation(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		filter  models.ExpenseFilter
+		wantErr bool
+	}{
+		{name: "条件なしは正常", filter: models.ExpenseFilter{}, wantErr: false},
+		{name: "期間指定は正常", filter: models.ExpenseFilter{From: "2025-01-01", To: "2025-01-31"}, wantErr: false},
+		{name: "開始日の形式が不正", filter: models.ExpenseFilter{From: "2025/01/01"}, wantErr: true},
+		{name: "開始日が終了日より後", filter: models.ExpenseFilter{From: "2025-02-01", To: "2025-01-31"}, wantErr: true},
+		{name: "カテゴリIDが0以下", filter: models.ExpenseFilter{CategoryIDs: []int{1, 0}}, wantErr: true},
+		{name: "ステータスが不正", filter: models.ExpenseFilter{Status: "done"}, wantErr: true},
+		{name: "最小金額が負数", filter: models.ExpenseFilter{AmountMin: intPtr(-1)}, wantErr: true},
+		{name: "最小金額が最大金額より大きい", filter: models.ExpenseFilter{AmountMin: intPtr(500), AmountMax: intPtr(100)}, wantErr: true},
+		{name: "メモが最大長を超える", filter: models.ExpenseFilter{Memo: strings.Repeat("a", 5001)}, wantErr: true},
+		{name: "カーソルが不正", filter: models.ExpenseFilter{Cursor: "!!invalid!!"}, wantErr: true},
+		{name: "limitが上限を超える", filter: models.ExpenseFilter{Limit: 201}, wantErr: true},
+		{name: "limitが負数", filter: models.ExpenseFilter{Limit: -1}, wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			repo := &mockListRepo{}
+			s := NewExpenseService(repo, &mockCategoryRepo{}, &mockAuditRepo{}, &fakeTxManager{})
+
+			_, err := s.ListExpenses(context.Background(), "test-user", tc.filter)
+
+			if tc.wantErr {
+				var ve *ValidationError
+				assert.ErrorAs(t, err, &ve)
+				assert.False(t, repo.called)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, repo.called)
+			}
+		})
+	}
+}
+
+func TestListExpenses_BuildsQuery(t *testing.T) {
+	t.Parallel()
+
+	repo := &mockListRepo{}
+	s := NewExpenseService(repo, &mockCategoryRepo{}, &mockAuditRepo{}, &fakeTxManager{})
+
+	_, err := s.ListExpenses(context.Background(), "test-user", models.ExpenseFilter{
+		From:        "2025-01-01",
+		To:          "2025-01-31",
+		CategoryIDs: []int{1, 3},
+		Status:      "PLANNED",
+		AmountMin:   intPtr(100),
+		AmountMax:   intPtr(5000),
+		Memo:        "ランチ",
+	})
+
+	assert.NoError(t, err)
+	q := repo.query
+	assert.Equal(t, "2025-01-01", q.From.Format("2006-01-02"))
+	assert.Equal(t, "2025-01-31", q.To.Format("2006-01-02"))
+	assert.Equal(t, []int32{1, 3}, q.CategoryIDs)
+	assert.Equal(t, "planned", q.Status)
+	assert.Equal(t, int32(100), *q.AmountMin)
+	assert.Equal(t, int32(5000), *q.AmountMax)
+	assert.Equal(t, "ランチ", q.Memo)
+	assert.Nil(t, q.CursorSpentAt)
+	// 次ページ判定のため limit + 1 件を要求する
+	assert.Equal(t, int32(DefaultExpensePageSize+1), q.Limit)
+}
+
+func TestListExpenses_CursorPagination(t *testing.T) {
+	t.Parallel()
+
+	repo := &mockListRepo{items: []models.Expense{
+		{ID: 5, SpentAt: "2025-01-10T00:00:00Z"},
+		{ID: 4, SpentAt: "2025-01-10T00:00:00Z"},
+		{ID: 3, SpentAt: "2025-01-08T00:00:00Z"},
+	}}
+	s := NewExpenseService(repo, &mockCategoryRepo{}, &mockAuditRepo{}, &fakeTxManager{})
+
+	// 1ページ目: 2件取得し、次のカーソルが返る
+	page, err := s.ListExpenses(context.Background(), "test-user", models.ExpenseFilter{Limit: 2})
+	assert.NoError(t, err)
+	assert.Len(t, page.Expenses, 2)
+	if !assert.NotNil(t, page.NextCursor) {
+		return
+	}
+
+	// 2ページ目: カーソルが (spent_at, id) に復元される
+	_, err = s.ListExpenses(context.Background(), "test-user", models.ExpenseFilter{Limit: 2, Cursor: *page.NextCursor})
+	assert.NoError(t, err)
+	if assert.NotNil(t, repo.query.CursorSpentAt) {
+		assert.Equal(t, "2025-01-10", repo.query.CursorSpentAt.Format("2006-01-02"))
+	}
+	assert.Equal(t, int32(4), repo.query.CursorID)
+
+	// 最終ページ: 件数が limit 以下ならカーソルは nil
+	page, err = s.ListExpenses(context.Background(), "test-user", models.ExpenseFilter{Limit: 5})
+	assert.NoError(t, err)
+	assert.Len(t, page.Expenses, 3)
+	assert.Nil(t, page.NextCursor)
+}
+
+// mockExportRepo は呼び出しごとに pages を順に返し、受け取ったクエリを記録します。
+type mockExportRepo struct {
+	mockListRepo
+	pages   [][]models.Expense
+	queries []repositories.ExpenseListQuery
+}
+
+func (m *mockExportRepo) FindAll(ctx context.Context, userID string, query repositories.ExpenseListQuery) ([]models.Expense, error) {
+	m.queries = append(m.queries, query)
+	if len(m.queries) > len(m.pages) {
+		return nil, nil
+	}
+	return m.pages[len(m.queries)-1], nil
+}
+
+func exportTestExpenses(startID, n int) []models.Expense {
+	items := make([]models.Expense, n)
+	for i := range items {
+		items[i] = models.Expense{ID: startID - i, Amount: 100, SpentAt: "2025-01-10T00:00:00Z"}
+	}
+	return items
+}
+
+func TestExportExpenses_PagesThroughAllRows(t *testing.T) {
+	first := exportTestExpenses(1000, ExpenseExportBatchSize)
+	second := exportTestExpenses(500, 3)
+	repo := &mockExportRepo{pages: [][]models.Expense{first, second}}
+	s := NewExpenseService(repo, &mockCategoryRepo{}, &mockAuditRepo{}, &fakeTxManager{})
+
+	var got []int
+	err := s.ExportExpenses(context.Background(), "user1", models.ExpenseFilter{Status: "confirmed", Cursor: "ignored", Limit: 10}, func(e models.Expense) error {
+		got = append(got, e.ID)
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, got, ExpenseExportBatchSize+3)
+	if assert.Len(t, repo.queries, 2) {
+		// cursor / limit は無視し、書き出し用の件数で取得する
+		assert.Nil(t, repo.queries[0].CursorSpentAt)
+		assert.Equal(t, int32(ExpenseExportBatchSize), repo.queries[0].Limit)
+		assert.Equal(t, "confirmed", repo.queries[0].Status)
+		// 2 回目は 1 回目の最終行をカーソルにする
+		if assert.NotNil(t, repo.queries[1].CursorSpentAt) {
+			assert.Equal(t, "2025-01-10", repo.queries[1].CursorSpentAt.Format("2006-01-02"))
+		}
+		assert.Equal(t, int32(first[len(first)-1].ID), repo.queries[1].CursorID)
+	}
+}
+
+func TestExportExpenses_Errors(t *testing.T) {
+	t.Run("条件が不正な場合はfnを呼ばない", func(t *testing.T) {
+		repo := &mockExportRepo{}
+		s := NewExpenseService(repo, &mockCategoryRepo{}, &mockAuditRepo{}, &fakeTxManager{})
+
+		called := false
+		err := s.ExportExpenses(context.Background(), "user1", models.ExpenseFilter{From: "bad"}, func(models.Expense) error {
+			called = true
+			return nil
+		})
+
+		var ve *ValidationError
+		assert.True(t, errors.As(err, &ve))
+		assert.False(t, called)
+		assert.Empty(t, repo.queries)
+	})
+
+	t.Run("fnのエラーで中断する", func(t *testing.T) {
+		repo := &mockExportRepo{pages: [][]models.Expense{exportTestExpenses(10, 3)}}
+		s := NewExpenseService(repo, &mockCategoryRepo{}, &mockAuditRepo{}, &fakeTxManager{})
+
+		writeErr := errors.New("broken pipe")
+		count := 0
+		err := s.ExportExpenses(context.Background(), "user1", models.ExpenseFilter{}, func(models.Expense) error {
+			count++
+			return writeErr
+		})
+
+		assert.ErrorIs(t, err, writeErr)
+		assert.Equal(t, 1, count)
+	})
+}
+
+// mockCtxRepo は受け取った ctx が終了していればそのエラーを返すモックです
+type mockCtxRepo struct {
+	mockRepo
+	ctxErrs []error
+}
+
+func (m *mockCtxRepo) FindAll(ctx context.Context, userID string, query repositories.ExpenseListQuery) ([]models.Expense, error) {
+	m.ctxErrs = append(m.ctxErrs, ctx.Err())
+	return nil, ctx.Err()
+}
+
+func (m *mockCtxRepo) GetExpenseByID(ctx context.Context, userID string, id int32) (models.Expense, error) {
+	m.ctxErrs = append(m.ctxErrs, ctx.Err())
+	return models.Expense{}, ctx.Err()
+}
+
+// TestExpenseService_PassesContext はリクエストの ctx がリポジトリまで渡され、中断したクエリが内部エラーになることのテストです
+func TestExpenseService_PassesContext(t *testing.T) {
+	repo := &mockCtxRepo{}
+	s := NewExpenseService(repo, &mockCategoryRepo{}, &mockAuditRepo{}, &fakeTxManager{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := s.ListExpenses(ctx, "user1", models.ExpenseFilter{})
+	var ie *InternalError
+	assert.ErrorAs(t, err, &ie)
+
+	_, err = s.GetExpense(ctx, "user1", 1)
+	assert.ErrorAs(t, err, &ie)
+
+	err = s.ExportExpenses(ctx, "user1", models.ExpenseFilter{}, func(models.Expense) error { return nil })
+	assert.ErrorAs(t, err, &ie)
+
+	assert.Equal(t, []error{context.Canceled, context.Canceled, context.Canceled}, repo.ctxErrs)
+}
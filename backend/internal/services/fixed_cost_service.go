@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"strings"
+	"time"
 
 	"money-buddy-backend/internal/models"
 	"money-buddy-backend/internal/repositories"
@@ -13,22 +14,42 @@ const (
 	FixedCostNameMaxLen = 100
 )
 
+// FixedCostService は固定費を扱います。
+// effectiveFrom（YYYY-MM、省略時は当月）は変更を適用する最初の月で、それより前の月の集計には影響しません。
+// 作成・更新・解約・復元は変更と同じトランザクションで変更履歴を記録します。
 type FixedCostService interface {
-	CreateFixedCost(ctx context.Context, userID string, name string, amount int) (models.FixedCost, error)
+	CreateFixedCost(ctx context.Context, userID string, name string, amount int, schedule models.FixedCostSchedule, effectiveFrom string) (models.FixedCost, error)
 	ListFixedCosts(ctx context.Context, userID string) ([]models.FixedCost, error)
-	UpdateFixedCost(ctx context.Context, userID string, id int, name string, amount int) (models.FixedCost, error)
-	DeleteFixedCost(ctx context.Context, userID string, id int) error
+	GetFixedCost(ctx context.Context, userID string, id int) (models.FixedCost, error)
+	// UpdateFixedCost は version が現在のバージョンと一致する場合のみ更新します。
+	// 一致しない場合は現在の固定費とともに ErrVersionConflict を返します。
+	UpdateFixedCost(ctx context.Context, userID string, id int, version int, name string, amount int, schedule models.FixedCostSchedule, effectiveFrom string) (models.FixedCost, error)
+	// DeleteFixedCost は固定費を解約し、effectiveFrom の月以降は計上しないようにします。
+	// 解約した固定費はゴミ箱に移り、TrashRetentionDays 日以内であれば RestoreFixedCost で元に戻せます。
+	DeleteFixedCost(ctx context.Context, userID string, id int, effectiveFrom string) error
+	// RestoreFixedCost はゴミ箱の固定費の解約を取り消し、解約月以降も再び計上するようにします。
+	RestoreFixedCost(ctx context.Context, userID string, id int) (models.FixedCost, error)
 }
 
 type fixedCostService struct {
-	repo repositories.FixedCostRepository
+	repo      repositories.FixedCostRepository
+	auditRepo repositories.AuditRepository
+	txManager TxManager
+	now       func() time.Time
 }
 
-func NewFixedCostService(repo repositories.FixedCostRepository) FixedCostService {
-	return &fixedCostService{repo: repo}
+func NewFixedCostService(repo repositories.FixedCostRepository, auditRepo repositories.AuditRepository, txManager TxManager) FixedCostService {
+	return &fixedCostService{repo: repo, auditRepo: auditRepo, txManager: txManager, now: time.Now}
 }
 
-func (s *fixedCostService) CreateFixedCost(ctx context.Context, userID string, name string, amount int) (models.FixedCost, error) {
+// fixedCostSnapshot は変更履歴に記録する固定費です。変更を適用した月・解約月も記録します。
+type fixedCostSnapshot struct {
+	models.FixedCost
+	EffectiveFrom string `json:"effective_from,omitempty"`
+	EndedFrom     string `json:"ended_from,omitempty"`
+}
+
+func (s *fixedCostService) CreateFixedCost(ctx context.Context, userID string, name string, amount int, schedule models.FixedCostSchedule, effectiveFrom string) (models.FixedCost, error) {
 	// 名前を正規化（前後の空白を除去）
 	name = strings.TrimSpace(name)
 
@@ -36,16 +57,40 @@ func (s *fixedCostService) CreateFixedCost(ctx context.Context, userID string, n
 	if err := validateFixedCostInput(name, amount); err != nil {
 		return models.FixedCost{}, err
 	}
+	if err := normalizeFixedCostSchedule(&schedule); err != nil {
+		return models.FixedCost{}, err
+	}
+	month, err := resolveEffectiveMonth(effectiveFrom, s.now())
+	if err != nil {
+		return models.FixedCost{}, err
+	}
 
 	// 作成実行
-	return s.repo.CreateFixedCost(ctx, userID, name, amount)
+	var created models.FixedCost
+	err = withTx(ctx, s.txManager, func(txCtx context.Context) error {
+		var err error
+		created, err = s.repo.CreateFixedCost(txCtx, userID, name, amount, schedule, month)
+		if err != nil {
+			return err
+		}
+		after := fixedCostSnapshot{FixedCost: created, EffectiveFrom: month.Format("2006-01")}
+		return recordAudit(txCtx, s.auditRepo, userID, models.AuditEntityFixedCost, created.ID, models.AuditActionCreate, nil, after)
+	})
+	if err != nil {
+		return models.FixedCost{}, err
+	}
+	return created, nil
 }
 
 func (s *fixedCostService) ListFixedCosts(ctx context.Context, userID string) ([]models.FixedCost, error) {
 	return s.repo.ListFixedCostsByUser(ctx, userID)
 }
 
-func (s *fixedCostService) UpdateFixedCost(ctx context.Context, userID string, id int, name string, amount int) (models.FixedCost, error) {
+func (s *fixedCostService) GetFixedCost(ctx context.Context, userID string, id int) (models.FixedCost, error) {
+	return s.findFixedCost(ctx, userID, id)
+}
+
+func (s *fixedCostService) UpdateFixedCost(ctx context.Context, userID string, id int, version int, name string, amount int, schedule models.FixedCostSchedule, effectiveFrom string) (models.FixedCost, error) {
 	// 名前を正規化（前後の空白を除去）
 	name = strings.TrimSpace(name)
 
@@ -53,49 +98,109 @@ func (s *fixedCostService) UpdateFixedCost(ctx context.Context, userID string, i
 	if err := validateFixedCostInput(name, amount); err != nil {
 		return models.FixedCost{}, err
 	}
-
-	// 更新実行（トリム済みのnameを使用）
-	if err := s.repo.UpdateFixedCost(ctx, int32(id), userID, name, amount); err != nil {
+	if err := normalizeFixedCostSchedule(&schedule); err != nil {
+		return models.FixedCost{}, err
+	}
+	month, err := resolveEffectiveMonth(effectiveFrom, s.now())
+	if err != nil {
 		return models.FixedCost{}, err
 	}
 
-	// 更新後のデータを取得して返す
-	fixedCosts, err := s.repo.ListFixedCostsByUser(ctx, userID)
+	current, err := s.findFixedCost(ctx, userID, id)
 	if err != nil {
 		return models.FixedCost{}, err
 	}
+	if current.Version != version {
+		return current, ErrVersionConflict
+	}
 
-	// 更新した固定費を探す
-	for _, fc := range fixedCosts {
-		if fc.ID == id {
-			return fc, nil
+	// 更新実行（トリム済みのnameを使用）
+	var updated bool
+	var fc models.FixedCost
+	err = withTx(ctx, s.txManager, func(txCtx context.Context) error {
+		var err error
+		updated, err = s.repo.UpdateFixedCost(txCtx, int32(id), userID, name, amount, schedule, month, version)
+		if err != nil {
+			return err
 		}
-	}
 
-	return models.FixedCost{}, &NotFoundError{Message: "固定費が見つかりません"}
+		// 更新後のデータを取得して返す
+		fc, err = s.findFixedCost(txCtx, userID, id)
+		if err != nil || !updated {
+			return err
+		}
+		after := fixedCostSnapshot{FixedCost: fc, EffectiveFrom: month.Format("2006-01")}
+		return recordAudit(txCtx, s.auditRepo, userID, models.AuditEntityFixedCost, id, models.AuditActionUpdate, fixedCostSnapshot{FixedCost: current}, after)
+	})
+	if err != nil {
+		return models.FixedCost{}, err
+	}
+	if !updated {
+		// 取得してから更新するまでの間に他のリクエストで更新された
+		return fc, ErrVersionConflict
+	}
+	return fc, nil
 }
 
-func (s *fixedCostService) DeleteFixedCost(ctx context.Context, userID string, id int) error {
+func (s *fixedCostService) DeleteFixedCost(ctx context.Context, userID string, id int, effectiveFrom string) error {
+	month, err := resolveEffectiveMonth(effectiveFrom, s.now())
+	if err != nil {
+		return err
+	}
+
 	// 削除前に対象が存在するか確認
-	fixedCosts, err := s.repo.ListFixedCostsByUser(ctx, userID)
+	current, err := s.findFixedCost(ctx, userID, id)
 	if err != nil {
 		return err
 	}
 
-	found := false
-	for _, fc := range fixedCosts {
-		if fc.ID == id {
-			found = true
-			break
+	// 解約実行（過去月の集計のため、削除せずに終了月を記録する）
+	return withTx(ctx, s.txManager, func(txCtx context.Context) error {
+		if err := s.repo.EndFixedCost(txCtx, int32(id), userID, month); err != nil {
+			return err
+		}
+		after := fixedCostSnapshot{FixedCost: current, EndedFrom: month.Format("2006-01")}
+		return recordAudit(txCtx, s.auditRepo, userID, models.AuditEntityFixedCost, id, models.AuditActionDelete, fixedCostSnapshot{FixedCost: current}, after)
+	})
+}
+
+func (s *fixedCostService) RestoreFixedCost(ctx context.Context, userID string, id int) (models.FixedCost, error) {
+	var fc models.FixedCost
+	err := withTx(ctx, s.txManager, func(txCtx context.Context) error {
+		restored, err := s.repo.RestoreFixedCost(txCtx, int32(id), userID, trashCutoff(s.now()))
+		if err != nil {
+			return err
+		}
+		if !restored {
+			return &NotFoundError{Message: "ゴミ箱に固定費が見つかりません"}
+		}
+
+		fc, err = s.findFixedCost(txCtx, userID, id)
+		if err != nil {
+			return err
 		}
+		return recordAudit(txCtx, s.auditRepo, userID, models.AuditEntityFixedCost, id, models.AuditActionRestore, nil, fixedCostSnapshot{FixedCost: fc})
+	})
+	if err != nil {
+		return models.FixedCost{}, err
+	}
+	return fc, nil
+}
+
+// findFixedCost は解約されていない固定費を ID で探します
+func (s *fixedCostService) findFixedCost(ctx context.Context, userID string, id int) (models.FixedCost, error) {
+	fixedCosts, err := s.repo.ListFixedCostsByUser(ctx, userID)
+	if err != nil {
+		return models.FixedCost{}, err
 	}
 
-	if !found {
-		return &NotFoundError{Message: "固定費が見つかりません"}
+	for _, fc := range fixedCosts {
+		if fc.ID == id {
+			return fc, nil
+		}
 	}
 
-	// 削除実行
-	return s.repo.DeleteFixedCost(ctx, int32(id), userID)
+	return models.FixedCost{}, &NotFoundError{Message: "固定費が見つかりません"}
 }
 
 // validateFixedCostInput は固定費の入力バリデーションを行います
@@ -118,3 +223,31 @@ func validateFixedCostInput(name string, amount int) error {
 
 	return nil
 }
+
+// normalizeFixedCostSchedule は支払いスケジュールを検証し、正規化します。
+// 支払い周期の省略時は毎月とし、毎月の場合は請求月を使用しないため破棄します。
+func normalizeFixedCostSchedule(schedule *models.FixedCostSchedule) error {
+	if schedule.Frequency == "" {
+		schedule.Frequency = string(models.FixedCostMonthly)
+	}
+	if !models.IsValidFixedCostFrequency(schedule.Frequency) {
+		return &ValidationError{Message: "支払い周期は毎月・2か月ごと・3か月ごと・毎年から選択してください"}
+	}
+
+	if schedule.Frequency == string(models.FixedCostMonthly) {
+		schedule.BillingMonth = nil
+	} else {
+		if schedule.BillingMonth == nil {
+			return &ValidationError{Message: "毎月以外の固定費は請求月を指定してください"}
+		}
+		if *schedule.BillingMonth < 1 || *schedule.BillingMonth > 12 {
+			return &ValidationError{Message: "請求月は1〜12で指定してください"}
+		}
+	}
+
+	if schedule.BillingDay != nil && (*schedule.BillingDay < 1 || *schedule.BillingDay > 31) {
+		return &ValidationError{Message: "請求日は1〜31で指定してください"}
+	}
+
+	return nil
+}
@@ -3,9 +3,11 @@ package services
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"money-buddy-backend/internal/models"
 )
@@ -15,8 +17,8 @@ type mockFixedCostRepo struct {
 	mock.Mock
 }
 
-func (m *mockFixedCostRepo) CreateFixedCost(ctx context.Context, userID string, name string, amount int) (models.FixedCost, error) {
-	args := m.Called(ctx, userID, name, amount)
+func (m *mockFixedCostRepo) CreateFixedCost(ctx context.Context, userID string, name string, amount int, schedule models.FixedCostSchedule, effectiveFrom time.Time) (models.FixedCost, error) {
+	args := m.Called(ctx, userID, name, amount, schedule, effectiveFrom)
 	return args.Get(0).(models.FixedCost), args.Error(1)
 }
 
@@ -28,26 +30,37 @@ func (m *mockFixedCostRepo) ListFixedCostsByUser(ctx context.Context, userID str
 	return nil, args.Error(1)
 }
 
-func (m *mockFixedCostRepo) DeleteFixedCostsByUser(ctx context.Context, userID string) error {
-	args := m.Called(ctx, userID)
+func (m *mockFixedCostRepo) EndFixedCostsByUser(ctx context.Context, userID string, endedFrom time.Time) error {
+	args := m.Called(ctx, userID, endedFrom)
 	return args.Error(0)
 }
 
-func (m *mockFixedCostRepo) BulkCreateFixedCosts(ctx context.Context, userID string, fixedCosts []models.FixedCostInput) error {
-	args := m.Called(ctx, userID, fixedCosts)
+func (m *mockFixedCostRepo) BulkCreateFixedCosts(ctx context.Context, userID string, fixedCosts []models.FixedCostInput, effectiveFrom time.Time) error {
+	args := m.Called(ctx, userID, fixedCosts, effectiveFrom)
 	return args.Error(0)
 }
 
-func (m *mockFixedCostRepo) UpdateFixedCost(ctx context.Context, id int32, userID string, name string, amount int) error {
-	args := m.Called(ctx, id, userID, name, amount)
-	return args.Error(0)
+func (m *mockFixedCostRepo) UpdateFixedCost(ctx context.Context, id int32, userID string, name string, amount int, schedule models.FixedCostSchedule, effectiveFrom time.Time, version int) (bool, error) {
+	args := m.Called(ctx, id, userID, name, amount, schedule, effectiveFrom, version)
+	return args.Bool(0), args.Error(1)
 }
 
-func (m *mockFixedCostRepo) DeleteFixedCost(ctx context.Context, id int32, userID string) error {
-	args := m.Called(ctx, id, userID)
+func (m *mockFixedCostRepo) EndFixedCost(ctx context.Context, id int32, userID string, endedFrom time.Time) error {
+	args := m.Called(ctx, id, userID, endedFrom)
 	return args.Error(0)
 }
 
+func (m *mockFixedCostRepo) RestoreFixedCost(ctx context.Context, id int32, userID string, deletedAfter time.Time) (bool, error) {
+	args := m.Called(ctx, id, userID, deletedAfter)
+	return args.Bool(0), args.Error(1)
+}
+
+// monthlySchedule は支払いスケジュール省略時に正規化された値です
+var monthlySchedule = models.FixedCostSchedule{Frequency: "monthly"}
+
+// anyMonth は適用開始月を問わない場合の引数マッチャーです
+var anyMonth = mock.AnythingOfType("time.Time")
+
 // TestListFixedCosts は固定費一覧取得のテストです
 func TestListFixedCosts(t *testing.T) {
 	ctx := context.Background()
@@ -60,7 +73,7 @@ func TestListFixedCosts(t *testing.T) {
 		}
 		repo.On("ListFixedCostsByUser", ctx, "user1").Return(expected, nil)
 
-		service := NewFixedCostService(repo)
+		service := NewFixedCostService(repo, &mockAuditRepo{}, &fakeTxManager{})
 		result, err := service.ListFixedCosts(ctx, "user1")
 
 		assert.NoError(t, err)
@@ -72,7 +85,7 @@ func TestListFixedCosts(t *testing.T) {
 		repo := new(mockFixedCostRepo)
 		repo.On("ListFixedCostsByUser", ctx, "user1").Return([]models.FixedCost{}, nil)
 
-		service := NewFixedCostService(repo)
+		service := NewFixedCostService(repo, &mockAuditRepo{}, &fakeTxManager{})
 		result, err := service.ListFixedCosts(ctx, "user1")
 
 		assert.NoError(t, err)
@@ -93,10 +106,10 @@ func TestCreateFixedCost(t *testing.T) {
 			Name:   "家賃",
 			Amount: 80000,
 		}
-		repo.On("CreateFixedCost", ctx, "user1", "家賃", 80000).Return(expected, nil)
+		repo.On("CreateFixedCost", ctx, "user1", "家賃", 80000, monthlySchedule, anyMonth).Return(expected, nil)
 
-		service := NewFixedCostService(repo)
-		result, err := service.CreateFixedCost(ctx, "user1", "家賃", 80000)
+		service := NewFixedCostService(repo, &mockAuditRepo{}, &fakeTxManager{})
+		result, err := service.CreateFixedCost(ctx, "user1", "家賃", 80000, models.FixedCostSchedule{}, "")
 
 		assert.NoError(t, err)
 		assert.Equal(t, expected, result)
@@ -112,10 +125,10 @@ func TestCreateFixedCost(t *testing.T) {
 			Amount: 80000,
 		}
 		// トリム後の値で呼ばれることを確認
-		repo.On("CreateFixedCost", ctx, "user1", "家賃", 80000).Return(expected, nil)
+		repo.On("CreateFixedCost", ctx, "user1", "家賃", 80000, monthlySchedule, anyMonth).Return(expected, nil)
 
-		service := NewFixedCostService(repo)
-		result, err := service.CreateFixedCost(ctx, "user1", "  家賃  ", 80000)
+		service := NewFixedCostService(repo, &mockAuditRepo{}, &fakeTxManager{})
+		result, err := service.CreateFixedCost(ctx, "user1", "  家賃  ", 80000, models.FixedCostSchedule{}, "")
 
 		assert.NoError(t, err)
 		assert.Equal(t, expected, result)
@@ -125,8 +138,8 @@ func TestCreateFixedCost(t *testing.T) {
 	t.Run("名前が空の場合はエラー", func(t *testing.T) {
 		repo := new(mockFixedCostRepo)
 
-		service := NewFixedCostService(repo)
-		_, err := service.CreateFixedCost(ctx, "user1", "", 80000)
+		service := NewFixedCostService(repo, &mockAuditRepo{}, &fakeTxManager{})
+		_, err := service.CreateFixedCost(ctx, "user1", "", 80000, models.FixedCostSchedule{}, "")
 
 		assert.Error(t, err)
 		var ve *ValidationError
@@ -137,8 +150,8 @@ func TestCreateFixedCost(t *testing.T) {
 	t.Run("名前が空白のみの場合はエラー", func(t *testing.T) {
 		repo := new(mockFixedCostRepo)
 
-		service := NewFixedCostService(repo)
-		_, err := service.CreateFixedCost(ctx, "user1", "   ", 80000)
+		service := NewFixedCostService(repo, &mockAuditRepo{}, &fakeTxManager{})
+		_, err := service.CreateFixedCost(ctx, "user1", "   ", 80000, models.FixedCostSchedule{}, "")
 
 		assert.Error(t, err)
 		var ve *ValidationError
@@ -150,8 +163,8 @@ func TestCreateFixedCost(t *testing.T) {
 		repo := new(mockFixedCostRepo)
 		longName := string(make([]byte, 101))
 
-		service := NewFixedCostService(repo)
-		_, err := service.CreateFixedCost(ctx, "user1", longName, 80000)
+		service := NewFixedCostService(repo, &mockAuditRepo{}, &fakeTxManager{})
+		_, err := service.CreateFixedCost(ctx, "user1", longName, 80000, models.FixedCostSchedule{}, "")
 
 		assert.Error(t, err)
 		var ve *ValidationError
@@ -162,8 +175,8 @@ func TestCreateFixedCost(t *testing.T) {
 	t.Run("金額が0以下の場合はエラー", func(t *testing.T) {
 		repo := new(mockFixedCostRepo)
 
-		service := NewFixedCostService(repo)
-		_, err := service.CreateFixedCost(ctx, "user1", "家賃", 0)
+		service := NewFixedCostService(repo, &mockAuditRepo{}, &fakeTxManager{})
+		_, err := service.CreateFixedCost(ctx, "user1", "家賃", 0, models.FixedCostSchedule{}, "")
 
 		assert.Error(t, err)
 		var ve *ValidationError
@@ -174,8 +187,8 @@ func TestCreateFixedCost(t *testing.T) {
 	t.Run("金額が上限を超える場合はエラー", func(t *testing.T) {
 		repo := new(mockFixedCostRepo)
 
-		service := NewFixedCostService(repo)
-		_, err := service.CreateFixedCost(ctx, "user1", "家賃", BusinessMaxAmount+1)
+		service := NewFixedCostService(repo, &mockAuditRepo{}, &fakeTxManager{})
+		_, err := service.CreateFixedCost(ctx, "user1", "家賃", BusinessMaxAmount+1, models.FixedCostSchedule{}, "")
 
 		assert.Error(t, err)
 		var ve *ValidationError
@@ -184,32 +197,89 @@ func TestCreateFixedCost(t *testing.T) {
 	})
 }
 
+// TestCreateFixedCost_Schedule は支払いスケジュールの検証と正規化のテストです
+func TestCreateFixedCost_Schedule(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("毎年の固定費を請求月・請求日付きで作成できる", func(t *testing.T) {
+		repo := new(mockFixedCostRepo)
+		schedule := models.FixedCostSchedule{Frequency: "yearly", BillingMonth: intPtr(5), BillingDay: intPtr(31)}
+		repo.On("CreateFixedCost", ctx, "user1", "自動車税", 34500, schedule, anyMonth).Return(models.FixedCost{ID: 1}, nil)
+
+		service := NewFixedCostService(repo, &mockAuditRepo{}, &fakeTxManager{})
+		_, err := service.CreateFixedCost(ctx, "user1", "自動車税", 34500, schedule, "")
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("毎月の場合は請求月を破棄する", func(t *testing.T) {
+		repo := new(mockFixedCostRepo)
+		expected := models.FixedCostSchedule{Frequency: "monthly", BillingDay: intPtr(27)}
+		repo.On("CreateFixedCost", ctx, "user1", "家賃", 80000, expected, anyMonth).Return(models.FixedCost{ID: 1}, nil)
+
+		service := NewFixedCostService(repo, &mockAuditRepo{}, &fakeTxManager{})
+		_, err := service.CreateFixedCost(ctx, "user1", "家賃", 80000, models.FixedCostSchedule{Frequency: "monthly", BillingMonth: intPtr(4), BillingDay: intPtr(27)}, "")
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	cases := []struct {
+		name     string
+		schedule models.FixedCostSchedule
+		wantMsg  string
+	}{
+		{name: "支払い周期が不正", schedule: models.FixedCostSchedule{Frequency: "weekly"}, wantMsg: "支払い周期は毎月・2か月ごと・3か月ごと・毎年から選択してください"},
+		{name: "毎月以外で請求月がない", schedule: models.FixedCostSchedule{Frequency: "quarterly"}, wantMsg: "毎月以外の固定費は請求月を指定してください"},
+		{name: "請求月が範囲外", schedule: models.FixedCostSchedule{Frequency: "yearly", BillingMonth: intPtr(13)}, wantMsg: "請求月は1〜12で指定してください"},
+		{name: "請求日が範囲外", schedule: models.FixedCostSchedule{BillingDay: intPtr(0)}, wantMsg: "請求日は1〜31で指定してください"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			repo := new(mockFixedCostRepo)
+
+			service := NewFixedCostService(repo, &mockAuditRepo{}, &fakeTxManager{})
+			_, err := service.CreateFixedCost(ctx, "user1", "家賃", 80000, tc.schedule, "")
+
+			var ve *ValidationError
+			assert.ErrorAs(t, err, &ve)
+			assert.Equal(t, tc.wantMsg, ve.Message)
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
 // TestUpdateFixedCost は固定費更新のテストです
 func TestUpdateFixedCost(t *testing.T) {
 	ctx := context.Background()
 
 	t.Run("正常に固定費を更新できる", func(t *testing.T) {
 		repo := new(mockFixedCostRepo)
-		repo.On("UpdateFixedCost", ctx, int32(1), "user1", "家賃（更新）", 85000).Return(nil)
 		repo.On("ListFixedCostsByUser", ctx, "user1").Return([]models.FixedCost{
-			{ID: 1, UserID: "user1", Name: "家賃（更新）", Amount: 85000},
-		}, nil)
+			{ID: 1, UserID: "user1", Name: "家賃", Amount: 80000, Version: 1},
+		}, nil).Once()
+		repo.On("UpdateFixedCost", ctx, int32(1), "user1", "家賃（更新）", 85000, monthlySchedule, anyMonth, 1).Return(true, nil)
+		repo.On("ListFixedCostsByUser", ctx, "user1").Return([]models.FixedCost{
+			{ID: 1, UserID: "user1", Name: "家賃（更新）", Amount: 85000, Version: 2},
+		}, nil).Once()
 
-		service := NewFixedCostService(repo)
-		result, err := service.UpdateFixedCost(ctx, "user1", 1, "家賃（更新）", 85000)
+		service := NewFixedCostService(repo, &mockAuditRepo{}, &fakeTxManager{})
+		result, err := service.UpdateFixedCost(ctx, "user1", 1, 1, "家賃（更新）", 85000, models.FixedCostSchedule{}, "")
 
 		assert.NoError(t, err)
 		assert.Equal(t, 1, result.ID)
 		assert.Equal(t, "家賃（更新）", result.Name)
 		assert.Equal(t, 85000, result.Amount)
+		assert.Equal(t, 2, result.Version)
 		repo.AssertExpectations(t)
 	})
 
 	t.Run("名前が空の場合はエラー", func(t *testing.T) {
 		repo := new(mockFixedCostRepo)
 
-		service := NewFixedCostService(repo)
-		_, err := service.UpdateFixedCost(ctx, "user1", 1, "", 80000)
+		service := NewFixedCostService(repo, &mockAuditRepo{}, &fakeTxManager{})
+		_, err := service.UpdateFixedCost(ctx, "user1", 1, 1, "", 80000, models.FixedCostSchedule{}, "")
 
 		assert.Error(t, err)
 		var ve *ValidationError
@@ -220,8 +290,8 @@ func TestUpdateFixedCost(t *testing.T) {
 	t.Run("名前が空白のみの場合はエラー", func(t *testing.T) {
 		repo := new(mockFixedCostRepo)
 
-		service := NewFixedCostService(repo)
-		_, err := service.UpdateFixedCost(ctx, "user1", 1, "   ", 80000)
+		service := NewFixedCostService(repo, &mockAuditRepo{}, &fakeTxManager{})
+		_, err := service.UpdateFixedCost(ctx, "user1", 1, 1, "   ", 80000, models.FixedCostSchedule{}, "")
 
 		assert.Error(t, err)
 		var ve *ValidationError
@@ -232,8 +302,8 @@ func TestUpdateFixedCost(t *testing.T) {
 		repo := new(mockFixedCostRepo)
 		longName := string(make([]byte, 101)) // 101文字
 
-		service := NewFixedCostService(repo)
-		_, err := service.UpdateFixedCost(ctx, "user1", 1, longName, 80000)
+		service := NewFixedCostService(repo, &mockAuditRepo{}, &fakeTxManager{})
+		_, err := service.UpdateFixedCost(ctx, "user1", 1, 1, longName, 80000, models.FixedCostSchedule{}, "")
 
 		assert.Error(t, err)
 		var ve *ValidationError
@@ -244,8 +314,8 @@ func TestUpdateFixedCost(t *testing.T) {
 	t.Run("金額が0以下の場合はエラー", func(t *testing.T) {
 		repo := new(mockFixedCostRepo)
 
-		service := NewFixedCostService(repo)
-		_, err := service.UpdateFixedCost(ctx, "user1", 1, "家賃", 0)
+		service := NewFixedCostService(repo, &mockAuditRepo{}, &fakeTxManager{})
+		_, err := service.UpdateFixedCost(ctx, "user1", 1, 1, "家賃", 0, models.FixedCostSchedule{}, "")
 
 		assert.Error(t, err)
 		var ve *ValidationError
@@ -256,8 +326,8 @@ func TestUpdateFixedCost(t *testing.T) {
 	t.Run("金額が負の値の場合はエラー", func(t *testing.T) {
 		repo := new(mockFixedCostRepo)
 
-		service := NewFixedCostService(repo)
-		_, err := service.UpdateFixedCost(ctx, "user1", 1, "家賃", -1000)
+		service := NewFixedCostService(repo, &mockAuditRepo{}, &fakeTxManager{})
+		_, err := service.UpdateFixedCost(ctx, "user1", 1, 1, "家賃", -1000, models.FixedCostSchedule{}, "")
 
 		assert.Error(t, err)
 		var ve *ValidationError
@@ -267,8 +337,8 @@ func TestUpdateFixedCost(t *testing.T) {
 	t.Run("金額が上限を超える場合はエラー", func(t *testing.T) {
 		repo := new(mockFixedCostRepo)
 
-		service := NewFixedCostService(repo)
-		_, err := service.UpdateFixedCost(ctx, "user1", 1, "家賃", 1000000001)
+		service := NewFixedCostService(repo, &mockAuditRepo{}, &fakeTxManager{})
+		_, err := service.UpdateFixedCost(ctx, "user1", 1, 1, "家賃", 1000000001, models.FixedCostSchedule{}, "")
 
 		assert.Error(t, err)
 		var ve *ValidationError
@@ -276,19 +346,52 @@ func TestUpdateFixedCost(t *testing.T) {
 		assert.Contains(t, err.Error(), "金額は10億円以下で入力してください")
 	})
 
-	t.Run("更新後に固定費が見つからない場合はエラー", func(t *testing.T) {
+	t.Run("存在しない固定費の場合はNotFoundError", func(t *testing.T) {
 		repo := new(mockFixedCostRepo)
-		repo.On("UpdateFixedCost", ctx, int32(1), "user1", "家賃", 80000).Return(nil)
 		repo.On("ListFixedCostsByUser", ctx, "user1").Return([]models.FixedCost{}, nil)
 
-		service := NewFixedCostService(repo)
-		_, err := service.UpdateFixedCost(ctx, "user1", 1, "家賃", 80000)
+		service := NewFixedCostService(repo, &mockAuditRepo{}, &fakeTxManager{})
+		_, err := service.UpdateFixedCost(ctx, "user1", 1, 1, "家賃", 80000, models.FixedCostSchedule{}, "")
 
 		assert.Error(t, err)
 		var ne *NotFoundError
 		assert.ErrorAs(t, err, &ne)
 		repo.AssertExpectations(t)
 	})
+
+	t.Run("バージョンが一致しない場合は更新せず現在の固定費を返す", func(t *testing.T) {
+		repo := new(mockFixedCostRepo)
+		repo.On("ListFixedCostsByUser", ctx, "user1").Return([]models.FixedCost{
+			{ID: 1, UserID: "user1", Name: "家賃", Amount: 82000, Version: 3},
+		}, nil)
+
+		service := NewFixedCostService(repo, &mockAuditRepo{}, &fakeTxManager{})
+		result, err := service.UpdateFixedCost(ctx, "user1", 1, 2, "家賃", 85000, models.FixedCostSchedule{}, "")
+
+		assert.ErrorIs(t, err, ErrVersionConflict)
+		assert.Equal(t, 82000, result.Amount)
+		assert.Equal(t, 3, result.Version)
+		repo.AssertNotCalled(t, "UpdateFixedCost", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("取得後に他のリクエストで更新された場合は最新の固定費を返す", func(t *testing.T) {
+		repo := new(mockFixedCostRepo)
+		repo.On("ListFixedCostsByUser", ctx, "user1").Return([]models.FixedCost{
+			{ID: 1, UserID: "user1", Name: "家賃", Amount: 80000, Version: 1},
+		}, nil).Once()
+		repo.On("UpdateFixedCost", ctx, int32(1), "user1", "家賃", 85000, monthlySchedule, anyMonth, 1).Return(false, nil)
+		repo.On("ListFixedCostsByUser", ctx, "user1").Return([]models.FixedCost{
+			{ID: 1, UserID: "user1", Name: "家賃", Amount: 90000, Version: 2},
+		}, nil).Once()
+
+		service := NewFixedCostService(repo, &mockAuditRepo{}, &fakeTxManager{})
+		result, err := service.UpdateFixedCost(ctx, "user1", 1, 1, "家賃", 85000, models.FixedCostSchedule{}, "")
+
+		assert.ErrorIs(t, err, ErrVersionConflict)
+		assert.Equal(t, 90000, result.Amount)
+		assert.Equal(t, 2, result.Version)
+		repo.AssertExpectations(t)
+	})
 }
 
 // TestDeleteFixedCost は固定費削除のテストです
@@ -302,10 +405,10 @@ func TestDeleteFixedCost(t *testing.T) {
 			{ID: 1, Name: "家賃", Amount: 80000},
 			{ID: 2, Name: "光熱費", Amount: 15000},
 		}, nil)
-		repo.On("DeleteFixedCost", ctx, int32(1), "user1").Return(nil)
+		repo.On("EndFixedCost", ctx, int32(1), "user1", anyMonth).Return(nil)
 
-		service := NewFixedCostService(repo)
-		err := service.DeleteFixedCost(ctx, "user1", 1)
+		service := NewFixedCostService(repo, &mockAuditRepo{}, &fakeTxManager{})
+		err := service.DeleteFixedCost(ctx, "user1", 1, "")
 
 		assert.NoError(t, err)
 		repo.AssertExpectations(t)
@@ -319,8 +422,8 @@ func TestDeleteFixedCost(t *testing.T) {
 			{ID: 2, Name: "光熱費", Amount: 15000},
 		}, nil)
 
-		service := NewFixedCostService(repo)
-		err := service.DeleteFixedCost(ctx, "user1", 999)
+		service := NewFixedCostService(repo, &mockAuditRepo{}, &fakeTxManager{})
+		err := service.DeleteFixedCost(ctx, "user1", 999, "")
 
 		assert.Error(t, err)
 		var ne *NotFoundError
@@ -328,3 +431,144 @@ func TestDeleteFixedCost(t *testing.T) {
 		repo.AssertExpectations(t)
 	})
 }
+
+// TestRestoreFixedCost はゴミ箱の固定費の復元のテストです
+func TestRestoreFixedCost(t *testing.T) {
+	ctx := context.Background()
+	now := func() time.Time { return time.Date(2025, 6, 18, 9, 0, 0, 0, time.UTC) }
+
+	t.Run("削除から30日以内の固定費を復元できる", func(t *testing.T) {
+		repo := new(mockFixedCostRepo)
+		repo.On("RestoreFixedCost", ctx, int32(1), "user1", time.Date(2025, 5, 19, 9, 0, 0, 0, time.UTC)).Return(true, nil)
+		repo.On("ListFixedCostsByUser", ctx, "user1").Return([]models.FixedCost{{ID: 1, Name: "家賃", Amount: 80000, Version: 3}}, nil)
+
+		service := &fixedCostService{repo: repo, auditRepo: &mockAuditRepo{}, txManager: &fakeTxManager{}, now: now}
+		fc, err := service.RestoreFixedCost(ctx, "user1", 1)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, fc.Version)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("ゴミ箱にない固定費はNotFoundError", func(t *testing.T) {
+		repo := new(mockFixedCostRepo)
+		repo.On("RestoreFixedCost", ctx, int32(999), "user1", anyMonth).Return(false, nil)
+
+		service := &fixedCostService{repo: repo, auditRepo: &mockAuditRepo{}, txManager: &fakeTxManager{}, now: now}
+		_, err := service.RestoreFixedCost(ctx, "user1", 999)
+
+		var ne *NotFoundError
+		assert.ErrorAs(t, err, &ne)
+		repo.AssertExpectations(t)
+	})
+}
+
+// TestFixedCost_EffectiveFrom は適用開始月の解決と検証のテストです
+func TestFixedCost_EffectiveFrom(t *testing.T) {
+	ctx := context.Background()
+	now := func() time.Time { return time.Date(2025, 6, 18, 9, 0, 0, 0, time.UTC) }
+
+	t.Run("省略時は当月から適用する", func(t *testing.T) {
+		repo := new(mockFixedCostRepo)
+		repo.On("CreateFixedCost", ctx, "user1", "家賃", 80000, monthlySchedule, date("2025-06-01")).Return(models.FixedCost{ID: 1}, nil)
+
+		service := &fixedCostService{repo: repo, auditRepo: &mockAuditRepo{}, txManager: &fakeTxManager{}, now: now}
+		_, err := service.CreateFixedCost(ctx, "user1", "家賃", 80000, models.FixedCostSchedule{}, "")
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("過去の月から値上げを適用できる", func(t *testing.T) {
+		repo := new(mockFixedCostRepo)
+		repo.On("UpdateFixedCost", ctx, int32(1), "user1", "家賃", 85000, monthlySchedule, date("2025-04-01"), 1).Return(true, nil)
+		repo.On("ListFixedCostsByUser", ctx, "user1").Return([]models.FixedCost{{ID: 1, Name: "家賃", Amount: 85000, Version: 1}}, nil)
+
+		service := &fixedCostService{repo: repo, auditRepo: &mockAuditRepo{}, txManager: &fakeTxManager{}, now: now}
+		_, err := service.UpdateFixedCost(ctx, "user1", 1, 1, "家賃", 85000, models.FixedCostSchedule{}, "2025-04")
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("解約月を指定して解約できる", func(t *testing.T) {
+		repo := new(mockFixedCostRepo)
+		repo.On("ListFixedCostsByUser", ctx, "user1").Return([]models.FixedCost{{ID: 1, Name: "動画配信", Amount: 1500}}, nil)
+		repo.On("EndFixedCost", ctx, int32(1), "user1", date("2025-05-01")).Return(nil)
+
+		service := &fixedCostService{repo: repo, auditRepo: &mockAuditRepo{}, txManager: &fakeTxManager{}, now: now}
+		err := service.DeleteFixedCost(ctx, "user1", 1, "2025-05")
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	for _, month := range []string{"2025-07", "2025/05", "2025-13"} {
+		t.Run("不正な適用開始月_"+month, func(t *testing.T) {
+			repo := new(mockFixedCostRepo)
+
+			service := &fixedCostService{repo: repo, auditRepo: &mockAuditRepo{}, txManager: &fakeTxManager{}, now: now}
+			_, err := service.UpdateFixedCost(ctx, "user1", 1, 1, "家賃", 85000, models.FixedCostSchedule{}, month)
+
+			var ve *ValidationError
+			assert.ErrorAs(t, err, &ve)
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+// TestFixedCost_RecordsAudit は固定費の変更が同じトランザクションで履歴に記録されることのテストです
+func TestFixedCost_RecordsAudit(t *testing.T) {
+	ctx := context.Background()
+	now := func() time.Time { return time.Date(2025, 6, 18, 9, 0, 0, 0, time.UTC) }
+
+	t.Run("更新は変更前後と適用開始月を記録する", func(t *testing.T) {
+		repo := new(mockFixedCostRepo)
+		repo.On("ListFixedCostsByUser", ctx, "user1").Return([]models.FixedCost{{ID: 1, Name: "家賃", Amount: 80000, Version: 1}}, nil).Once()
+		repo.On("UpdateFixedCost", ctx, int32(1), "user1", "家賃", 85000, monthlySchedule, date("2025-04-01"), 1).Return(true, nil)
+		repo.On("ListFixedCostsByUser", ctx, "user1").Return([]models.FixedCost{{ID: 1, Name: "家賃", Amount: 85000, Version: 2}}, nil).Once()
+		auditRepo := &mockAuditRepo{}
+		tm := &fakeTxManager{}
+
+		service := &fixedCostService{repo: repo, auditRepo: auditRepo, txManager: tm, now: now}
+		_, err := service.UpdateFixedCost(ctx, "user1", 1, 1, "家賃", 85000, models.FixedCostSchedule{}, "2025-04")
+		require.NoError(t, err)
+
+		require.Len(t, auditRepo.created, 1)
+		event := auditRepo.created[0]
+		assert.Equal(t, models.AuditEntityFixedCost, event.EntityType)
+		assert.Equal(t, models.AuditActionUpdate, event.Action)
+		assert.Contains(t, event.Before, `"amount":80000`)
+		assert.Contains(t, event.After, `"amount":85000`)
+		assert.Contains(t, event.After, `"effective_from":"2025-04"`)
+		assert.Equal(t, 1, tm.commits)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("バージョンが競合した場合は記録しない", func(t *testing.T) {
+		repo := new(mockFixedCostRepo)
+		repo.On("ListFixedCostsByUser", ctx, "user1").Return([]models.FixedCost{{ID: 1, Name: "家賃", Amount: 80000, Version: 1}}, nil)
+		repo.On("UpdateFixedCost", ctx, int32(1), "user1", "家賃", 85000, monthlySchedule, anyMonth, 1).Return(false, nil)
+		auditRepo := &mockAuditRepo{}
+
+		service := &fixedCostService{repo: repo, auditRepo: auditRepo, txManager: &fakeTxManager{}, now: now}
+		_, err := service.UpdateFixedCost(ctx, "user1", 1, 1, "家賃", 85000, models.FixedCostSchedule{}, "")
+
+		assert.ErrorIs(t, err, ErrVersionConflict)
+		assert.Empty(t, auditRepo.created)
+	})
+
+	t.Run("解約は解約月を記録する", func(t *testing.T) {
+		repo := new(mockFixedCostRepo)
+		repo.On("ListFixedCostsByUser", ctx, "user1").Return([]models.FixedCost{{ID: 1, Name: "動画配信", Amount: 1500}}, nil)
+		repo.On("EndFixedCost", ctx, int32(1), "user1", date("2025-05-01")).Return(nil)
+		auditRepo := &mockAuditRepo{}
+
+		service := &fixedCostService{repo: repo, auditRepo: auditRepo, txManager: &fakeTxManager{}, now: now}
+		require.NoError(t, service.DeleteFixedCost(ctx, "user1", 1, "2025-05"))
+
+		require.Len(t, auditRepo.created, 1)
+		assert.Equal(t, models.AuditActionDelete, auditRepo.created[0].Action)
+		assert.Contains(t, auditRepo.created[0].After, `"ended_from":"2025-05"`)
+	})
+}
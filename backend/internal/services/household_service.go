@@ -0,0 +1,328 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/repositories"
+)
+
+const (
+	// HouseholdNameMaxLen は世帯名の最大文字数
+	HouseholdNameMaxLen = 50
+	// HouseholdInvitationExpiresInDays は招待コードの有効日数
+	HouseholdInvitationExpiresInDays = 7
+	// householdInvitationCodeLen は招待コードの文字数
+	householdInvitationCodeLen = 10
+)
+
+// householdInvitationCodeChars は招待コードに使う文字です（読み間違えやすい 0/O・1/I は除く）。
+const householdInvitationCodeChars = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+var (
+	// ErrHouseholdAlreadyOwned はすでに世帯を作成しているユーザーが世帯を作成しようとしたことを表すエラーです。
+	ErrHouseholdAlreadyOwned = errors.New("household already owned")
+	// ErrAlreadyHouseholdMember はすでにメンバーである世帯の招待コードを使おうとしたことを表すエラーです。
+	ErrAlreadyHouseholdMember = errors.New("already household member")
+	// ErrHouseholdOwnerOnly はオーナー以外が世帯の管理操作をしようとしたことを表すエラーです。
+	ErrHouseholdOwnerOnly = errors.New("household owner only")
+)
+
+// HouseholdService は世帯サービスのインターフェースです。
+// 世帯の家計簿はオーナーのデータをメンバーで共有します。
+type HouseholdService interface {
+	// CurrentMembership は userID が選択中の世帯での所属情報を返します。世帯を選択していない場合は nil を返します。
+	CurrentMembership(ctx context.Context, userID string) (*models.HouseholdMembership, error)
+	ListHouseholds(ctx context.Context, userID string) ([]models.Household, error)
+	GetHousehold(ctx context.Context, userID string, id int) (models.HouseholdDetail, error)
+	// CreateHousehold は userID をオーナーとする世帯を作成し、作成した世帯を選択中にします。
+	CreateHousehold(ctx context.Context, userID string, name string) (models.Household, error)
+	// SwitchHousehold は選択中の世帯を切り替えます。id が nil の場合は自分の家計簿に戻します。
+	SwitchHousehold(ctx context.Context, userID string, id *int) error
+	// CreateInvitation は世帯への招待コードを発行します（オーナーのみ）。
+	CreateInvitation(ctx context.Context, userID string, id int, role string) (models.HouseholdInvitation, error)
+	// JoinHousehold は招待コードの世帯に参加し、参加した世帯を選択中にします。招待コードは使用後に無効になります。
+	JoinHousehold(ctx context.Context, userID string, code string) (models.Household, error)
+	// UpdateMemberRole はメンバーの役割を変更します（オーナーのみ）。
+	UpdateMemberRole(ctx context.Context, userID string, id int, memberID string, role string) error
+	// RemoveMember はメンバーを世帯から外します。オーナーは他のメンバーを、メンバーは自分自身を外せます。
+	RemoveMember(ctx context.Context, userID string, id int, memberID string) error
+	// DeleteHousehold は世帯を削除します（オーナーのみ）。家計簿のデータはオーナーのもとに残ります。
+	DeleteHousehold(ctx context.Context, userID string, id int) error
+}
+
+type householdService struct {
+	repo      repositories.HouseholdRepository
+	userRepo  repositories.UserRepository
+	txManager TxManager
+}
+
+func NewHouseholdService(repo repositories.HouseholdRepository, userRepo repositories.UserRepository, txManager TxManager) HouseholdService {
+	return &householdService{repo: repo, userRepo: userRepo, txManager: txManager}
+}
+
+func (s *householdService) CurrentMembership(ctx context.Context, userID string) (*models.HouseholdMembership, error) {
+	m, err := s.repo.GetCurrentMembership(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (s *householdService) ListHouseholds(ctx context.Context, userID string) ([]models.Household, error) {
+	return s.repo.ListHouseholds(ctx, userID)
+}
+
+func (s *householdService) GetHousehold(ctx context.Context, userID string, id int) (models.HouseholdDetail, error) {
+	household, err := s.getHousehold(ctx, userID, id)
+	if err != nil {
+		return models.HouseholdDetail{}, err
+	}
+	members, err := s.repo.ListMembers(ctx, int32(id))
+	if err != nil {
+		return models.HouseholdDetail{}, err
+	}
+	return models.HouseholdDetail{Household: household, Members: members}, nil
+}
+
+func (s *householdService) CreateHousehold(ctx context.Context, userID string, name string) (models.Household, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return models.Household{}, &ValidationError{Message: "世帯名を入力してください"}
+	}
+	if utf8.RuneCountInString(name) > HouseholdNameMaxLen {
+		return models.Household{}, &ValidationError{Message: "世帯名は50文字以内で入力してください"}
+	}
+	if err := s.ensureUser(ctx, userID); err != nil {
+		return models.Household{}, err
+	}
+
+	// 世帯の家計簿はオーナーのデータを共有するため、作成できる世帯は1つまで
+	owns, err := s.repo.OwnsHousehold(ctx, userID)
+	if err != nil {
+		return models.Household{}, err
+	}
+	if owns {
+		return models.Household{}, ErrHouseholdAlreadyOwned
+	}
+
+	tx, err := s.txManager.Begin(ctx)
+	if err != nil {
+		return models.Household{}, err
+	}
+
+	txCtx := tx.Context(ctx)
+	id, err := s.repo.CreateHousehold(txCtx, userID, name)
+	if err != nil {
+		_ = tx.Rollback()
+		return models.Household{}, err
+	}
+	if _, err := s.repo.AddMember(txCtx, id, userID, models.HouseholdRoleOwner); err != nil {
+		_ = tx.Rollback()
+		return models.Household{}, err
+	}
+	if err := s.repo.SetCurrentHousehold(txCtx, userID, &id); err != nil {
+		_ = tx.Rollback()
+		return models.Household{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return models.Household{}, err
+	}
+
+	return s.repo.GetHousehold(ctx, userID, id)
+}
+
+func (s *householdService) SwitchHousehold(ctx context.Context, userID string, id *int) error {
+	var householdID *int32
+	if id != nil {
+		if _, err := s.getHousehold(ctx, userID, *id); err != nil {
+			return err
+		}
+		v := int32(*id)
+		householdID = &v
+	}
+
+	if err := s.repo.SetCurrentHousehold(ctx, userID, householdID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &NotFoundError{Message: "ユーザーが見つかりません"}
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *householdService) CreateInvitation(ctx context.Context, userID string, id int, role string) (models.HouseholdInvitation, error) {
+	if !models.IsValidHouseholdRole(role) {
+		return models.HouseholdInvitation{}, &ValidationError{Message: "役割は member または viewer を指定してください"}
+	}
+	if _, err := s.getOwnedHousehold(ctx, userID, id); err != nil {
+		return models.HouseholdInvitation{}, err
+	}
+
+	code, err := generateInvitationCode()
+	if err != nil {
+		return models.HouseholdInvitation{}, err
+	}
+	return s.repo.CreateInvitation(ctx, int32(id), userID, code, role, HouseholdInvitationExpiresInDays)
+}
+
+func (s *householdService) JoinHousehold(ctx context.Context, userID string, code string) (models.Household, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if code == "" {
+		return models.Household{}, &ValidationError{Message: "招待コードを入力してください"}
+	}
+	if err := s.ensureUser(ctx, userID); err != nil {
+		return models.Household{}, err
+	}
+
+	tx, err := s.txManager.Begin(ctx)
+	if err != nil {
+		return models.Household{}, err
+	}
+
+	txCtx := tx.Context(ctx)
+	id, role, err := s.repo.ConsumeInvitation(txCtx, code)
+	if err != nil {
+		_ = tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Household{}, &NotFoundError{Message: "招待コードが無効か、有効期限が切れています"}
+		}
+		return models.Household{}, err
+	}
+	added, err := s.repo.AddMember(txCtx, id, userID, role)
+	if err != nil {
+		_ = tx.Rollback()
+		return models.Household{}, err
+	}
+	if !added {
+		// 招待コードは使用しなかったものとして残す
+		_ = tx.Rollback()
+		return models.Household{}, ErrAlreadyHouseholdMember
+	}
+	if err := s.repo.SetCurrentHousehold(txCtx, userID, &id); err != nil {
+		_ = tx.Rollback()
+		return models.Household{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return models.Household{}, err
+	}
+
+	return s.repo.GetHousehold(ctx, userID, id)
+}
+
+func (s *householdService) UpdateMemberRole(ctx context.Context, userID string, id int, memberID string, role string) error {
+	if !models.IsValidHouseholdRole(role) {
+		return &ValidationError{Message: "役割は member または viewer を指定してください"}
+	}
+	if _, err := s.getOwnedHousehold(ctx, userID, id); err != nil {
+		return err
+	}
+	if memberID == userID {
+		return &ValidationError{Message: "オーナーの役割は変更できません"}
+	}
+
+	updated, err := s.repo.UpdateMemberRole(ctx, int32(id), memberID, role)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return &NotFoundError{Message: "メンバーが見つかりません"}
+	}
+	return nil
+}
+
+func (s *householdService) RemoveMember(ctx context.Context, userID string, id int, memberID string) error {
+	household, err := s.getHousehold(ctx, userID, id)
+	if err != nil {
+		return err
+	}
+	if memberID == userID {
+		// 自分自身の退出。オーナーが抜けると家計簿の所有者がいなくなるため、世帯の削除を求める
+		if household.Role == models.HouseholdRoleOwner {
+			return &ValidationError{Message: "オーナーは世帯から退出できません。世帯を削除してください"}
+		}
+	} else if household.Role != models.HouseholdRoleOwner {
+		return ErrHouseholdOwnerOnly
+	}
+
+	deleted, err := s.repo.DeleteMember(ctx, int32(id), memberID)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return &NotFoundError{Message: "メンバーが見つかりません"}
+	}
+	return nil
+}
+
+func (s *householdService) DeleteHousehold(ctx context.Context, userID string, id int) error {
+	if _, err := s.getOwnedHousehold(ctx, userID, id); err != nil {
+		return err
+	}
+
+	deleted, err := s.repo.DeleteHousehold(ctx, userID, int32(id))
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return &NotFoundError{Message: "世帯が見つかりません"}
+	}
+	return nil
+}
+
+// getHousehold は userID が所属する世帯を返します。所属していない場合は NotFoundError を返します。
+func (s *householdService) getHousehold(ctx context.Context, userID string, id int) (models.Household, error) {
+	household, err := s.repo.GetHousehold(ctx, userID, int32(id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Household{}, &NotFoundError{Message: "世帯が見つかりません"}
+		}
+		return models.Household{}, err
+	}
+	return household, nil
+}
+
+// getOwnedHousehold は userID がオーナーである世帯を返します。
+// 所属していない場合は NotFoundError、オーナーでない場合は ErrHouseholdOwnerOnly を返します。
+func (s *householdService) getOwnedHousehold(ctx context.Context, userID string, id int) (models.Household, error) {
+	household, err := s.getHousehold(ctx, userID, id)
+	if err != nil {
+		return models.Household{}, err
+	}
+	if household.Role != models.HouseholdRoleOwner {
+		return models.Household{}, ErrHouseholdOwnerOnly
+	}
+	return household, nil
+}
+
+// ensureUser は初期設定済みのユーザーかを確認します。世帯のメンバーは users に登録されている必要があります。
+func (s *householdService) ensureUser(ctx context.Context, userID string) error {
+	if _, err := s.userRepo.GetUserByID(ctx, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return &NotFoundError{Message: "ユーザーが見つかりません"}
+		}
+		return err
+	}
+	return nil
+}
+
+// generateInvitationCode はランダムな招待コードを生成します。
+func generateInvitationCode() (string, error) {
+	b := make([]byte, householdInvitationCodeLen)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	// 文字種は32文字のため、下位5ビットで偏りなく選べる
+	for i := range b {
+		b[i] = householdInvitationCodeChars[b[i]&31]
+	}
+	return string(b), nil
+}
@@ -0,0 +1,552 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"money-buddy-backend/internal/models"
+)
+
+// mockHouseholdRepo は HouseholdRepository のモック実装です
+type mockHouseholdRepo struct {
+	getCurrentMembershipFunc func(ctx context.Context, userID string) (models.HouseholdMembership, error)
+	getHouseholdFunc         func(ctx context.Context, userID string, id int32) (models.Household, error)
+	listMembersFunc          func(ctx context.Context, id int32) ([]models.HouseholdMember, error)
+	ownsHouseholdFunc        func(ctx context.Context, userID string) (bool, error)
+	createHouseholdFunc      func(ctx context.Context, ownerID string, name string) (int32, error)
+	addMemberFunc            func(ctx context.Context, id int32, userID string, role string) (bool, error)
+	setCurrentHouseholdFunc  func(ctx context.Context, userID string, id *int32) error
+	updateMemberRoleFunc     func(ctx context.Context, id int32, userID string, role string) (bool, error)
+	deleteMemberFunc         func(ctx context.Context, id int32, userID string) (bool, error)
+	deleteHouseholdFunc      func(ctx context.Context, ownerID string, id int32) (bool, error)
+	createInvitationFunc     func(ctx context.Context, id int32, createdBy string, code string, role string, expiresInDays int) (models.HouseholdInvitation, error)
+	consumeInvitationFunc    func(ctx context.Context, code string) (int32, string, error)
+}
+
+func (m *mockHouseholdRepo) GetCurrentMembership(ctx context.Context, userID string) (models.HouseholdMembership, error) {
+	if m.getCurrentMembershipFunc != nil {
+		return m.getCurrentMembershipFunc(ctx, userID)
+	}
+	return models.HouseholdMembership{}, errors.New("not implemented")
+}
+
+func (m *mockHouseholdRepo) ListHouseholds(ctx context.Context, userID string) ([]models.Household, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockHouseholdRepo) GetHousehold(ctx context.Context, userID string, id int32) (models.Household, error) {
+	if m.getHouseholdFunc != nil {
+		return m.getHouseholdFunc(ctx, userID, id)
+	}
+	return models.Household{}, errors.New("not implemented")
+}
+
+func (m *mockHouseholdRepo) ListMembers(ctx context.Context, id int32) ([]models.HouseholdMember, error) {
+	if m.listMembersFunc != nil {
+		return m.listMembersFunc(ctx, id)
+	}
+	return nil, errors.New("not implemented")
+}
+
+func (m *mockHouseholdRepo) OwnsHousehold(ctx context.Context, userID string) (bool, error) {
+	if m.ownsHouseholdFunc != nil {
+		return m.ownsHouseholdFunc(ctx, userID)
+	}
+	return false, errors.New("not implemented")
+}
+
+func (m *mockHouseholdRepo) CreateHousehold(ctx context.Context, ownerID string, name string) (int32, error) {
+	if m.createHouseholdFunc != nil {
+		return m.createHouseholdFunc(ctx, ownerID, name)
+	}
+	return 0, errors.New("not implemented")
+}
+
+func (m *mockHouseholdRepo) AddMember(ctx context.Context, id int32, userID string, role string) (bool, error) {
+	if m.addMemberFunc != nil {
+		return m.addMemberFunc(ctx, id, userID, role)
+	}
+	return false, errors.New("not implemented")
+}
+
+func (m *mockHouseholdRepo) SetCurrentHousehold(ctx context.Context, userID string, id *int32) error {
+	if m.setCurrentHouseholdFunc != nil {
+		return m.setCurrentHouseholdFunc(ctx, userID, id)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *mockHouseholdRepo) UpdateMemberRole(ctx context.Context, id int32, userID string, role string) (bool, error) {
+	if m.updateMemberRoleFunc != nil {
+		return m.updateMemberRoleFunc(ctx, id, userID, role)
+	}
+	return false, errors.New("not implemented")
+}
+
+func (m *mockHouseholdRepo) DeleteMember(ctx context.Context, id int32, userID string) (bool, error) {
+	if m.deleteMemberFunc != nil {
+		return m.deleteMemberFunc(ctx, id, userID)
+	}
+	return false, errors.New("not implemented")
+}
+
+func (m *mockHouseholdRepo) DeleteHousehold(ctx context.Context, ownerID string, id int32) (bool, error) {
+	if m.deleteHouseholdFunc != nil {
+		return m.deleteHouseholdFunc(ctx, ownerID, id)
+	}
+	return false, errors.New("not implemented")
+}
+
+func (m *mockHouseholdRepo) CreateInvitation(ctx context.Context, id int32, createdBy string, code string, role string, expiresInDays int) (models.HouseholdInvitation, error) {
+	if m.createInvitationFunc != nil {
+		return m.createInvitationFunc(ctx, id, createdBy, code, role, expiresInDays)
+	}
+	return models.HouseholdInvitation{}, errors.New("not implemented")
+}
+
+func (m *mockHouseholdRepo) ConsumeInvitation(ctx context.Context, code string) (int32, string, error) {
+	if m.consumeInvitationFunc != nil {
+		return m.consumeInvitationFunc(ctx, code)
+	}
+	return 0, "", errors.New("not implemented")
+}
+
+// existingUserRepo は初期設定済みのユーザーを返す UserRepository です
+func existingUserRepo() *mockUserRepo {
+	return &mockUserRepo{
+		getUserByIDFunc: func(ctx context.Context, id string) (models.User, error) {
+			return models.User{ID: id}, nil
+		},
+	}
+}
+
+// householdWithRole は id 1 の世帯で role の役割を持つユーザーとして GetHousehold を返すモックです
+func householdWithRole(role string) func(ctx context.Context, userID string, id int32) (models.Household, error) {
+	return func(ctx context.Context, userID string, id int32) (models.Household, error) {
+		if id != 1 {
+			return models.Household{}, sql.ErrNoRows
+		}
+		return models.Household{ID: 1, Name: "わが家", OwnerID: "owner-user", Role: role}, nil
+	}
+}
+
+// TestCurrentMembership は選択中の世帯の解決のテストです
+func TestCurrentMembership(t *testing.T) {
+	t.Run("世帯を選択している", func(t *testing.T) {
+		repo := &mockHouseholdRepo{
+			getCurrentMembershipFunc: func(ctx context.Context, userID string) (models.HouseholdMembership, error) {
+				return models.HouseholdMembership{HouseholdID: 1, OwnerID: "owner-user", Role: models.HouseholdRoleMember}, nil
+			},
+		}
+		s := NewHouseholdService(repo, existingUserRepo(), new(txManagerMock))
+
+		m, err := s.CurrentMembership(context.Background(), "partner-user")
+		require.NoError(t, err)
+		require.NotNil(t, m)
+		assert.Equal(t, "owner-user", m.OwnerID)
+	})
+
+	t.Run("世帯を選択していない", func(t *testing.T) {
+		repo := &mockHouseholdRepo{
+			getCurrentMembershipFunc: func(ctx context.Context, userID string) (models.HouseholdMembership, error) {
+				return models.HouseholdMembership{}, sql.ErrNoRows
+			},
+		}
+		s := NewHouseholdService(repo, existingUserRepo(), new(txManagerMock))
+
+		m, err := s.CurrentMembership(context.Background(), "test-user")
+		require.NoError(t, err)
+		assert.Nil(t, m)
+	})
+}
+
+// TestCreateHousehold は世帯の作成（オーナーとして登録し、選択中にする）のテストです
+func TestCreateHousehold(t *testing.T) {
+	ctx := context.Background()
+	tm := new(txManagerMock)
+	tx := new(txMock)
+	tm.On("Begin", ctx).Return(tx, nil)
+	tx.On("Commit").Return(nil)
+
+	var calls []string
+	repo := &mockHouseholdRepo{
+		ownsHouseholdFunc: func(ctx context.Context, userID string) (bool, error) {
+			return false, nil
+		},
+		createHouseholdFunc: func(ctx context.Context, ownerID string, name string) (int32, error) {
+			assert.Equal(t, "owner-user", ownerID)
+			assert.Equal(t, "わが家", name)
+			calls = append(calls, "create")
+			return 1, nil
+		},
+		addMemberFunc: func(ctx context.Context, id int32, userID string, role string) (bool, error) {
+			assert.Equal(t, models.HouseholdRoleOwner, role)
+			calls = append(calls, "add:"+userID)
+			return true, nil
+		},
+		setCurrentHouseholdFunc: func(ctx context.Context, userID string, id *int32) error {
+			require.NotNil(t, id)
+			assert.Equal(t, int32(1), *id)
+			calls = append(calls, "current")
+			return nil
+		},
+		getHouseholdFunc: householdWithRole(models.HouseholdRoleOwner),
+	}
+	s := NewHouseholdService(repo, existingUserRepo(), tm)
+
+	household, err := s.CreateHousehold(ctx, "owner-user", "  わが家 ")
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, household.ID)
+	assert.Equal(t, []string{"create", "add:owner-user", "current"}, calls)
+	tx.AssertExpectations(t)
+}
+
+// TestCreateHousehold_Errors は世帯の作成ができない場合のテストです
+func TestCreateHousehold_Errors(t *testing.T) {
+	t.Run("世帯名が空", func(t *testing.T) {
+		s := NewHouseholdService(&mockHouseholdRepo{}, existingUserRepo(), new(txManagerMock))
+		_, err := s.CreateHousehold(context.Background(), "owner-user", " ")
+		var ve *ValidationError
+		assert.ErrorAs(t, err, &ve)
+	})
+
+	t.Run("世帯名が長すぎる", func(t *testing.T) {
+		s := NewHouseholdService(&mockHouseholdRepo{}, existingUserRepo(), new(txManagerMock))
+		_, err := s.CreateHousehold(context.Background(), "owner-user", strings.Repeat("家", HouseholdNameMaxLen+1))
+		var ve *ValidationError
+		assert.ErrorAs(t, err, &ve)
+	})
+
+	t.Run("初期設定前のユーザー", func(t *testing.T) {
+		users := &mockUserRepo{
+			getUserByIDFunc: func(ctx context.Context, id string) (models.User, error) {
+				return models.User{}, sql.ErrNoRows
+			},
+		}
+		s := NewHouseholdService(&mockHouseholdRepo{}, users, new(txManagerMock))
+		_, err := s.CreateHousehold(context.Background(), "new-user", "わが家")
+		var ne *NotFoundError
+		assert.ErrorAs(t, err, &ne)
+	})
+
+	t.Run("すでに世帯を作成している", func(t *testing.T) {
+		repo := &mockHouseholdRepo{
+			ownsHouseholdFunc: func(ctx context.Context, userID string) (bool, error) {
+				return true, nil
+			},
+		}
+		tm := new(txManagerMock)
+		s := NewHouseholdService(repo, existingUserRepo(), tm)
+		_, err := s.CreateHousehold(context.Background(), "owner-user", "わが家")
+		assert.ErrorIs(t, err, ErrHouseholdAlreadyOwned)
+		tm.AssertNotCalled(t, "Begin", context.Background())
+	})
+}
+
+// TestSwitchHousehold は選択中の世帯の切り替えのテストです
+func TestSwitchHousehold(t *testing.T) {
+	t.Run("所属している世帯に切り替える", func(t *testing.T) {
+		var got *int32
+		repo := &mockHouseholdRepo{
+			getHouseholdFunc: householdWithRole(models.HouseholdRoleMember),
+			setCurrentHouseholdFunc: func(ctx context.Context, userID string, id *int32) error {
+				got = id
+				return nil
+			},
+		}
+		s := NewHouseholdService(repo, existingUserRepo(), new(txManagerMock))
+
+		id := 1
+		require.NoError(t, s.SwitchHousehold(context.Background(), "partner-user", &id))
+		require.NotNil(t, got)
+		assert.Equal(t, int32(1), *got)
+	})
+
+	t.Run("自分の家計簿に戻す", func(t *testing.T) {
+		called := false
+		repo := &mockHouseholdRepo{
+			setCurrentHouseholdFunc: func(ctx context.Context, userID string, id *int32) error {
+				called = true
+				assert.Nil(t, id)
+				return nil
+			},
+		}
+		s := NewHouseholdService(repo, existingUserRepo(), new(txManagerMock))
+
+		require.NoError(t, s.SwitchHousehold(context.Background(), "partner-user", nil))
+		assert.True(t, called)
+	})
+
+	t.Run("所属していない世帯", func(t *testing.T) {
+		repo := &mockHouseholdRepo{getHouseholdFunc: householdWithRole(models.HouseholdRoleMember)}
+		s := NewHouseholdService(repo, existingUserRepo(), new(txManagerMock))
+
+		id := 2
+		err := s.SwitchHousehold(context.Background(), "partner-user", &id)
+		var ne *NotFoundError
+		assert.ErrorAs(t, err, &ne)
+	})
+}
+
+// TestCreateInvitation は招待コードの発行のテストです
+func TestCreateInvitation(t *testing.T) {
+	t.Run("オーナーが発行する", func(t *testing.T) {
+		var gotCode string
+		repo := &mockHouseholdRepo{
+			getHouseholdFunc: householdWithRole(models.HouseholdRoleOwner),
+			createInvitationFunc: func(ctx context.Context, id int32, createdBy string, code string, role string, expiresInDays int) (models.HouseholdInvitation, error) {
+				assert.Equal(t, "owner-user", createdBy)
+				assert.Equal(t, models.HouseholdRoleViewer, role)
+				assert.Equal(t, HouseholdInvitationExpiresInDays, expiresInDays)
+				gotCode = code
+				return models.HouseholdInvitation{Code: code, Role: role}, nil
+			},
+		}
+		s := NewHouseholdService(repo, existingUserRepo(), new(txManagerMock))
+
+		inv, err := s.CreateInvitation(context.Background(), "owner-user", 1, models.HouseholdRoleViewer)
+
+		require.NoError(t, err)
+		assert.Equal(t, gotCode, inv.Code)
+		assert.Len(t, inv.Code, householdInvitationCodeLen)
+		for _, r := range inv.Code {
+			assert.Contains(t, householdInvitationCodeChars, string(r))
+		}
+	})
+
+	t.Run("オーナー以外は発行できない", func(t *testing.T) {
+		repo := &mockHouseholdRepo{getHouseholdFunc: householdWithRole(models.HouseholdRoleMember)}
+		s := NewHouseholdService(repo, existingUserRepo(), new(txManagerMock))
+
+		_, err := s.CreateInvitation(context.Background(), "partner-user", 1, models.HouseholdRoleMember)
+		assert.ErrorIs(t, err, ErrHouseholdOwnerOnly)
+	})
+
+	t.Run("オーナーの役割では招待できない", func(t *testing.T) {
+		s := NewHouseholdService(&mockHouseholdRepo{}, existingUserRepo(), new(txManagerMock))
+
+		_, err := s.CreateInvitation(context.Background(), "owner-user", 1, models.HouseholdRoleOwner)
+		var ve *ValidationError
+		assert.ErrorAs(t, err, &ve)
+	})
+}
+
+// TestJoinHousehold は招待コードによる世帯への参加のテストです
+func TestJoinHousehold(t *testing.T) {
+	t.Run("招待コードの役割で参加し、選択中にする", func(t *testing.T) {
+		ctx := context.Background()
+		tm := new(txManagerMock)
+		tx := new(txMock)
+		tm.On("Begin", ctx).Return(tx, nil)
+		tx.On("Commit").Return(nil)
+
+		current := false
+		repo := &mockHouseholdRepo{
+			consumeInvitationFunc: func(ctx context.Context, code string) (int32, string, error) {
+				// 前後の空白を除き、大文字で照合する
+				assert.Equal(t, "ABCDEFGH23", code)
+				return 1, models.HouseholdRoleViewer, nil
+			},
+			addMemberFunc: func(ctx context.Context, id int32, userID string, role string) (bool, error) {
+				assert.Equal(t, "partner-user", userID)
+				assert.Equal(t, models.HouseholdRoleViewer, role)
+				return true, nil
+			},
+			setCurrentHouseholdFunc: func(ctx context.Context, userID string, id *int32) error {
+				current = true
+				return nil
+			},
+			getHouseholdFunc: householdWithRole(models.HouseholdRoleViewer),
+		}
+		s := NewHouseholdService(repo, existingUserRepo(), tm)
+
+		household, err := s.JoinHousehold(ctx, "partner-user", " abcdefgh23 ")
+
+		require.NoError(t, err)
+		assert.Equal(t, models.HouseholdRoleViewer, household.Role)
+		assert.True(t, current)
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("無効な招待コード", func(t *testing.T) {
+		ctx := context.Background()
+		tm := new(txManagerMock)
+		tx := new(txMock)
+		tm.On("Begin", ctx).Return(tx, nil)
+		tx.On("Rollback").Return(nil)
+
+		repo := &mockHouseholdRepo{
+			consumeInvitationFunc: func(ctx context.Context, code string) (int32, string, error) {
+				return 0, "", sql.ErrNoRows
+			},
+		}
+		s := NewHouseholdService(repo, existingUserRepo(), tm)
+
+		_, err := s.JoinHousehold(ctx, "partner-user", "EXPIRED000")
+
+		var ne *NotFoundError
+		assert.ErrorAs(t, err, &ne)
+		tx.AssertExpectations(t)
+	})
+
+	t.Run("すでにメンバーの場合は招待コードを使わない", func(t *testing.T) {
+		ctx := context.Background()
+		tm := new(txManagerMock)
+		tx := new(txMock)
+		tm.On("Begin", ctx).Return(tx, nil)
+		tx.On("Rollback").Return(nil)
+
+		repo := &mockHouseholdRepo{
+			consumeInvitationFunc: func(ctx context.Context, code string) (int32, string, error) {
+				return 1, models.HouseholdRoleMember, nil
+			},
+			addMemberFunc: func(ctx context.Context, id int32, userID string, role string) (bool, error) {
+				return false, nil
+			},
+		}
+		s := NewHouseholdService(repo, existingUserRepo(), tm)
+
+		_, err := s.JoinHousehold(ctx, "partner-user", "ABCDEFGH23")
+
+		assert.ErrorIs(t, err, ErrAlreadyHouseholdMember)
+		tx.AssertExpectations(t)
+		tx.AssertNotCalled(t, "Commit")
+	})
+}
+
+// TestUpdateMemberRole はメンバーの役割の変更のテストです
+func TestUpdateMemberRole(t *testing.T) {
+	t.Run("オーナーが変更する", func(t *testing.T) {
+		repo := &mockHouseholdRepo{
+			getHouseholdFunc: householdWithRole(models.HouseholdRoleOwner),
+			updateMemberRoleFunc: func(ctx context.Context, id int32, userID string, role string) (bool, error) {
+				assert.Equal(t, "partner-user", userID)
+				assert.Equal(t, models.HouseholdRoleViewer, role)
+				return true, nil
+			},
+		}
+		s := NewHouseholdService(repo, existingUserRepo(), new(txManagerMock))
+
+		assert.NoError(t, s.UpdateMemberRole(context.Background(), "owner-user", 1, "partner-user", models.HouseholdRoleViewer))
+	})
+
+	t.Run("メンバーが存在しない", func(t *testing.T) {
+		repo := &mockHouseholdRepo{
+			getHouseholdFunc: householdWithRole(models.HouseholdRoleOwner),
+			updateMemberRoleFunc: func(ctx context.Context, id int32, userID string, role string) (bool, error) {
+				return false, nil
+			},
+		}
+		s := NewHouseholdService(repo, existingUserRepo(), new(txManagerMock))
+
+		err := s.UpdateMemberRole(context.Background(), "owner-user", 1, "unknown-user", models.HouseholdRoleMember)
+		var ne *NotFoundError
+		assert.ErrorAs(t, err, &ne)
+	})
+
+	t.Run("オーナー自身の役割は変更できない", func(t *testing.T) {
+		repo := &mockHouseholdRepo{getHouseholdFunc: householdWithRole(models.HouseholdRoleOwner)}
+		s := NewHouseholdService(repo, existingUserRepo(), new(txManagerMock))
+
+		err := s.UpdateMemberRole(context.Background(), "owner-user", 1, "owner-user", models.HouseholdRoleViewer)
+		var ve *ValidationError
+		assert.ErrorAs(t, err, &ve)
+	})
+
+	t.Run("オーナー以外は変更できない", func(t *testing.T) {
+		repo := &mockHouseholdRepo{getHouseholdFunc: householdWithRole(models.HouseholdRoleMember)}
+		s := NewHouseholdService(repo, existingUserRepo(), new(txManagerMock))
+
+		err := s.UpdateMemberRole(context.Background(), "partner-user", 1, "viewer-user", models.HouseholdRoleMember)
+		assert.ErrorIs(t, err, ErrHouseholdOwnerOnly)
+	})
+}
+
+// TestRemoveMember はメンバーの削除・退出のテストです
+func TestRemoveMember(t *testing.T) {
+	deleted := func(got *string) func(ctx context.Context, id int32, userID string) (bool, error) {
+		return func(ctx context.Context, id int32, userID string) (bool, error) {
+			*got = userID
+			return true, nil
+		}
+	}
+
+	t.Run("オーナーがメンバーを外す", func(t *testing.T) {
+		var got string
+		repo := &mockHouseholdRepo{
+			getHouseholdFunc: householdWithRole(models.HouseholdRoleOwner),
+			deleteMemberFunc: deleted(&got),
+		}
+		s := NewHouseholdService(repo, existingUserRepo(), new(txManagerMock))
+
+		require.NoError(t, s.RemoveMember(context.Background(), "owner-user", 1, "partner-user"))
+		assert.Equal(t, "partner-user", got)
+	})
+
+	t.Run("メンバーが自分で退出する", func(t *testing.T) {
+		var got string
+		repo := &mockHouseholdRepo{
+			getHouseholdFunc: householdWithRole(models.HouseholdRoleViewer),
+			deleteMemberFunc: deleted(&got),
+		}
+		s := NewHouseholdService(repo, existingUserRepo(), new(txManagerMock))
+
+		require.NoError(t, s.RemoveMember(context.Background(), "viewer-user", 1, "viewer-user"))
+		assert.Equal(t, "viewer-user", got)
+	})
+
+	t.Run("メンバーは他のメンバーを外せない", func(t *testing.T) {
+		repo := &mockHouseholdRepo{getHouseholdFunc: householdWithRole(models.HouseholdRoleMember)}
+		s := NewHouseholdService(repo, existingUserRepo(), new(txManagerMock))
+
+		err := s.RemoveMember(context.Background(), "partner-user", 1, "viewer-user")
+		assert.ErrorIs(t, err, ErrHouseholdOwnerOnly)
+	})
+
+	t.Run("オーナーは退出できない", func(t *testing.T) {
+		repo := &mockHouseholdRepo{getHouseholdFunc: householdWithRole(models.HouseholdRoleOwner)}
+		s := NewHouseholdService(repo, existingUserRepo(), new(txManagerMock))
+
+		err := s.RemoveMember(context.Background(), "owner-user", 1, "owner-user")
+		var ve *ValidationError
+		assert.ErrorAs(t, err, &ve)
+	})
+}
+
+// TestDeleteHousehold は世帯の削除のテストです
+func TestDeleteHousehold(t *testing.T) {
+	t.Run("オーナーが削除する", func(t *testing.T) {
+		repo := &mockHouseholdRepo{
+			getHouseholdFunc: householdWithRole(models.HouseholdRoleOwner),
+			deleteHouseholdFunc: func(ctx context.Context, ownerID string, id int32) (bool, error) {
+				assert.Equal(t, "owner-user", ownerID)
+				return true, nil
+			},
+		}
+		s := NewHouseholdService(repo, existingUserRepo(), new(txManagerMock))
+
+		assert.NoError(t, s.DeleteHousehold(context.Background(), "owner-user", 1))
+	})
+
+	t.Run("オーナー以外は削除できない", func(t *testing.T) {
+		repo := &mockHouseholdRepo{getHouseholdFunc: householdWithRole(models.HouseholdRoleMember)}
+		s := NewHouseholdService(repo, existingUserRepo(), new(txManagerMock))
+
+		assert.ErrorIs(t, s.DeleteHousehold(context.Background(), "partner-user", 1), ErrHouseholdOwnerOnly)
+	})
+
+	t.Run("所属していない世帯", func(t *testing.T) {
+		repo := &mockHouseholdRepo{getHouseholdFunc: householdWithRole(models.HouseholdRoleOwner)}
+		s := NewHouseholdService(repo, existingUserRepo(), new(txManagerMock))
+
+		var ne *NotFoundError
+		assert.ErrorAs(t, s.DeleteHousehold(context.Background(), "owner-user", 2), &ne)
+	})
+}
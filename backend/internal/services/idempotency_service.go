@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/repositories"
+)
+
+// IdempotencyService は POST リクエストの Idempotency-Key を保存するサービスのインターフェースです。
+// キーはユーザーごとに24時間有効です。
+type IdempotencyService interface {
+	// Reserve はキーを処理中として予約します。予約できた場合は nil を返し、
+	// 有効なキーがすでにある場合は保存済みの記録（処理中を含む）を返します。
+	Reserve(ctx context.Context, userID string, key string, requestHash string) (*models.IdempotencyRecord, error)
+	// Save は処理を終えたリクエストのレスポンスを保存します。
+	Save(ctx context.Context, userID string, key string, record models.IdempotencyRecord) error
+	// Release は予約を取り消し、同じキーで再試行できるようにします。
+	Release(ctx context.Context, userID string, key string) error
+	// PurgeExpired は有効期限を過ぎたキーを削除し、削除件数を返します。
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+type idempotencyService struct {
+	repo repositories.IdempotencyRepository
+}
+
+func NewIdempotencyService(repo repositories.IdempotencyRepository) IdempotencyService {
+	return &idempotencyService{repo: repo}
+}
+
+func (s *idempotencyService) Reserve(ctx context.Context, userID string, key string, requestHash string) (*models.IdempotencyRecord, error) {
+	// 予約に失敗してから取得するまでの間にキーが失効した場合は、予約をやり直す
+	for range 2 {
+		reserved, err := s.repo.ReserveIdempotencyKey(ctx, userID, key, requestHash)
+		if err != nil {
+			return nil, err
+		}
+		if reserved {
+			return nil, nil
+		}
+
+		record, err := s.repo.GetIdempotencyKey(ctx, userID, key)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &record, nil
+	}
+	// 他のリクエストと競合し続けている場合は処理中として扱い、再試行してもらう
+	return &models.IdempotencyRecord{RequestHash: requestHash}, nil
+}
+
+func (s *idempotencyService) Save(ctx context.Context, userID string, key string, record models.IdempotencyRecord) error {
+	return s.repo.SaveIdempotencyResponse(ctx, userID, key, record)
+}
+
+func (s *idempotencyService) Release(ctx context.Context, userID string, key string) error {
+	return s.repo.DeleteIdempotencyKey(ctx, userID, key)
+}
+
+func (s *idempotencyService) PurgeExpired(ctx context.Context) (int64, error) {
+	return s.repo.DeleteExpiredIdempotencyKeys(ctx)
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"money-buddy-backend/internal/models"
+)
+
+// mockIdempotencyRepo は IdempotencyRepository のモック実装です
+type mockIdempotencyRepo struct {
+	reserveFunc func(ctx context.Context, userID, key, requestHash string) (bool, error)
+	getFunc     func(ctx context.Context, userID, key string) (models.IdempotencyRecord, error)
+	saveFunc    func(ctx context.Context, userID, key string, record models.IdempotencyRecord) error
+	deleteFunc  func(ctx context.Context, userID, key string) error
+}
+
+func (m *mockIdempotencyRepo) ReserveIdempotencyKey(ctx context.Context, userID string, key string, requestHash string) (bool, error) {
+	if m.reserveFunc != nil {
+		return m.reserveFunc(ctx, userID, key, requestHash)
+	}
+	return false, errors.New("not implemented")
+}
+
+func (m *mockIdempotencyRepo) GetIdempotencyKey(ctx context.Context, userID string, key string) (models.IdempotencyRecord, error) {
+	if m.getFunc != nil {
+		return m.getFunc(ctx, userID, key)
+	}
+	return models.IdempotencyRecord{}, errors.New("not implemented")
+}
+
+func (m *mockIdempotencyRepo) SaveIdempotencyResponse(ctx context.Context, userID string, key string, record models.IdempotencyRecord) error {
+	if m.saveFunc != nil {
+		return m.saveFunc(ctx, userID, key, record)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *mockIdempotencyRepo) DeleteIdempotencyKey(ctx context.Context, userID string, key string) error {
+	if m.deleteFunc != nil {
+		return m.deleteFunc(ctx, userID, key)
+	}
+	return errors.New("not implemented")
+}
+
+func (m *mockIdempotencyRepo) DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+
+// TestIdempotencyReserve はキーの予約と保存済みの記録の取得のテストです
+func TestIdempotencyReserve(t *testing.T) {
+	t.Run("新しいキーは予約できる", func(t *testing.T) {
+		svc := NewIdempotencyService(&mockIdempotencyRepo{
+			reserveFunc: func(ctx context.Context, userID, key, requestHash string) (bool, error) {
+				assert.Equal(t, "user-1", userID)
+				assert.Equal(t, "key-1", key)
+				assert.Equal(t, "hash", requestHash)
+				return true, nil
+			},
+		})
+
+		record, err := svc.Reserve(context.Background(), "user-1", "key-1", "hash")
+		require.NoError(t, err)
+		assert.Nil(t, record)
+	})
+
+	t.Run("保存済みのキーは記録を返す", func(t *testing.T) {
+		svc := NewIdempotencyService(&mockIdempotencyRepo{
+			reserveFunc: func(ctx context.Context, userID, key, requestHash string) (bool, error) {
+				return false, nil
+			},
+			getFunc: func(ctx context.Context, userID, key string) (models.IdempotencyRecord, error) {
+				return models.IdempotencyRecord{RequestHash: "hash", StatusCode: 201, Body: []byte(`{}`)}, nil
+			},
+		})
+
+		record, err := svc.Reserve(context.Background(), "user-1", "key-1", "hash")
+		require.NoError(t, err)
+		require.NotNil(t, record)
+		assert.Equal(t, 201, record.StatusCode)
+		assert.False(t, record.InProgress())
+	})
+
+	t.Run("取得までに失効したキーは予約をやり直す", func(t *testing.T) {
+		reserveCalls := 0
+		svc := NewIdempotencyService(&mockIdempotencyRepo{
+			reserveFunc: func(ctx context.Context, userID, key, requestHash string) (bool, error) {
+				reserveCalls++
+				return reserveCalls == 2, nil
+			},
+			getFunc: func(ctx context.Context, userID, key string) (models.IdempotencyRecord, error) {
+				return models.IdempotencyRecord{}, sql.ErrNoRows
+			},
+		})
+
+		record, err := svc.Reserve(context.Background(), "user-1", "key-1", "hash")
+		require.NoError(t, err)
+		assert.Nil(t, record)
+		assert.Equal(t, 2, reserveCalls)
+	})
+
+	t.Run("競合が続く場合は処理中として扱う", func(t *testing.T) {
+		svc := NewIdempotencyService(&mockIdempotencyRepo{
+			reserveFunc: func(ctx context.Context, userID, key, requestHash string) (bool, error) {
+				return false, nil
+			},
+			getFunc: func(ctx context.Context, userID, key string) (models.IdempotencyRecord, error) {
+				return models.IdempotencyRecord{}, sql.ErrNoRows
+			},
+		})
+
+		record, err := svc.Reserve(context.Background(), "user-1", "key-1", "hash")
+		require.NoError(t, err)
+		require.NotNil(t, record)
+		assert.True(t, record.InProgress())
+	})
+
+	t.Run("リポジトリのエラーを返す", func(t *testing.T) {
+		svc := NewIdempotencyService(&mockIdempotencyRepo{
+			reserveFunc: func(ctx context.Context, userID, key, requestHash string) (bool, error) {
+				return false, errors.New("db down")
+			},
+		})
+
+		_, err := svc.Reserve(context.Background(), "user-1", "key-1", "hash")
+		require.Error(t, err)
+	})
+}
@@ -5,26 +5,32 @@ import (
 	"database/sql"
 	"errors"
 	"strings"
+	"time"
 
 	"money-buddy-backend/internal/models"
 	"money-buddy-backend/internal/repositories"
 )
 
 type InitialSetupService interface {
+	// CompleteInitialSetup は収入・貯金目標を登録し、固定費を入れ替えます。変更前後の内容を1件の変更履歴として記録します。
 	CompleteInitialSetup(ctx context.Context, userID string, income, savingGoal int, fixedCosts []models.FixedCostInput) error
 }
 
 type initialSetupService struct {
 	userRepo      repositories.UserRepository
 	fixedCostRepo repositories.FixedCostRepository
+	auditRepo     repositories.AuditRepository
 	txManager     TxManager
+	now           func() time.Time
 }
 
-func NewInitialSetupService(userRepo repositories.UserRepository, fixedCostRepo repositories.FixedCostRepository, txManager TxManager) InitialSetupService {
+func NewInitialSetupService(userRepo repositories.UserRepository, fixedCostRepo repositories.FixedCostRepository, auditRepo repositories.AuditRepository, txManager TxManager) InitialSetupService {
 	return &initialSetupService{
 		userRepo:      userRepo,
 		fixedCostRepo: fixedCostRepo,
+		auditRepo:     auditRepo,
 		txManager:     txManager,
+		now:           time.Now,
 	}
 }
 
@@ -52,13 +58,22 @@ func (s *initialSetupService) CompleteInitialSetup(ctx context.Context, userID s
 			return &ValidationError{Message: "固定費の名前は100文字以内で入力してください"}
 		}
 
+		schedule := fc.FixedCostSchedule
+		if err := normalizeFixedCostSchedule(&schedule); err != nil {
+			return err
+		}
+
 		// 正規化された値を使用
 		normalizedFixedCosts[i] = models.FixedCostInput{
-			Name:   trimmedName,
-			Amount: fc.Amount,
+			Name:              trimmedName,
+			Amount:            fc.Amount,
+			FixedCostSchedule: schedule,
 		}
 	}
 
+	// 初期設定の内容は当月から適用する
+	month := monthStart(s.now())
+
 	tx, err := s.txManager.Begin(ctx)
 	if err != nil {
 		return err
@@ -66,10 +81,13 @@ func (s *initialSetupService) CompleteInitialSetup(ctx context.Context, userID s
 
 	txCtx := tx.Context(ctx)
 
+	// 変更履歴の変更前の内容（初回の初期設定では null）
+	var before map[string]any
+
 	user, err := s.userRepo.GetUserByID(txCtx, userID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			if err := s.userRepo.CreateUser(txCtx, userID, income, savingGoal); err != nil {
+			if err := s.userRepo.CreateUser(txCtx, userID, income, savingGoal, month); err != nil {
 				_ = tx.Rollback()
 				return err
 			}
@@ -78,18 +96,32 @@ func (s *initialSetupService) CompleteInitialSetup(ctx context.Context, userID s
 			return err
 		}
 	} else if user != (models.User{}) {
-		if err := s.userRepo.UpdateUserSettings(txCtx, userID, income, savingGoal); err != nil {
+		currentFixedCosts, err := s.fixedCostRepo.ListFixedCostsByUser(txCtx, userID)
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		before = map[string]any{"income": user.Income, "saving_goal": user.SavingGoal, "fixed_costs": currentFixedCosts}
+
+		if err := s.userRepo.UpdateUserSettings(txCtx, userID, income, savingGoal, month); err != nil {
 			_ = tx.Rollback()
 			return err
 		}
 	}
 
-	if err := s.fixedCostRepo.DeleteFixedCostsByUser(txCtx, userID); err != nil {
+	// 既存の固定費は過去月の集計に残すため、削除せずに当月で解約扱いにする
+	if err := s.fixedCostRepo.EndFixedCostsByUser(txCtx, userID, month); err != nil {
 		_ = tx.Rollback()
 		return err
 	}
 	// 正規化された固定費を使用
-	if err := s.fixedCostRepo.BulkCreateFixedCosts(txCtx, userID, normalizedFixedCosts); err != nil {
+	if err := s.fixedCostRepo.BulkCreateFixedCosts(txCtx, userID, normalizedFixedCosts, month); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	after := map[string]any{"income": income, "saving_goal": savingGoal, "effective_from": month.Format("2006-01"), "fixed_costs": normalizedFixedCosts}
+	if err := recordAudit(txCtx, s.auditRepo, userID, models.AuditEntityInitialSetup, 0, models.AuditActionUpdate, before, after); err != nil {
 		_ = tx.Rollback()
 		return err
 	}
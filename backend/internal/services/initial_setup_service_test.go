@@ -5,9 +5,11 @@ import (
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"money-buddy-backend/internal/models"
 )
@@ -34,6 +36,14 @@ func (m *txMock) Context(ctx context.Context) context.Context {
 	return ctx
 }
 
+func (m *txMock) Savepoint(ctx context.Context, name string) error {
+	return nil
+}
+
+func (m *txMock) RollbackToSavepoint(ctx context.Context, name string) error {
+	return nil
+}
+
 func (m *txManagerMock) Begin(ctx context.Context) (Tx, error) {
 	args := m.Called(ctx)
 	if tx, ok := args.Get(0).(Tx); ok {
@@ -42,8 +52,8 @@ func (m *txManagerMock) Begin(ctx context.Context) (Tx, error) {
 	return nil, args.Error(1)
 }
 
-func (m *userRepoMock) CreateUser(ctx context.Context, id string, income int, savingGoal int) error {
-	args := m.Called(ctx, id, income, savingGoal)
+func (m *userRepoMock) CreateUser(ctx context.Context, id string, income int, savingGoal int, effectiveFrom time.Time) error {
+	args := m.Called(ctx, id, income, savingGoal, effectiveFrom)
 	return args.Error(0)
 }
 
@@ -55,13 +65,13 @@ func (m *userRepoMock) GetUserByID(ctx context.Context, id string) (models.User,
 	return models.User{}, args.Error(1)
 }
 
-func (m *userRepoMock) UpdateUserSettings(ctx context.Context, id string, income int, savingGoal int) error {
-	args := m.Called(ctx, id, income, savingGoal)
+func (m *userRepoMock) UpdateUserSettings(ctx context.Context, id string, income int, savingGoal int, effectiveFrom time.Time) error {
+	args := m.Called(ctx, id, income, savingGoal, effectiveFrom)
 	return args.Error(0)
 }
 
-func (m *fixedCostRepoMock) CreateFixedCost(ctx context.Context, userID string, name string, amount int) (models.FixedCost, error) {
-	args := m.Called(ctx, userID, name, amount)
+func (m *fixedCostRepoMock) CreateFixedCost(ctx context.Context, userID string, name string, amount int, schedule models.FixedCostSchedule, effectiveFrom time.Time) (models.FixedCost, error) {
+	args := m.Called(ctx, userID, name, amount, schedule, effectiveFrom)
 	if fc, ok := args.Get(0).(models.FixedCost); ok {
 		return fc, args.Error(1)
 	}
@@ -76,31 +86,39 @@ func (m *fixedCostRepoMock) ListFixedCostsByUser(ctx context.Context, userID str
 	return nil, args.Error(1)
 }
 
-func (m *fixedCostRepoMock) DeleteFixedCostsByUser(ctx context.Context, userID string) error {
-	args := m.Called(ctx, userID)
+func (m *fixedCostRepoMock) EndFixedCostsByUser(ctx context.Context, userID string, endedFrom time.Time) error {
+	args := m.Called(ctx, userID, endedFrom)
 	return args.Error(0)
 }
 
-func (m *fixedCostRepoMock) BulkCreateFixedCosts(ctx context.Context, userID string, fixedCosts []models.FixedCostInput) error {
-	args := m.Called(ctx, userID, fixedCosts)
+func (m *fixedCostRepoMock) BulkCreateFixedCosts(ctx context.Context, userID string, fixedCosts []models.FixedCostInput, effectiveFrom time.Time) error {
+	args := m.Called(ctx, userID, fixedCosts, effectiveFrom)
 	return args.Error(0)
 }
 
-func (m *fixedCostRepoMock) UpdateFixedCost(ctx context.Context, id int32, userID string, name string, amount int) error {
-	args := m.Called(ctx, id, userID, name, amount)
-	return args.Error(0)
+func (m *fixedCostRepoMock) UpdateFixedCost(ctx context.Context, id int32, userID string, name string, amount int, schedule models.FixedCostSchedule, effectiveFrom time.Time, version int) (bool, error) {
+	args := m.Called(ctx, id, userID, name, amount, schedule, effectiveFrom, version)
+	return args.Bool(0), args.Error(1)
 }
 
-func (m *fixedCostRepoMock) DeleteFixedCost(ctx context.Context, id int32, userID string) error {
-	args := m.Called(ctx, id, userID)
+func (m *fixedCostRepoMock) EndFixedCost(ctx context.Context, id int32, userID string, endedFrom time.Time) error {
+	args := m.Called(ctx, id, userID, endedFrom)
 	return args.Error(0)
 }
 
+func (m *fixedCostRepoMock) RestoreFixedCost(ctx context.Context, id int32, userID string, deletedAfter time.Time) (bool, error) {
+	args := m.Called(ctx, id, userID, deletedAfter)
+	return args.Bool(0), args.Error(1)
+}
+
 func TestCompleteInitialSetup(t *testing.T) {
 	userID := "user-1"
+	// 初期設定の内容は当月（2025-06）から適用される
+	now := func() time.Time { return time.Date(2025, 6, 18, 9, 0, 0, 0, time.UTC) }
+	month := date("2025-06-01")
 	validFixedCosts := []models.FixedCostInput{
-		{Name: "rent", Amount: 50000},
-		{Name: "phone", Amount: 6000},
+		{Name: "rent", Amount: 50000, FixedCostSchedule: models.FixedCostSchedule{Frequency: "monthly"}},
+		{Name: "car tax", Amount: 34500, FixedCostSchedule: models.FixedCostSchedule{Frequency: "yearly", BillingMonth: intPtr(5)}},
 	}
 
 	cases := []struct {
@@ -123,14 +141,14 @@ func TestCompleteInitialSetup(t *testing.T) {
 			setupMocks: func(tx *txMock, tm *txManagerMock, ur *userRepoMock, fr *fixedCostRepoMock, calls *[]string) {
 				tm.On("Begin", mock.Anything).Run(func(args mock.Arguments) { *calls = append(*calls, "begin") }).Return(tx, nil)
 				ur.On("GetUserByID", mock.Anything, userID).Run(func(args mock.Arguments) { *calls = append(*calls, "get_user") }).Return(models.User{}, sql.ErrNoRows)
-				ur.On("CreateUser", mock.Anything, userID, 300000, 50000).Run(func(args mock.Arguments) { *calls = append(*calls, "create_user") }).Return(nil)
-				fr.On("DeleteFixedCostsByUser", mock.Anything, userID).Run(func(args mock.Arguments) { *calls = append(*calls, "delete_fixed") }).Return(nil)
-				fr.On("BulkCreateFixedCosts", mock.Anything, userID, validFixedCosts).Run(func(args mock.Arguments) { *calls = append(*calls, "bulk_create") }).Return(nil)
+				ur.On("CreateUser", mock.Anything, userID, 300000, 50000, month).Run(func(args mock.Arguments) { *calls = append(*calls, "create_user") }).Return(nil)
+				fr.On("EndFixedCostsByUser", mock.Anything, userID, month).Run(func(args mock.Arguments) { *calls = append(*calls, "end_fixed") }).Return(nil)
+				fr.On("BulkCreateFixedCosts", mock.Anything, userID, validFixedCosts, month).Run(func(args mock.Arguments) { *calls = append(*calls, "bulk_create") }).Return(nil)
 				tx.On("Commit").Run(func(args mock.Arguments) { *calls = append(*calls, "commit") }).Return(nil)
 			},
 			wantCommit:   true,
 			wantRollback: false,
-			wantCalls:    []string{"begin", "get_user", "create_user", "delete_fixed", "bulk_create", "commit"},
+			wantCalls:    []string{"begin", "get_user", "create_user", "end_fixed", "bulk_create", "commit"},
 		},
 		{
 			name:       "固定費名が前後に空白を含む場合トリムされる",
@@ -143,14 +161,14 @@ func TestCompleteInitialSetup(t *testing.T) {
 			setupMocks: func(tx *txMock, tm *txManagerMock, ur *userRepoMock, fr *fixedCostRepoMock, calls *[]string) {
 				tm.On("Begin", mock.Anything).Return(tx, nil)
 				ur.On("GetUserByID", mock.Anything, userID).Return(models.User{}, sql.ErrNoRows)
-				ur.On("CreateUser", mock.Anything, userID, 300000, 50000).Return(nil)
-				fr.On("DeleteFixedCostsByUser", mock.Anything, userID).Return(nil)
-				// トリム後の値で呼ばれることを確認
+				ur.On("CreateUser", mock.Anything, userID, 300000, 50000, month).Return(nil)
+				fr.On("EndFixedCostsByUser", mock.Anything, userID, month).Return(nil)
+				// トリム後の値・省略した支払い周期は毎月として呼ばれることを確認
 				trimmedFixedCosts := []models.FixedCostInput{
-					{Name: "rent", Amount: 50000},
-					{Name: "phone", Amount: 6000},
+					{Name: "rent", Amount: 50000, FixedCostSchedule: models.FixedCostSchedule{Frequency: "monthly"}},
+					{Name: "phone", Amount: 6000, FixedCostSchedule: models.FixedCostSchedule{Frequency: "monthly"}},
 				}
-				fr.On("BulkCreateFixedCosts", mock.Anything, userID, trimmedFixedCosts).Return(nil)
+				fr.On("BulkCreateFixedCosts", mock.Anything, userID, trimmedFixedCosts, month).Return(nil)
 				tx.On("Commit").Return(nil)
 			},
 			wantCommit:   true,
@@ -165,6 +183,15 @@ func TestCompleteInitialSetup(t *testing.T) {
 			wantErr:      true,
 			wantValidate: true,
 		},
+		{
+			name:         "毎年の固定費で請求月がない場合はエラー",
+			income:       100,
+			savingGoal:   0,
+			fixedCosts:   []models.FixedCostInput{{Name: "car tax", Amount: 34500, FixedCostSchedule: models.FixedCostSchedule{Frequency: "yearly"}}},
+			setupMocks:   func(tx *txMock, tm *txManagerMock, ur *userRepoMock, fr *fixedCostRepoMock, calls *[]string) {},
+			wantErr:      true,
+			wantValidate: true,
+		},
 		{
 			name:         "固定費 amount が 0 以下でエラー",
 			income:       100,
@@ -209,14 +236,15 @@ func TestCompleteInitialSetup(t *testing.T) {
 			setupMocks: func(tx *txMock, tm *txManagerMock, ur *userRepoMock, fr *fixedCostRepoMock, calls *[]string) {
 				tm.On("Begin", mock.Anything).Run(func(args mock.Arguments) { *calls = append(*calls, "begin") }).Return(tx, nil)
 				ur.On("GetUserByID", mock.Anything, userID).Run(func(args mock.Arguments) { *calls = append(*calls, "get_user") }).Return(models.User{ID: userID}, nil)
-				ur.On("UpdateUserSettings", mock.Anything, userID, 100, 0).Run(func(args mock.Arguments) { *calls = append(*calls, "update_user") }).Return(nil)
-				fr.On("DeleteFixedCostsByUser", mock.Anything, userID).Run(func(args mock.Arguments) { *calls = append(*calls, "delete_fixed") }).Return(errors.New("delete failed"))
+				fr.On("ListFixedCostsByUser", mock.Anything, userID).Run(func(args mock.Arguments) { *calls = append(*calls, "list_fixed") }).Return([]models.FixedCost{}, nil)
+				ur.On("UpdateUserSettings", mock.Anything, userID, 100, 0, month).Run(func(args mock.Arguments) { *calls = append(*calls, "update_user") }).Return(nil)
+				fr.On("EndFixedCostsByUser", mock.Anything, userID, month).Run(func(args mock.Arguments) { *calls = append(*calls, "end_fixed") }).Return(errors.New("delete failed"))
 				tx.On("Rollback").Run(func(args mock.Arguments) { *calls = append(*calls, "rollback") }).Return(nil)
 			},
 			wantErr:      true,
 			wantCommit:   false,
 			wantRollback: true,
-			wantCalls:    []string{"begin", "get_user", "update_user", "delete_fixed", "rollback"},
+			wantCalls:    []string{"begin", "get_user", "list_fixed", "update_user", "end_fixed", "rollback"},
 		},
 		{
 			name:       "fixed_costs 作成失敗で rollback",
@@ -226,15 +254,16 @@ func TestCompleteInitialSetup(t *testing.T) {
 			setupMocks: func(tx *txMock, tm *txManagerMock, ur *userRepoMock, fr *fixedCostRepoMock, calls *[]string) {
 				tm.On("Begin", mock.Anything).Run(func(args mock.Arguments) { *calls = append(*calls, "begin") }).Return(tx, nil)
 				ur.On("GetUserByID", mock.Anything, userID).Run(func(args mock.Arguments) { *calls = append(*calls, "get_user") }).Return(models.User{ID: userID}, nil)
-				ur.On("UpdateUserSettings", mock.Anything, userID, 100, 0).Run(func(args mock.Arguments) { *calls = append(*calls, "update_user") }).Return(nil)
-				fr.On("DeleteFixedCostsByUser", mock.Anything, userID).Run(func(args mock.Arguments) { *calls = append(*calls, "delete_fixed") }).Return(nil)
-				fr.On("BulkCreateFixedCosts", mock.Anything, userID, validFixedCosts).Run(func(args mock.Arguments) { *calls = append(*calls, "bulk_create") }).Return(errors.New("bulk failed"))
+				fr.On("ListFixedCostsByUser", mock.Anything, userID).Run(func(args mock.Arguments) { *calls = append(*calls, "list_fixed") }).Return([]models.FixedCost{}, nil)
+				ur.On("UpdateUserSettings", mock.Anything, userID, 100, 0, month).Run(func(args mock.Arguments) { *calls = append(*calls, "update_user") }).Return(nil)
+				fr.On("EndFixedCostsByUser", mock.Anything, userID, month).Run(func(args mock.Arguments) { *calls = append(*calls, "end_fixed") }).Return(nil)
+				fr.On("BulkCreateFixedCosts", mock.Anything, userID, validFixedCosts, month).Run(func(args mock.Arguments) { *calls = append(*calls, "bulk_create") }).Return(errors.New("bulk failed"))
 				tx.On("Rollback").Run(func(args mock.Arguments) { *calls = append(*calls, "rollback") }).Return(nil)
 			},
 			wantErr:      true,
 			wantCommit:   false,
 			wantRollback: true,
-			wantCalls:    []string{"begin", "get_user", "update_user", "delete_fixed", "bulk_create", "rollback"},
+			wantCalls:    []string{"begin", "get_user", "list_fixed", "update_user", "end_fixed", "bulk_create", "rollback"},
 		},
 	}
 
@@ -253,7 +282,7 @@ func TestCompleteInitialSetup(t *testing.T) {
 				tc.setupMocks(tx, tm, ur, fr, &calls)
 			}
 
-			s := NewInitialSetupService(ur, fr, tm)
+			s := &initialSetupService{userRepo: ur, fixedCostRepo: fr, auditRepo: &mockAuditRepo{}, txManager: tm, now: now}
 			err := s.CompleteInitialSetup(context.Background(), userID, tc.income, tc.savingGoal, tc.fixedCosts)
 
 			if tc.wantErr {
@@ -288,3 +317,38 @@ func TestCompleteInitialSetup(t *testing.T) {
 		})
 	}
 }
+
+func TestCompleteInitialSetup_RecordsAudit(t *testing.T) {
+	userID := "user-1"
+	now := func() time.Time { return time.Date(2025, 6, 18, 9, 0, 0, 0, time.UTC) }
+	month := date("2025-06-01")
+	fixedCosts := []models.FixedCostInput{{Name: "rent", Amount: 50000, FixedCostSchedule: models.FixedCostSchedule{Frequency: "monthly"}}}
+
+	tx := &txMock{}
+	tm := &txManagerMock{}
+	ur := &userRepoMock{}
+	fr := &fixedCostRepoMock{}
+	tm.On("Begin", mock.Anything).Return(tx, nil)
+	ur.On("GetUserByID", mock.Anything, userID).Return(models.User{ID: userID, Income: 250000, SavingGoal: 20000}, nil)
+	fr.On("ListFixedCostsByUser", mock.Anything, userID).Return([]models.FixedCost{{ID: 3, Name: "phone", Amount: 6000}}, nil)
+	ur.On("UpdateUserSettings", mock.Anything, userID, 300000, 50000, month).Return(nil)
+	fr.On("EndFixedCostsByUser", mock.Anything, userID, month).Return(nil)
+	fr.On("BulkCreateFixedCosts", mock.Anything, userID, fixedCosts, month).Return(nil)
+	tx.On("Commit").Return(nil)
+	auditRepo := &mockAuditRepo{}
+
+	s := &initialSetupService{userRepo: ur, fixedCostRepo: fr, auditRepo: auditRepo, txManager: tm, now: now}
+	err := s.CompleteInitialSetup(context.Background(), userID, 300000, 50000, fixedCosts)
+	require.NoError(t, err)
+
+	// 変更前の設定と固定費、変更後の内容を1件の履歴として記録する
+	require.Len(t, auditRepo.created, 1)
+	event := auditRepo.created[0]
+	assert.Equal(t, models.AuditEntityInitialSetup, event.EntityType)
+	assert.Nil(t, event.EntityID)
+	assert.Contains(t, event.Before, `"income":250000`)
+	assert.Contains(t, event.Before, `"name":"phone"`)
+	assert.Contains(t, event.After, `"income":300000`)
+	assert.Contains(t, event.After, `"effective_from":"2025-06"`)
+	tx.AssertCalled(t, "Commit")
+}
@@ -0,0 +1,643 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/repositories"
+)
+
+const (
+	// RecurringWindowDays は繰り返しルールから予定支出を先行して生成する期間（今日から何日先まで）
+	RecurringWindowDays = 60
+)
+
+// RecurringExpenseService は繰り返しの予定支出ルールを扱うサービスです。
+// ルールの各回は ExpenseService.CreateExpense を通じて planned の支出として生成されます。
+// 生成済みの回の変更・削除も ExpenseService を通じて行い、支出の変更と同じ検証・変更履歴の記録を行います。
+type RecurringExpenseService interface {
+	ListRules(ctx context.Context, userID string) ([]models.RecurringRule, error)
+	// CreateRule はルールを作成し、生成期間内の予定支出を生成します。
+	CreateRule(ctx context.Context, userID string, input models.RecurringRuleInput) (models.RecurringRule, error)
+	// UpdateOccurrence は date（YYYY-MM-DD）の回のみを変更します。ルール自体は変更しません。
+	UpdateOccurrence(ctx context.Context, userID string, id int, date string, input models.RecurringOccurrenceInput) (models.Expense, error)
+	// UpdateFutureOccurrences は from（YYYY-MM-DD、空の場合は今日）以降の回をすべて新しいルールで置き換えます。
+	// 確定済みの支出は変更しません。
+	UpdateFutureOccurrences(ctx context.Context, userID string, id int, from string, input models.RecurringRuleInput) (models.RecurringRule, error)
+	// DeleteOccurrence は date の回のみを削除します。削除した回は再生成されません。
+	DeleteOccurrence(ctx context.Context, userID string, id int, date string) error
+	// DeleteFutureOccurrences は from（空の場合は今日）以降の回をすべて削除し、ルールをその前日で終了します。
+	// from がルールの開始日以前の場合はルール自体を削除します。確定済みの支出は削除しません。
+	DeleteFutureOccurrences(ctx context.Context, userID string, id int, from string) error
+	// Materialize はユーザーのすべてのルールについて、生成期間内で未生成の予定支出を生成し、生成件数を返します。
+	// ルールごとに1つのトランザクションで生成し、生成済みの日付もあわせて更新します。
+	Materialize(ctx context.Context, userID string) (int, error)
+	// MaterializeAll はルールを持つすべてのユーザーについて Materialize を実行し、生成件数の合計を返します。
+	// 失敗したユーザーがあっても残りのユーザーの生成は続け、エラーをまとめて返します。
+	MaterializeAll(ctx context.Context) (int, error)
+}
+
+// recurringExpenseService の変更を伴う操作は、回の記録・支出の作成・生成済みの日付の更新を
+// 1つのトランザクションで行います。途中で失敗した場合はすべて取り消されるため、再実行しても重複しません。
+// ExpenseService の withTx がこのトランザクションに参加するよう、トランザクションは withTxScope で開始します。
+type recurringExpenseService struct {
+	repo         repositories.RecurringRepository
+	expenseRepo  repositories.ExpenseRepository
+	expenses     ExpenseService
+	categoryRepo repositories.CategoryRepository
+	txManager    TxManager
+	now          func() time.Time
+}
+
+func NewRecurringExpenseService(repo repositories.RecurringRepository, expenseRepo repositories.ExpenseRepository, expenses ExpenseService, categoryRepo repositories.CategoryRepository, txManager TxManager) RecurringExpenseService {
+	return &recurringExpenseService{repo: repo, expenseRepo: expenseRepo, expenses: expenses, categoryRepo: categoryRepo, txManager: txManager, now: time.Now}
+}
+
+func (s *recurringExpenseService) ListRules(ctx context.Context, userID string) ([]models.RecurringRule, error) {
+	rules, err := s.repo.ListRules(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]models.RecurringRule, 0, len(rules))
+	for _, r := range rules {
+		out = append(out, recurringRuleToModel(r))
+	}
+	return out, nil
+}
+
+func (s *recurringExpenseService) CreateRule(ctx context.Context, userID string, input models.RecurringRuleInput) (models.RecurringRule, error) {
+	rule, err := s.validateRuleInput(ctx, userID, input)
+	if err != nil {
+		return models.RecurringRule{}, err
+	}
+
+	err = withTxScope(ctx, s.txManager, func(txCtx context.Context, _ Tx) error {
+		id, err := s.repo.CreateRule(txCtx, userID, rule)
+		if err != nil {
+			return err
+		}
+		rule.ID = id
+
+		_, err = s.materializeRule(txCtx, userID, rule, s.horizon())
+		return err
+	})
+	if err != nil {
+		return models.RecurringRule{}, err
+	}
+
+	return s.getRuleModel(ctx, userID, rule.ID)
+}
+
+func (s *recurringExpenseService) UpdateOccurrence(ctx context.Context, userID string, id int, date string, input models.RecurringOccurrenceInput) (models.Expense, error) {
+	if err := validateAmountAndMemo(input.Amount, input.Memo); err != nil {
+		return models.Expense{}, err
+	}
+	if err := s.validateCategory(ctx, userID, input.CategoryID); err != nil {
+		return models.Expense{}, err
+	}
+
+	var updated models.Expense
+	err := withTxScope(ctx, s.txManager, func(txCtx context.Context, _ Tx) error {
+		rule, err := s.getRule(txCtx, userID, id)
+		if err != nil {
+			return err
+		}
+		on, err := parseOccurrenceDate(rule, date)
+		if err != nil {
+			return err
+		}
+
+		occ, err := s.repo.GetOccurrence(txCtx, rule.ID, on)
+		if errors.Is(err, sql.ErrNoRows) {
+			// 生成期間外でまだ生成されていない回は、変更後の内容で生成する
+			updated, _, err = s.createOccurrence(txCtx, userID, rule.ID, on, models.CreateExpenseInput{
+				Amount:     input.Amount,
+				CategoryID: input.CategoryID,
+				Memo:       input.Memo,
+				SpentAt:    on.Format("2006-01-02"),
+				Status:     string(models.StatusPlanned),
+			})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		if occ.ExpenseID == nil {
+			return &NotFoundError{Message: "この回は削除されています"}
+		}
+
+		current, err := s.expenseRepo.GetExpenseByID(txCtx, userID, *occ.ExpenseID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return &NotFoundError{Message: "支出が見つかりません"}
+			}
+			return err
+		}
+
+		updated, err = s.expenses.UpdateExpense(txCtx, userID, models.UpdateExpenseInput{
+			ID:         current.ID,
+			Amount:     input.Amount,
+			CategoryID: input.CategoryID,
+			Memo:       input.Memo,
+			SpentAt:    on.Format("2006-01-02"),
+			Status:     current.Status,
+			Version:    current.Version,
+		})
+		return err
+	})
+	if err != nil {
+		return models.Expense{}, err
+	}
+	return updated, nil
+}
+
+func (s *recurringExpenseService) UpdateFutureOccurrences(ctx context.Context, userID string, id int, from string, input models.RecurringRuleInput) (models.RecurringRule, error) {
+	fromDate, err := s.parseFromDate(from)
+	if err != nil {
+		return models.RecurringRule{}, err
+	}
+	// from の前日までは変更前のルールで生成するため、生成期間より先の from は受け付けない
+	if fromDate.After(s.horizon().AddDate(0, 0, 1)) {
+		return models.RecurringRule{}, &ValidationError{Message: fmt.Sprintf("変更を適用する日付は今日から%d日以内で指定してください", RecurringWindowDays+1)}
+	}
+	updated, err := s.validateRuleInput(ctx, userID, input)
+	if err != nil {
+		return models.RecurringRule{}, err
+	}
+
+	err = withTxScope(ctx, s.txManager, func(txCtx context.Context, _ Tx) error {
+		current, err := s.getRule(txCtx, userID, id)
+		if err != nil {
+			return err
+		}
+		updated.ID = current.ID
+
+		// from より前の回は変更前のルールのまま残す（生成期間の最終日まで）
+		until := fromDate.AddDate(0, 0, -1)
+		if horizon := s.horizon(); until.After(horizon) {
+			until = horizon
+		}
+		if _, err := s.materializeRule(txCtx, userID, current, until); err != nil {
+			return err
+		}
+		if err := s.removeFutureOccurrences(txCtx, userID, current.ID, fromDate); err != nil {
+			return err
+		}
+
+		if err := s.repo.UpdateRule(txCtx, userID, updated); err != nil {
+			return err
+		}
+		if err := s.repo.SetGeneratedUntil(txCtx, userID, updated.ID, until); err != nil {
+			return err
+		}
+		updated.GeneratedUntil = &until
+
+		_, err = s.materializeRule(txCtx, userID, updated, s.horizon())
+		return err
+	})
+	if err != nil {
+		return models.RecurringRule{}, err
+	}
+
+	return s.getRuleModel(ctx, userID, updated.ID)
+}
+
+func (s *recurringExpenseService) DeleteOccurrence(ctx context.Context, userID string, id int, date string) error {
+	return withTxScope(ctx, s.txManager, func(txCtx context.Context, _ Tx) error {
+		rule, err := s.getRule(txCtx, userID, id)
+		if err != nil {
+			return err
+		}
+		on, err := parseOccurrenceDate(rule, date)
+		if err != nil {
+			return err
+		}
+
+		occ, err := s.repo.GetOccurrence(txCtx, rule.ID, on)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return err
+		}
+		if err == nil && occ.ExpenseID != nil {
+			// 確定・立替・取りやめにした支出は実績のため、「以降すべて」の削除と同じく繰り返しの操作では削除しない
+			if occ.Status != string(models.StatusPlanned) {
+				return &ValidationError{Message: "予定から変更した回は削除できません。支出の一覧から削除してください"}
+			}
+			if err := s.deleteOccurrenceExpense(txCtx, userID, *occ.ExpenseID); err != nil {
+				return err
+			}
+		}
+
+		// 支出が紐付かない回として記録し、再生成されないようにする
+		return s.repo.SetOccurrenceExpense(txCtx, rule.ID, on, nil)
+	})
+}
+
+func (s *recurringExpenseService) DeleteFutureOccurrences(ctx context.Context, userID string, id int, from string) error {
+	fromDate, err := s.parseFromDate(from)
+	if err != nil {
+		return err
+	}
+
+	return withTxScope(ctx, s.txManager, func(txCtx context.Context, _ Tx) error {
+		rule, err := s.getRule(txCtx, userID, id)
+		if err != nil {
+			return err
+		}
+
+		if err := s.removeFutureOccurrences(txCtx, userID, rule.ID, fromDate); err != nil {
+			return err
+		}
+
+		if !fromDate.After(rule.StartDate) {
+			return s.repo.DeleteRule(txCtx, userID, rule.ID)
+		}
+
+		end := fromDate.AddDate(0, 0, -1)
+		if rule.EndDate != nil && rule.EndDate.Before(end) {
+			return nil
+		}
+		rule.EndDate = &end
+		return s.repo.UpdateRule(txCtx, userID, rule)
+	})
+}
+
+func (s *recurringExpenseService) Materialize(ctx context.Context, userID string) (int, error) {
+	rules, err := s.repo.ListRules(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	horizon := s.horizon()
+	total := 0
+	for _, rule := range rules {
+		var n int
+		err := withTxScope(ctx, s.txManager, func(txCtx context.Context, _ Tx) error {
+			var err error
+			n, err = s.materializeRule(txCtx, userID, rule, horizon)
+			return err
+		})
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+func (s *recurringExpenseService) MaterializeAll(ctx context.Context) (int, error) {
+	userIDs, err := s.repo.ListRuleOwners(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	total := 0
+	var errs []error
+	for _, userID := range userIDs {
+		n, err := s.Materialize(ctx, userID)
+		total += n
+		if err != nil {
+			errs = append(errs, fmt.Errorf("user %s: %w", userID, err))
+		}
+	}
+	return total, errors.Join(errs...)
+}
+
+// materializeRule は rule の未生成の回のうち until までの分を予定支出として生成し、生成件数を返します。
+// 生成した回と生成済みの日付が食い違わないよう、トランザクション内で呼び出します。
+func (s *recurringExpenseService) materializeRule(ctx context.Context, userID string, rule repositories.RecurringRule, until time.Time) (int, error) {
+	from := rule.StartDate
+	if rule.GeneratedUntil != nil {
+		if next := rule.GeneratedUntil.AddDate(0, 0, 1); next.After(from) {
+			from = next
+		}
+	} else if today := s.today(); from.Before(today) {
+		// 初回の生成は今日以降の回のみとし、開始日が過去のルールでも過去の日付の予定支出は作らない
+		from = today
+	}
+	if rule.EndDate != nil && rule.EndDate.Before(until) {
+		until = *rule.EndDate
+	}
+	if from.After(until) {
+		return 0, nil
+	}
+
+	amount := int(rule.Amount)
+	categoryID := int(rule.CategoryID)
+	created := 0
+	for _, on := range occurrencesBetween(rule, from, until) {
+		_, ok, err := s.createOccurrence(ctx, userID, rule.ID, on, models.CreateExpenseInput{
+			Amount:     &amount,
+			CategoryID: &categoryID,
+			Memo:       rule.Memo,
+			SpentAt:    on.Format("2006-01-02"),
+			Status:     string(models.StatusPlanned),
+		})
+		if err != nil {
+			return created, err
+		}
+		if ok {
+			created++
+		}
+	}
+
+	if err := s.repo.SetGeneratedUntil(ctx, userID, rule.ID, until); err != nil {
+		return created, err
+	}
+	return created, nil
+}
+
+// createOccurrence は on の回を記録してから支出を作成し、記録に紐付けます。
+// 既に記録済みの回（生成済み・スキップ済み）の場合は何もせず false を返します。
+// 支出の作成に失敗した場合の記録の取り消しは、呼び出し元のトランザクションのロールバックに任せます。
+func (s *recurringExpenseService) createOccurrence(ctx context.Context, userID string, ruleID int32, on time.Time, input models.CreateExpenseInput) (models.Expense, bool, error) {
+	claimed, err := s.repo.ClaimOccurrence(ctx, ruleID, on)
+	if err != nil {
+		return models.Expense{}, false, err
+	}
+	if !claimed {
+		return models.Expense{}, false, nil
+	}
+
+	exp, err := s.expenses.CreateExpense(ctx, userID, input)
+	if err != nil {
+		return models.Expense{}, false, err
+	}
+
+	expenseID := int32(exp.ID)
+	if err := s.repo.SetOccurrenceExpense(ctx, ruleID, on, &expenseID); err != nil {
+		return models.Expense{}, false, err
+	}
+	return exp, true, nil
+}
+
+// removeFutureOccurrences は from 以降の回の記録を削除し、未確定の予定支出も削除します。
+// 予定以外（確定・立替・取りやめ）の支出が紐付く回は記録ごと残します（再生成させないため）。
+func (s *recurringExpenseService) removeFutureOccurrences(ctx context.Context, userID string, ruleID int32, from time.Time) error {
+	occs, err := s.repo.ListOccurrencesFrom(ctx, ruleID, from)
+	if err != nil {
+		return err
+	}
+
+	for _, occ := range occs {
+		if occ.ExpenseID != nil {
+			if occ.Status != string(models.StatusPlanned) {
+				continue
+			}
+			if err := s.deleteOccurrenceExpense(ctx, userID, *occ.ExpenseID); err != nil {
+				return err
+			}
+		}
+		if err := s.repo.DeleteOccurrence(ctx, ruleID, occ.OccursOn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteOccurrenceExpense は回に紐付く支出をゴミ箱に移し、変更履歴を記録します。
+// 支出の一覧から既に削除されている場合は何もしません。
+func (s *recurringExpenseService) deleteOccurrenceExpense(ctx context.Context, userID string, expenseID int32) error {
+	err := s.expenses.DeleteExpense(ctx, userID, int(expenseID))
+	var ne *NotFoundError
+	if errors.As(err, &ne) {
+		return nil
+	}
+	return err
+}
+
+func (s *recurringExpenseService) getRule(ctx context.Context, userID string, id int) (repositories.RecurringRule, error) {
+	rule, err := s.repo.GetRule(ctx, userID, int32(id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return repositories.RecurringRule{}, &NotFoundError{Message: "繰り返しルールが見つかりません"}
+		}
+		return repositories.RecurringRule{}, err
+	}
+	return rule, nil
+}
+
+func (s *recurringExpenseService) getRuleModel(ctx context.Context, userID string, id int32) (models.RecurringRule, error) {
+	rule, err := s.getRule(ctx, userID, int(id))
+	if err != nil {
+		return models.RecurringRule{}, err
+	}
+	return recurringRuleToModel(rule), nil
+}
+
+// today は現在日付（AppLocation の日付を UTC の 00:00 で表したもの）を返します。
+func (s *recurringExpenseService) today() time.Time {
+	return calendarDate(s.now())
+}
+
+// horizon は予定支出を生成する期間の最終日を返します。
+func (s *recurringExpenseService) horizon() time.Time {
+	return s.today().AddDate(0, 0, RecurringWindowDays)
+}
+
+// parseFromDate は「以降すべて」の起点日を解析します。空の場合は今日を返します。
+func (s *recurringExpenseService) parseFromDate(from string) (time.Time, error) {
+	if from == "" {
+		return s.today(), nil
+	}
+	d, err := time.Parse("2006-01-02", from)
+	if err != nil {
+		return time.Time{}, &ValidationError{Message: "日付の形式が正しくありません"}
+	}
+	return d, nil
+}
+
+// parseOccurrenceDate は「この回のみ」の対象日を解析し、ルールの対象日であることを確認します。
+func parseOccurrenceDate(rule repositories.RecurringRule, date string) (time.Time, error) {
+	if date == "" {
+		return time.Time{}, &ValidationError{Message: "対象の日付を指定してください"}
+	}
+	on, err := time.Parse("2006-01-02", date)
+	if err != nil {
+		return time.Time{}, &ValidationError{Message: "日付の形式が正しくありません"}
+	}
+	if len(occurrencesBetween(rule, on, on)) == 0 {
+		return time.Time{}, &ValidationError{Message: "指定した日付はこのルールの対象日ではありません"}
+	}
+	return on, nil
+}
+
+// validateRuleInput はルールの入力を検証し、保存形式に変換します。
+func (s *recurringExpenseService) validateRuleInput(ctx context.Context, userID string, input models.RecurringRuleInput) (repositories.RecurringRule, error) {
+	var rule repositories.RecurringRule
+
+	if err := validateAmountAndMemo(input.Amount, input.Memo); err != nil {
+		return rule, err
+	}
+
+	if !models.IsValidFrequency(input.Frequency) {
+		return rule, &ValidationError{Message: "繰り返しは「毎週」「隔週」「毎月」「毎年」から選択してください"}
+	}
+	if input.Frequency == string(models.FrequencyMonthly) {
+		if input.DayOfMonth == nil || *input.DayOfMonth < 1 || *input.DayOfMonth > 31 {
+			return rule, &ValidationError{Message: "毎月の日付は1〜31で指定してください"}
+		}
+		day := int32(*input.DayOfMonth)
+		rule.DayOfMonth = &day
+	} else if input.DayOfMonth != nil {
+		return rule, &ValidationError{Message: "日付の指定は毎月の場合のみ有効です"}
+	}
+
+	if input.StartDate == "" {
+		return rule, &ValidationError{Message: "開始日を入力してください"}
+	}
+	start, err := time.Parse("2006-01-02", input.StartDate)
+	if err != nil {
+		return rule, &ValidationError{Message: "開始日の形式が正しくありません"}
+	}
+	rule.StartDate = start
+	if input.EndDate != "" {
+		end, err := time.Parse("2006-01-02", input.EndDate)
+		if err != nil {
+			return rule, &ValidationError{Message: "終了日の形式が正しくありません"}
+		}
+		if end.Before(start) {
+			return rule, &ValidationError{Message: "終了日は開始日以降の日付を指定してください"}
+		}
+		rule.EndDate = &end
+	}
+
+	if err := s.validateCategory(ctx, userID, input.CategoryID); err != nil {
+		return rule, err
+	}
+
+	rule.Amount = int32(*input.Amount)
+	rule.CategoryID = int32(*input.CategoryID)
+	rule.Memo = input.Memo
+	rule.Frequency = input.Frequency
+	return rule, nil
+}
+
+func (s *recurringExpenseService) validateCategory(ctx context.Context, userID string, categoryID *int) error {
+	if categoryID == nil {
+		return &ValidationError{Message: "カテゴリを選択してください"}
+	}
+	if *categoryID <= 0 {
+		return &ValidationError{Message: "有効なカテゴリを選択してください"}
+	}
+	exists, err := s.categoryRepo.CategoryExists(ctx, userID, int32(*categoryID))
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return &ValidationError{Message: "カテゴリが存在しません"}
+	}
+	return nil
+}
+
+// validateAmountAndMemo は金額とメモの入力チェックを行います。
+func validateAmountAndMemo(amount *int, memo string) error {
+	if amount == nil {
+		return &ValidationError{Message: "金額を入力してください"}
+	}
+	if *amount <= 0 {
+		return &ValidationError{Message: "金額は1円以上で入力してください"}
+	}
+	if *amount > BusinessMaxAmount {
+		return &ValidationError{Message: "金額は10億円以下で入力してください"}
+	}
+	if len(memo) > MemoMaxLen {
+		return &ValidationError{Message: "メモは5000文字以内で入力してください"}
+	}
+	return nil
+}
+
+// occurrencesBetween は rule の対象日のうち from 以上 to 以下のものを日付順に返します。
+// 開始日より前・終了日より後の日付は含みません。
+//   - weekly / biweekly: 開始日から7日 / 14日ごと
+//   - monthly: 毎月 day_of_month 日（その月に存在しない場合は月末日）
+//   - yearly: 毎年開始日と同じ月日（2月29日は平年では2月28日）
+func occurrencesBetween(rule repositories.RecurringRule, from, to time.Time) []time.Time {
+	start := rule.StartDate
+	if from.Before(start) {
+		from = start
+	}
+	if rule.EndDate != nil && rule.EndDate.Before(to) {
+		to = *rule.EndDate
+	}
+	if from.After(to) {
+		return nil
+	}
+
+	var out []time.Time
+	switch models.Frequency(rule.Frequency) {
+	case models.FrequencyWeekly, models.FrequencyBiweekly:
+		step := 7
+		if rule.Frequency == string(models.FrequencyBiweekly) {
+			step = 14
+		}
+		days := int(from.Sub(start).Hours() / 24)
+		k := (days + step - 1) / step
+		for d := start.AddDate(0, 0, k*step); !d.After(to); d = d.AddDate(0, 0, step) {
+			out = append(out, d)
+		}
+	case models.FrequencyMonthly:
+		if rule.DayOfMonth == nil {
+			return nil
+		}
+		for y, m := from.Year(), from.Month(); ; m++ {
+			d := clampedDate(y, m, int(*rule.DayOfMonth))
+			if d.After(to) {
+				break
+			}
+			if !d.Before(from) {
+				out = append(out, d)
+			}
+		}
+	case models.FrequencyYearly:
+		for y := from.Year(); ; y++ {
+			d := clampedDate(y, start.Month(), start.Day())
+			if d.After(to) {
+				break
+			}
+			if !d.Before(from) {
+				out = append(out, d)
+			}
+		}
+	}
+	return out
+}
+
+// clampedDate は y 年 m 月 day 日を返します。その月に day 日が存在しない場合は月末日を返します。
+// m が12を超える場合は翌年以降の月として扱います。
+func clampedDate(y int, m time.Month, day int) time.Time {
+	first := time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+	last := first.AddDate(0, 1, -1).Day()
+	if day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+func recurringRuleToModel(r repositories.RecurringRule) models.RecurringRule {
+	rule := models.RecurringRule{
+		ID:        int(r.ID),
+		Amount:    int(r.Amount),
+		Memo:      r.Memo,
+		Category:  models.Category{ID: int(r.CategoryID), Name: r.CategoryName},
+		Frequency: r.Frequency,
+		StartDate: r.StartDate.Format("2006-01-02"),
+	}
+	if r.DayOfMonth != nil {
+		v := int(*r.DayOfMonth)
+		rule.DayOfMonth = &v
+	}
+	if r.EndDate != nil {
+		v := r.EndDate.Format("2006-01-02")
+		rule.EndDate = &v
+	}
+	if r.GeneratedUntil != nil {
+		v := r.GeneratedUntil.Format("2006-01-02")
+		rule.GeneratedUntil = &v
+	}
+	return rule
+}
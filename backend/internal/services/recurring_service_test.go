@@ -0,0 +1,668 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/repositories"
+)
+
+// fakeRecurringRepo は RecurringRepository のインメモリ実装です
+type fakeRecurringRepo struct {
+	rules       map[int32]repositories.RecurringRule
+	occurrences map[int32]map[time.Time]*int32
+	expenses    *fakeExpenseRepo
+	owners      []string
+	nextID      int32
+}
+
+func newFakeRecurringRepo(expenses *fakeExpenseRepo) *fakeRecurringRepo {
+	return &fakeRecurringRepo{
+		rules:       map[int32]repositories.RecurringRule{},
+		occurrences: map[int32]map[time.Time]*int32{},
+		expenses:    expenses,
+	}
+}
+
+func (f *fakeRecurringRepo) ListRules(ctx context.Context, userID string) ([]repositories.RecurringRule, error) {
+	var out []repositories.RecurringRule
+	for _, r := range f.rules {
+		out = append(out, r)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, nil
+}
+
+func (f *fakeRecurringRepo) ListRuleOwners(ctx context.Context) ([]string, error) {
+	return f.owners, nil
+}
+
+func (f *fakeRecurringRepo) GetRule(ctx context.Context, userID string, id int32) (repositories.RecurringRule, error) {
+	r, ok := f.rules[id]
+	if !ok {
+		return repositories.RecurringRule{}, sql.ErrNoRows
+	}
+	return r, nil
+}
+
+func (f *fakeRecurringRepo) CreateRule(ctx context.Context, userID string, rule repositories.RecurringRule) (int32, error) {
+	f.nextID++
+	rule.ID = f.nextID
+	f.rules[rule.ID] = rule
+	f.occurrences[rule.ID] = map[time.Time]*int32{}
+	return rule.ID, nil
+}
+
+func (f *fakeRecurringRepo) UpdateRule(ctx context.Context, userID string, rule repositories.RecurringRule) error {
+	rule.GeneratedUntil = f.rules[rule.ID].GeneratedUntil
+	f.rules[rule.ID] = rule
+	return nil
+}
+
+func (f *fakeRecurringRepo) DeleteRule(ctx context.Context, userID string, id int32) error {
+	delete(f.rules, id)
+	delete(f.occurrences, id)
+	return nil
+}
+
+func (f *fakeRecurringRepo) SetGeneratedUntil(ctx context.Context, userID string, id int32, until time.Time) error {
+	r := f.rules[id]
+	r.GeneratedUntil = &until
+	f.rules[id] = r
+	return nil
+}
+
+func (f *fakeRecurringRepo) ListOccurrencesFrom(ctx context.Context, ruleID int32, from time.Time) ([]repositories.RecurringOccurrence, error) {
+	var out []repositories.RecurringOccurrence
+	for on := range f.occurrences[ruleID] {
+		if !on.Before(from) {
+			occ, _ := f.GetOccurrence(ctx, ruleID, on)
+			out = append(out, occ)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].OccursOn.Before(out[j].OccursOn) })
+	return out, nil
+}
+
+func (f *fakeRecurringRepo) GetOccurrence(ctx context.Context, ruleID int32, on time.Time) (repositories.RecurringOccurrence, error) {
+	expenseID, ok := f.occurrences[ruleID][on]
+	if !ok {
+		return repositories.RecurringOccurrence{}, sql.ErrNoRows
+	}
+	occ := repositories.RecurringOccurrence{OccursOn: on, ExpenseID: expenseID}
+	if expenseID != nil {
+		occ.Status = f.expenses.items[*expenseID].Status
+	}
+	return occ, nil
+}
+
+func (f *fakeRecurringRepo) ClaimOccurrence(ctx context.Context, ruleID int32, on time.Time) (bool, error) {
+	if _, ok := f.occurrences[ruleID][on]; ok {
+		return false, nil
+	}
+	f.occurrences[ruleID][on] = nil
+	return true, nil
+}
+
+func (f *fakeRecurringRepo) SetOccurrenceExpense(ctx context.Context, ruleID int32, on time.Time, expenseID *int32) error {
+	f.occurrences[ruleID][on] = expenseID
+	return nil
+}
+
+func (f *fakeRecurringRepo) DeleteOccurrence(ctx context.Context, ruleID int32, on time.Time) error {
+	delete(f.occurrences[ruleID], on)
+	return nil
+}
+
+// fakeExpenseRepo は ExpenseRepository のインメモリ実装です
+type fakeExpenseRepo struct {
+	items  map[int32]models.Expense
+	nextID int32
+	// failAfter が正の場合、その件数を作成した後の CreateExpense はエラーを返す
+	failAfter int
+}
+
+func newFakeExpenseRepo() *fakeExpenseRepo {
+	return &fakeExpenseRepo{items: map[int32]models.Expense{}}
+}
+
+func (f *fakeExpenseRepo) CreateExpense(ctx context.Context, userID string, input models.CreateExpenseInput) (models.Expense, error) {
+	if f.failAfter > 0 && len(f.items) >= f.failAfter {
+		return models.Expense{}, errors.New("db error")
+	}
+	f.nextID++
+	exp := models.Expense{
+		ID:       int(f.nextID),
+		Amount:   *input.Amount,
+		Memo:     input.Memo,
+		SpentAt:  input.SpentAt,
+		Status:   input.Status,
+		Category: models.Category{ID: *input.CategoryID},
+		Version:  1,
+	}
+	f.items[f.nextID] = exp
+	return exp, nil
+}
+
+func (f *fakeExpenseRepo) FindAll(ctx context.Context, userID string, query repositories.ExpenseListQuery) ([]models.Expense, error) {
+	return nil, nil
+}
+
+func (f *fakeExpenseRepo) GetExpenseByID(ctx context.Context, userID string, id int32) (models.Expense, error) {
+	exp, ok := f.items[id]
+	if !ok {
+		return models.Expense{}, sql.ErrNoRows
+	}
+	return exp, nil
+}
+
+func (f *fakeExpenseRepo) DeleteExpense(ctx context.Context, userID string, id int32) error {
+	delete(f.items, id)
+	return nil
+}
+
+func (f *fakeExpenseRepo) RestoreExpense(ctx context.Context, userID string, id int32, deletedAfter time.Time) (models.Expense, error) {
+	return models.Expense{}, sql.ErrNoRows
+}
+
+// UpdateExpense は実際のリポジトリと同じく、バージョンが一致する場合のみ更新します
+func (f *fakeExpenseRepo) UpdateExpense(ctx context.Context, userID string, input models.UpdateExpenseInput) (models.Expense, error) {
+	exp, ok := f.items[int32(input.ID)]
+	if !ok || exp.Version != input.Version {
+		return models.Expense{}, sql.ErrNoRows
+	}
+	exp.Version++
+	exp.Amount = *input.Amount
+	exp.Category = models.Category{ID: *input.CategoryID}
+	exp.Memo = input.Memo
+	exp.SpentAt = input.SpentAt
+	exp.Status = input.Status
+	f.items[int32(input.ID)] = exp
+	return exp, nil
+}
+
+func (f *fakeExpenseRepo) UpdateExpenseStatus(ctx context.Context, userID string, id int32, status string, version int, plannedAmount *int) (models.Expense, error) {
+	exp := f.items[id]
+	exp.Status = status
+	f.items[id] = exp
+	return exp, nil
+}
+
+func (f *fakeExpenseRepo) BulkCreateExpenses(ctx context.Context, userID string, inputs []models.CreateExpenseInput) ([]models.Expense, error) {
+	var created []models.Expense
+	for _, in := range inputs {
+		e, err := f.CreateExpense(ctx, userID, in)
+		if err != nil {
+			return nil, err
+		}
+		created = append(created, e)
+	}
+	return created, nil
+}
+
+// spentDates は生成済み支出の日付を昇順で返します
+func (f *fakeExpenseRepo) spentDates() []string {
+	var out []string
+	for _, e := range f.items {
+		out = append(out, e.SpentAt)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func date(s string) time.Time {
+	d, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+func formatDates(ds []time.Time) []string {
+	out := make([]string, 0, len(ds))
+	for _, d := range ds {
+		out = append(out, d.Format("2006-01-02"))
+	}
+	return out
+}
+
+func TestOccurrencesBetween(t *testing.T) {
+	day := func(v int32) *int32 { return &v }
+	end := date("2025-03-10")
+
+	tests := []struct {
+		name     string
+		rule     repositories.RecurringRule
+		from, to string
+		want     []string
+	}{
+		{
+			name: "毎週は開始日と同じ曜日",
+			rule: repositories.RecurringRule{Frequency: "weekly", StartDate: date("2025-01-06")},
+			from: "2025-01-10", to: "2025-01-31",
+			want: []string{"2025-01-13", "2025-01-20", "2025-01-27"},
+		},
+		{
+			name: "隔週は開始日から14日ごと",
+			rule: repositories.RecurringRule{Frequency: "biweekly", StartDate: date("2025-01-06")},
+			from: "2025-01-01", to: "2025-02-28",
+			want: []string{"2025-01-06", "2025-01-20", "2025-02-03", "2025-02-17"},
+		},
+		{
+			name: "毎月31日は月末日に丸める",
+			rule: repositories.RecurringRule{Frequency: "monthly", DayOfMonth: day(31), StartDate: date("2025-01-01")},
+			from: "2025-01-01", to: "2025-04-30",
+			want: []string{"2025-01-31", "2025-02-28", "2025-03-31", "2025-04-30"},
+		},
+		{
+			name: "毎月は年をまたぐ",
+			rule: repositories.RecurringRule{Frequency: "monthly", DayOfMonth: day(25), StartDate: date("2024-11-26")},
+			from: "2024-11-01", to: "2025-02-01",
+			want: []string{"2024-12-25", "2025-01-25"},
+		},
+		{
+			name: "毎年2月29日は平年では2月28日",
+			rule: repositories.RecurringRule{Frequency: "yearly", StartDate: date("2024-02-29")},
+			from: "2024-01-01", to: "2028-12-31",
+			want: []string{"2024-02-29", "2025-02-28", "2026-02-28", "2027-02-28", "2028-02-29"},
+		},
+		{
+			name: "終了日以降は含まない",
+			rule: repositories.RecurringRule{Frequency: "weekly", StartDate: date("2025-03-01"), EndDate: &end},
+			from: "2025-01-01", to: "2025-12-31",
+			want: []string{"2025-03-01", "2025-03-08"},
+		},
+		{
+			name: "期間が逆転している場合は空",
+			rule: repositories.RecurringRule{Frequency: "weekly", StartDate: date("2025-03-01")},
+			from: "2025-03-10", to: "2025-03-01",
+			want: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := occurrencesBetween(tt.rule, date(tt.from), date(tt.to))
+			assert.Equal(t, tt.want, formatDates(got))
+		})
+	}
+}
+
+func newTestRecurringService(now string) (*recurringExpenseService, *fakeRecurringRepo, *fakeExpenseRepo) {
+	expenses := newFakeExpenseRepo()
+	repo := newFakeRecurringRepo(expenses)
+	categoryRepo := &mockCategoryRepo{exists: map[int32]bool{1: true, 2: true}}
+	txManager := &fakeTxManager{}
+	s := &recurringExpenseService{
+		repo:         repo,
+		expenseRepo:  expenses,
+		expenses:     NewExpenseService(expenses, categoryRepo, &mockAuditRepo{}, txManager),
+		categoryRepo: categoryRepo,
+		txManager:    txManager,
+		now:          func() time.Time { return date(now).Add(9 * time.Hour) },
+	}
+	return s, repo, expenses
+}
+
+func weeklyInput(start string) models.RecurringRuleInput {
+	amount, categoryID := 1500, 1
+	return models.RecurringRuleInput{
+		Amount:     &amount,
+		CategoryID: &categoryID,
+		Memo:       "ジム",
+		Frequency:  "weekly",
+		StartDate:  start,
+	}
+}
+
+func TestCreateRule_MaterializesWindow(t *testing.T) {
+	s, _, expenses := newTestRecurringService("2025-01-01")
+	ctx := context.Background()
+
+	rule, err := s.CreateRule(ctx, "user1", weeklyInput("2025-01-01"))
+	require.NoError(t, err)
+
+	// 開始日から今日 + 60日（2025-03-02）までの毎週分が planned で生成される
+	dates := expenses.spentDates()
+	assert.Len(t, dates, 9)
+	assert.Equal(t, "2025-01-01", dates[0])
+	assert.Equal(t, "2025-02-26", dates[len(dates)-1])
+	for _, e := range expenses.items {
+		assert.Equal(t, "planned", e.Status)
+		assert.Equal(t, 1500, e.Amount)
+	}
+	require.NotNil(t, rule.GeneratedUntil)
+	assert.Equal(t, "2025-03-02", *rule.GeneratedUntil)
+
+	// 再生成しても重複しない
+	n, err := s.Materialize(ctx, "user1")
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	// 日付が進むと不足分のみ生成される
+	s.now = func() time.Time { return date("2025-01-08") }
+	n, err = s.Materialize(ctx, "user1")
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
+
+func TestCreateRule_PastStartDate(t *testing.T) {
+	s, _, expenses := newTestRecurringService("2025-01-01")
+	ctx := context.Background()
+
+	// 1年前の金曜日に始まる毎週のルール
+	rule, err := s.CreateRule(ctx, "user1", weeklyInput("2024-01-05"))
+	require.NoError(t, err)
+
+	// 過去の回は生成せず、今日以降の回のみ生成する
+	dates := expenses.spentDates()
+	assert.Len(t, dates, 9)
+	assert.Equal(t, "2025-01-03", dates[0])
+	assert.Equal(t, "2025-02-28", dates[len(dates)-1])
+	assert.Equal(t, "2024-01-05", rule.StartDate)
+	require.NotNil(t, rule.GeneratedUntil)
+	assert.Equal(t, "2025-03-02", *rule.GeneratedUntil)
+}
+
+func TestMaterialize_Transaction(t *testing.T) {
+	t.Run("ルールごとにコミットする", func(t *testing.T) {
+		s, repo, expenses := newTestRecurringService("2025-01-01")
+		ctx := context.Background()
+
+		_, err := s.CreateRule(ctx, "user1", weeklyInput("2025-01-01"))
+		require.NoError(t, err)
+		_, err = s.CreateRule(ctx, "user1", weeklyInput("2025-01-02"))
+		require.NoError(t, err)
+
+		txm := &fakeTxManager{}
+		s.txManager = txm
+		s.now = func() time.Time { return date("2025-01-15") }
+		repo.owners = []string{"user1"}
+		before := len(expenses.items)
+
+		n, err := s.MaterializeAll(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 4, n)
+		assert.Len(t, expenses.items, before+4)
+		assert.Equal(t, 2, txm.commits)
+	})
+
+	t.Run("途中で失敗した場合は生成済みの日付を進めずロールバックする", func(t *testing.T) {
+		s, repo, expenses := newTestRecurringService("2025-01-01")
+		ctx := context.Background()
+
+		rule, err := s.CreateRule(ctx, "user1", weeklyInput("2025-01-01"))
+		require.NoError(t, err)
+
+		txm := &fakeTxManager{}
+		s.txManager = txm
+		s.now = func() time.Time { return date("2025-01-22") }
+		expenses.failAfter = len(expenses.items) + 1
+
+		_, err = s.Materialize(ctx, "user1")
+		require.Error(t, err)
+		assert.Equal(t, 0, txm.commits)
+		assert.Equal(t, 1, txm.rollbacks)
+		assert.Equal(t, "2025-03-02", repo.rules[int32(rule.ID)].GeneratedUntil.Format("2006-01-02"))
+	})
+}
+
+func TestCreateRule_Validation(t *testing.T) {
+	s, _, _ := newTestRecurringService("2025-01-01")
+	ctx := context.Background()
+	day := 10
+
+	tests := []struct {
+		name   string
+		modify func(in *models.RecurringRuleInput)
+	}{
+		{name: "頻度が不正", modify: func(in *models.RecurringRuleInput) { in.Frequency = "daily" }},
+		{name: "毎月で日付なし", modify: func(in *models.RecurringRuleInput) { in.Frequency = "monthly" }},
+		{name: "毎週で日付指定", modify: func(in *models.RecurringRuleInput) { in.DayOfMonth = &day }},
+		{name: "開始日なし", modify: func(in *models.RecurringRuleInput) { in.StartDate = "" }},
+		{name: "終了日が開始日より前", modify: func(in *models.RecurringRuleInput) { in.EndDate = "2024-12-01" }},
+		{name: "存在しないカテゴリ", modify: func(in *models.RecurringRuleInput) { v := 99; in.CategoryID = &v }},
+		{name: "金額なし", modify: func(in *models.RecurringRuleInput) { in.Amount = nil }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := weeklyInput("2025-01-01")
+			tt.modify(&in)
+			_, err := s.CreateRule(ctx, "user1", in)
+
+			var ve *ValidationError
+			assert.ErrorAs(t, err, &ve)
+		})
+	}
+}
+
+func TestDeleteOccurrence_NotRegenerated(t *testing.T) {
+	s, repo, expenses := newTestRecurringService("2025-01-01")
+	ctx := context.Background()
+
+	rule, err := s.CreateRule(ctx, "user1", weeklyInput("2025-01-01"))
+	require.NoError(t, err)
+	before := len(expenses.items)
+
+	require.NoError(t, s.DeleteOccurrence(ctx, "user1", rule.ID, "2025-01-15"))
+	assert.Len(t, expenses.items, before-1)
+	assert.NotContains(t, expenses.spentDates(), "2025-01-15")
+
+	// 生成済みの記録を巻き戻しても、スキップした回は再生成されない
+	r := repo.rules[int32(rule.ID)]
+	r.GeneratedUntil = nil
+	repo.rules[int32(rule.ID)] = r
+	_, err = s.Materialize(ctx, "user1")
+	require.NoError(t, err)
+	assert.NotContains(t, expenses.spentDates(), "2025-01-15")
+
+	// 対象日でない日付はエラー
+	err = s.DeleteOccurrence(ctx, "user1", rule.ID, "2025-01-16")
+	var ve *ValidationError
+	assert.ErrorAs(t, err, &ve)
+}
+
+func TestDeleteOccurrence_KeepsConfirmed(t *testing.T) {
+	s, _, expenses := newTestRecurringService("2025-01-01")
+	ctx := context.Background()
+
+	rule, err := s.CreateRule(ctx, "user1", weeklyInput("2025-01-01"))
+	require.NoError(t, err)
+	for id, e := range expenses.items {
+		if e.SpentAt == "2025-01-08" {
+			e.Status = "confirmed"
+			expenses.items[id] = e
+		}
+	}
+	before := len(expenses.items)
+
+	// 予定から変更した回は削除しない
+	err = s.DeleteOccurrence(ctx, "user1", rule.ID, "2025-01-08")
+	var ve *ValidationError
+	require.ErrorAs(t, err, &ve)
+	assert.Len(t, expenses.items, before)
+	assert.Contains(t, expenses.spentDates(), "2025-01-08")
+}
+
+// TestRecurringOccurrences_Audit は回の生成・削除が支出の変更履歴に記録されることのテストです
+func TestRecurringOccurrences_Audit(t *testing.T) {
+	s, _, expenses := newTestRecurringService("2025-01-01")
+	audits := s.expenses.(*expenseService).auditRepo.(*mockAuditRepo)
+	ctx := context.Background()
+
+	rule, err := s.CreateRule(ctx, "user1", weeklyInput("2025-01-01"))
+	require.NoError(t, err)
+	require.Len(t, audits.created, len(expenses.items))
+	for _, event := range audits.created {
+		assert.Equal(t, models.AuditActionCreate, event.Action)
+		require.NotNil(t, event.EntityID)
+		assert.Contains(t, expenses.items, *event.EntityID)
+	}
+
+	audits.created = nil
+	require.NoError(t, s.DeleteOccurrence(ctx, "user1", rule.ID, "2025-01-08"))
+	require.NoError(t, s.DeleteFutureOccurrences(ctx, "user1", rule.ID, "2025-02-19"))
+
+	// この回のみ（1件）と以降すべて（2025-02-19・2025-02-26 の2件）の削除
+	require.Len(t, audits.created, 3)
+	for _, event := range audits.created {
+		assert.Equal(t, models.AuditActionDelete, event.Action)
+		assert.NotEmpty(t, event.Before)
+	}
+}
+
+func TestUpdateOccurrence_OnlyThatDate(t *testing.T) {
+	s, _, expenses := newTestRecurringService("2025-01-01")
+	ctx := context.Background()
+
+	rule, err := s.CreateRule(ctx, "user1", weeklyInput("2025-01-01"))
+	require.NoError(t, err)
+
+	amount, categoryID := 3000, 2
+	updated, err := s.UpdateOccurrence(ctx, "user1", rule.ID, "2025-01-08", models.RecurringOccurrenceInput{
+		Amount: &amount, CategoryID: &categoryID, Memo: "ジム（体験）",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3000, updated.Amount)
+	assert.Equal(t, "2025-01-08", updated.SpentAt)
+
+	for _, e := range expenses.items {
+		if e.SpentAt != "2025-01-08" {
+			assert.Equal(t, 1500, e.Amount)
+		}
+	}
+
+	// ルール自体は変更されない
+	rules, err := s.ListRules(ctx, "user1")
+	require.NoError(t, err)
+	assert.Equal(t, 1500, rules[0].Amount)
+}
+
+func TestUpdateOccurrence_ThroughExpenseService(t *testing.T) {
+	s, _, expenses := newTestRecurringService("2025-01-01")
+	ctx := context.Background()
+
+	rule, err := s.CreateRule(ctx, "user1", weeklyInput("2025-01-01"))
+	require.NoError(t, err)
+
+	audits := &mockAuditRepo{}
+	txm := &fakeTxManager{}
+	s.txManager = txm
+	s.expenses = NewExpenseService(expenses, s.categoryRepo, audits, txm)
+
+	// 変更のたびにバージョンが進んでも、現在のバージョンで更新できる
+	amount, categoryID := 3000, 2
+	for i := 0; i < 2; i++ {
+		updated, err := s.UpdateOccurrence(ctx, "user1", rule.ID, "2025-01-08", models.RecurringOccurrenceInput{
+			Amount: &amount, CategoryID: &categoryID, Memo: "ジム（体験）",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, i+2, updated.Version)
+		assert.Equal(t, "planned", updated.Status)
+		amount += 500
+	}
+
+	// 支出の変更と同じトランザクションで変更履歴を記録する
+	require.Len(t, audits.created, 2)
+	assert.Equal(t, models.AuditActionUpdate, audits.created[0].Action)
+	assert.Equal(t, 2, txm.commits)
+	assert.Equal(t, 0, txm.rollbacks)
+}
+
+func TestUpdateFutureOccurrences_KeepsPastAndConfirmed(t *testing.T) {
+	s, _, expenses := newTestRecurringService("2025-01-01")
+	ctx := context.Background()
+
+	rule, err := s.CreateRule(ctx, "user1", weeklyInput("2025-01-01"))
+	require.NoError(t, err)
+
+	// 2025-01-22 の回は確定済み
+	for id, e := range expenses.items {
+		if e.SpentAt == "2025-01-22" {
+			e.Status = "confirmed"
+			expenses.items[id] = e
+		}
+	}
+
+	in := weeklyInput("2025-01-01")
+	amount := 2000
+	in.Amount = &amount
+	_, err = s.UpdateFutureOccurrences(ctx, "user1", rule.ID, "2025-01-15", in)
+	require.NoError(t, err)
+
+	byDate := map[string]models.Expense{}
+	for _, e := range expenses.items {
+		_, dup := byDate[e.SpentAt]
+		assert.False(t, dup, "duplicate occurrence on %s", e.SpentAt)
+		byDate[e.SpentAt] = e
+	}
+	assert.Equal(t, 1500, byDate["2025-01-08"].Amount) // from より前は変更しない
+	assert.Equal(t, 2000, byDate["2025-01-15"].Amount) // from 以降は新しいルール
+	assert.Equal(t, 1500, byDate["2025-01-22"].Amount) // 確定済みは変更しない
+	assert.Equal(t, "confirmed", byDate["2025-01-22"].Status)
+	assert.Equal(t, 2000, byDate["2025-01-29"].Amount)
+}
+
+func TestUpdateFutureOccurrences_BeyondWindow(t *testing.T) {
+	s, _, expenses := newTestRecurringService("2025-01-01")
+	ctx := context.Background()
+
+	rule, err := s.CreateRule(ctx, "user1", weeklyInput("2025-01-01"))
+	require.NoError(t, err)
+	before := len(expenses.items)
+
+	// 生成期間の翌日（2025-03-03）までは変更できる
+	_, err = s.UpdateFutureOccurrences(ctx, "user1", rule.ID, "2025-03-03", weeklyInput("2025-01-01"))
+	require.NoError(t, err)
+	assert.Len(t, expenses.items, before)
+
+	// それより先の日付では変更前のルールで何年分も生成しない
+	_, err = s.UpdateFutureOccurrences(ctx, "user1", rule.ID, "2027-01-01", weeklyInput("2025-01-01"))
+	var ve *ValidationError
+	require.ErrorAs(t, err, &ve)
+	assert.Len(t, expenses.items, before)
+}
+
+func TestDeleteFutureOccurrences(t *testing.T) {
+	t.Run("途中の日付以降を削除するとルールが終了する", func(t *testing.T) {
+		s, repo, expenses := newTestRecurringService("2025-01-01")
+		ctx := context.Background()
+
+		rule, err := s.CreateRule(ctx, "user1", weeklyInput("2025-01-01"))
+		require.NoError(t, err)
+
+		require.NoError(t, s.DeleteFutureOccurrences(ctx, "user1", rule.ID, "2025-01-15"))
+		assert.Equal(t, []string{"2025-01-01", "2025-01-08"}, expenses.spentDates())
+		require.NotNil(t, repo.rules[int32(rule.ID)].EndDate)
+		assert.Equal(t, "2025-01-14", repo.rules[int32(rule.ID)].EndDate.Format("2006-01-02"))
+	})
+
+	t.Run("開始日以前を指定するとルールごと削除する", func(t *testing.T) {
+		s, repo, expenses := newTestRecurringService("2025-01-01")
+		ctx := context.Background()
+
+		rule, err := s.CreateRule(ctx, "user1", weeklyInput("2025-01-01"))
+		require.NoError(t, err)
+
+		require.NoError(t, s.DeleteFutureOccurrences(ctx, "user1", rule.ID, "2025-01-01"))
+		assert.Empty(t, expenses.items)
+		assert.Empty(t, repo.rules)
+	})
+
+	t.Run("存在しないルールは見つからない", func(t *testing.T) {
+		s, _, _ := newTestRecurringService("2025-01-01")
+		err := s.DeleteFutureOccurrences(context.Background(), "user1", 99, "")
+
+		var ne *NotFoundError
+		assert.ErrorAs(t, err, &ne)
+	})
+}
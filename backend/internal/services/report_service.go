@@ -0,0 +1,351 @@
+package services
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/repositories"
+)
+
+// Trends は月ごとの集計とカテゴリ別の支出の推移です。
+type Trends struct {
+	From          string               // 開始月（YYYY-MM）
+	To            string               // 終了月（YYYY-MM）
+	FixedCostMode models.FixedCostMode // 毎月以外の固定費の計上方法
+	Months        []*Dashboard         // 月ごとの集計（古い月から順）。カテゴリ別の内訳・利用ペースは含みません
+	Categories    []CategoryTrend      // カテゴリ別の支出の推移（期間内に支出があるカテゴリのみ）
+}
+
+// CategoryTrend はカテゴリ別の支出の推移です。
+type CategoryTrend struct {
+	CategoryID   int
+	CategoryName string
+	Series       []CategoryMonthlyExpenses // 期間内の全月分（古い月から順、支出がない月は 0）
+}
+
+// CategoryMonthlyExpenses はカテゴリの1か月分の支出です。
+type CategoryMonthlyExpenses struct {
+	Month             string // 対象月（YYYY-MM）
+	ConfirmedExpenses int64  // 確定支出
+	PlannedExpenses   int64  // 予定支出
+}
+
+// EstimateAccuracy は予定から確定にした支出の見積もり精度のレポートです。
+type EstimateAccuracy struct {
+	From       string                     // 開始月（YYYY-MM）
+	To         string                     // 終了月（YYYY-MM）
+	Total      EstimateAccuracyStats      // 期間全体の集計
+	Categories []CategoryEstimateAccuracy // 期間全体のカテゴリ別の集計（カテゴリID順）
+	Months     []MonthlyEstimateAccuracy  // 月ごとの集計（予定から確定にした支出がある月のみ、古い月から順）
+}
+
+// MonthlyEstimateAccuracy は1か月分の見積もり精度です。
+type MonthlyEstimateAccuracy struct {
+	Month string // 対象月（YYYY-MM）
+	EstimateAccuracyStats
+	Categories []CategoryEstimateAccuracy // カテゴリ別の集計（カテゴリID順）
+}
+
+// CategoryEstimateAccuracy はカテゴリ別の見積もり精度です。
+type CategoryEstimateAccuracy struct {
+	CategoryID   int
+	CategoryName string
+	EstimateAccuracyStats
+}
+
+// EstimateAccuracyStats は予定金額と確定した金額の比較です。
+// 集計対象の支出がない場合、VarianceRate と Accuracy は nil になります。
+type EstimateAccuracyStats struct {
+	ExpenseCount        int64    // 予定から確定にした支出の件数
+	PlannedTotal        int64    // 予定金額の合計
+	ActualTotal         int64    // 確定した金額の合計
+	Variance            int64    // ActualTotal - PlannedTotal（正の値は見積もりより多く使ったことを表す）
+	VarianceRate        *float64 // Variance の PlannedTotal に対する割合（%、小数第1位まで）
+	Accuracy            *float64 // 見積もり精度（%、小数第1位まで）。100 から支出ごとの差額の絶対値の合計の割合を引いた値で、0 未満にはならない
+	UnderestimatedCount int64    // 見積もりより多く使った件数
+	OverestimatedCount  int64    // 見積もりより少なく済んだ件数
+
+	absoluteVariance int64
+}
+
+// add は集計結果を加算します。
+func (st *EstimateAccuracyStats) add(row repositories.MonthlyCategoryEstimateAccuracy) {
+	st.ExpenseCount += row.ExpenseCount
+	st.PlannedTotal += row.PlannedTotal
+	st.ActualTotal += row.ActualTotal
+	st.absoluteVariance += row.AbsoluteVariance
+	st.UnderestimatedCount += row.UnderestimatedCount
+	st.OverestimatedCount += row.OverestimatedCount
+	st.Variance = st.ActualTotal - st.PlannedTotal
+	st.VarianceRate = nil
+	st.Accuracy = nil
+	if st.PlannedTotal > 0 {
+		rate := roundPercent(float64(st.Variance) / float64(st.PlannedTotal))
+		accuracy := roundPercent(math.Max(0, 1-float64(st.absoluteVariance)/float64(st.PlannedTotal)))
+		st.VarianceRate = &rate
+		st.Accuracy = &accuracy
+	}
+}
+
+// TagTotals はタグ別の支出のレポートです。
+type TagTotals struct {
+	From string     // 開始日（YYYY-MM-DD）
+	To   string     // 終了日（YYYY-MM-DD）
+	Tags []TagTotal // タグ別の集計（期間内に支出があるタグのみ、確定支出の多い順）
+}
+
+// TagTotal はタグ別の支出の集計です。1件の支出に複数のタグが付いている場合は、それぞれのタグに計上します。
+type TagTotal struct {
+	TagID             int
+	TagName           string
+	ExpenseCount      int64                 // 支出の件数
+	ConfirmedExpenses int64                 // 確定支出
+	PlannedExpenses   int64                 // 予定支出
+	Categories        []TagCategoryExpenses // カテゴリ別の内訳（カテゴリID順）
+}
+
+// TagCategoryExpenses はタグの支出のカテゴリ別の内訳です。
+type TagCategoryExpenses struct {
+	CategoryID        int
+	CategoryName      string
+	ConfirmedExpenses int64
+	PlannedExpenses   int64
+}
+
+// roundPercent は割合を小数第1位までのパーセントに丸めます。
+func roundPercent(ratio float64) float64 {
+	return math.Round(ratio*1000) / 10
+}
+
+// ReportService はレポートサービスのインターフェースです。
+type ReportService interface {
+	// GetTrends は from から to（YYYY-MM、両端を含む）までの月ごとの集計とカテゴリ別の支出の推移を返します。
+	// to が空の場合は当月、from が空の場合は to を含む直近12か月を対象とします。
+	// fixedCostMode は毎月以外の固定費の計上方法（amortize / billing_month）で、空の場合は amortize とします。
+	GetTrends(ctx context.Context, userID string, from, to string, fixedCostMode string) (*Trends, error)
+	// GetEstimateAccuracy は from から to（YYYY-MM、両端を含む）までに予定から確定にした支出について、
+	// 予定金額と確定した金額の差を月別・カテゴリ別に集計します。期間の既定値は GetTrends と同じです。
+	GetEstimateAccuracy(ctx context.Context, userID string, from, to string) (*EstimateAccuracy, error)
+	// GetTagTotals は from から to（YYYY-MM-DD、両端を含む）までの支出をタグ別・カテゴリ別に集計します。
+	// to が空の場合は今日、from が空の場合は to を含む直近12か月の初日を対象とします。
+	GetTagTotals(ctx context.Context, userID string, from, to string) (*TagTotals, error)
+}
+
+type reportService struct {
+	repo repositories.DashboardRepository
+	now  func() time.Time
+}
+
+// NewReportService は ReportService の新しいインスタンスを作成します。
+func NewReportService(repo repositories.DashboardRepository) ReportService {
+	return &reportService{repo: repo, now: time.Now}
+}
+
+// GetTrends は月ごとの集計とカテゴリ別の支出の推移を取得します。
+func (s *reportService) GetTrends(ctx context.Context, userID string, from, to string, fixedCostMode string) (*Trends, error) {
+	fromMonth, toMonth, err := resolveMonthRange(from, to, s.now())
+	if err != nil {
+		return nil, err
+	}
+	mode, err := resolveFixedCostMode(fixedCostMode)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries, err := s.repo.ListMonthlySummaries(ctx, userID, fromMonth, toMonth, mode)
+	if err != nil {
+		return nil, err
+	}
+	categoryExpenses, err := s.repo.ListMonthlyCategoryExpenses(ctx, userID, fromMonth, toMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	trends := &Trends{
+		From:          fromMonth.Format("2006-01"),
+		To:            toMonth.Format("2006-01"),
+		FixedCostMode: mode,
+		Months:        make([]*Dashboard, 0, len(summaries)),
+	}
+	for _, ms := range summaries {
+		trends.Months = append(trends.Months, buildDashboard(ms.Month, mode, &ms.Summary, &ms.Expenses))
+	}
+	trends.Categories = buildCategoryTrends(fromMonth, toMonth, categoryExpenses)
+	return trends, nil
+}
+
+// buildCategoryTrends はカテゴリ別・月別の支出集計から、カテゴリごとに期間内の全月分の推移を組み立てます。
+// カテゴリの並びは集計結果の順序（カテゴリID順）に従います。
+func buildCategoryTrends(from, to time.Time, rows []repositories.MonthlyCategoryExpenses) []CategoryTrend {
+	monthCount := 0
+	for m := from; !m.After(to); m = m.AddDate(0, 1, 0) {
+		monthCount++
+	}
+
+	trends := make([]CategoryTrend, 0)
+	index := make(map[int32]int)
+	for _, row := range rows {
+		i, ok := index[row.CategoryID]
+		if !ok {
+			series := make([]CategoryMonthlyExpenses, 0, monthCount)
+			for m := from; !m.After(to); m = m.AddDate(0, 1, 0) {
+				series = append(series, CategoryMonthlyExpenses{Month: m.Format("2006-01")})
+			}
+			i = len(trends)
+			index[row.CategoryID] = i
+			trends = append(trends, CategoryTrend{
+				CategoryID:   int(row.CategoryID),
+				CategoryName: row.CategoryName,
+				Series:       series,
+			})
+		}
+
+		// 月の位置は開始月からの月数で求める
+		month := row.Month.UTC()
+		pos := (month.Year()-from.Year())*12 + int(month.Month()) - int(from.Month())
+		if pos < 0 || pos >= monthCount {
+			continue
+		}
+		trends[i].Series[pos].ConfirmedExpenses = row.ConfirmedExpenses
+		trends[i].Series[pos].PlannedExpenses = row.PlannedExpenses
+	}
+	return trends
+}
+
+// GetEstimateAccuracy は予定から確定にした支出の見積もり精度を取得します。
+func (s *reportService) GetEstimateAccuracy(ctx context.Context, userID string, from, to string) (*EstimateAccuracy, error) {
+	fromMonth, toMonth, err := resolveMonthRange(from, to, s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repo.ListMonthlyCategoryEstimateAccuracy(ctx, userID, fromMonth, toMonth)
+	if err != nil {
+		return nil, err
+	}
+
+	report := buildEstimateAccuracy(rows)
+	report.From = fromMonth.Format("2006-01")
+	report.To = toMonth.Format("2006-01")
+	return report, nil
+}
+
+// buildEstimateAccuracy は月別・カテゴリ別の集計（古い月から順、同じ月の中はカテゴリID順）から、
+// 月ごと・カテゴリごと・期間全体の見積もり精度を組み立てます。
+func buildEstimateAccuracy(rows []repositories.MonthlyCategoryEstimateAccuracy) *EstimateAccuracy {
+	report := &EstimateAccuracy{
+		Categories: make([]CategoryEstimateAccuracy, 0),
+		Months:     make([]MonthlyEstimateAccuracy, 0),
+	}
+	categoryIndex := make(map[int32]int)
+	for _, row := range rows {
+		report.Total.add(row)
+
+		i, ok := categoryIndex[row.CategoryID]
+		if !ok {
+			i = len(report.Categories)
+			categoryIndex[row.CategoryID] = i
+			report.Categories = append(report.Categories, CategoryEstimateAccuracy{
+				CategoryID:   int(row.CategoryID),
+				CategoryName: row.CategoryName,
+			})
+		}
+		report.Categories[i].add(row)
+
+		month := row.Month.UTC().Format("2006-01")
+		if n := len(report.Months); n == 0 || report.Months[n-1].Month != month {
+			report.Months = append(report.Months, MonthlyEstimateAccuracy{
+				Month:      month,
+				Categories: make([]CategoryEstimateAccuracy, 0),
+			})
+		}
+		m := &report.Months[len(report.Months)-1]
+		m.add(row)
+		category := CategoryEstimateAccuracy{CategoryID: int(row.CategoryID), CategoryName: row.CategoryName}
+		category.add(row)
+		m.Categories = append(m.Categories, category)
+	}
+
+	// 期間全体のカテゴリ別の集計は月をまたいで出現順になるため、カテゴリID順に並べ直す
+	sort.Slice(report.Categories, func(a, b int) bool {
+		return report.Categories[a].CategoryID < report.Categories[b].CategoryID
+	})
+	return report
+}
+
+// GetTagTotals はタグ別の支出を取得します。
+func (s *reportService) GetTagTotals(ctx context.Context, userID string, from, to string) (*TagTotals, error) {
+	fromDate, toDate, err := resolveDateRange(from, to, s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := s.repo.ListTagTotals(ctx, userID, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+	categories, err := s.repo.ListTagCategoryExpenses(ctx, userID, fromDate, toDate)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &TagTotals{
+		From: fromDate.Format("2006-01-02"),
+		To:   toDate.Format("2006-01-02"),
+		Tags: make([]TagTotal, 0, len(totals)),
+	}
+	index := make(map[int32]int, len(totals))
+	for _, row := range totals {
+		index[row.TagID] = len(report.Tags)
+		report.Tags = append(report.Tags, TagTotal{
+			TagID:             int(row.TagID),
+			TagName:           row.TagName,
+			ExpenseCount:      row.ExpenseCount,
+			ConfirmedExpenses: row.ConfirmedExpenses,
+			PlannedExpenses:   row.PlannedExpenses,
+			Categories:        make([]TagCategoryExpenses, 0),
+		})
+	}
+	for _, row := range categories {
+		i, ok := index[row.TagID]
+		if !ok {
+			continue
+		}
+		report.Tags[i].Categories = append(report.Tags[i].Categories, TagCategoryExpenses{
+			CategoryID:        int(row.CategoryID),
+			CategoryName:      row.CategoryName,
+			ConfirmedExpenses: row.ConfirmedExpenses,
+			PlannedExpenses:   row.PlannedExpenses,
+		})
+	}
+	return report, nil
+}
+
+// resolveDateRange は YYYY-MM-DD 形式の期間を検証し、未指定の値を補います。
+// to が空の場合は今日、from が空の場合は to を含む月の11か月前の月初日を使います。
+func resolveDateRange(from, to string, now time.Time) (time.Time, time.Time, error) {
+	toDate := calendarDate(now)
+	if to != "" {
+		var err error
+		if toDate, err = time.Parse("2006-01-02", to); err != nil {
+			return time.Time{}, time.Time{}, &ValidationError{Message: "終了日は YYYY-MM-DD 形式で指定してください"}
+		}
+	}
+	fromDate := monthStart(toDate).AddDate(0, -11, 0)
+	if from != "" {
+		var err error
+		if fromDate, err = time.Parse("2006-01-02", from); err != nil {
+			return time.Time{}, time.Time{}, &ValidationError{Message: "開始日は YYYY-MM-DD 形式で指定してください"}
+		}
+	}
+	if fromDate.After(toDate) {
+		return time.Time{}, time.Time{}, &ValidationError{Message: "開始日は終了日以前を指定してください"}
+	}
+	if !fromDate.AddDate(0, MonthRangeMaxMonths, 0).After(toDate) {
+		return time.Time{}, time.Time{}, &ValidationError{Message: "期間は120か月以内で指定してください"}
+	}
+	return fromDate, toDate, nil
+}
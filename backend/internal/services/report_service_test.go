@@ -0,0 +1,302 @@
+package services
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/repositories"
+)
+
+// TestGetTrends は推移レポートのテストです
+func TestGetTrends(t *testing.T) {
+	var gotFrom, gotTo time.Time
+	var gotMode models.FixedCostMode
+	repo := &mockDashboardRepo{
+		listMonthlySummariesFunc: func(ctx context.Context, userID string, from, to time.Time, mode models.FixedCostMode) ([]repositories.MonthlyTrendSummary, error) {
+			assert.Equal(t, "test-user", userID)
+			gotFrom, gotTo, gotMode = from, to, mode
+			var out []repositories.MonthlyTrendSummary
+			for m := from; !m.After(to); m = m.AddDate(0, 1, 0) {
+				out = append(out, repositories.MonthlyTrendSummary{
+					Month:    m,
+					Summary:  repositories.MonthlySummary{Income: 300000, SavingGoal: 50000, FixedCosts: 100000},
+					Expenses: repositories.MonthlyExpensesSummary{ConfirmedExpenses: int64(m.Month()) * 1000, PlannedExpenses: 500},
+				})
+			}
+			return out, nil
+		},
+		listCategoryExpensesFunc: func(ctx context.Context, userID string, from, to time.Time) ([]repositories.MonthlyCategoryExpenses, error) {
+			return []repositories.MonthlyCategoryExpenses{
+				{Month: date("2024-12-01"), CategoryID: 1, CategoryName: "食費", ConfirmedExpenses: 30000},
+				{Month: date("2025-02-01"), CategoryID: 1, CategoryName: "食費", ConfirmedExpenses: 28000, PlannedExpenses: 2000},
+				{Month: date("2025-01-01"), CategoryID: 3, CategoryName: "交通費", ConfirmedExpenses: 5000},
+			}, nil
+		},
+	}
+	service := NewReportService(repo)
+
+	trends, err := service.GetTrends(context.Background(), "test-user", "2024-11", "2025-02", "billing_month")
+
+	require.NoError(t, err)
+	assert.Equal(t, date("2024-11-01"), gotFrom)
+	assert.Equal(t, date("2025-02-01"), gotTo)
+	assert.Equal(t, models.FixedCostModeBillingMonth, gotMode)
+	assert.Equal(t, "2024-11", trends.From)
+	assert.Equal(t, "2025-02", trends.To)
+	assert.Equal(t, models.FixedCostModeBillingMonth, trends.FixedCostMode)
+
+	require.Len(t, trends.Months, 4)
+	assert.Equal(t, "2024-11", trends.Months[0].Month)
+	assert.Equal(t, "2025-02", trends.Months[3].Month)
+	assert.Equal(t, int64(150000), trends.Months[3].VariableBudget)
+	// 残額 = 150000 - (2000 + 500)
+	assert.Equal(t, int64(147500), trends.Months[3].Remaining)
+
+	// カテゴリごとに期間内の全月分を返し、支出がない月は 0 とする
+	require.Len(t, trends.Categories, 2)
+	food := trends.Categories[0]
+	assert.Equal(t, 1, food.CategoryID)
+	assert.Equal(t, "食費", food.CategoryName)
+	assert.Equal(t, []CategoryMonthlyExpenses{
+		{Month: "2024-11"},
+		{Month: "2024-12", ConfirmedExpenses: 30000},
+		{Month: "2025-01"},
+		{Month: "2025-02", ConfirmedExpenses: 28000, PlannedExpenses: 2000},
+	}, food.Series)
+	transport := trends.Categories[1]
+	assert.Equal(t, 3, transport.CategoryID)
+	require.Len(t, transport.Series, 4)
+	assert.Equal(t, int64(5000), transport.Series[2].ConfirmedExpenses)
+}
+
+// TestGetTrends_DefaultPeriod は期間未指定時に当月までの12か月を対象とすることのテストです
+func TestGetTrends_DefaultPeriod(t *testing.T) {
+	var gotFrom, gotTo time.Time
+	repo := &mockDashboardRepo{
+		listMonthlySummariesFunc: func(ctx context.Context, userID string, from, to time.Time, mode models.FixedCostMode) ([]repositories.MonthlyTrendSummary, error) {
+			gotFrom, gotTo = from, to
+			return []repositories.MonthlyTrendSummary{{Month: from}}, nil
+		},
+	}
+	service := &reportService{
+		repo: repo,
+		now:  func() time.Time { return time.Date(2025, 11, 18, 9, 30, 0, 0, time.UTC) },
+	}
+
+	trends, err := service.GetTrends(context.Background(), "test-user", "", "", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, date("2024-12-01"), gotFrom)
+	assert.Equal(t, date("2025-11-01"), gotTo)
+	assert.Equal(t, models.FixedCostModeAmortize, trends.FixedCostMode)
+	assert.Empty(t, trends.Categories)
+}
+
+// TestGetTrends_InvalidParams は期間・固定費の計上方法が不正な場合のテストです
+func TestGetTrends_InvalidParams(t *testing.T) {
+	cases := []struct{ name, from, to, mode string }{
+		{name: "開始月の形式が不正", from: "2025/01", to: "2025-02"},
+		{name: "開始月が終了月より後", from: "2025-03", to: "2025-02"},
+		{name: "期間が上限を超える", from: "2015-01", to: "2025-01"},
+		{name: "固定費の計上方法が不正", from: "2025-01", to: "2025-02", mode: "daily"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := NewReportService(&mockDashboardRepo{})
+
+			_, err := service.GetTrends(context.Background(), "test-user", tc.from, tc.to, tc.mode)
+
+			var ve *ValidationError
+			assert.ErrorAs(t, err, &ve)
+		})
+	}
+}
+
+// TestGetTrends_UserNotFound はユーザーが存在しない場合のテストです
+func TestGetTrends_UserNotFound(t *testing.T) {
+	repo := &mockDashboardRepo{
+		listMonthlySummariesFunc: func(ctx context.Context, userID string, from, to time.Time, mode models.FixedCostMode) ([]repositories.MonthlyTrendSummary, error) {
+			return nil, sql.ErrNoRows
+		},
+	}
+	service := NewReportService(repo)
+
+	trends, err := service.GetTrends(context.Background(), "test-user", "2025-01", "2025-02", "")
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.Nil(t, trends)
+}
+
+// TestGetEstimateAccuracy は見積もり精度レポートのテストです
+func TestGetEstimateAccuracy(t *testing.T) {
+	var gotFrom, gotTo time.Time
+	repo := &mockDashboardRepo{
+		listEstimateAccuracyFunc: func(ctx context.Context, userID string, from, to time.Time) ([]repositories.MonthlyCategoryEstimateAccuracy, error) {
+			assert.Equal(t, "test-user", userID)
+			gotFrom, gotTo = from, to
+			return []repositories.MonthlyCategoryEstimateAccuracy{
+				{Month: date("2025-01-01"), CategoryID: 3, CategoryName: "旅行", ExpenseCount: 1, PlannedTotal: 30000, ActualTotal: 27000, AbsoluteVariance: 3000, OverestimatedCount: 1},
+				{Month: date("2025-02-01"), CategoryID: 1, CategoryName: "食費", ExpenseCount: 2, PlannedTotal: 30000, ActualTotal: 33000, AbsoluteVariance: 5000, UnderestimatedCount: 1, OverestimatedCount: 1},
+				{Month: date("2025-02-01"), CategoryID: 3, CategoryName: "旅行", ExpenseCount: 1, PlannedTotal: 10000, ActualTotal: 10000},
+			}, nil
+		},
+	}
+	service := NewReportService(repo)
+
+	report, err := service.GetEstimateAccuracy(context.Background(), "test-user", "2025-01", "2025-02")
+
+	require.NoError(t, err)
+	assert.Equal(t, date("2025-01-01"), gotFrom)
+	assert.Equal(t, date("2025-02-01"), gotTo)
+	assert.Equal(t, "2025-01", report.From)
+	assert.Equal(t, "2025-02", report.To)
+
+	assertStats := func(t *testing.T, st EstimateAccuracyStats, count, planned, actual, variance int64, rate, accuracy float64, under, over int64) {
+		t.Helper()
+		assert.Equal(t, count, st.ExpenseCount)
+		assert.Equal(t, planned, st.PlannedTotal)
+		assert.Equal(t, actual, st.ActualTotal)
+		assert.Equal(t, variance, st.Variance)
+		require.NotNil(t, st.VarianceRate)
+		assert.Equal(t, rate, *st.VarianceRate)
+		require.NotNil(t, st.Accuracy)
+		assert.Equal(t, accuracy, *st.Accuracy)
+		assert.Equal(t, under, st.UnderestimatedCount)
+		assert.Equal(t, over, st.OverestimatedCount)
+	}
+
+	// 見積もり精度 = 100 - 差額の絶対値の合計 / 予定金額の合計
+	assertStats(t, report.Total, 4, 70000, 70000, 0, 0, 88.6, 1, 2)
+
+	// 期間全体のカテゴリ別の集計はカテゴリID順
+	require.Len(t, report.Categories, 2)
+	assert.Equal(t, 1, report.Categories[0].CategoryID)
+	assert.Equal(t, "食費", report.Categories[0].CategoryName)
+	assertStats(t, report.Categories[0].EstimateAccuracyStats, 2, 30000, 33000, 3000, 10, 83.3, 1, 1)
+	assert.Equal(t, 3, report.Categories[1].CategoryID)
+	assertStats(t, report.Categories[1].EstimateAccuracyStats, 2, 40000, 37000, -3000, -7.5, 92.5, 0, 1)
+
+	require.Len(t, report.Months, 2)
+	assert.Equal(t, "2025-01", report.Months[0].Month)
+	assertStats(t, report.Months[0].EstimateAccuracyStats, 1, 30000, 27000, -3000, -10, 90, 0, 1)
+	require.Len(t, report.Months[0].Categories, 1)
+	assert.Equal(t, 3, report.Months[0].Categories[0].CategoryID)
+	assert.Equal(t, "2025-02", report.Months[1].Month)
+	assertStats(t, report.Months[1].EstimateAccuracyStats, 3, 40000, 43000, 3000, 7.5, 87.5, 1, 1)
+	require.Len(t, report.Months[1].Categories, 2)
+	assertStats(t, report.Months[1].Categories[1].EstimateAccuracyStats, 1, 10000, 10000, 0, 0, 100, 0, 0)
+}
+
+// TestGetEstimateAccuracy_NoExpenses は予定から確定にした支出がない場合のテストです
+func TestGetEstimateAccuracy_NoExpenses(t *testing.T) {
+	service := NewReportService(&mockDashboardRepo{})
+
+	report, err := service.GetEstimateAccuracy(context.Background(), "test-user", "2025-01", "2025-02")
+
+	require.NoError(t, err)
+	assert.Zero(t, report.Total.ExpenseCount)
+	assert.Nil(t, report.Total.VarianceRate)
+	assert.Nil(t, report.Total.Accuracy)
+	assert.NotNil(t, report.Categories)
+	assert.Empty(t, report.Categories)
+	assert.NotNil(t, report.Months)
+	assert.Empty(t, report.Months)
+}
+
+// TestGetEstimateAccuracy_InvalidPeriod は期間が不正な場合のテストです
+func TestGetEstimateAccuracy_InvalidPeriod(t *testing.T) {
+	service := NewReportService(&mockDashboardRepo{})
+
+	_, err := service.GetEstimateAccuracy(context.Background(), "test-user", "2025-03", "2025-02")
+
+	var ve *ValidationError
+	assert.ErrorAs(t, err, &ve)
+}
+
+// TestGetTagTotals はタグ別レポートのテストです
+func TestGetTagTotals(t *testing.T) {
+	var gotFrom, gotTo time.Time
+	repo := &mockDashboardRepo{
+		listTagTotalsFunc: func(ctx context.Context, userID string, from, to time.Time) ([]repositories.TagTotal, error) {
+			gotFrom, gotTo = from, to
+			return []repositories.TagTotal{
+				{TagID: 2, TagName: "沖縄旅行2026", ExpenseCount: 3, ConfirmedExpenses: 90000, PlannedExpenses: 20000},
+				{TagID: 1, TagName: "仕事関連", ExpenseCount: 1, ConfirmedExpenses: 3000},
+			}, nil
+		},
+		listTagCategoryExpensesFunc: func(ctx context.Context, userID string, from, to time.Time) ([]repositories.TagCategoryExpenses, error) {
+			return []repositories.TagCategoryExpenses{
+				{TagID: 1, CategoryID: 3, CategoryName: "交通費", ConfirmedExpenses: 3000},
+				{TagID: 2, CategoryID: 1, CategoryName: "食費", ConfirmedExpenses: 30000},
+				{TagID: 2, CategoryID: 3, CategoryName: "交通費", ConfirmedExpenses: 60000, PlannedExpenses: 20000},
+			}, nil
+		},
+	}
+	service := NewReportService(repo)
+
+	report, err := service.GetTagTotals(context.Background(), "test-user", "2026-01-01", "2026-03-31")
+
+	require.NoError(t, err)
+	assert.Equal(t, date("2026-01-01"), gotFrom)
+	assert.Equal(t, date("2026-03-31"), gotTo)
+	assert.Equal(t, "2026-01-01", report.From)
+	assert.Equal(t, "2026-03-31", report.To)
+	require.Len(t, report.Tags, 2)
+	// 集計の並び（確定支出の多い順）を保つ
+	assert.Equal(t, "沖縄旅行2026", report.Tags[0].TagName)
+	assert.Equal(t, int64(20000), report.Tags[0].PlannedExpenses)
+	assert.Equal(t, []TagCategoryExpenses{
+		{CategoryID: 1, CategoryName: "食費", ConfirmedExpenses: 30000},
+		{CategoryID: 3, CategoryName: "交通費", ConfirmedExpenses: 60000, PlannedExpenses: 20000},
+	}, report.Tags[0].Categories)
+	assert.Len(t, report.Tags[1].Categories, 1)
+}
+
+// TestGetTagTotals_DefaultPeriod は期間を省略した場合に今日を含む直近12か月を集計するテストです
+func TestGetTagTotals_DefaultPeriod(t *testing.T) {
+	var gotFrom, gotTo time.Time
+	repo := &mockDashboardRepo{
+		listTagTotalsFunc: func(ctx context.Context, userID string, from, to time.Time) ([]repositories.TagTotal, error) {
+			gotFrom, gotTo = from, to
+			return nil, nil
+		},
+	}
+	service := &reportService{
+		repo: repo,
+		now:  func() time.Time { return time.Date(2025, 11, 18, 9, 30, 0, 0, time.UTC) },
+	}
+
+	report, err := service.GetTagTotals(context.Background(), "test-user", "", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, date("2024-12-01"), gotFrom)
+	assert.Equal(t, date("2025-11-18"), gotTo)
+	assert.NotNil(t, report.Tags)
+	assert.Empty(t, report.Tags)
+}
+
+// TestGetTagTotals_InvalidPeriod は期間が不正な場合のテストです
+func TestGetTagTotals_InvalidPeriod(t *testing.T) {
+	cases := []struct{ name, from, to string }{
+		{name: "開始日の形式が不正", from: "2025-01", to: "2025-02-01"},
+		{name: "終了日の形式が不正", from: "2025-01-01", to: "2025/02/01"},
+		{name: "開始日が終了日より後", from: "2025-03-01", to: "2025-02-28"},
+		{name: "期間が上限を超える", from: "2015-01-01", to: "2025-01-01"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			service := NewReportService(&mockDashboardRepo{})
+
+			_, err := service.GetTagTotals(context.Background(), "test-user", tc.from, tc.to)
+
+			var ve *ValidationError
+			assert.ErrorAs(t, err, &ve)
+		})
+	}
+}
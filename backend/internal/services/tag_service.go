@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"unicode/utf8"
+
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/repositories"
+)
+
+const (
+	// TagNameMaxLen はタグ名の最大文字数
+	TagNameMaxLen = 50
+)
+
+// TagService はタグの一覧・名前の変更・削除を扱います。
+// タグの作成と支出への付け外しは ExpenseService の登録・更新で行います。
+type TagService interface {
+	ListTags(ctx context.Context, userID string) ([]models.TagSummary, error)
+	RenameTag(ctx context.Context, userID string, id int, name string) (models.Tag, error)
+	// DeleteTag はタグを削除し、支出からも外します。支出は削除しません。
+	DeleteTag(ctx context.Context, userID string, id int) error
+}
+
+type tagService struct {
+	repo repositories.TagRepository
+}
+
+func NewTagService(repo repositories.TagRepository) TagService {
+	return &tagService{repo: repo}
+}
+
+func (s *tagService) ListTags(ctx context.Context, userID string) ([]models.TagSummary, error) {
+	return s.repo.ListTags(ctx, userID)
+}
+
+func (s *tagService) RenameTag(ctx context.Context, userID string, id int, name string) (models.Tag, error) {
+	name = strings.TrimSpace(name)
+	if err := validateTagName(name); err != nil {
+		return models.Tag{}, err
+	}
+
+	tags, err := s.repo.ListTags(ctx, userID)
+	if err != nil {
+		return models.Tag{}, err
+	}
+	found := false
+	for _, t := range tags {
+		if t.ID == id {
+			found = true
+		} else if t.Name == name {
+			return models.Tag{}, &ValidationError{Message: "同じ名前のタグが既に存在します"}
+		}
+	}
+	if !found {
+		return models.Tag{}, &NotFoundError{Message: "タグが見つかりません"}
+	}
+
+	renamed, err := s.repo.RenameTag(ctx, userID, int32(id), name)
+	if err != nil {
+		return models.Tag{}, err
+	}
+	if !renamed {
+		return models.Tag{}, &NotFoundError{Message: "タグが見つかりません"}
+	}
+
+	return models.Tag{ID: id, Name: name}, nil
+}
+
+func (s *tagService) DeleteTag(ctx context.Context, userID string, id int) error {
+	deleted, err := s.repo.DeleteTag(ctx, userID, int32(id))
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return &NotFoundError{Message: "タグが見つかりません"}
+	}
+	return nil
+}
+
+// validateTagName はタグ名の入力チェックを行います（呼び出し側で既にTrimSpaceされていることを前提）。
+func validateTagName(name string) error {
+	if name == "" {
+		return &ValidationError{Message: "タグ名を入力してください"}
+	}
+	if utf8.RuneCountInString(name) > TagNameMaxLen {
+		return &ValidationError{Message: "タグ名は50文字以内で入力してください"}
+	}
+	return nil
+}
+
+// normalizeTagNames は支出に付けるタグ名の前後の空白を除いて検証し、重複を取り除きます（最初に指定した順序を保ちます）。
+func normalizeTagNames(names []string) ([]string, error) {
+	out := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if err := validateTagName(name); err != nil {
+			return nil, err
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		out = append(out, name)
+	}
+	if len(out) > MaxExpenseTags {
+		return nil, &ValidationError{Message: "タグは20個以内で指定してください"}
+	}
+	return out, nil
+}
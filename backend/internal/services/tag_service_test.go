@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"money-buddy-backend/internal/models"
+)
+
+// tagRepoMock はタグリポジトリのモックです
+type tagRepoMock struct{ mock.Mock }
+
+func (m *tagRepoMock) ListTags(ctx context.Context, userID string) ([]models.TagSummary, error) {
+	args := m.Called(ctx, userID)
+	if list, ok := args.Get(0).([]models.TagSummary); ok {
+		return list, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *tagRepoMock) RenameTag(ctx context.Context, userID string, id int32, name string) (bool, error) {
+	args := m.Called(ctx, userID, id, name)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *tagRepoMock) DeleteTag(ctx context.Context, userID string, id int32) (bool, error) {
+	args := m.Called(ctx, userID, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestRenameTag(t *testing.T) {
+	ctx := context.Background()
+	tags := []models.TagSummary{
+		{Tag: models.Tag{ID: 1, Name: "沖縄旅行2026"}, ExpenseCount: 3},
+		{Tag: models.Tag{ID: 2, Name: "結婚式"}, ExpenseCount: 1},
+	}
+
+	t.Run("前後の空白を除いた名前に変更できる", func(t *testing.T) {
+		repo := new(tagRepoMock)
+		repo.On("ListTags", ctx, "user1").Return(tags, nil)
+		repo.On("RenameTag", ctx, "user1", int32(1), "沖縄旅行").Return(true, nil)
+
+		got, err := NewTagService(repo).RenameTag(ctx, "user1", 1, "  沖縄旅行 ")
+
+		assert.NoError(t, err)
+		assert.Equal(t, models.Tag{ID: 1, Name: "沖縄旅行"}, got)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("他のタグと同じ名前にはできない", func(t *testing.T) {
+		repo := new(tagRepoMock)
+		repo.On("ListTags", ctx, "user1").Return(tags, nil)
+
+		_, err := NewTagService(repo).RenameTag(ctx, "user1", 1, "結婚式")
+
+		var ve *ValidationError
+		assert.ErrorAs(t, err, &ve)
+		repo.AssertNotCalled(t, "RenameTag", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("名前が空・長すぎる場合はエラー", func(t *testing.T) {
+		for _, name := range []string{"", "   ", string(make([]rune, TagNameMaxLen+1))} {
+			repo := new(tagRepoMock)
+
+			_, err := NewTagService(repo).RenameTag(ctx, "user1", 1, name)
+
+			var ve *ValidationError
+			assert.ErrorAs(t, err, &ve)
+			repo.AssertNotCalled(t, "ListTags", mock.Anything, mock.Anything)
+		}
+	})
+
+	t.Run("存在しないタグは NotFound", func(t *testing.T) {
+		repo := new(tagRepoMock)
+		repo.On("ListTags", ctx, "user1").Return(tags, nil)
+
+		_, err := NewTagService(repo).RenameTag(ctx, "user1", 99, "仕事関連")
+
+		var ne *NotFoundError
+		assert.ErrorAs(t, err, &ne)
+	})
+}
+
+func TestDeleteTag(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("削除できる", func(t *testing.T) {
+		repo := new(tagRepoMock)
+		repo.On("DeleteTag", ctx, "user1", int32(1)).Return(true, nil)
+
+		err := NewTagService(repo).DeleteTag(ctx, "user1", 1)
+
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("存在しないタグは NotFound", func(t *testing.T) {
+		repo := new(tagRepoMock)
+		repo.On("DeleteTag", ctx, "user1", int32(99)).Return(false, nil)
+
+		err := NewTagService(repo).DeleteTag(ctx, "user1", 99)
+
+		var ne *NotFoundError
+		assert.ErrorAs(t, err, &ne)
+	})
+}
+
+func TestNormalizeTagNames(t *testing.T) {
+	t.Run("前後の空白を除き、重複を取り除く", func(t *testing.T) {
+		got, err := normalizeTagNames([]string{" 結婚式", "仕事関連", "結婚式 "})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"結婚式", "仕事関連"}, got)
+	})
+
+	t.Run("空のタグ名はエラー", func(t *testing.T) {
+		_, err := normalizeTagNames([]string{"結婚式", " "})
+
+		var ve *ValidationError
+		assert.ErrorAs(t, err, &ve)
+	})
+
+	t.Run("タグが多すぎる場合はエラー", func(t *testing.T) {
+		names := make([]string, 0, MaxExpenseTags+1)
+		for i := 0; i <= MaxExpenseTags; i++ {
+			names = append(names, string(rune('a'+i)))
+		}
+
+		_, err := normalizeTagNames(names)
+
+		var ve *ValidationError
+		assert.ErrorAs(t, err, &ve)
+	})
+}
@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/repositories"
+)
+
+const (
+	// TrashRetentionDays はゴミ箱に移した支出・固定費を復元できる日数
+	TrashRetentionDays = 30
+)
+
+// TrashService はゴミ箱（削除した支出・固定費）を扱います。
+// 削除から TrashRetentionDays 日以内であれば ExpenseService・FixedCostService の復元で元に戻せます。
+type TrashService interface {
+	ListTrash(ctx context.Context, userID string) (models.Trash, error)
+	// PurgeExpired は復元できる期限を過ぎた支出を完全に削除し、削除件数を返します。
+	// 固定費は過去月の集計に使われていないものだけを削除し、それ以外は解約済みとして残します。
+	PurgeExpired(ctx context.Context) (int64, error)
+}
+
+type trashService struct {
+	repo repositories.TrashRepository
+	now  func() time.Time
+}
+
+func NewTrashService(repo repositories.TrashRepository) TrashService {
+	return &trashService{repo: repo, now: time.Now}
+}
+
+func (s *trashService) ListTrash(ctx context.Context, userID string) (models.Trash, error) {
+	cutoff := trashCutoff(s.now())
+
+	expenses, err := s.repo.ListTrashedExpenses(ctx, userID, cutoff)
+	if err != nil {
+		return models.Trash{}, err
+	}
+	fixedCosts, err := s.repo.ListTrashedFixedCosts(ctx, userID, cutoff)
+	if err != nil {
+		return models.Trash{}, err
+	}
+
+	trash := models.Trash{
+		Expenses:   make([]models.TrashedExpense, 0, len(expenses)),
+		FixedCosts: make([]models.TrashedFixedCost, 0, len(fixedCosts)),
+	}
+	for _, e := range expenses {
+		trash.Expenses = append(trash.Expenses, models.TrashedExpense{
+			Expense:         e.Expense,
+			DeletedAt:       e.DeletedAt.Format(time.RFC3339),
+			RestorableUntil: trashRestorableUntil(e.DeletedAt).Format(time.RFC3339),
+		})
+	}
+	for _, fc := range fixedCosts {
+		trash.FixedCosts = append(trash.FixedCosts, models.TrashedFixedCost{
+			FixedCost:       fc.FixedCost,
+			EndedFrom:       fc.EndedFrom.Format("2006-01"),
+			DeletedAt:       fc.DeletedAt.Format(time.RFC3339),
+			RestorableUntil: trashRestorableUntil(fc.DeletedAt).Format(time.RFC3339),
+		})
+	}
+	return trash, nil
+}
+
+func (s *trashService) PurgeExpired(ctx context.Context) (int64, error) {
+	cutoff := trashCutoff(s.now())
+
+	expenses, err := s.repo.PurgeTrashedExpenses(ctx, cutoff)
+	if err != nil {
+		return 0, err
+	}
+	fixedCosts, err := s.repo.PurgeTrashedFixedCosts(ctx, cutoff)
+	if err != nil {
+		return expenses, err
+	}
+	return expenses + fixedCosts, nil
+}
+
+// trashCutoff は now の時点で復元できる、最も古い削除日時（これより後に削除したもの）を返します。
+func trashCutoff(now time.Time) time.Time {
+	return now.AddDate(0, 0, -TrashRetentionDays)
+}
+
+// trashRestorableUntil は deletedAt に削除したものを復元できる期限を返します。
+func trashRestorableUntil(deletedAt time.Time) time.Time {
+	return deletedAt.AddDate(0, 0, TrashRetentionDays)
+}
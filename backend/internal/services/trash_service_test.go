@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"money-buddy-backend/internal/models"
+	"money-buddy-backend/internal/repositories"
+)
+
+// mockTrashRepo は TrashRepository のモック実装です
+type mockTrashRepo struct {
+	expenses          []repositories.TrashedExpense
+	fixedCosts        []repositories.TrashedFixedCost
+	listErr           error
+	purgedExpenses    int64
+	purgedFixedCosts  int64
+	purgeErr          error
+	deletedAfter      time.Time
+	deletedBefore     time.Time
+	purgeFixedCostsOK bool
+}
+
+func (m *mockTrashRepo) ListTrashedExpenses(ctx context.Context, userID string, deletedAfter time.Time) ([]repositories.TrashedExpense, error) {
+	m.deletedAfter = deletedAfter
+	return m.expenses, m.listErr
+}
+
+func (m *mockTrashRepo) ListTrashedFixedCosts(ctx context.Context, userID string, deletedAfter time.Time) ([]repositories.TrashedFixedCost, error) {
+	return m.fixedCosts, m.listErr
+}
+
+func (m *mockTrashRepo) PurgeTrashedExpenses(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	m.deletedBefore = deletedBefore
+	return m.purgedExpenses, m.purgeErr
+}
+
+func (m *mockTrashRepo) PurgeTrashedFixedCosts(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	m.purgeFixedCostsOK = deletedBefore.Equal(m.deletedBefore)
+	return m.purgedFixedCosts, nil
+}
+
+// TestListTrash はゴミ箱の一覧取得のテストです
+func TestListTrash(t *testing.T) {
+	now := time.Date(2025, 6, 18, 9, 0, 0, 0, time.UTC)
+	deletedAt := time.Date(2025, 6, 1, 12, 30, 0, 0, time.UTC)
+
+	t.Run("削除日時と復元できる期限を付けて返す", func(t *testing.T) {
+		repo := &mockTrashRepo{
+			expenses: []repositories.TrashedExpense{
+				{Expense: models.Expense{ID: 1, Amount: 1200}, DeletedAt: deletedAt},
+			},
+			fixedCosts: []repositories.TrashedFixedCost{
+				{FixedCost: models.FixedCost{ID: 2, Name: "動画配信"}, EndedFrom: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), DeletedAt: deletedAt},
+			},
+		}
+		svc := &trashService{repo: repo, now: func() time.Time { return now }}
+
+		trash, err := svc.ListTrash(context.Background(), "user-1")
+		require.NoError(t, err)
+
+		assert.Equal(t, time.Date(2025, 5, 19, 9, 0, 0, 0, time.UTC), repo.deletedAfter)
+		require.Len(t, trash.Expenses, 1)
+		assert.Equal(t, 1, trash.Expenses[0].ID)
+		assert.Equal(t, "2025-06-01T12:30:00Z", trash.Expenses[0].DeletedAt)
+		assert.Equal(t, "2025-07-01T12:30:00Z", trash.Expenses[0].RestorableUntil)
+		require.Len(t, trash.FixedCosts, 1)
+		assert.Equal(t, "動画配信", trash.FixedCosts[0].Name)
+		assert.Equal(t, "2025-06", trash.FixedCosts[0].EndedFrom)
+		assert.Equal(t, "2025-07-01T12:30:00Z", trash.FixedCosts[0].RestorableUntil)
+	})
+
+	t.Run("空の場合も空配列を返す", func(t *testing.T) {
+		svc := &trashService{repo: &mockTrashRepo{}, now: func() time.Time { return now }}
+
+		trash, err := svc.ListTrash(context.Background(), "user-1")
+		require.NoError(t, err)
+		assert.NotNil(t, trash.Expenses)
+		assert.NotNil(t, trash.FixedCosts)
+	})
+
+	t.Run("リポジトリのエラーを返す", func(t *testing.T) {
+		svc := &trashService{repo: &mockTrashRepo{listErr: errors.New("db error")}, now: func() time.Time { return now }}
+
+		_, err := svc.ListTrash(context.Background(), "user-1")
+		assert.Error(t, err)
+	})
+}
+
+// TestPurgeExpiredTrash は期限切れのゴミ箱の削除のテストです
+func TestPurgeExpiredTrash(t *testing.T) {
+	now := time.Date(2025, 6, 18, 9, 0, 0, 0, time.UTC)
+
+	t.Run("30日より前に削除した支出・固定費を削除する", func(t *testing.T) {
+		repo := &mockTrashRepo{purgedExpenses: 3, purgedFixedCosts: 1}
+		svc := &trashService{repo: repo, now: func() time.Time { return now }}
+
+		n, err := svc.PurgeExpired(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), n)
+		assert.Equal(t, time.Date(2025, 5, 19, 9, 0, 0, 0, time.UTC), repo.deletedBefore)
+		assert.True(t, repo.purgeFixedCostsOK)
+	})
+
+	t.Run("支出の削除に失敗した場合はエラーを返す", func(t *testing.T) {
+		repo := &mockTrashRepo{purgeErr: errors.New("db error")}
+		svc := &trashService{repo: repo, now: func() time.Time { return now }}
+
+		_, err := svc.PurgeExpired(context.Background())
+		assert.Error(t, err)
+		assert.False(t, repo.purgeFixedCostsOK)
+	})
+}
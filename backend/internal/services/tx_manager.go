@@ -7,9 +7,54 @@ type Tx interface {
 	Commit() error
 	Rollback() error
 	Context(ctx context.Context) context.Context
+	// Savepoint はトランザクション内に name のセーブポイントを作成します。
+	Savepoint(ctx context.Context, name string) error
+	// RollbackToSavepoint は name のセーブポイント以降の変更のみを取り消します。
+	RollbackToSavepoint(ctx context.Context, name string) error
 }
 
 // TxManager はトランザクション開始を担うインターフェースです。
 type TxManager interface {
 	Begin(ctx context.Context) (Tx, error)
 }
+
+// txScopeKey は withTxScope が開始したトランザクションを ctx に保持するためのキーです。
+type txScopeKey struct{}
+
+// withTx は fn を1つのトランザクション内で実行し、fn が成功した場合はコミットします。
+// fn がエラーを返した場合はロールバックし、そのエラーをそのまま返します。
+// ctx が withTxScope のトランザクション内の場合は、そのトランザクションで fn を実行し、
+// コミット・ロールバックは withTxScope に任せます。
+func withTx(ctx context.Context, txManager TxManager, fn func(txCtx context.Context) error) error {
+	if _, ok := ctx.Value(txScopeKey{}).(Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := txManager.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx.Context(ctx)); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// withTxScope は withTx と同じく fn を1つのトランザクション内で実行します。
+// fn の中で呼び出した withTx はこのトランザクションに参加するため、複数のサービスメソッドを1つのトランザクションにまとめられます。
+// fn はセーブポイントを使うためにトランザクションを受け取ります。
+func withTxScope(ctx context.Context, txManager TxManager, fn func(txCtx context.Context, tx Tx) error) error {
+	tx, err := txManager.Begin(ctx)
+	if err != nil {
+		return err
+	}
+
+	txCtx := context.WithValue(tx.Context(ctx), txScopeKey{}, tx)
+	if err := fn(txCtx, tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
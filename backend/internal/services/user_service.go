@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"time"
 
 	"money-buddy-backend/internal/models"
 	"money-buddy-backend/internal/repositories"
@@ -9,16 +10,24 @@ import (
 
 type UserService interface {
 	GetUserByID(ctx context.Context, userID string) (*models.User, error)
-	UpdateUserSettings(ctx context.Context, userID string, income int, savingGoal int) error
+	// UpdateUserSettings は effectiveFrom（YYYY-MM、省略時は当月）から適用する収入・貯金目標を登録します。
+	// 適用開始月より前の月の集計には影響しません。変更と同じトランザクションで変更履歴を記録します。
+	UpdateUserSettings(ctx context.Context, userID string, income int, savingGoal int, effectiveFrom string) error
 }
 
 type userService struct {
-	userRepo repositories.UserRepository
+	userRepo  repositories.UserRepository
+	auditRepo repositories.AuditRepository
+	txManager TxManager
+	now       func() time.Time
 }
 
-func NewUserService(userRepo repositories.UserRepository) UserService {
+func NewUserService(userRepo repositories.UserRepository, auditRepo repositories.AuditRepository, txManager TxManager) UserService {
 	return &userService{
-		userRepo: userRepo,
+		userRepo:  userRepo,
+		auditRepo: auditRepo,
+		txManager: txManager,
+		now:       time.Now,
 	}
 }
 
@@ -30,7 +39,7 @@ func (s *userService) GetUserByID(ctx context.Context, userID string) (*models.U
 	return &user, nil
 }
 
-func (s *userService) UpdateUserSettings(ctx context.Context, userID string, income int, savingGoal int) error {
+func (s *userService) UpdateUserSettings(ctx context.Context, userID string, income int, savingGoal int, effectiveFrom string) error {
 	// Validate income
 	if income <= 0 {
 		return &ValidationError{Message: "収入は1円以上で入力してください"}
@@ -47,6 +56,39 @@ func (s *userService) UpdateUserSettings(ctx context.Context, userID string, inc
 		return &ValidationError{Message: "貯金目標は10億円以下で入力してください"}
 	}
 
+	month, err := resolveEffectiveMonth(effectiveFrom, s.now())
+	if err != nil {
+		return err
+	}
+
 	// Update user settings
-	return s.userRepo.UpdateUserSettings(ctx, userID, income, savingGoal)
+	return withTx(ctx, s.txManager, func(txCtx context.Context) error {
+		current, err := s.userRepo.GetUserByID(txCtx, userID)
+		if err != nil {
+			return err
+		}
+		if err := s.userRepo.UpdateUserSettings(txCtx, userID, income, savingGoal, month); err != nil {
+			return err
+		}
+		before := map[string]any{"income": current.Income, "saving_goal": current.SavingGoal}
+		after := map[string]any{"income": income, "saving_goal": savingGoal, "effective_from": month.Format("2006-01")}
+		return recordAudit(txCtx, s.auditRepo, userID, models.AuditEntityUserSettings, 0, models.AuditActionUpdate, before, after)
+	})
+}
+
+// resolveEffectiveMonth は設定の適用開始月（月初日）を返します。
+// 空の場合は当月を使用します。過去の月は指定できますが、未来の月は指定できません。
+func resolveEffectiveMonth(month string, now time.Time) (time.Time, error) {
+	current := monthStart(now)
+	if month == "" {
+		return current, nil
+	}
+	t, err := time.Parse("2006-01", month)
+	if err != nil {
+		return time.Time{}, &ValidationError{Message: "適用開始月は YYYY-MM 形式で指定してください"}
+	}
+	if t.After(current) {
+		return time.Time{}, &ValidationError{Message: "適用開始月に未来の月は指定できません"}
+	}
+	return t, nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -13,10 +14,10 @@ import (
 
 type mockUserRepo struct {
 	getUserByIDFunc        func(ctx context.Context, id string) (models.User, error)
-	updateUserSettingsFunc func(ctx context.Context, id string, income int, savingGoal int) error
+	updateUserSettingsFunc func(ctx context.Context, id string, income int, savingGoal int, effectiveFrom time.Time) error
 }
 
-func (m *mockUserRepo) CreateUser(ctx context.Context, id string, income int, savingGoal int) error {
+func (m *mockUserRepo) CreateUser(ctx context.Context, id string, income int, savingGoal int, effectiveFrom time.Time) error {
 	return errors.New("not implemented")
 }
 
@@ -27,13 +28,18 @@ func (m *mockUserRepo) GetUserByID(ctx context.Context, id string) (models.User,
 	return models.User{}, errors.New("not implemented")
 }
 
-func (m *mockUserRepo) UpdateUserSettings(ctx context.Context, id string, income int, savingGoal int) error {
+func (m *mockUserRepo) UpdateUserSettings(ctx context.Context, id string, income int, savingGoal int, effectiveFrom time.Time) error {
 	if m.updateUserSettingsFunc != nil {
-		return m.updateUserSettingsFunc(ctx, id, income, savingGoal)
+		return m.updateUserSettingsFunc(ctx, id, income, savingGoal, effectiveFrom)
 	}
 	return errors.New("not implemented")
 }
 
+// existingUser は収入・貯金目標を登録済みのユーザーを返します（設定の更新前の状態）
+func existingUser(ctx context.Context, id string) (models.User, error) {
+	return models.User{ID: id, Income: 280000, SavingGoal: 30000}, nil
+}
+
 func TestUserService_GetUserByID_Success(t *testing.T) {
 	expectedUser := models.User{
 		ID:         "test-user",
@@ -50,7 +56,7 @@ func TestUserService_GetUserByID_Success(t *testing.T) {
 		},
 	}
 
-	service := NewUserService(repo)
+	service := NewUserService(repo, &mockAuditRepo{}, &fakeTxManager{})
 	user, err := service.GetUserByID(context.Background(), "test-user")
 
 	require.NoError(t, err)
@@ -67,7 +73,7 @@ func TestUserService_GetUserByID_NotFound(t *testing.T) {
 		},
 	}
 
-	service := NewUserService(repo)
+	service := NewUserService(repo, &mockAuditRepo{}, &fakeTxManager{})
 	user, err := service.GetUserByID(context.Background(), "non-existent-user")
 
 	require.Error(t, err)
@@ -82,7 +88,7 @@ func TestUserService_GetUserByID_RepositoryError(t *testing.T) {
 		},
 	}
 
-	service := NewUserService(repo)
+	service := NewUserService(repo, &mockAuditRepo{}, &fakeTxManager{})
 	user, err := service.GetUserByID(context.Background(), "test-user")
 
 	require.Error(t, err)
@@ -93,7 +99,8 @@ func TestUserService_GetUserByID_RepositoryError(t *testing.T) {
 func TestUpdateUserSettings_Success(t *testing.T) {
 	called := false
 	repo := &mockUserRepo{
-		updateUserSettingsFunc: func(ctx context.Context, id string, income int, savingGoal int) error {
+		getUserByIDFunc: existingUser,
+		updateUserSettingsFunc: func(ctx context.Context, id string, income int, savingGoal int, effectiveFrom time.Time) error {
 			called = true
 			assert.Equal(t, "test-user", id)
 			assert.Equal(t, 300000, income)
@@ -102,8 +109,8 @@ func TestUpdateUserSettings_Success(t *testing.T) {
 		},
 	}
 
-	service := NewUserService(repo)
-	err := service.UpdateUserSettings(context.Background(), "test-user", 300000, 50000)
+	service := NewUserService(repo, &mockAuditRepo{}, &fakeTxManager{})
+	err := service.UpdateUserSettings(context.Background(), "test-user", 300000, 50000, "")
 
 	require.NoError(t, err)
 	assert.True(t, called, "repository method should be called")
@@ -122,14 +129,14 @@ func TestUpdateUserSettings_InvalidIncome(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			called := false
 			repo := &mockUserRepo{
-				updateUserSettingsFunc: func(ctx context.Context, id string, income int, savingGoal int) error {
+				updateUserSettingsFunc: func(ctx context.Context, id string, income int, savingGoal int, effectiveFrom time.Time) error {
 					called = true
 					return nil
 				},
 			}
 
-			service := NewUserService(repo)
-			err := service.UpdateUserSettings(context.Background(), "test-user", tc.income, 50000)
+			service := NewUserService(repo, &mockAuditRepo{}, &fakeTxManager{})
+			err := service.UpdateUserSettings(context.Background(), "test-user", tc.income, 50000, "")
 
 			require.Error(t, err)
 			assert.False(t, called, "repository should not be called for invalid input")
@@ -141,14 +148,14 @@ func TestUpdateUserSettings_InvalidIncome(t *testing.T) {
 func TestUpdateUserSettings_InvalidSavingGoal(t *testing.T) {
 	called := false
 	repo := &mockUserRepo{
-		updateUserSettingsFunc: func(ctx context.Context, id string, income int, savingGoal int) error {
+		updateUserSettingsFunc: func(ctx context.Context, id string, income int, savingGoal int, effectiveFrom time.Time) error {
 			called = true
 			return nil
 		},
 	}
 
-	service := NewUserService(repo)
-	err := service.UpdateUserSettings(context.Background(), "test-user", 300000, -100)
+	service := NewUserService(repo, &mockAuditRepo{}, &fakeTxManager{})
+	err := service.UpdateUserSettings(context.Background(), "test-user", 300000, -100, "")
 
 	require.Error(t, err)
 	assert.False(t, called, "repository should not be called for invalid input")
@@ -158,14 +165,14 @@ func TestUpdateUserSettings_InvalidSavingGoal(t *testing.T) {
 func TestUpdateUserSettings_IncomeExceedsLimit(t *testing.T) {
 	called := false
 	repo := &mockUserRepo{
-		updateUserSettingsFunc: func(ctx context.Context, id string, income int, savingGoal int) error {
+		updateUserSettingsFunc: func(ctx context.Context, id string, income int, savingGoal int, effectiveFrom time.Time) error {
 			called = true
 			return nil
 		},
 	}
 
-	service := NewUserService(repo)
-	err := service.UpdateUserSettings(context.Background(), "test-user", 1000000001, 50000)
+	service := NewUserService(repo, &mockAuditRepo{}, &fakeTxManager{})
+	err := service.UpdateUserSettings(context.Background(), "test-user", 1000000001, 50000, "")
 
 	require.Error(t, err)
 	assert.False(t, called, "repository should not be called for invalid input")
@@ -175,14 +182,14 @@ func TestUpdateUserSettings_IncomeExceedsLimit(t *testing.T) {
 func TestUpdateUserSettings_SavingGoalExceedsLimit(t *testing.T) {
 	called := false
 	repo := &mockUserRepo{
-		updateUserSettingsFunc: func(ctx context.Context, id string, income int, savingGoal int) error {
+		updateUserSettingsFunc: func(ctx context.Context, id string, income int, savingGoal int, effectiveFrom time.Time) error {
 			called = true
 			return nil
 		},
 	}
 
-	service := NewUserService(repo)
-	err := service.UpdateUserSettings(context.Background(), "test-user", 300000, 1000000001)
+	service := NewUserService(repo, &mockAuditRepo{}, &fakeTxManager{})
+	err := service.UpdateUserSettings(context.Background(), "test-user", 300000, 1000000001, "")
 
 	require.Error(t, err)
 	assert.False(t, called, "repository should not be called for invalid input")
@@ -192,16 +199,106 @@ func TestUpdateUserSettings_SavingGoalExceedsLimit(t *testing.T) {
 func TestUpdateUserSettings_RepositoryError(t *testing.T) {
 	called := false
 	repo := &mockUserRepo{
-		updateUserSettingsFunc: func(ctx context.Context, id string, income int, savingGoal int) error {
+		getUserByIDFunc: existingUser,
+		updateUserSettingsFunc: func(ctx context.Context, id string, income int, savingGoal int, effectiveFrom time.Time) error {
 			called = true
 			return errors.New("database connection error")
 		},
 	}
 
-	service := NewUserService(repo)
-	err := service.UpdateUserSettings(context.Background(), "test-user", 300000, 50000)
+	service := NewUserService(repo, &mockAuditRepo{}, &fakeTxManager{})
+	err := service.UpdateUserSettings(context.Background(), "test-user", 300000, 50000, "")
 
 	require.Error(t, err)
 	assert.True(t, called, "repository method should be called")
 	assert.Contains(t, err.Error(), "database connection error")
 }
+
+func TestUpdateUserSettings_EffectiveFrom(t *testing.T) {
+	now := func() time.Time { return time.Date(2025, 6, 18, 9, 0, 0, 0, time.UTC) }
+
+	cases := []struct {
+		name          string
+		effectiveFrom string
+		want          time.Time
+	}{
+		{name: "省略時は当月", effectiveFrom: "", want: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "当月", effectiveFrom: "2025-06", want: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{name: "過去の月", effectiveFrom: "2025-01", want: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got time.Time
+			repo := &mockUserRepo{
+				getUserByIDFunc: existingUser,
+				updateUserSettingsFunc: func(ctx context.Context, id string, income int, savingGoal int, effectiveFrom time.Time) error {
+					got = effectiveFrom
+					return nil
+				},
+			}
+
+			service := &userService{userRepo: repo, auditRepo: &mockAuditRepo{}, txManager: &fakeTxManager{}, now: now}
+			err := service.UpdateUserSettings(context.Background(), "test-user", 320000, 50000, tc.effectiveFrom)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestUpdateUserSettings_InvalidEffectiveFrom(t *testing.T) {
+	now := func() time.Time { return time.Date(2025, 6, 18, 9, 0, 0, 0, time.UTC) }
+
+	cases := []struct {
+		effectiveFrom string
+		wantMsg       string
+	}{
+		{effectiveFrom: "2025-07", wantMsg: "適用開始月に未来の月は指定できません"},
+		{effectiveFrom: "2025-6", wantMsg: "適用開始月は YYYY-MM 形式で指定してください"},
+		{effectiveFrom: "2025-06-01", wantMsg: "適用開始月は YYYY-MM 形式で指定してください"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.effectiveFrom, func(t *testing.T) {
+			called := false
+			repo := &mockUserRepo{
+				updateUserSettingsFunc: func(ctx context.Context, id string, income int, savingGoal int, effectiveFrom time.Time) error {
+					called = true
+					return nil
+				},
+			}
+
+			service := &userService{userRepo: repo, auditRepo: &mockAuditRepo{}, txManager: &fakeTxManager{}, now: now}
+			err := service.UpdateUserSettings(context.Background(), "test-user", 320000, 50000, tc.effectiveFrom)
+
+			var ve *ValidationError
+			require.ErrorAs(t, err, &ve)
+			assert.Equal(t, tc.wantMsg, ve.Message)
+			assert.False(t, called, "repository should not be called for invalid input")
+		})
+	}
+}
+
+func TestUpdateUserSettings_RecordsAudit(t *testing.T) {
+	now := func() time.Time { return time.Date(2025, 6, 18, 9, 0, 0, 0, time.UTC) }
+	repo := &mockUserRepo{
+		getUserByIDFunc: existingUser,
+		updateUserSettingsFunc: func(ctx context.Context, id string, income int, savingGoal int, effectiveFrom time.Time) error {
+			return nil
+		},
+	}
+	auditRepo := &mockAuditRepo{}
+	tm := &fakeTxManager{}
+
+	service := &userService{userRepo: repo, auditRepo: auditRepo, txManager: tm, now: now}
+	err := service.UpdateUserSettings(context.Background(), "test-user", 320000, 50000, "2025-04")
+	require.NoError(t, err)
+
+	require.Len(t, auditRepo.created, 1)
+	event := auditRepo.created[0]
+	assert.Equal(t, models.AuditEntityUserSettings, event.EntityType)
+	assert.Equal(t, models.AuditActionUpdate, event.Action)
+	assert.Nil(t, event.EntityID)
+	assert.JSONEq(t, `{"income":280000,"saving_goal":30000}`, event.Before)
+	assert.JSONEq(t, `{"income":320000,"saving_goal":50000,"effective_from":"2025-04"}`, event.After)
+	assert.Equal(t, 1, tm.commits)
+}
@@ -1,7 +1,8 @@
 'use client'
 
 import { useEffect, useState } from 'react'
-import { CreateExpenseInput, UpdateExpenseInput, Expense } from '@/lib/types/expense'
+import { CreateExpenseInput, UpdateExpenseInput, Expense, ExpenseStatus } from '@/lib/types/expense'
+import { EXPENSE_STATUS_LABELS, EXPENSE_STATUS_TRANSITIONS } from '@/lib/constants'
 import { getCategories } from '@/lib/api/categories'
 import { Category } from '@/lib/types/category'
 
@@ -13,12 +14,21 @@ type Props = {
     isSubmitting: boolean
 }
 
+// ステータスの選択肢と選択時の見た目
+const STATUS_OPTIONS: { value: ExpenseStatus; activeClassName: string }[] = [
+    { value: 'confirmed', activeClassName: 'border-primary bg-primary/20 text-primary font-semibold shadow-md' },
+    { value: 'planned', activeClassName: 'border-warning bg-warning/20 text-warning font-semibold shadow-md' },
+    { value: 'reimbursable', activeClassName: 'border-primary bg-primary/10 text-primary font-semibold shadow-md' },
+    { value: 'reimbursed', activeClassName: 'border-success bg-success/20 text-success font-semibold shadow-md' },
+    { value: 'cancelled', activeClassName: 'border-muted-foreground bg-muted text-muted-foreground font-semibold shadow-md' },
+]
+
 export function ExpenseForm({ mode = 'create', initialData, onSubmit, onCancel, isSubmitting }: Props) {
     const [amount, setAmount] = useState(initialData?.amount.toString() || '')
     const [categoryId, setCategoryId] = useState(initialData?.category.id.toString() || '1')
     const [memo, setMemo] = useState(initialData?.memo || '')
     const [spentAt, setSpentAt] = useState(initialData?.spent_at || '')
-    const [status, setStatus] = useState<'planned' | 'confirmed'>(initialData?.status || 'confirmed')
+    const [status, setStatus] = useState<ExpenseStatus>(initialData?.status || 'confirmed')
     const [categories, setCategories] = useState<Category[]>([])
 
     useEffect(() => {
@@ -45,6 +55,12 @@ export function ExpenseForm({ mode = 'create', initialData, onSubmit, onCancel,
         status?: string
     }>({})
 
+    // 編集モードでは現在のステータスから変更できるものだけ選択可能にする
+    const canSelectStatus = (next: ExpenseStatus): boolean => {
+        if (mode !== 'edit' || !initialData || initialData.status === next) return true
+        return EXPENSE_STATUS_TRANSITIONS[initialData.status].includes(next)
+    }
+
     const validate = (): boolean => {
         const newErrors: typeof errors = {}
 
@@ -58,10 +74,8 @@ export function ExpenseForm({ mode = 'create', initialData, onSubmit, onCancel,
         }
 
         // 編集モードでstatus遷移ルールをチェック
-        if (mode === 'edit' && initialData) {
-            if (initialData.status === 'confirmed' && status === 'planned') {
-                newErrors.status = '確定済みの支出を予定に戻すことはできません'
-            }
+        if (mode === 'edit' && initialData && !canSelectStatus(status)) {
+            newErrors.status = `「${EXPENSE_STATUS_LABELS[initialData.status]}」から「${EXPENSE_STATUS_LABELS[status]}」には変更できません`
         }
 
         setErrors(newErrors)
@@ -153,45 +167,28 @@ export function ExpenseForm({ mode = 'create', initialData, onSubmit, onCancel,
 
             <div className="space-y-2">
                 <label className="block text-xs sm:text-sm font-medium text-foreground">ステータス</label>
-                <div className="flex gap-3 sm:gap-4">
-                    <label className={`
-                        flex items-center justify-center px-4 py-2.5 rounded-lg border-2 cursor-pointer
-                        transition-all duration-200 flex-1 sm:flex-initial
-                        ${status === 'confirmed' 
-                            ? 'border-primary bg-primary/20 text-primary font-semibold shadow-md' 
-                            : 'border-border bg-card hover:border-primary/50 hover:bg-primary/5 hover:shadow-sm text-foreground'}
-                    `}>
-                        <input
-                            className="sr-only"
-                            type="radio"
-                            name="status"
-                            value="confirmed"
-                            checked={status === 'confirmed'}
-                            onChange={(e) => setStatus(e.target.value as 'confirmed')}
-                        />
-                        <span className="text-xs sm:text-sm">確定</span>
-                    </label>
-                    <label className={`
-                        flex items-center justify-center px-4 py-2.5 rounded-lg border-2 cursor-pointer
-                        transition-all duration-200 flex-1 sm:flex-initial
-                        ${status === 'planned' 
-                            ? 'border-warning bg-warning/20 text-warning font-semibold shadow-md' 
-                            : 'border-border bg-card hover:border-warning/50 hover:bg-warning/5 hover:shadow-sm text-foreground'}
-                        ${mode === 'edit' && initialData?.status === 'confirmed' 
-                            ? 'opacity-50 cursor-not-allowed' 
-                            : ''}
-                    `}>
-                        <input
-                            className="sr-only"
-                            type="radio"
-                            name="status"
-                            value="planned"
-                            checked={status === 'planned'}
-                            onChange={(e) => setStatus(e.target.value as 'planned')}
-                            disabled={mode === 'edit' && initialData?.status === 'confirmed'}
-                        />
-                        <span className="text-xs sm:text-sm">予定</span>
-                    </label>
+                <div className="flex flex-wrap gap-3 sm:gap-4">
+                    {STATUS_OPTIONS.map(({ value, activeClassName }) => (
+                        <label key={value} className={`
+                            flex items-center justify-center px-4 py-2.5 rounded-lg border-2 cursor-pointer
+                            transition-all duration-200 flex-1 sm:flex-initial
+                            ${status === value
+                                ? activeClassName
+                                : 'border-border bg-card hover:border-primary/50 hover:bg-primary/5 hover:shadow-sm text-foreground'}
+                            ${canSelectStatus(value) ? '' : 'opacity-50 cursor-not-allowed'}
+                        `}>
+                            <input
+                                className="sr-only"
+                                type="radio"
+                                name="status"
+                                value={value}
+                                checked={status === value}
+                                onChange={() => setStatus(value)}
+                                disabled={!canSelectStatus(value)}
+                            />
+                            <span className="text-xs sm:text-sm">{EXPENSE_STATUS_LABELS[value]}</span>
+                        </label>
+                    ))}
                 </div>
                 {errors.status && <p className="text-xs sm:text-sm text-danger">{errors.status}</p>}
             </div>
@@ -1,4 +1,14 @@
-import { Expense } from "@/lib/types/expense"
+import { Expense, ExpenseStatus } from "@/lib/types/expense"
+import { EXPENSE_STATUS_LABELS } from "@/lib/constants"
+
+// ステータスごとのバッジの色
+const STATUS_BADGE_CLASSES: Record<ExpenseStatus, string> = {
+  confirmed: "bg-success text-success-foreground",
+  planned: "bg-warning text-warning-foreground",
+  reimbursable: "bg-primary text-primary-foreground",
+  reimbursed: "bg-muted text-muted-foreground",
+  cancelled: "bg-muted text-muted-foreground line-through",
+}
 
 type Props = {
   expenses: Expense[]
@@ -26,11 +36,9 @@ export function ExpenseList({ expenses, onEdit, onDelete, isSubmitting }: Props)
             <div>
               <span className={`
                 inline-block px-2 py-1 rounded text-xs font-bold
-                ${e.status === 'confirmed' 
-                  ? 'bg-success text-success-foreground' 
-                  : 'bg-warning text-warning-foreground'}
+                ${STATUS_BADGE_CLASSES[e.status]}
               `}>
-                {e.status === 'confirmed' ? '確定' : '予定'}
+                {EXPENSE_STATUS_LABELS[e.status]}
               </span>
             </div>
             <div className="text-sm text-foreground space-x-2">
@@ -38,7 +46,9 @@ export function ExpenseList({ expenses, onEdit, onDelete, isSubmitting }: Props)
               <span className="text-muted-foreground">/</span>
               <span className="font-semibold">¥{e.amount.toLocaleString()}</span>
               <span className="text-muted-foreground">/</span>
-              <span className="text-muted-foreground">カテゴリ: {e.category.name}</span>
+              <span className="text-muted-foreground">
+                カテゴリ: {e.items ? e.items.map((item) => item.category.name).join('・') : e.category.name}
+              </span>
               {e.memo && (
                 <>
                   <span className="text-muted-foreground">/</span>
@@ -46,6 +56,15 @@ export function ExpenseList({ expenses, onEdit, onDelete, isSubmitting }: Props)
                 </>
               )}
             </div>
+            {e.tags && (
+              <div className="flex flex-wrap gap-1">
+                {e.tags.map((tag) => (
+                  <span key={tag.id} className="px-2 py-0.5 rounded text-xs bg-muted text-muted-foreground">
+                    #{tag.name}
+                  </span>
+                ))}
+              </div>
+            )}
           </div>
           <div className="flex gap-2 sm:flex-shrink-0">
             <button
@@ -50,7 +50,8 @@ export function useExpenses() {
         setError(null);
 
         try {
-            const updatedExpense = await updateExpense(id, input);
+            const current = expenses.find((exp) => exp.id === id);
+            const updatedExpense = await updateExpense(id, input, current?.version ?? 0);
             setExpenses((prevExpenses) =>
                 prevExpenses.map((exp) => (exp.id === id ? updatedExpense : exp))
             );
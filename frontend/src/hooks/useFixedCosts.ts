@@ -75,7 +75,8 @@ export function useFixedCosts() {
     setError(null);
 
     try {
-      const updatedFixedCost = await updateFixedCost(id, input);
+      const current = fixedCosts.find((fc) => fc.id === id);
+      const updatedFixedCost = await updateFixedCost(id, input, current?.version ?? 0);
       setFixedCosts((prevFixedCosts) =>
         prevFixedCosts.map((fc) => (fc.id === id ? updatedFixedCost : fc))
       );
@@ -1,9 +1,14 @@
 import { Dashboard } from "@/lib/types/dashboard";
 import { API_BASE_URL, getAuthHeaders, handleApiError } from "./client";
 
-export async function getDashboard(): Promise<Dashboard> {
+/**
+ * ダッシュボードを取得します
+ * @param month 対象月（YYYY-MM）。省略時は当月
+ */
+export async function getDashboard(month?: string): Promise<Dashboard> {
   const headers = await getAuthHeaders();
-  const res = await fetch(`${API_BASE_URL}/dashboard`, {
+  const query = month ? `?month=${encodeURIComponent(month)}` : "";
+  const res = await fetch(`${API_BASE_URL}/dashboard${query}`, {
     method: "GET",
     headers,
   });
@@ -49,14 +49,16 @@ export async function getExpenses(): Promise<GetExpensesResponse> {
   return data;
 }
 
+// version は取得時の値。他の端末で更新されていた場合は 412 になる
 export async function updateExpense(
   id: number,
-  input: UpdateExpenseInput
+  input: UpdateExpenseInput,
+  version: number
 ): Promise<Expense> {
   const headers = await getAuthHeaders(true);
   const res = await fetch(`${API_BASE_URL}/expenses/${id}`, {
     method: 'PUT',
-    headers,
+    headers: { ...headers, 'If-Match': `"${version}"` },
     body: JSON.stringify(input),
   })
 
@@ -51,14 +51,16 @@ export async function getFixedCosts(): Promise<GetFixedCostsResponse> {
   return data;
 }
 
+// version は取得時の値。他の端末で更新されていた場合は 412 になる
 export async function updateFixedCost(
   id: number,
-  input: UpdateFixedCostInput
+  input: UpdateFixedCostInput,
+  version: number
 ): Promise<FixedCost> {
   const headers = await getAuthHeaders(true);
   const res = await fetch(`${API_BASE_URL}/fixed-costs/${id}`, {
     method: "PUT",
-    headers,
+    headers: { ...headers, "If-Match": `"${version}"` },
     body: JSON.stringify(input),
   });
 
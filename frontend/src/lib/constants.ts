@@ -1,3 +1,5 @@
+import { ExpenseStatus } from "./types/expense";
+
 /**
  * ビジネスロジックに関する定数
  */
@@ -17,3 +19,26 @@ export const MEMO_MAX_LENGTH = 5000;
  * 固定費名の最大文字数
  */
 export const FIXED_COST_NAME_MAX_LENGTH = 100;
+
+/**
+ * 支出ステータスの表示名
+ */
+export const EXPENSE_STATUS_LABELS: Record<ExpenseStatus, string> = {
+  planned: "予定",
+  confirmed: "確定",
+  cancelled: "取りやめ",
+  reimbursable: "立替中",
+  reimbursed: "精算済み",
+};
+
+/**
+ * 支出ステータスごとに変更できるステータス（バックエンドの遷移表と同じ）
+ * 同じステータスのままの更新は常に可能
+ */
+export const EXPENSE_STATUS_TRANSITIONS: Record<ExpenseStatus, ExpenseStatus[]> = {
+  planned: ["confirmed", "cancelled", "reimbursable"],
+  confirmed: ["reimbursable"],
+  cancelled: ["planned"],
+  reimbursable: ["confirmed", "reimbursed"],
+  reimbursed: [],
+};
@@ -0,0 +1,21 @@
+export type APITokenScope = "read" | "write:expenses"
+
+export type APIToken = {
+  id: number
+  name: string
+  scopes: APITokenScope[]
+  expires_at: string
+  last_used_at: string | null
+  created_at: string
+}
+
+// 作成時のみ平文のトークンを含む
+export type CreatedAPIToken = APIToken & {
+  token: string // mb_pat_ で始まるトークン
+}
+
+export type CreateAPITokenInput = {
+  name: string
+  scopes: APITokenScope[]
+  expires_in_days?: number // 1〜365（省略時は90）
+}
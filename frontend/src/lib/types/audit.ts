@@ -0,0 +1,29 @@
+// 変更履歴の対象
+export type AuditEntityType = "expense" | "fixed_cost" | "user_settings" | "initial_setup"
+
+// 変更の種類
+export type AuditAction = "create" | "update" | "delete" | "restore" | "import"
+
+// 変更履歴の1件
+export type AuditEvent = {
+  id: number
+  entity_type: AuditEntityType
+  entity_id: number | null // 設定・初期設定は null
+  action: AuditAction
+  actor_id: string // 変更したユーザー（世帯のメンバーの場合あり）
+  request_id: string
+  before: unknown // 作成・復元・取り込みは null
+  after: unknown // 支出の削除は null
+  created_at: string
+}
+
+// GET /audit（新しい順）
+export type GetAuditEventsResponse = {
+  events: AuditEvent[]
+  next_cursor: string | null // 次のページがない場合は null
+}
+
+// GET /expenses/:id/history（古い順）
+export type GetExpenseHistoryResponse = {
+  events: AuditEvent[]
+}
@@ -1,4 +1,5 @@
 export type Category = {
   id: number
   name: string
-}
\ No newline at end of file
+  is_custom?: boolean
+}
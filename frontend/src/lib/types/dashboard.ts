@@ -1,9 +1,48 @@
+export type BudgetLevel = "green" | "yellow" | "red"
+
+export type CategoryBudgetStatus = {
+  category_id: number
+  category_name: string
+  limit: number | null // 予算未設定の場合は null
+  confirmed_expenses: number
+  planned_expenses: number
+  remaining: number | null
+  level: BudgetLevel | null
+}
+
+export type FixedCostMode = "amortize" | "billing_month"
+
+// 利用ペースと月末の見込み（days_elapsed・days_remaining は今日を含む）
+export type DashboardPace = {
+  days_in_month: number
+  days_elapsed: number
+  days_remaining: number
+  ideal_spend_to_date: number
+  actual_spend_to_date: number
+  safe_to_spend_per_day: number
+  projected_remaining: number
+}
+
+// 登録したメンバー別の支出（オーナーが先頭）
+export type MemberExpenses = {
+  user_id: string
+  confirmed_expenses: number
+  planned_expenses: number
+}
+
 export type Dashboard = {
+  month: string // YYYY-MM
+  period_start: string // YYYY-MM-DD
+  period_end: string // YYYY-MM-DD
   income: number
   saving_goal: number
+  fixed_cost_mode: FixedCostMode
   fixed_costs: number
   variable_budget: number
   confirmed_expenses: number
   planned_expenses: number
   remaining: number
+  categories: CategoryBudgetStatus[]
+  members: MemberExpenses[]
+  pace: DashboardPace
 }
@@ -1,9 +1,21 @@
+// planned: 予定 / confirmed: 確定 / cancelled: 取りやめ / reimbursable: 立替中 / reimbursed: 精算済み
+export type ExpenseStatus = 'planned' | 'confirmed' | 'cancelled' | 'reimbursable' | 'reimbursed'
+
+// 支出を複数のカテゴリに分ける明細（金額の合計は支出の金額と一致させる）
+export type ExpenseItemInput = {
+    amount: number
+    category_id: number
+    memo?: string
+}
+
 export type CreateExpenseInput = {
     amount: number
     category_id: number
     memo?: string
     spent_at: string // yyyy-mm-dd
-    status?: 'planned' | 'confirmed'
+    status?: ExpenseStatus
+    items?: ExpenseItemInput[]
+    tags?: string[] // タグ名（ないタグは作成される）
 }
 
 export type UpdateExpenseInput = {
@@ -11,7 +23,9 @@ export type UpdateExpenseInput = {
     category_id: number
     memo?: string
     spent_at: string // yyyy-mm-dd or date-time
-    status?: 'planned' | 'confirmed'
+    status?: ExpenseStatus
+    items?: ExpenseItemInput[] // 省略時は明細を変更しない、空配列で明細を削除
+    tags?: string[] // 省略時はタグを変更しない、空配列でタグをすべて外す
 }
 
 export type Expense = {
@@ -19,7 +33,27 @@ export type Expense = {
     amount: number;
     memo: string | null;
     spent_at: string; // YYYY-MM-DD
-    status: 'planned' | 'confirmed';
+    status: ExpenseStatus;
+    created_by: string; // 登録したユーザーID
+    version: number; // 更新ごとに加算（If-Match に指定する）
+    planned_amount: number | null; // 予定から確定にしたときの予定金額
+    variance: number | null; // amount - planned_amount
+    category: {
+        id: number;
+        name: string;
+    }
+    items?: ExpenseItem[]; // 明細（分けていない支出では省略）
+    tags?: Tag[]; // タグ（名前順、タグがない支出では省略）
+};
+
+export type Tag = {
+    id: number;
+    name: string;
+};
+
+export type ExpenseItem = {
+    amount: number;
+    memo: string;
     category: {
         id: number;
         name: string;
@@ -28,4 +62,59 @@ export type Expense = {
 
 export type GetExpensesResponse = {
     expenses: Expense[];
-};
\ No newline at end of file
+    next_cursor: string | null;
+};
+export type ExpenseImportMapping = {
+    date: string;
+    amount: string;
+    memo?: string;
+    category?: string;
+};
+
+export type ExpenseImportRow = {
+    line: number;
+    status: 'accepted' | 'rejected';
+    amount?: number;
+    category_id?: number;
+    memo: string;
+    spent_at?: string; // YYYY-MM-DD
+    error?: string;
+};
+
+export type ExpenseImportResult = {
+    committed: boolean;
+    total: number;
+    accepted: number;
+    rejected: number;
+    rows: ExpenseImportRow[];
+};
+
+// POST /expenses/batch の操作（update の expense は PATCH /expenses/:id と同じく変更する項目のみ）
+export type ExpenseBatchOperation =
+    | { op: 'create'; expense: CreateExpenseInput }
+    | { op: 'update'; id: number; version: number; expense: Partial<UpdateExpenseInput> }
+    | { op: 'delete'; id: number }
+    | { op: 'confirm'; id: number; version: number; amount?: number }; // amount: 実際の金額（省略時は予定の金額）
+
+export type ExpenseBatchRequest = {
+    mode?: 'atomic' | 'best_effort'; // atomic（既定）は1件でも失敗するとすべて取り消す
+    operations: ExpenseBatchOperation[];
+};
+
+export type ExpenseBatchItemResult = {
+    index: number;
+    op: ExpenseBatchOperation['op'];
+    status: 'succeeded' | 'failed' | 'rolled_back';
+    expense?: Expense; // 操作後の支出（version_conflict では現在の支出）
+    code?: 'validation' | 'not_found' | 'invalid_transition' | 'version_conflict' | 'internal';
+    error?: string;
+};
+
+export type ExpenseBatchResult = {
+    mode: 'atomic' | 'best_effort';
+    committed: boolean;
+    total: number;
+    succeeded: number;
+    failed: number;
+    results: ExpenseBatchItemResult[];
+};
@@ -1,21 +1,33 @@
+export type FixedCostFrequency = "monthly" | "bimonthly" | "quarterly" | "yearly"
+
+export type FixedCostSchedule = {
+  frequency?: FixedCostFrequency // 省略時は monthly
+  billing_month?: number | null // 1〜12。毎月以外の場合は必須
+  billing_day?: number | null // 1〜31
+}
+
 export type FixedCost = {
   id: number
   user_id: string
   name: string
   amount: number
+  frequency: FixedCostFrequency
+  billing_month: number | null
+  billing_day: number | null
   created_at: string
   updated_at: string
+  version: number // 更新ごとに加算（If-Match に指定する）
 }
 
 // フォーム入力用の共通型
 export type FixedCostInput = {
   name: string
   amount: number
-}
+} & FixedCostSchedule
 
-// API型定義（互換性のため残す）
-export type CreateFixedCostInput = FixedCostInput
-export type UpdateFixedCostInput = FixedCostInput
+// API型定義（effective_from は YYYY-MM、省略時は当月から適用）
+export type CreateFixedCostInput = FixedCostInput & { effective_from?: string }
+export type UpdateFixedCostInput = FixedCostInput & { effective_from?: string }
 
 export type CreateFixedCostResponse = {
   fixed_cost: FixedCost
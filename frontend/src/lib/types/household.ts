@@ -0,0 +1,35 @@
+export type HouseholdRole = "owner" | "member" | "viewer"
+
+// role・current はログイン中のユーザーから見た値
+export type Household = {
+  id: number
+  name: string
+  owner_id: string
+  role: HouseholdRole
+  current: boolean // 選択中の世帯の場合 true
+  created_at: string
+}
+
+export type HouseholdMember = {
+  user_id: string
+  role: HouseholdRole
+  joined_at: string
+}
+
+export type HouseholdDetail = Household & {
+  members: HouseholdMember[]
+}
+
+export type HouseholdInvitation = {
+  code: string
+  role: Exclude<HouseholdRole, "owner">
+  expires_at: string // 発行から7日間
+}
+
+export type CreateHouseholdInput = {
+  name: string // 50文字以内
+}
+
+export type SwitchHouseholdInput = {
+  household_id: number | null // null で自分の家計簿に戻す
+}
@@ -0,0 +1,28 @@
+import { Category } from "./category"
+
+export type RecurringFrequency = "weekly" | "biweekly" | "monthly" | "yearly"
+
+export type RecurringExpense = {
+  id: number
+  amount: number
+  memo: string
+  category: Category
+  frequency: RecurringFrequency
+  day_of_month: number | null // monthly の場合のみ
+  start_date: string // YYYY-MM-DD
+  end_date: string | null
+  generated_until: string | null
+}
+
+export type RecurringExpenseRequest = {
+  amount: number
+  category_id: number
+  memo?: string
+  frequency: RecurringFrequency
+  day_of_month?: number
+  start_date: string
+  end_date?: string
+}
+
+// occurrence: この回のみ / future: 以降すべて
+export type RecurringScope = "occurrence" | "future"
@@ -0,0 +1,34 @@
+import { FixedCostMode } from "./dashboard"
+
+// 月ごとの集計（値の意味はダッシュボードと同じ）
+export type TrendMonth = {
+  month: string // YYYY-MM
+  income: number
+  saving_goal: number
+  fixed_costs: number
+  variable_budget: number
+  confirmed_expenses: number
+  planned_expenses: number
+  remaining: number
+}
+
+export type CategoryTrendPoint = {
+  month: string // YYYY-MM
+  confirmed_expenses: number
+  planned_expenses: number
+}
+
+// series は months と同じ月の並びで、支出がない月は 0
+export type CategoryTrend = {
+  category_id: number
+  category_name: string
+  series: CategoryTrendPoint[]
+}
+
+export type Trends = {
+  from: string // YYYY-MM
+  to: string // YYYY-MM
+  fixed_cost_mode: FixedCostMode
+  months: TrendMonth[]
+  categories: CategoryTrend[]
+}
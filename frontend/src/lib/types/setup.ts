@@ -1,7 +1,9 @@
+import { FixedCostSchedule } from "./fixed-cost"
+
 export type FixedCostInput = {
   name: string
   amount: number
-}
+} & FixedCostSchedule
 
 export type InitialSetupRequest = {
   income: number
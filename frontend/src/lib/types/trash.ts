@@ -0,0 +1,21 @@
+import type { Expense } from "./expense"
+import type { FixedCost } from "./fixed-cost"
+
+// ゴミ箱の支出（明細・タグは省略）
+export type TrashedExpense = Expense & {
+  deleted_at: string
+  restorable_until: string // この日時を過ぎると完全に削除される
+}
+
+// ゴミ箱の固定費
+export type TrashedFixedCost = FixedCost & {
+  ended_from: string // 削除時に記録した解約月（YYYY-MM）
+  deleted_at: string
+  restorable_until: string // この日時を過ぎると復元できない
+}
+
+// GET /trash（削除から30日以内、新しく削除した順）
+export type GetTrashResponse = {
+  expenses: TrashedExpense[]
+  fixed_costs: TrashedFixedCost[]
+}
@@ -2,6 +2,7 @@ export type User = {
   id: string // Firebase UID
   income: number
   saving_goal: number
+  current_household_id: number | null // 選択中の世帯（null は自分の家計簿）
   created_at: string
   updated_at: string
 }
@@ -9,4 +10,5 @@ export type User = {
 export type UpdateUserInput = {
   income: number
   saving_goal: number
+  effective_from?: string // YYYY-MM（省略時は当月）
 }